@@ -108,9 +108,70 @@ func init() {
 			"of the options. The default is true to make it easy to run a node locally. "+
 			"See https://github.com/unrolled/secure for more info. Note that")
 
+	// IP Rate Limiting
+	runCmd.PersistentFlags().Float64("ip-rate-limit-requests-per-second", 0,
+		"The number of requests per second each client IP can make across all endpoints. Requests beyond the "+
+			"limit receive a 429. Set to 0 to disable IP rate limiting.")
+	runCmd.PersistentFlags().Uint64("ip-rate-limit-burst", 0,
+		"The number of requests each client IP can make at once before being rate limited. Defaults to one "+
+			"second's worth of requests.")
+	runCmd.PersistentFlags().StringSlice("trusted-proxies", []string{},
+		"A comma-separated list of IPs or CIDR ranges of proxies in front of the node. The X-Forwarded-For header "+
+			"is only used to determine the client IP for requests coming from these proxies.")
+	runCmd.PersistentFlags().StringSlice("max-concurrent-requests-per-route", []string{},
+		"A comma-separated list of RouteName=MaxInFlightRequests entries, e.g. GetDAOCoinLimitOrders=16. Requests to "+
+			"a listed route beyond its limit of simultaneous requests receive a 503. Other routes are not limited.")
+
+	// Request Tracing
+	runCmd.PersistentFlags().String("correlation-id-header", "X-Request-ID",
+		"The header carrying a request's correlation ID, e.g. traceparent. The ID is included in the node's logs for "+
+			"the request, echoed on the response, and forwarded on outbound HTTP calls the node makes while serving "+
+			"it. Requests without the header are assigned a random ID.")
+
+	// Response Encoding
+	runCmd.PersistentFlags().String("json-field-naming-convention", "PascalCase",
+		"The naming convention for the field names in JSON responses, either PascalCase or snake_case. PascalCase "+
+			"matches the Go field names. snake_case suits clients in ecosystems such as Python and Ruby.")
+
+	// Messaging
+	runCmd.PersistentFlags().StringSlice("message-signer-seeds", []string{},
+		"A comma-separated list of seed phrases the node may sign and broadcast messages with. A message send request "+
+			"with Broadcast set is only accepted when its sender's key is derived from one of these seeds and the "+
+			"request carries a valid JWT for the sender. The node holds these users' keys, so only set this on "+
+			"trusted nodes. Empty by default, which disables broadcasting.")
+	runCmd.PersistentFlags().Int("default-max-messages-to-fetch", 25,
+		"The number of messages returned by the paginated DM and group chat endpoints when a request omits "+
+			"MaxMessagesToFetch.")
+	runCmd.PersistentFlags().Int("max-messages-scanned-for-unread-count", 1000,
+		"The most messages GetUnreadMessageCount scans in a single thread. Threads with more unread messages than "+
+			"this are reported with this many and flagged as truncated.")
+	runCmd.PersistentFlags().Int("max-dm-message-size-bytes", 64*1024,
+		"The largest EncryptedMessageText, in bytes after hex decoding, the DM and group chat send endpoints accept. "+
+			"Larger messages are rejected before a transaction is built.")
+	runCmd.PersistentFlags().StringSlice("messaging-denylist", []string{},
+		"A comma-separated list of public keys the node refuses to build message transactions for, each optionally "+
+			"followed by =Reason, e.g. BC1YLg...=Spam. Users can look up whether they're on the list, and the reason, "+
+			"via the GetDenylistStatus endpoint, so reasons shouldn't contain anything that isn't fit to publish.")
+
+	// Message Decryption
+	runCmd.PersistentFlags().Bool("enable-message-decryption", false,
+		"When set, messages sent to a key derived from one of --message-decryption-seeds are returned with their "+
+			"DecryptedText by the message endpoints, but only to a caller with a valid JWT for that key. The node holds "+
+			"these users' keys, so only set this on trusted relay nodes. Off by default.")
+	runCmd.PersistentFlags().StringSlice("message-decryption-seeds", []string{},
+		"A comma-separated list of seed phrases whose keys the node decrypts messages with when "+
+			"--enable-message-decryption is set. Each seed's public key must also be listed in "+
+			"--message-decryption-allowed-public-keys, or the node won't start.")
+	runCmd.PersistentFlags().StringSlice("message-decryption-allowed-public-keys", []string{},
+		"A comma-separated list of the public keys of --message-decryption-seeds the operator allows the node to "+
+			"decrypt messages for. Listing each key a second time guards against decrypting for a key by mistake.")
+
 	// Analytics + Profiling
 	runCmd.PersistentFlags().String("amplitude-key", "", "Client-side amplitude key for instrumenting user behavior.")
 	runCmd.PersistentFlags().String("amplitude-domain", "api.amplitude.com", "Client-side amplitude API Endpoint.")
+	runCmd.PersistentFlags().Uint64("client-events-per-minute", 30,
+		"The maximum number of client events a single IP, or a single public key, can log per minute "+
+			"through the log-client-event endpoint. Set to 0 to disable rate limiting.")
 
 	// Transactions
 	runCmd.PersistentFlags().Int("max-optional-preceding-transactions", 0,
@@ -118,6 +179,15 @@ func init() {
 			"endpoints to accept transactions that should be connected "+
 			"before constructing the specified transaction. "+
 			"Setting this flag can aid in workflows that deal with atomic transaction construction.")
+	runCmd.PersistentFlags().Uint64("max-extra-data-entries", 64,
+		"The maximum number of ExtraData entries that clients can attach to a message. Set to 0 to disable the limit.")
+	runCmd.PersistentFlags().Uint64("max-extra-data-size-bytes", 16384,
+		"The maximum total size in bytes of the encoded ExtraData keys and values that clients can attach to a "+
+			"message. Set to 0 to disable the limit.")
+	runCmd.PersistentFlags().Uint64("max-request-body-size-bytes", 0,
+		"Requests whose Content-Length header exceeds this many bytes are rejected with a 413 before the body is "+
+			"read. Defaults to 10MB, which is also the most it can be set to. Endpoints that accept file uploads allow "+
+			"a little more for multipart overhead.")
 
 	// User Interface
 	runCmd.PersistentFlags().String("support-email", "", "Show a support email to users of this node")
@@ -137,6 +207,9 @@ func init() {
 		"A list of public keys which gives users access to the super admin panel. "+
 			"If '*' is specified as a key, anyone can access the super admin panel. You can add a space "+
 			"and a comment after every public key and leave a note about who the public key belongs to.")
+	runCmd.PersistentFlags().String("admin-secret", "",
+		"If set, requests to admin and super admin endpoints must also send this value in the X-Admin-Secret "+
+			"header, in addition to passing the admin public key check. Requests without it get a 401.")
 
 	// Wyre
 	runCmd.PersistentFlags().String("wyre-account-id", "", "Wyre Account ID")
@@ -186,6 +259,28 @@ func init() {
 	runCmd.PersistentFlags().Uint64("metamask-airdrop-deso-nanos-amount", 0, "Amount of DESO in nanos to send to metamask users as an airdrop")
 	runCmd.PersistentFlags().String("hcaptcha-secret", "", "Secret key for hcaptcha service. Used to verify captcha token verifications.")
 	runCmd.PersistentFlags().StringSlice("peers-to-monitor", []string{}, "List of peer URLs to monitor for networking connectivity while the node is running.")
+
+	// DAO Coin Order Book Warmer
+	runCmd.PersistentFlags().Bool("run-dao-coin-order-book-warmer", false,
+		"If set, runs a go routine that keeps a warm cache of the order book for popular DAO coin pairs.")
+	runCmd.PersistentFlags().StringSlice("dao-coin-order-book-hot-pairs", []string{},
+		"A comma-separated list of '<coin1>:<coin2>' pairs whose order books should always be kept warm. "+
+			"Each coin is either a base58 public key or DESO.")
+	runCmd.PersistentFlags().Int("dao-coin-order-book-auto-warm-pairs", 0,
+		"In addition to the configured hot pairs, keep this many of the most requested pairs warm.")
+	runCmd.PersistentFlags().Uint64("dao-coin-order-book-warm-interval-millis", 1000,
+		"How often the order book warmer refreshes the cache. Cached order books are never served for longer than this.")
+
+	// DAO Coin Exchange
+	runCmd.PersistentFlags().Bool("skip-deprecated-dao-coin-limit-order-float-fields", false,
+		"If set, DAO coin limit order responses never compute or include the deprecated ExchangeRateCoinsToSellPerCoinToBuy "+
+			"and QuantityToFill float fields, as though every request set OmitDeprecatedFloatFields. Only set this once "+
+			"clients have migrated to Price and Quantity.")
+
+	// UtxoView Timing
+	runCmd.PersistentFlags().Uint64("slow-utxo-view-threshold-millis", 0,
+		"If set, a warning naming the endpoint is logged whenever building a UtxoView from the mempool for a request "+
+			"takes longer than this many milliseconds. Zero disables the warning.")
 	runCmd.PersistentFlags().VisitAll(func(flag *pflag.Flag) {
 		viper.BindPFlag(flag.Name, flag)
 	})
@@ -46,12 +46,43 @@ type Config struct {
 	SecureHeaderAllowHosts    []string
 	AdminPublicKeys           []string
 	SuperAdminPublicKeys      []string
+	AdminSecret               string
+
+	// IP Rate Limiting
+	IPRateLimitRequestsPerSecond float64
+	IPRateLimitBurst             uint64
+	TrustedProxies               []string
+
+	// Concurrency Limiting
+	MaxConcurrentRequestsPerRoute []string
+
+	// Request Tracing
+	CorrelationIDHeader string
+
+	// Response Encoding
+	JSONFieldNamingConvention string
+
+	// Messaging
+	MessageSignerSeeds               []string
+	DefaultMaxMessagesToFetch        int
+	MaxMessagesScannedForUnreadCount int
+	MaxDmMessageSizeBytes            int
+	MessagingDenylist                []string
+
+	// Message Decryption
+	EnableMessageDecryption            bool
+	MessageDecryptionSeeds             []string
+	MessageDecryptionAllowedPublicKeys []string
 
 	// Analytics
-	AmplitudeKey string
+	AmplitudeKey          string
+	ClientEventsPerMinute uint64
 
 	// Transactions
 	MaxOptionalPrecedingTransactions int
+	MaxExtraDataEntries              uint64
+	MaxExtraDataSizeBytes            uint64
+	MaxRequestBodySizeBytes          uint64
 
 	// Images
 	GCPCredentialsPath string
@@ -107,6 +138,18 @@ type Config struct {
 
 	// URLs + optional port for peers that we may connect to and should monitor.
 	PeersToMonitor []string
+
+	// DAO Coin Order Book Warmer
+	RunDAOCoinOrderBookWarmer          bool
+	DAOCoinOrderBookHotPairs           []string
+	DAOCoinOrderBookAutoWarmPairs      int
+	DAOCoinOrderBookWarmIntervalMillis uint64
+
+	// DAO Coin Exchange
+	SkipDeprecatedDAOCoinLimitOrderFloatFields bool
+
+	// UtxoView Timing
+	SlowUtxoViewThresholdMillis uint64
 }
 
 func LoadConfig(coreConfig *coreCmd.Config) *Config {
@@ -158,12 +201,43 @@ func LoadConfig(coreConfig *coreCmd.Config) *Config {
 	config.SecureHeaderAllowHosts = viper.GetStringSlice("secure-header-allow-hosts")
 	config.AdminPublicKeys = viper.GetStringSlice("admin-public-keys")
 	config.SuperAdminPublicKeys = viper.GetStringSlice("super-admin-public-keys")
+	config.AdminSecret = viper.GetString("admin-secret")
+
+	// IP Rate Limiting
+	config.IPRateLimitRequestsPerSecond = viper.GetFloat64("ip-rate-limit-requests-per-second")
+	config.IPRateLimitBurst = viper.GetUint64("ip-rate-limit-burst")
+	config.TrustedProxies = viper.GetStringSlice("trusted-proxies")
+
+	// Concurrency Limiting
+	config.MaxConcurrentRequestsPerRoute = viper.GetStringSlice("max-concurrent-requests-per-route")
+
+	// Request Tracing
+	config.CorrelationIDHeader = viper.GetString("correlation-id-header")
+
+	// Response Encoding
+	config.JSONFieldNamingConvention = viper.GetString("json-field-naming-convention")
+
+	// Messaging
+	config.MessageSignerSeeds = viper.GetStringSlice("message-signer-seeds")
+	config.DefaultMaxMessagesToFetch = viper.GetInt("default-max-messages-to-fetch")
+	config.MaxMessagesScannedForUnreadCount = viper.GetInt("max-messages-scanned-for-unread-count")
+	config.MaxDmMessageSizeBytes = viper.GetInt("max-dm-message-size-bytes")
+	config.MessagingDenylist = viper.GetStringSlice("messaging-denylist")
+
+	// Message Decryption
+	config.EnableMessageDecryption = viper.GetBool("enable-message-decryption")
+	config.MessageDecryptionSeeds = viper.GetStringSlice("message-decryption-seeds")
+	config.MessageDecryptionAllowedPublicKeys = viper.GetStringSlice("message-decryption-allowed-public-keys")
 
 	// Analytics
 	config.AmplitudeKey = viper.GetString("amplitude-key")
+	config.ClientEventsPerMinute = viper.GetUint64("client-events-per-minute")
 
 	// Transactions
 	config.MaxOptionalPrecedingTransactions = viper.GetInt("max-optional-preceding-transactions")
+	config.MaxExtraDataEntries = viper.GetUint64("max-extra-data-entries")
+	config.MaxExtraDataSizeBytes = viper.GetUint64("max-extra-data-size-bytes")
+	config.MaxRequestBodySizeBytes = viper.GetUint64("max-request-body-size-bytes")
 
 	// Images
 	config.GCPCredentialsPath = viper.GetString("gcp-credentials-path")
@@ -219,6 +293,18 @@ func LoadConfig(coreConfig *coreCmd.Config) *Config {
 
 	config.PeersToMonitor = viper.GetStringSlice("peers-to-monitor")
 
+	// DAO Coin Order Book Warmer
+	config.RunDAOCoinOrderBookWarmer = viper.GetBool("run-dao-coin-order-book-warmer")
+	config.DAOCoinOrderBookHotPairs = viper.GetStringSlice("dao-coin-order-book-hot-pairs")
+	config.DAOCoinOrderBookAutoWarmPairs = viper.GetInt("dao-coin-order-book-auto-warm-pairs")
+	config.DAOCoinOrderBookWarmIntervalMillis = viper.GetUint64("dao-coin-order-book-warm-interval-millis")
+
+	// DAO Coin Exchange
+	config.SkipDeprecatedDAOCoinLimitOrderFloatFields = viper.GetBool("skip-deprecated-dao-coin-limit-order-float-fields")
+
+	// UtxoView Timing
+	config.SlowUtxoViewThresholdMillis = viper.GetUint64("slow-utxo-view-threshold-millis")
+
 	// Public keys that need their balances monitored. Map of Label to Public key
 	labelsToPublicKeys := viper.GetString("public-key-balances-to-monitor")
 	if len(labelsToPublicKeys) > 0 {
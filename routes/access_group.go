@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sort"
+	"strings"
 
 	"github.com/deso-protocol/core/lib"
 	"github.com/pkg/errors"
@@ -48,29 +50,19 @@ func (fes *APIServer) accessGroupHandler(
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CreateAccessGroupRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		return fmt.Errorf("problem parsing request body: %v", err)
 	}
 
-	// Decode the access group owner public key.
-	accessGroupOwnerPkBytes, _, err := lib.Base58CheckDecode(requestData.AccessGroupOwnerPublicKeyBase58Check)
+	// Decode and validate the access group owner public key and the access group key name.
+	accessGroupOwnerPkBytes, accessGroupKeyNameBytes, err := ValidateAccessGroupPublicKeyAndName(
+		requestData.AccessGroupOwnerPublicKeyBase58Check, requestData.AccessGroupKeyName)
 	if err != nil {
-		return fmt.Errorf("problem decoding owner"+
-			"base58 public key %s: %v", requestData.AccessGroupOwnerPublicKeyBase58Check, err)
-	}
-
-	accessGroupKeyNameBytes := []byte(requestData.AccessGroupKeyName)
-
-	// get the byte array of the access group key name.
-
-	// Validates whether the accessGroupOwner key is a valid public key and
-	// some basic checks on access group key name like Min and Max characters.
-	if err = lib.ValidateAccessGroupPublicKeyAndName(accessGroupOwnerPkBytes, accessGroupKeyNameBytes); err != nil {
 		return fmt.Errorf("problem validating access group owner "+
 			"public key and access group key name %s: %v", requestData.AccessGroupKeyName, err)
 	}
 
-	// Access group name key cannot be equal to base name key (equal to all zeros).
+	// Access group name key cannot be equal to base name key (empty or all zeros).
 	if lib.EqualGroupKeyName(lib.NewGroupKeyName(accessGroupKeyNameBytes), lib.BaseGroupKeyName()) {
 		return fmt.Errorf(
 			"access group key cannot be same as base key (all zeros)."+"access group key name %s", requestData.AccessGroupKeyName)
@@ -254,7 +246,7 @@ func (fes *APIServer) accessGroupMemberHandler(
 	// Parse the request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AddAccessGroupMembersRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		return fmt.Errorf("problem parsing request body: %v", err)
 	}
 	// Decode the access group owner public key.
@@ -289,6 +281,18 @@ func (fes *APIServer) accessGroupMemberHandler(
 		return fmt.Errorf("error getting utxo view: %v", err)
 	}
 
+	// Only the owner of an existing access group can change its members, so the group must exist under the
+	// owner public key in the request. The owner public key must also sign the transaction.
+	accessGroupEntry, err := utxoView.GetAccessGroupEntry(
+		lib.NewPublicKey(accessGroupOwnerPkBytes), lib.NewGroupKeyName(accessGroupKeyNameBytes))
+	if err != nil {
+		return fmt.Errorf("error checking existence of access group entry: %v", err)
+	}
+	if accessGroupEntry == nil || accessGroupEntry.IsDeleted() {
+		return fmt.Errorf("access group %s does not exist for owner %s",
+			requestData.AccessGroupKeyName, requestData.AccessGroupOwnerPublicKeyBase58Check)
+	}
+
 	// DeSo core library expects the member list input the form of []*lib.AccessGroupMember{}
 	accessGroupMembers := []*lib.AccessGroupMember{}
 	// Map is used to identify Duplicate entries in the access member list.
@@ -559,7 +563,7 @@ func (fes *APIServer) getMemberOnlyAccessEntriesForPublicKey(pkBytes []byte, utx
 // Returns groups where the public key is a owner and a member.
 func (fes *APIServer) GetAllUserAccessGroups(ww http.ResponseWriter, req *http.Request) {
 	if err := fes.getUserAccessGroupsHandler(ww, req, true, true); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetAllUserAccessGroups: %v", err))
+		_AddHandlerError(ww, fmt.Sprintf("GetAllUserAccessGroups: %v", err), err)
 		return
 	}
 }
@@ -567,7 +571,7 @@ func (fes *APIServer) GetAllUserAccessGroups(ww http.ResponseWriter, req *http.R
 // API to fetch access groups where the given public key is an owner.
 func (fes *APIServer) GetAllUserAccessGroupsOwned(ww http.ResponseWriter, req *http.Request) {
 	if err := fes.getUserAccessGroupsHandler(ww, req, true, false); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetAllUserAccessGroupsOwned: %v", err))
+		_AddHandlerError(ww, fmt.Sprintf("GetAllUserAccessGroupsOwned: %v", err), err)
 		return
 	}
 }
@@ -575,7 +579,7 @@ func (fes *APIServer) GetAllUserAccessGroupsOwned(ww http.ResponseWriter, req *h
 // API to fetch access groups where the given public key is a member.
 func (fes *APIServer) GetAllUserAccessGroupsMemberOnly(ww http.ResponseWriter, req *http.Request) {
 	if err := fes.getUserAccessGroupsHandler(ww, req, false, true); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetAllUserAccessGroupsMemberOnly: %v", err))
+		_AddHandlerError(ww, fmt.Sprintf("GetAllUserAccessGroupsMemberOnly: %v", err), err)
 		return
 	}
 }
@@ -583,7 +587,7 @@ func (fes *APIServer) GetAllUserAccessGroupsMemberOnly(ww http.ResponseWriter, r
 func (fes *APIServer) getUserAccessGroupsHandler(ww http.ResponseWriter, req *http.Request, getOwned bool, getMember bool) error {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetAccessGroupsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		return errors.Wrapf(err, "Problem parsing request body: ")
 	}
 
@@ -596,7 +600,7 @@ func (fes *APIServer) getUserAccessGroupsHandler(ww http.ResponseWriter, req *ht
 		))
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("getUserAccessGroupsHandler")
 	if err != nil {
 		return errors.Wrapf(err, "Error generating utxo view: ")
 	}
@@ -647,7 +651,7 @@ type CheckPartyAccessGroupsResponse struct {
 func (fes *APIServer) CheckPartyAccessGroups(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CheckPartyAccessGroupsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CheckPartyAccessGroups: Problem parsing request body: %v", err))
 		return
 	}
@@ -687,7 +691,7 @@ func (fes *APIServer) CheckPartyAccessGroups(ww http.ResponseWriter, req *http.R
 		recipientKeyName)
 
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("CheckPartyAccessGroups: %v", err))
+		_AddHandlerError(ww, fmt.Sprintf("CheckPartyAccessGroups: %v", err), err)
 		return
 	}
 
@@ -713,7 +717,7 @@ func (fes *APIServer) CreateCheckPartyAccessGroupKeysResponse(
 	}
 
 	// Get the augmented UtxoView.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("CreateCheckPartyAccessGroupKeysResponse")
 	if err != nil {
 		return nil, err
 	}
@@ -755,10 +759,9 @@ type GetAccessGroupInfoRequest struct {
 
 // returns information about the access group.
 func (fes *APIServer) getAccessGroupInfo(publicKeyBase58DecodedBytes []byte, accessGroupKeyNameBytes []byte) (*AccessGroupEntryResponse, error) {
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("getAccessGroupInfo")
 	if err != nil {
-		return nil, errors.Wrap(fmt.Errorf("getAccessGroupInfo: Error generating "+
-			"utxo view: %v", err), "")
+		return nil, errors.Wrapf(err, "getAccessGroupInfo: Error generating utxo view: ")
 	}
 
 	// call the core library to fetch info about the access group.
@@ -781,7 +784,7 @@ func (fes *APIServer) GetAccessGroupInfo(ww http.ResponseWriter, req *http.Reque
 	// Parse the request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetAccessGroupInfoRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetAccessGroupInfo: Problem parsing request body: %v", err))
 		return
 	}
@@ -821,9 +824,9 @@ func (fes *APIServer) GetAccessGroupInfo(ww http.ResponseWriter, req *http.Reque
 	// get all the access groups associated with the public key.
 	accessGroupInfo, err := fes.getAccessGroupInfo(accessGroupOwnerPkBytes, accessGroupKeyNameBytes)
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetAccessGroupInfo: Problem getting access group of"+
+		_AddHandlerError(ww, fmt.Sprintf("GetAccessGroupInfo: Problem getting access group of"+
 			"public key, access group key name %s: %s: %v",
-			requestData.AccessGroupOwnerPublicKeyBase58Check, requestData.AccessGroupKeyName, err))
+			requestData.AccessGroupOwnerPublicKeyBase58Check, requestData.AccessGroupKeyName, err), err)
 		return
 	}
 	if accessGroupInfo == nil {
@@ -855,10 +858,9 @@ type GetAccessGroupMemberRequest struct {
 
 // returns information about the access group.
 func (fes *APIServer) getAccessGroupMemberInfo(memberPkBase58DecodedBytes []byte, ownerPkBase58DecodedBytes []byte, accessGroupKeyNameBytes []byte) (*AccessGroupMemberEntryResponse, error) {
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("getAccessGroupMemberInfo")
 	if err != nil {
-		return nil, errors.Wrap(fmt.Errorf("getAccessGroupMemberInfo: Error generating "+
-			"utxo view: %v", err), "")
+		return nil, errors.Wrapf(err, "getAccessGroupMemberInfo: Error generating utxo view: ")
 	}
 
 	// call the core library to fetch info about the access group.
@@ -875,7 +877,7 @@ func (fes *APIServer) GetAccessGroupMemberInfo(ww http.ResponseWriter, req *http
 	// Parse the request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetAccessGroupMemberRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetAccessGroupMemberInfo: Problem parsing request body: %v", err))
 		return
 	}
@@ -916,10 +918,10 @@ func (fes *APIServer) GetAccessGroupMemberInfo(ww http.ResponseWriter, req *http
 	// get all the access groups associated with the public key.
 	accessGroupMember, err := fes.getAccessGroupMemberInfo(accessGroupMemberPkBytes, accessGroupOwnerPkBytes, accessGroupKeyNameBytes)
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetAccessGroupMemberInfo: Problem getting access group member info of"+
+		_AddHandlerError(ww, fmt.Sprintf("GetAccessGroupMemberInfo: Problem getting access group member info of"+
 			"member publickey, owner publickey, access group key name %s: %s: %s: %v",
 			requestData.AccessGroupMemberPublicKeyBase58Check, requestData.AccessGroupOwnerPublicKeyBase58Check,
-			requestData.AccessGroupKeyName, err))
+			requestData.AccessGroupKeyName, err), err)
 		return
 	}
 
@@ -957,7 +959,7 @@ func (fes *APIServer) GetPaginatedAccessGroupMembers(ww http.ResponseWriter, req
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetPaginatedAccessGroupMembersRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedAccessGroupMembers: Problem parsing request body: %v", err))
 		return
 	}
@@ -1008,9 +1010,9 @@ func (fes *APIServer) GetPaginatedAccessGroupMembers(ww http.ResponseWriter, req
 		}
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetPaginatedAccessGroupMembers")
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedAccessGroupMembers: Error generating "+
+		_AddInternalServerError(ww, fmt.Sprintf("GetPaginatedAccessGroupMembers: Error generating "+
 			"utxo view: %v", err))
 		return
 	}
@@ -1075,14 +1077,14 @@ type GetBulkAccessGroupEntriesResponse struct {
 func (fes *APIServer) GetBulkAccessGroupEntries(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetBulkAccessGroupEntriesRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetBulkAccessGroupEntries: Problem parsing request body: %v", err))
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetBulkAccessGroupEntries")
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetBulkAccessGroupEntries: Problem fetching utxoView: %v", err))
+		_AddInternalServerError(ww, fmt.Sprintf("GetBulkAccessGroupEntries: Problem fetching utxoView: %v", err))
 		return
 	}
 
@@ -1118,3 +1120,287 @@ func (fes *APIServer) GetBulkAccessGroupEntries(ww http.ResponseWriter, req *htt
 		return
 	}
 }
+
+type IsAccessGroupKeyNameAvailableRequest struct {
+	AccessGroupOwnerPublicKeyBase58Check string `safeForLogging:"true"`
+	AccessGroupKeyName                   string `safeForLogging:"true"`
+}
+
+type IsAccessGroupKeyNameAvailableResponse struct {
+	// True if the owner doesn't have an access group with this key name, so CreateAccessGroup would accept it.
+	Available bool `safeForLogging:"true"`
+}
+
+// IsAccessGroupKeyNameAvailable lets clients check a key name before building a CreateAccessGroup transaction. Key
+// names that can never be registered, including the base key, are rejected with a bad request rather than reported
+// as unavailable.
+func (fes *APIServer) IsAccessGroupKeyNameAvailable(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := IsAccessGroupKeyNameAvailableRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("IsAccessGroupKeyNameAvailable: Problem parsing request body: %v", err))
+		return
+	}
+
+	accessGroupOwnerPkBytes, accessGroupKeyNameBytes, err := ValidateAccessGroupPublicKeyAndName(
+		requestData.AccessGroupOwnerPublicKeyBase58Check, requestData.AccessGroupKeyName)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("IsAccessGroupKeyNameAvailable: %v", err))
+		return
+	}
+
+	// Every user implicitly owns the base group, so its key name can't be registered.
+	if lib.EqualGroupKeyName(lib.NewGroupKeyName(accessGroupKeyNameBytes), lib.BaseGroupKeyName()) {
+		_AddBadRequestError(ww, "IsAccessGroupKeyNameAvailable: Access group key name cannot be the base key "+
+			"(empty or all zeros)")
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("IsAccessGroupKeyNameAvailable")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("IsAccessGroupKeyNameAvailable: Error generating utxo view: %v", err))
+		return
+	}
+
+	accessGroupEntry, err := utxoView.GetAccessGroupEntry(
+		lib.NewPublicKey(accessGroupOwnerPkBytes), lib.NewGroupKeyName(accessGroupKeyNameBytes))
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("IsAccessGroupKeyNameAvailable: Problem getting access group "+
+			"entry: %v", err))
+		return
+	}
+
+	res := IsAccessGroupKeyNameAvailableResponse{
+		Available: accessGroupEntry == nil || accessGroupEntry.IsDeleted(),
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("IsAccessGroupKeyNameAvailable: Problem encoding response as "+
+			"JSON: %v", err))
+		return
+	}
+}
+
+const (
+	// The number of groups SearchAccessGroupsByKeyNamePrefix returns when a request omits MaxResults, and the most it
+	// returns.
+	DefaultAccessGroupKeyNamePrefixSearchResults = 20
+	MaxAccessGroupKeyNamePrefixSearchResults     = 100
+)
+
+type SearchAccessGroupsByKeyNamePrefixRequest struct {
+	// Only groups owned by this public key are searched.
+	AccessGroupOwnerPublicKeyBase58Check string `safeForLogging:"true"`
+	AccessGroupKeyNamePrefix             string `safeForLogging:"true"`
+
+	// Defaults to DefaultAccessGroupKeyNamePrefixSearchResults.
+	MaxResults int `safeForLogging:"true"`
+}
+
+type SearchAccessGroupsByKeyNamePrefixResponse struct {
+	// Sorted by key name.
+	AccessGroups []AccessGroupEntryResponse `safeForLogging:"true"`
+	// True if more groups matched than MaxResults, in which case the ones with the lowest key names are returned.
+	Truncated bool `safeForLogging:"true"`
+}
+
+// SearchAccessGroupsByKeyNamePrefix returns the access groups an owner has whose key names start with a prefix, so
+// clients can offer name-based discovery within a user's groups.
+func (fes *APIServer) SearchAccessGroupsByKeyNamePrefix(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := SearchAccessGroupsByKeyNamePrefixRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("SearchAccessGroupsByKeyNamePrefix: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier(
+		"AccessGroupOwnerPublicKeyBase58Check", requestData.AccessGroupOwnerPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("SearchAccessGroupsByKeyNamePrefix: %v", err))
+		return
+	}
+	accessGroupOwnerPkBytes, _, err := lib.Base58CheckDecode(requestData.AccessGroupOwnerPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("SearchAccessGroupsByKeyNamePrefix: Problem decoding owner public "+
+			"key %s: %v", requestData.AccessGroupOwnerPublicKeyBase58Check, err))
+		return
+	}
+
+	if len(requestData.AccessGroupKeyNamePrefix) == 0 ||
+		len(requestData.AccessGroupKeyNamePrefix) > lib.MaxAccessGroupKeyNameCharacters {
+		_AddBadRequestError(ww, fmt.Sprintf("SearchAccessGroupsByKeyNamePrefix: AccessGroupKeyNamePrefix must be "+
+			"between 1 and %v characters", lib.MaxAccessGroupKeyNameCharacters))
+		return
+	}
+
+	maxResults := requestData.MaxResults
+	if maxResults == 0 {
+		maxResults = DefaultAccessGroupKeyNamePrefixSearchResults
+	}
+	if maxResults < 0 || maxResults > MaxAccessGroupKeyNamePrefixSearchResults {
+		_AddBadRequestError(ww, fmt.Sprintf("SearchAccessGroupsByKeyNamePrefix: MaxResults must be between 1 and %v",
+			MaxAccessGroupKeyNamePrefixSearchResults))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("SearchAccessGroupsByKeyNamePrefix")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("SearchAccessGroupsByKeyNamePrefix: Error generating utxo view: %v", err))
+		return
+	}
+	accessGroupIdsOwned, err := utxoView.GetAccessGroupIdsForOwner(accessGroupOwnerPkBytes)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("SearchAccessGroupsByKeyNamePrefix: Problem getting access group "+
+			"ids for owner: %v", err))
+		return
+	}
+
+	matchingAccessGroupIds, truncated := filterAccessGroupIdsByKeyNamePrefix(
+		accessGroupIdsOwned, requestData.AccessGroupKeyNamePrefix, maxResults)
+	accessGroups, err := fes.getAccessEntryResponsesForAccessIds(matchingAccessGroupIds, utxoView, accessGroupOwnerPkBytes)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("SearchAccessGroupsByKeyNamePrefix: Problem getting access group "+
+			"entries: %v", err))
+		return
+	}
+
+	res := SearchAccessGroupsByKeyNamePrefixResponse{
+		AccessGroups: []AccessGroupEntryResponse{},
+		Truncated:    truncated,
+	}
+	res.AccessGroups = append(res.AccessGroups, accessGroups...)
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("SearchAccessGroupsByKeyNamePrefix: Problem encoding response as "+
+			"JSON: %v", err))
+		return
+	}
+}
+
+// filterAccessGroupIdsByKeyNamePrefix returns the ids whose key names start with prefix, sorted by key name and
+// capped at maxResults. Key names are stored zero-padded to a fixed width, so the padding is stripped before
+// matching. Also returns whether any matches were left out.
+func filterAccessGroupIdsByKeyNamePrefix(
+	accessGroupIds []*lib.AccessGroupId,
+	prefix string,
+	maxResults int,
+) (_matchingAccessGroupIds []*lib.AccessGroupId, _truncated bool) {
+	var matchingAccessGroupIds []*lib.AccessGroupId
+	for _, accessGroupId := range accessGroupIds {
+		keyName := string(lib.MessagingKeyNameDecode(&accessGroupId.AccessGroupKeyName))
+		if strings.HasPrefix(keyName, prefix) {
+			matchingAccessGroupIds = append(matchingAccessGroupIds, accessGroupId)
+		}
+	}
+	sort.Slice(matchingAccessGroupIds, func(ii, jj int) bool {
+		return bytes.Compare(
+			matchingAccessGroupIds[ii].AccessGroupKeyName.ToBytes(),
+			matchingAccessGroupIds[jj].AccessGroupKeyName.ToBytes()) < 0
+	})
+	if len(matchingAccessGroupIds) > maxResults {
+		return matchingAccessGroupIds[:maxResults], true
+	}
+	return matchingAccessGroupIds, false
+}
+
+type GetGroupKeyMaterialForMemberRequest struct {
+	// EncodeAccessGroupIdToHex of the group.
+	AccessGroupIdHex string `safeForLogging:"true"`
+	// The member whose key material should be fetched.
+	MemberPublicKeyBase58Check string `safeForLogging:"true"`
+}
+
+type GetGroupKeyMaterialForMemberResponse struct {
+	AccessGroupOwnerPublicKeyBase58Check string `safeForLogging:"true"`
+	AccessGroupKeyName                   string `safeForLogging:"true"`
+	// The group's public key, which messages to the group are encrypted to.
+	AccessGroupPublicKeyBase58Check string `safeForLogging:"true"`
+
+	MemberPublicKeyBase58Check string `safeForLogging:"true"`
+	// The member's own access group that EncryptedKey is encrypted to.
+	AccessGroupMemberKeyName string `safeForLogging:"true"`
+	// The group's private key encrypted to the member's access group, exactly as the owner submitted it when adding
+	// the member.
+	EncryptedKey string
+}
+
+// GetGroupKeyMaterialForMember returns what a member's client needs to decrypt a group's messages: the group's
+// private key, encrypted to the member's access group when the owner added them.
+func (fes *APIServer) GetGroupKeyMaterialForMember(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetGroupKeyMaterialForMemberRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetGroupKeyMaterialForMember: Problem parsing request body: %v", err))
+		return
+	}
+
+	accessGroupId, err := DecodeAccessGroupIdFromHex(requestData.AccessGroupIdHex)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetGroupKeyMaterialForMember: Invalid AccessGroupIdHex: %v", err))
+		return
+	}
+	if err = ValidateNotDESOIdentifier("MemberPublicKeyBase58Check", requestData.MemberPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetGroupKeyMaterialForMember: %v", err))
+		return
+	}
+	memberPkBytes, _, err := lib.Base58CheckDecode(requestData.MemberPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetGroupKeyMaterialForMember: Problem decoding member public key %s: %v",
+			requestData.MemberPublicKeyBase58Check, err))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetGroupKeyMaterialForMember")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetGroupKeyMaterialForMember: Error generating utxo view: %v", err))
+		return
+	}
+	res, err := getGroupKeyMaterialForMember(
+		accessGroupId, memberPkBytes, utxoView.GetAccessGroupEntry, utxoView.GetAccessGroupMemberEntry, fes.Params)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetGroupKeyMaterialForMember: %v", err))
+		return
+	}
+	if res == nil {
+		_AddNotFoundError(ww, fmt.Sprintf("GetGroupKeyMaterialForMember: %v is not a member of group %v",
+			requestData.MemberPublicKeyBase58Check, requestData.AccessGroupIdHex))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetGroupKeyMaterialForMember: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getGroupKeyMaterialForMember returns the key material stored for the member when they were added to the group, or
+// nil if the group doesn't exist or the member isn't in it.
+func getGroupKeyMaterialForMember(
+	accessGroupId *lib.AccessGroupId,
+	memberPkBytes []byte,
+	getAccessGroupEntry func(*lib.PublicKey, *lib.GroupKeyName) (*lib.AccessGroupEntry, error),
+	getAccessGroupMemberEntry func(*lib.PublicKey, *lib.PublicKey, *lib.GroupKeyName) (*lib.AccessGroupMemberEntry, error),
+	params *lib.DeSoParams,
+) (*GetGroupKeyMaterialForMemberResponse, error) {
+	accessGroupEntry, err := getAccessGroupEntry(&accessGroupId.AccessGroupOwnerPublicKey, &accessGroupId.AccessGroupKeyName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Problem getting access group entry")
+	}
+	if accessGroupEntry == nil || accessGroupEntry.IsDeleted() || accessGroupEntry.AccessGroupPublicKey == nil {
+		return nil, nil
+	}
+	memberEntry, err := getAccessGroupMemberEntry(
+		lib.NewPublicKey(memberPkBytes), &accessGroupId.AccessGroupOwnerPublicKey, &accessGroupId.AccessGroupKeyName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Problem getting access group member entry")
+	}
+	if memberEntry == nil || memberEntry.IsDeleted() {
+		return nil, nil
+	}
+	return &GetGroupKeyMaterialForMemberResponse{
+		AccessGroupOwnerPublicKeyBase58Check: lib.PkToString(accessGroupId.AccessGroupOwnerPublicKey.ToBytes(), params),
+		AccessGroupKeyName:                   string(lib.MessagingKeyNameDecode(&accessGroupId.AccessGroupKeyName)),
+		AccessGroupPublicKeyBase58Check:      lib.PkToString(accessGroupEntry.AccessGroupPublicKey.ToBytes(), params),
+		MemberPublicKeyBase58Check:           lib.PkToString(memberPkBytes, params),
+		AccessGroupMemberKeyName:             string(lib.MessagingKeyNameDecode(memberEntry.AccessGroupMemberKeyName)),
+		EncryptedKey:                         string(memberEntry.EncryptedKey),
+	}, nil
+}
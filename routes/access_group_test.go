@@ -13,6 +13,7 @@ import (
 
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -345,3 +346,273 @@ func TestAPIAccessGroups(t *testing.T) {
 	// validate the actual response with the expected response
 	assert.Equal(&expectedResponse, actualMemberOnlyResponse)
 }
+
+func TestIsAccessGroupKeyNameAvailable(t *testing.T) {
+	require := require.New(t)
+	apiServer := newTestApiServer(t)
+
+	isAvailable := func(keyName string) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(IsAccessGroupKeyNameAvailableRequest{
+			AccessGroupOwnerPublicKeyBase58Check: senderPkString,
+			AccessGroupKeyName:                   keyName,
+		})
+		require.NoError(err)
+		request, err := http.NewRequest("POST", RoutePathIsAccessGroupKeyNameAvailable, bytes.NewBuffer(requestBody))
+		require.NoError(err)
+		response := httptest.NewRecorder()
+		apiServer.router.ServeHTTP(response, request)
+		return response
+	}
+	requireAvailable := func(keyName string, expectedAvailable bool) {
+		response := isAvailable(keyName)
+		require.Equal(http.StatusOK, response.Code, response.Body.String())
+		res := IsAccessGroupKeyNameAvailableResponse{}
+		require.NoError(json.Unmarshal(response.Body.Bytes(), &res))
+		require.Equal(expectedAvailable, res.Available, keyName)
+	}
+
+	// The name is available until the sender creates a group with it.
+	requireAvailable("group1", true)
+	requestBody, err := json.Marshal(CreateAccessGroupRequest{
+		AccessGroupOwnerPublicKeyBase58Check: senderPkString,
+		AccessGroupPublicKeyBase58Check:      lib.Base58CheckEncode(generateRandomPublicKey(t), false, apiServer.Params),
+		AccessGroupKeyName:                   "group1",
+		MinFeeRateNanosPerKB:                 apiServer.MinFeeRateNanosPerKB,
+	})
+	require.NoError(err)
+	createResponse := &CreateAccessGroupResponse{}
+	require.NoError(json.Unmarshal(ExecuteRequest(t, apiServer, RoutePathCreateAccessGroup, requestBody), createResponse))
+	SignAndSubmitTransaction(t, senderPrivString, createResponse.Transaction, apiServer)
+	requireAvailable("group1", false)
+	requireAvailable("group2", true)
+
+	// The base key and names that fail validation are rejected.
+	invalidKeyNames := []string{
+		"",
+		string(make([]byte, lib.MaxAccessGroupKeyNameCharacters)),
+		strings.Repeat("a", lib.MaxAccessGroupKeyNameCharacters+1),
+	}
+	for _, keyName := range invalidKeyNames {
+		require.Equal(http.StatusBadRequest, isAvailable(keyName).Code, keyName)
+	}
+}
+
+func TestCreateAccessGroupValidation(t *testing.T) {
+	require := require.New(t)
+	apiServer := newTestApiServer(t)
+
+	createAccessGroup := func(keyName string) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(CreateAccessGroupRequest{
+			AccessGroupOwnerPublicKeyBase58Check: senderPkString,
+			AccessGroupPublicKeyBase58Check:      lib.Base58CheckEncode(generateRandomPublicKey(t), false, apiServer.Params),
+			AccessGroupKeyName:                   keyName,
+			MinFeeRateNanosPerKB:                 apiServer.MinFeeRateNanosPerKB,
+		})
+		require.NoError(err)
+		request, err := http.NewRequest("POST", RoutePathCreateAccessGroup, bytes.NewBuffer(requestBody))
+		require.NoError(err)
+		response := httptest.NewRecorder()
+		apiServer.router.ServeHTTP(response, request)
+		return response
+	}
+
+	// A valid creation returns an unsigned access group txn.
+	response := createAccessGroup("group1")
+	require.Equal(http.StatusOK, response.Code, response.Body.String())
+	createResponse := &CreateAccessGroupResponse{}
+	require.NoError(json.Unmarshal(response.Body.Bytes(), createResponse))
+	require.Equal(lib.TxnTypeAccessGroup, createResponse.Transaction.TxnMeta.GetTxnType())
+	require.NotEmpty(createResponse.TransactionHex)
+	SignAndSubmitTransaction(t, senderPrivString, createResponse.Transaction, apiServer)
+
+	// The base key is reserved, whether it's passed as an empty name or all zeros.
+	for _, keyName := range []string{"", string(make([]byte, lib.MaxAccessGroupKeyNameCharacters))} {
+		response = createAccessGroup(keyName)
+		require.Equal(http.StatusBadRequest, response.Code, response.Body.String())
+	}
+
+	// A name the owner already uses can't be created again.
+	response = createAccessGroup("group1")
+	require.Equal(http.StatusBadRequest, response.Code, response.Body.String())
+	require.Contains(response.Body.String(), "already exists")
+}
+
+func TestAccessGroupMembersAddAndRemove(t *testing.T) {
+	require := require.New(t)
+	apiServer := newTestApiServer(t)
+
+	executeMembersRequest := func(routePath string, ownerPkString string, members []AccessGroupMember) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(AddAccessGroupMembersRequest{
+			AccessGroupOwnerPublicKeyBase58Check: ownerPkString,
+			AccessGroupKeyName:                   "group1",
+			AccessGroupMemberList:                members,
+			MinFeeRateNanosPerKB:                 apiServer.MinFeeRateNanosPerKB,
+		})
+		require.NoError(err)
+		request, err := http.NewRequest("POST", routePath, bytes.NewBuffer(requestBody))
+		require.NoError(err)
+		response := httptest.NewRecorder()
+		apiServer.router.ServeHTTP(response, request)
+		return response
+	}
+	signAndSubmitMembersTxn := func(response *httptest.ResponseRecorder) {
+		require.Equal(http.StatusOK, response.Code, response.Body.String())
+		membersResponse := &AddAccessGroupMembersResponse{}
+		require.NoError(json.Unmarshal(response.Body.Bytes(), membersResponse))
+		require.Equal(lib.TxnTypeAccessGroupMembers, membersResponse.Transaction.TxnMeta.GetTxnType())
+		SignAndSubmitTransaction(t, senderPrivString, membersResponse.Transaction, apiServer)
+	}
+	getMemberInfo := func() *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(GetAccessGroupMemberRequest{
+			AccessGroupMemberPublicKeyBase58Check: recipientPkString,
+			AccessGroupOwnerPublicKeyBase58Check:  senderPkString,
+			AccessGroupKeyName:                    "group1",
+		})
+		require.NoError(err)
+		request, err := http.NewRequest("POST", RoutePathGetAccessGroupMemberInfo, bytes.NewBuffer(requestBody))
+		require.NoError(err)
+		response := httptest.NewRecorder()
+		apiServer.router.ServeHTTP(response, request)
+		return response
+	}
+
+	// The sender creates group1.
+	requestBody, err := json.Marshal(CreateAccessGroupRequest{
+		AccessGroupOwnerPublicKeyBase58Check: senderPkString,
+		AccessGroupPublicKeyBase58Check:      lib.Base58CheckEncode(generateRandomPublicKey(t), false, apiServer.Params),
+		AccessGroupKeyName:                   "group1",
+		MinFeeRateNanosPerKB:                 apiServer.MinFeeRateNanosPerKB,
+	})
+	require.NoError(err)
+	createResponse := &CreateAccessGroupResponse{}
+	require.NoError(json.Unmarshal(ExecuteRequest(t, apiServer, RoutePathCreateAccessGroup, requestBody), createResponse))
+	SignAndSubmitTransaction(t, senderPrivString, createResponse.Transaction, apiServer)
+
+	// Someone who doesn't own group1 can't add members to it.
+	member := AccessGroupMember{
+		AccessGroupMemberPublicKeyBase58Check: recipientPkString,
+		EncryptedKey:                          string([]byte{1, 2, 3}),
+	}
+	response := executeMembersRequest(RoutePathAddAccessGroupMembers, recipientPkString, []AccessGroupMember{member})
+	require.Equal(http.StatusBadRequest, response.Code, response.Body.String())
+	require.Contains(response.Body.String(), "does not exist for owner")
+
+	// Malformed member keys are rejected.
+	response = executeMembersRequest(RoutePathAddAccessGroupMembers, senderPkString, []AccessGroupMember{{
+		AccessGroupMemberPublicKeyBase58Check: "not a public key",
+		EncryptedKey:                          string([]byte{1, 2, 3}),
+	}})
+	require.Equal(http.StatusBadRequest, response.Code, response.Body.String())
+
+	// The owner adds the recipient.
+	signAndSubmitMembersTxn(executeMembersRequest(RoutePathAddAccessGroupMembers, senderPkString, []AccessGroupMember{member}))
+	response = getMemberInfo()
+	require.Equal(http.StatusOK, response.Code, response.Body.String())
+	require.Contains(response.Body.String(), recipientPkString)
+
+	// The owner removes the recipient, after which there's no member left to remove.
+	removedMember := AccessGroupMember{AccessGroupMemberPublicKeyBase58Check: recipientPkString}
+	signAndSubmitMembersTxn(executeMembersRequest(RoutePathRemoveAccessGroupMembers, senderPkString, []AccessGroupMember{removedMember}))
+	response = executeMembersRequest(RoutePathRemoveAccessGroupMembers, senderPkString, []AccessGroupMember{removedMember})
+	require.Equal(http.StatusBadRequest, response.Code, response.Body.String())
+	require.Contains(response.Body.String(), "member entry not found")
+}
+
+func TestFilterAccessGroupIdsByKeyNamePrefix(t *testing.T) {
+	ownerPkBytes, _, err := lib.Base58CheckDecode(senderPkString)
+	require.NoError(t, err)
+	accessGroupIds := []*lib.AccessGroupId{}
+	for _, keyName := range []string{"work", "friends", "family", "fan-club"} {
+		accessGroupIds = append(accessGroupIds, lib.NewAccessGroupId(lib.NewPublicKey(ownerPkBytes), []byte(keyName)))
+	}
+	keyNames := func(accessGroupIds []*lib.AccessGroupId) []string {
+		names := []string{}
+		for _, accessGroupId := range accessGroupIds {
+			names = append(names, string(lib.MessagingKeyNameDecode(&accessGroupId.AccessGroupKeyName)))
+		}
+		return names
+	}
+
+	// Several groups match, and come back sorted by key name.
+	matches, truncated := filterAccessGroupIdsByKeyNamePrefix(accessGroupIds, "f", 10)
+	require.Equal(t, []string{"family", "fan-club", "friends"}, keyNames(matches))
+	require.False(t, truncated)
+
+	matches, truncated = filterAccessGroupIdsByKeyNamePrefix(accessGroupIds, "fa", 10)
+	require.Equal(t, []string{"family", "fan-club"}, keyNames(matches))
+	require.False(t, truncated)
+
+	// The cap keeps the lowest key names.
+	matches, truncated = filterAccessGroupIdsByKeyNamePrefix(accessGroupIds, "f", 2)
+	require.Equal(t, []string{"family", "fan-club"}, keyNames(matches))
+	require.True(t, truncated)
+
+	// A full key name matches itself, despite the zero padding.
+	matches, _ = filterAccessGroupIdsByKeyNamePrefix(accessGroupIds, "work", 10)
+	require.Equal(t, []string{"work"}, keyNames(matches))
+
+	// Nothing matches.
+	matches, truncated = filterAccessGroupIdsByKeyNamePrefix(accessGroupIds, "school", 10)
+	require.Empty(t, matches)
+	require.False(t, truncated)
+}
+
+func TestGetGroupKeyMaterialForMember(t *testing.T) {
+	owner := lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString))
+	member := lib.NewPublicKey(lib.MustBase58CheckDecode(recipientPkString))
+	nonMember := lib.NewPublicKey(lib.MustBase58CheckDecode(moneyPkString))
+	groupKeyName := lib.NewGroupKeyName([]byte("group1"))
+	memberKeyName := lib.NewGroupKeyName([]byte("memberkey"))
+	groupPublicKey := lib.NewPublicKey(generateRandomPublicKey(t))
+	accessGroupId := &lib.AccessGroupId{AccessGroupOwnerPublicKey: *owner, AccessGroupKeyName: *groupKeyName}
+
+	getAccessGroupEntry := func(groupOwner *lib.PublicKey, keyName *lib.GroupKeyName) (*lib.AccessGroupEntry, error) {
+		if *groupOwner != *owner || !lib.EqualGroupKeyName(keyName, groupKeyName) {
+			return nil, nil
+		}
+		return &lib.AccessGroupEntry{
+			AccessGroupOwnerPublicKey: owner,
+			AccessGroupKeyName:        groupKeyName,
+			AccessGroupPublicKey:      groupPublicKey,
+		}, nil
+	}
+	// Only member was added to the group.
+	getAccessGroupMemberEntry := func(memberPk *lib.PublicKey, groupOwner *lib.PublicKey, keyName *lib.GroupKeyName,
+	) (*lib.AccessGroupMemberEntry, error) {
+		if *memberPk != *member || *groupOwner != *owner || !lib.EqualGroupKeyName(keyName, groupKeyName) {
+			return nil, nil
+		}
+		return &lib.AccessGroupMemberEntry{
+			AccessGroupMemberPublicKey: member,
+			AccessGroupMemberKeyName:   memberKeyName,
+			EncryptedKey:               []byte("encrypted group key"),
+		}, nil
+	}
+
+	res, err := getGroupKeyMaterialForMember(accessGroupId, member.ToBytes(), getAccessGroupEntry,
+		getAccessGroupMemberEntry, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Equal(t, &GetGroupKeyMaterialForMemberResponse{
+		AccessGroupOwnerPublicKeyBase58Check: senderPkString,
+		AccessGroupKeyName:                   "group1",
+		AccessGroupPublicKeyBase58Check:      lib.PkToString(groupPublicKey.ToBytes(), &lib.DeSoTestnetParams),
+		MemberPublicKeyBase58Check:           recipientPkString,
+		AccessGroupMemberKeyName:             "memberkey",
+		EncryptedKey:                         "encrypted group key",
+	}, res)
+
+	// Someone who isn't in the group has no key material.
+	res, err = getGroupKeyMaterialForMember(accessGroupId, nonMember.ToBytes(), getAccessGroupEntry,
+		getAccessGroupMemberEntry, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Nil(t, res)
+
+	// Nor does anyone in a group that doesn't exist.
+	missingGroupId := &lib.AccessGroupId{
+		AccessGroupOwnerPublicKey: *owner, AccessGroupKeyName: *lib.NewGroupKeyName([]byte("missing")),
+	}
+	res, err = getGroupKeyMaterialForMember(missingGroupId, member.ToBytes(), getAccessGroupEntry,
+		getAccessGroupMemberEntry, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Nil(t, res)
+}
@@ -1,14 +1,35 @@
 package routes
 
 import (
+	"bytes"
+	"encoding/gob"
 	"encoding/json"
 	"fmt"
 	"github.com/deso-protocol/core/lib"
 	"github.com/golang/glog"
 	"io"
 	"net/http"
+	"time"
 )
 
+const (
+	ExchangeRateParameterUSDCentsPerDeSoReserveExchangeRate = "USDCentsPerDeSoReserveExchangeRate"
+	ExchangeRateParameterBuyDeSoFeeBasisPoints              = "BuyDeSoFeeBasisPoints"
+
+	// The maximum number of changes returned by GetExchangeRateParameterHistory.
+	MaxExchangeRateParameterHistoryToFetch = 1000
+	// Keys in the history are <prefix, TimestampNanos, ParameterName>. This bounds the length of the longest one.
+	exchangeRateParameterHistoryMaxKeyLen = 1 + 8 + 64
+)
+
+// ExchangeRateParameterChange records a single change to the reserve exchange rate or buy DeSo fee basis points.
+type ExchangeRateParameterChange struct {
+	ParameterName  string
+	Value          uint64
+	TimestampNanos uint64
+	AdminPublicKey string
+}
+
 type SetUSDCentsToDeSoExchangeRateRequest struct {
 	USDCentsPerDeSo uint64
 	AdminPublicKey  string
@@ -22,11 +43,20 @@ type SetUSDCentsToDeSoExchangeRateResponse struct {
 func (fes *APIServer) SetUSDCentsToDeSoReserveExchangeRate(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SetUSDCentsToDeSoExchangeRateRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SetUSDCentsToDeSoReserveExchangeRate: Problem parsing request body: %v", err))
 		return
 	}
 
+	// Record the change before applying it, so that a failed request never leaves a change missing from the history.
+	if err := fes.recordExchangeRateParameterChange(
+		ExchangeRateParameterUSDCentsPerDeSoReserveExchangeRate,
+		requestData.USDCentsPerDeSo,
+		requestData.AdminPublicKey); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("SetUSDCentsToDeSoReserveExchangeRate: Problem recording change in history: %v", err))
+		return
+	}
+
 	// Put the new value in global state
 	if err := fes.GlobalState.Put(
 		GlobalStateKeyForUSDCentsToDeSoReserveExchangeRate(),
@@ -99,11 +129,20 @@ type SetBuyDeSoFeeBasisPointsResponse struct {
 func (fes *APIServer) SetBuyDeSoFeeBasisPoints(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SetBuyDeSoFeeBasisPointsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SetBuyDeSoFeeBasisPoints: Problem parsing request body: %v", err))
 		return
 	}
 
+	// Record the change before applying it, so that a failed request never leaves a change missing from the history.
+	if err := fes.recordExchangeRateParameterChange(
+		ExchangeRateParameterBuyDeSoFeeBasisPoints,
+		requestData.BuyDeSoFeeBasisPoints,
+		requestData.AdminPublicKey); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("SetBuyDeSoFeeBasisPoints: Problem recording change in history: %v", err))
+		return
+	}
+
 	if err := fes.GlobalState.Put(
 		GlobalStateKeyForBuyDeSoFeeBasisPoints(),
 		lib.UintToBuf(requestData.BuyDeSoFeeBasisPoints)); err != nil {
@@ -158,3 +197,56 @@ func (fes *APIServer) SetBuyDeSoFeeBasisPointsResponseFromGlobalState() {
 	}
 	fes.BuyDESOFeeBasisPoints = feeBasisPoints
 }
+
+// recordExchangeRateParameterChange appends a change to the exchange rate parameter history in global state.
+func (fes *APIServer) recordExchangeRateParameterChange(parameterName string, value uint64, adminPublicKey string) error {
+	change := ExchangeRateParameterChange{
+		ParameterName:  parameterName,
+		Value:          value,
+		TimestampNanos: uint64(time.Now().UnixNano()),
+		AdminPublicKey: adminPublicKey,
+	}
+	changeDataBuf := bytes.NewBuffer([]byte{})
+	if err := gob.NewEncoder(changeDataBuf).Encode(change); err != nil {
+		return err
+	}
+	return fes.GlobalState.Put(
+		GlobalStateKeyForExchangeRateParameterChange(change.TimestampNanos, parameterName), changeDataBuf.Bytes())
+}
+
+type GetExchangeRateParameterHistoryResponse struct {
+	// Changes to the reserve exchange rate and buy DeSo fee basis points, newest first.
+	History []ExchangeRateParameterChange
+}
+
+// GetExchangeRateParameterHistory returns the most recent changes to the reserve exchange rate and buy DeSo fee basis
+// points. It's an admin route because each change names the admin who made it.
+func (fes *APIServer) GetExchangeRateParameterHistory(ww http.ResponseWriter, req *http.Request) {
+	_, vals, err := fes.GlobalState.Seek(
+		_GlobalStatePrefixForExchangeRateParameterHistory, /*startPrefix*/
+		_GlobalStatePrefixForExchangeRateParameterHistory, /*validForPrefix*/
+		exchangeRateParameterHistoryMaxKeyLen,             /*maxKeyLen*/
+		MaxExchangeRateParameterHistoryToFetch,            /*numToFetch*/
+		true,                                              /*reverse*/
+		true,                                              /*fetchValues*/
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetExchangeRateParameterHistory: Problem seeking history: %v", err))
+		return
+	}
+
+	res := GetExchangeRateParameterHistoryResponse{History: []ExchangeRateParameterChange{}}
+	for _, val := range vals {
+		change := ExchangeRateParameterChange{}
+		if err = gob.NewDecoder(bytes.NewReader(val)).Decode(&change); err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetExchangeRateParameterHistory: Problem decoding change: %v", err))
+			return
+		}
+		res.History = append(res.History, change)
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetExchangeRateParameterHistory: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
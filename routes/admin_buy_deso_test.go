@@ -0,0 +1,71 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetExchangeRateParameterHistory(t *testing.T) {
+	db, _ := GetTestBadgerDb(t)
+	apiServer := &APIServer{GlobalState: &GlobalState{GlobalStateDB: db}}
+
+	setBuyDeSoFeeBasisPoints := func(basisPoints uint64) {
+		requestBody, err := json.Marshal(SetBuyDeSoFeeBasisPointsRequest{
+			BuyDeSoFeeBasisPoints: basisPoints,
+			AdminPublicKey:        senderPkString,
+		})
+		require.NoError(t, err)
+		request, err := http.NewRequest("POST", RoutePathSetBuyDeSoFeeBasisPoints, bytes.NewBuffer(requestBody))
+		require.NoError(t, err)
+		response := httptest.NewRecorder()
+		apiServer.SetBuyDeSoFeeBasisPoints(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+	}
+	getHistory := func() []ExchangeRateParameterChange {
+		request, err := http.NewRequest("POST", RoutePathGetExchangeRateParameterHistory, nil)
+		require.NoError(t, err)
+		response := httptest.NewRecorder()
+		apiServer.GetExchangeRateParameterHistory(response, request)
+		require.Equal(t, http.StatusOK, response.Code)
+		res := GetExchangeRateParameterHistoryResponse{}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &res))
+		return res.History
+	}
+
+	require.Empty(t, getHistory())
+
+	// Changes accumulate and are returned newest first.
+	setBuyDeSoFeeBasisPoints(100)
+	require.NoError(t, apiServer.recordExchangeRateParameterChange(
+		ExchangeRateParameterUSDCentsPerDeSoReserveExchangeRate, 500, senderPkString))
+	setBuyDeSoFeeBasisPoints(250)
+
+	history := getHistory()
+	require.Len(t, history, 3)
+	require.Equal(t, ExchangeRateParameterBuyDeSoFeeBasisPoints, history[0].ParameterName)
+	require.Equal(t, uint64(250), history[0].Value)
+	require.Equal(t, senderPkString, history[0].AdminPublicKey)
+	require.Equal(t, ExchangeRateParameterUSDCentsPerDeSoReserveExchangeRate, history[1].ParameterName)
+	require.Equal(t, uint64(500), history[1].Value)
+	require.Equal(t, ExchangeRateParameterBuyDeSoFeeBasisPoints, history[2].ParameterName)
+	require.Equal(t, uint64(100), history[2].Value)
+	require.Greater(t, history[0].TimestampNanos, history[1].TimestampNanos)
+	require.Greater(t, history[1].TimestampNanos, history[2].TimestampNanos)
+}
+
+func TestGetExchangeRateParameterHistoryIsAdminOnly(t *testing.T) {
+	apiServer := newTestApiServer(t)
+
+	// The history names the admin behind each change, so it's only served to admins.
+	request, err := http.NewRequest("POST", RoutePathGetExchangeRateParameterHistory, bytes.NewBufferString("{}"))
+	require.NoError(t, err)
+	response := httptest.NewRecorder()
+	apiServer.router.ServeHTTP(response, request)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "CheckAdminPublicKey")
+}
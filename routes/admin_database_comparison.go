@@ -0,0 +1,435 @@
+package routes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/dgraph-io/badger/v3"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// databaseComparisonMaxBytesPerIteration is the maximum number of bytes fetched from each db in a single
+// DBIteratePrefixKeys call while comparing a prefix.
+const databaseComparisonMaxBytesPerIteration = uint32(8 << 22)
+
+// OpenBadgerDataDir returns the badgerDB handle associated with a dataDir path.
+func OpenBadgerDataDir(dataDir string) (*badger.DB, error) {
+	dir := lib.GetBadgerDbPath(dataDir)
+	opts := lib.PerformanceBadgerOptions(dir)
+	opts.ValueDir = lib.GetBadgerDbPath(dataDir)
+	db, err := badger.Open(opts)
+	if err != nil {
+		return nil, errors.Wrap(err, "OpenBadgerDataDir: failed to open badger")
+	}
+	return db, nil
+}
+
+// DatabaseComparisonPrefixResult describes how two dbs compare on a single state prefix.
+type DatabaseComparisonPrefixResult struct {
+	Prefix byte
+
+	// The dbs returned a different number of entries for the prefix.
+	InvalidLengths bool
+	// The dbs returned different keys at the same position.
+	InvalidKeys bool
+	// The dbs returned different values for the same position.
+	InvalidValues bool
+	// One db had more entries to iterate over than the other.
+	InvalidFull bool
+
+	// The number of entries that were found in db0 but not in db1.
+	EntriesMissingFromDb1 int
+
+	// The first key whose values differ, along with the differing values. The values are omitted from JSON
+	// responses since they can be arbitrarily large.
+	FirstMismatchedKey      []byte `json:",omitempty"`
+	FirstMismatchedValueDb0 []byte `json:"-"`
+	FirstMismatchedValueDb1 []byte `json:"-"`
+}
+
+// IsMismatch returns true if the prefix differs between the two dbs.
+func (result *DatabaseComparisonPrefixResult) IsMismatch() bool {
+	return result.InvalidLengths || result.InvalidKeys || result.InvalidValues || result.InvalidFull
+}
+
+// DatabaseComparisonReport is the result of comparing every state prefix of two dbs.
+type DatabaseComparisonReport struct {
+	PrefixResults  []*DatabaseComparisonPrefixResult
+	BrokenPrefixes []byte
+
+	// Set if the comparison was cancelled before every prefix was compared. PrefixResults then only covers the
+	// prefixes compared before the cancellation.
+	Incomplete bool `json:",omitempty"`
+}
+
+// GetDatabaseComparisonStatePrefixes returns the sorted list of state prefixes compared by CompareDatabases.
+func GetDatabaseComparisonStatePrefixes() []byte {
+	var prefixes []byte
+	for prefix, isState := range lib.StatePrefixes.StatePrefixesMap {
+		if !isState {
+			continue
+		}
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Slice(prefixes, func(ii, jj int) bool {
+		return prefixes[ii] < prefixes[jj]
+	})
+	return prefixes
+}
+
+// CompareDatabases compares every state prefix of db0 and db1. If onPrefixDone is non-nil, it is called with the
+// result of each prefix as soon as that prefix has been compared, which allows callers to report progress.
+func CompareDatabases(
+	db0 *badger.DB,
+	db1 *badger.DB,
+	onPrefixDone func(result *DatabaseComparisonPrefixResult),
+) (*DatabaseComparisonReport, error) {
+	return CompareDatabasesWithContext(context.Background(), db0, db1, onPrefixDone)
+}
+
+// CompareDatabasesWithContext is CompareDatabases, but stops once ctx is done. The prefix being compared when that
+// happens is finished first. The report of the prefixes compared so far is returned, marked Incomplete, along with
+// ctx's error.
+func CompareDatabasesWithContext(
+	ctx context.Context,
+	db0 *badger.DB,
+	db1 *badger.DB,
+	onPrefixDone func(result *DatabaseComparisonPrefixResult),
+) (*DatabaseComparisonReport, error) {
+	report := &DatabaseComparisonReport{}
+	for _, prefix := range GetDatabaseComparisonStatePrefixes() {
+		if err := ctx.Err(); err != nil {
+			report.Incomplete = true
+			return report, err
+		}
+		result, err := compareDatabasesOnPrefix(db0, db1, prefix)
+		if err != nil {
+			return nil, errors.Wrapf(err, "CompareDatabases: Problem comparing prefix %v", prefix)
+		}
+		report.PrefixResults = append(report.PrefixResults, result)
+		if result.IsMismatch() {
+			report.BrokenPrefixes = append(report.BrokenPrefixes, prefix)
+		}
+		if onPrefixDone != nil {
+			onPrefixDone(result)
+		}
+	}
+	return report, nil
+}
+
+func compareDatabasesOnPrefix(db0 *badger.DB, db1 *badger.DB, prefix byte) (*DatabaseComparisonPrefixResult, error) {
+	result := &DatabaseComparisonPrefixResult{Prefix: prefix}
+	prefixBytes := []byte{prefix}
+	lastPrefix := prefixBytes
+	existingEntriesDb0 := make(map[string][]byte)
+	for {
+		db0Entries, full0, err := lib.DBIteratePrefixKeys(db0, prefixBytes, lastPrefix, databaseComparisonMaxBytesPerIteration)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading db0 err: %v", err)
+		}
+		for _, entry := range db0Entries {
+			existingEntriesDb0[hex.EncodeToString(entry.Key)] = entry.Value
+		}
+
+		db1Entries, full1, err := lib.DBIteratePrefixKeys(db1, prefixBytes, lastPrefix, databaseComparisonMaxBytesPerIteration)
+		if err != nil {
+			return nil, fmt.Errorf("Error reading db1 err: %v", err)
+		}
+		for _, entry := range db1Entries {
+			delete(existingEntriesDb0, hex.EncodeToString(entry.Key))
+		}
+
+		if len(db0Entries) != len(db1Entries) {
+			result.InvalidLengths = true
+			break
+		}
+		for ii, entry := range db0Entries {
+			if !reflect.DeepEqual(entry.Key, db1Entries[ii].Key) {
+				result.InvalidKeys = true
+			}
+			if !reflect.DeepEqual(entry.Value, db1Entries[ii].Value) && !result.InvalidValues {
+				result.InvalidValues = true
+				result.FirstMismatchedKey = entry.Key
+				result.FirstMismatchedValueDb0 = entry.Value
+				result.FirstMismatchedValueDb1 = db1Entries[ii].Value
+			}
+		}
+		if full0 != full1 {
+			result.InvalidFull = true
+		}
+
+		if len(db0Entries) == 0 || !full0 {
+			break
+		}
+		lastPrefix = db0Entries[len(db0Entries)-1].Key
+	}
+	result.EntriesMissingFromDb1 = len(existingEntriesDb0)
+	return result, nil
+}
+
+// CompareDataDirs opens the dbs in both data dirs and compares them with CompareDatabases.
+func CompareDataDirs(
+	dataDir0 string,
+	dataDir1 string,
+	onPrefixDone func(result *DatabaseComparisonPrefixResult),
+) (*DatabaseComparisonReport, error) {
+	db0, err := OpenBadgerDataDir(dataDir0)
+	if err != nil {
+		return nil, errors.Wrapf(err, "CompareDataDirs: Problem opening %v", dataDir0)
+	}
+	defer db0.Close()
+	db1, err := OpenBadgerDataDir(dataDir1)
+	if err != nil {
+		return nil, errors.Wrapf(err, "CompareDataDirs: Problem opening %v", dataDir1)
+	}
+	defer db1.Close()
+	return CompareDatabases(db0, db1, onPrefixDone)
+}
+
+type DatabaseComparisonJobStatus string
+
+const (
+	DatabaseComparisonJobStatusRunning  DatabaseComparisonJobStatus = "RUNNING"
+	DatabaseComparisonJobStatusFinished DatabaseComparisonJobStatus = "FINISHED"
+	DatabaseComparisonJobStatusFailed   DatabaseComparisonJobStatus = "FAILED"
+)
+
+// DatabaseComparisonJob tracks a single comparison running in the background.
+type DatabaseComparisonJob struct {
+	JobID    string
+	DataDir0 string
+	DataDir1 string
+
+	Status          DatabaseComparisonJobStatus
+	TotalPrefixes   int
+	PrefixesDone    int
+	MismatchesFound int
+	StartedAt       time.Time
+	FinishedAt      time.Time
+	Report          *DatabaseComparisonReport
+	Error           string
+}
+
+// DatabaseComparisonJobs runs database comparisons in the background. Comparisons read every state prefix of both
+// dbs, so only one is allowed to run at a time.
+type DatabaseComparisonJobs struct {
+	mtx          sync.RWMutex
+	jobs         map[string]*DatabaseComparisonJob
+	runningJobID string
+
+	// compareDataDirs is swapped out in tests.
+	compareDataDirs func(dataDir0 string, dataDir1 string,
+		onPrefixDone func(result *DatabaseComparisonPrefixResult)) (*DatabaseComparisonReport, error)
+	totalPrefixes int
+}
+
+func NewDatabaseComparisonJobs() *DatabaseComparisonJobs {
+	return &DatabaseComparisonJobs{
+		jobs:            make(map[string]*DatabaseComparisonJob),
+		compareDataDirs: CompareDataDirs,
+		totalPrefixes:   len(GetDatabaseComparisonStatePrefixes()),
+	}
+}
+
+// Start launches a comparison of the two data dirs in the background and returns its job ID. It returns an error if
+// another comparison is still running.
+func (jobs *DatabaseComparisonJobs) Start(dataDir0 string, dataDir1 string) (string, error) {
+	jobs.mtx.Lock()
+	defer jobs.mtx.Unlock()
+
+	if jobs.runningJobID != "" {
+		return "", fmt.Errorf("Database comparison %v is still running", jobs.runningJobID)
+	}
+
+	jobIDBytes := make([]byte, 16)
+	if _, err := rand.Read(jobIDBytes); err != nil {
+		return "", errors.Wrapf(err, "Problem generating job ID")
+	}
+	job := &DatabaseComparisonJob{
+		JobID:         hex.EncodeToString(jobIDBytes),
+		DataDir0:      dataDir0,
+		DataDir1:      dataDir1,
+		Status:        DatabaseComparisonJobStatusRunning,
+		TotalPrefixes: jobs.totalPrefixes,
+		StartedAt:     time.Now(),
+	}
+	jobs.jobs[job.JobID] = job
+	jobs.runningJobID = job.JobID
+
+	go jobs.run(job)
+	return job.JobID, nil
+}
+
+func (jobs *DatabaseComparisonJobs) run(job *DatabaseComparisonJob) {
+	report, err := jobs.compareDataDirs(job.DataDir0, job.DataDir1, func(result *DatabaseComparisonPrefixResult) {
+		jobs.mtx.Lock()
+		defer jobs.mtx.Unlock()
+		job.PrefixesDone++
+		if result.IsMismatch() {
+			job.MismatchesFound++
+		}
+	})
+
+	jobs.mtx.Lock()
+	defer jobs.mtx.Unlock()
+	job.FinishedAt = time.Now()
+	if err != nil {
+		glog.Errorf("DatabaseComparisonJobs: Comparison %v failed: %v", job.JobID, err)
+		job.Status = DatabaseComparisonJobStatusFailed
+		job.Error = err.Error()
+	} else {
+		job.Status = DatabaseComparisonJobStatusFinished
+		job.Report = report
+	}
+	jobs.runningJobID = ""
+}
+
+// Get returns a copy of the job with the given ID, or nil if there is no such job.
+func (jobs *DatabaseComparisonJobs) Get(jobID string) *DatabaseComparisonJob {
+	jobs.mtx.RLock()
+	defer jobs.mtx.RUnlock()
+
+	job, exists := jobs.jobs[jobID]
+	if !exists {
+		return nil
+	}
+	jobCopy := *job
+	return &jobCopy
+}
+
+type StartDatabaseComparisonRequest struct {
+	DataDir0 string `safeForLogging:"true"`
+	DataDir1 string `safeForLogging:"true"`
+}
+
+type StartDatabaseComparisonResponse struct {
+	JobID string `safeForLogging:"true"`
+}
+
+// StartDatabaseComparison launches a comparison of two data dirs in the background.
+func (fes *APIServer) StartDatabaseComparison(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := StartDatabaseComparisonRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("StartDatabaseComparison: Problem parsing request body: %v", err))
+		return
+	}
+	if requestData.DataDir0 == "" || requestData.DataDir1 == "" {
+		_AddBadRequestError(ww, "StartDatabaseComparison: Must provide both DataDir0 and DataDir1")
+		return
+	}
+
+	jobID, err := fes.databaseComparisonJobs.Start(requestData.DataDir0, requestData.DataDir1)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("StartDatabaseComparison: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(StartDatabaseComparisonResponse{JobID: jobID}); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("StartDatabaseComparison: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+type GetDatabaseComparisonRequest struct {
+	JobID string `safeForLogging:"true"`
+}
+
+type GetDatabaseComparisonStatusResponse struct {
+	Status          DatabaseComparisonJobStatus `safeForLogging:"true"`
+	TotalPrefixes   int                         `safeForLogging:"true"`
+	PrefixesDone    int                         `safeForLogging:"true"`
+	MismatchesFound int                         `safeForLogging:"true"`
+	StartedAt       time.Time                   `safeForLogging:"true"`
+	FinishedAt      time.Time                   `safeForLogging:"true"`
+	Error           string                      `safeForLogging:"true"`
+}
+
+type GetDatabaseComparisonResultResponse struct {
+	DataDir0 string `safeForLogging:"true"`
+	DataDir1 string `safeForLogging:"true"`
+	// True if every state prefix is identical in both dbs.
+	Identical bool `safeForLogging:"true"`
+	Report    *DatabaseComparisonReport
+}
+
+// GetDatabaseComparisonStatus returns the progress of a database comparison.
+func (fes *APIServer) GetDatabaseComparisonStatus(ww http.ResponseWriter, req *http.Request) {
+	job, ok := fes.getDatabaseComparisonJobForRequest(ww, req, "GetDatabaseComparisonStatus")
+	if !ok {
+		return
+	}
+
+	res := GetDatabaseComparisonStatusResponse{
+		Status:          job.Status,
+		TotalPrefixes:   job.TotalPrefixes,
+		PrefixesDone:    job.PrefixesDone,
+		MismatchesFound: job.MismatchesFound,
+		StartedAt:       job.StartedAt,
+		FinishedAt:      job.FinishedAt,
+		Error:           job.Error,
+	}
+	if err := json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDatabaseComparisonStatus: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// GetDatabaseComparisonResult returns the final report of a finished database comparison.
+func (fes *APIServer) GetDatabaseComparisonResult(ww http.ResponseWriter, req *http.Request) {
+	job, ok := fes.getDatabaseComparisonJobForRequest(ww, req, "GetDatabaseComparisonResult")
+	if !ok {
+		return
+	}
+
+	switch job.Status {
+	case DatabaseComparisonJobStatusRunning:
+		_AddBadRequestError(ww, fmt.Sprintf("GetDatabaseComparisonResult: Job %v is still running", job.JobID))
+		return
+	case DatabaseComparisonJobStatusFailed:
+		_AddBadRequestError(ww, fmt.Sprintf("GetDatabaseComparisonResult: Job %v failed: %v", job.JobID, job.Error))
+		return
+	}
+
+	res := GetDatabaseComparisonResultResponse{
+		DataDir0:  job.DataDir0,
+		DataDir1:  job.DataDir1,
+		Identical: len(job.Report.BrokenPrefixes) == 0,
+		Report:    job.Report,
+	}
+	if err := json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDatabaseComparisonResult: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+func (fes *APIServer) getDatabaseComparisonJobForRequest(
+	ww http.ResponseWriter,
+	req *http.Request,
+	handlerName string,
+) (*DatabaseComparisonJob, bool) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDatabaseComparisonRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("%v: Problem parsing request body: %v", handlerName, err))
+		return nil, false
+	}
+
+	job := fes.databaseComparisonJobs.Get(requestData.JobID)
+	if job == nil {
+		_AddNotFoundError(ww, fmt.Sprintf("%v: No database comparison with JobID %v", handlerName, requestData.JobID))
+		return nil, false
+	}
+	return job, true
+}
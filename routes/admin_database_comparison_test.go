@@ -0,0 +1,134 @@
+package routes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDatabaseComparisonJobs(t *testing.T) {
+	// Replace the comparison with one that reports two prefixes and then waits to be released.
+	release := make(chan struct{})
+	prefixesReported := make(chan struct{})
+	jobs := NewDatabaseComparisonJobs()
+	jobs.compareDataDirs = func(dataDir0 string, dataDir1 string,
+		onPrefixDone func(result *DatabaseComparisonPrefixResult)) (*DatabaseComparisonReport, error) {
+
+		okResult := &DatabaseComparisonPrefixResult{Prefix: 1}
+		brokenResult := &DatabaseComparisonPrefixResult{Prefix: 2, InvalidValues: true}
+		onPrefixDone(okResult)
+		onPrefixDone(brokenResult)
+		close(prefixesReported)
+		<-release
+		return &DatabaseComparisonReport{
+			PrefixResults:  []*DatabaseComparisonPrefixResult{okResult, brokenResult},
+			BrokenPrefixes: []byte{2},
+		}, nil
+	}
+	apiServer := &APIServer{databaseComparisonJobs: jobs}
+
+	// Start a job.
+	response := postDatabaseComparisonRequest(t, apiServer.StartDatabaseComparison,
+		StartDatabaseComparisonRequest{DataDir0: "/data/a", DataDir1: "/data/b"})
+	require.Equal(t, http.StatusOK, response.Code)
+	startResponse := StartDatabaseComparisonResponse{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &startResponse))
+	require.NotEmpty(t, startResponse.JobID)
+	<-prefixesReported
+
+	// A second job is rejected while the first is running.
+	response = postDatabaseComparisonRequest(t, apiServer.StartDatabaseComparison,
+		StartDatabaseComparisonRequest{DataDir0: "/data/a", DataDir1: "/data/c"})
+	require.Equal(t, http.StatusBadRequest, response.Code)
+
+	// Progress is reported while the job runs, but the result isn't available yet.
+	response = postDatabaseComparisonRequest(t, apiServer.GetDatabaseComparisonStatus,
+		GetDatabaseComparisonRequest{JobID: startResponse.JobID})
+	require.Equal(t, http.StatusOK, response.Code)
+	statusResponse := GetDatabaseComparisonStatusResponse{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &statusResponse))
+	require.Equal(t, DatabaseComparisonJobStatusRunning, statusResponse.Status)
+	require.Equal(t, 2, statusResponse.PrefixesDone)
+	require.Equal(t, 1, statusResponse.MismatchesFound)
+
+	response = postDatabaseComparisonRequest(t, apiServer.GetDatabaseComparisonResult,
+		GetDatabaseComparisonRequest{JobID: startResponse.JobID})
+	require.Equal(t, http.StatusBadRequest, response.Code)
+
+	// Once the job finishes the result is available and a new job can be started.
+	close(release)
+	require.Eventually(t, func() bool {
+		return jobs.Get(startResponse.JobID).Status == DatabaseComparisonJobStatusFinished
+	}, time.Second, time.Millisecond)
+
+	response = postDatabaseComparisonRequest(t, apiServer.GetDatabaseComparisonResult,
+		GetDatabaseComparisonRequest{JobID: startResponse.JobID})
+	require.Equal(t, http.StatusOK, response.Code)
+	resultResponse := GetDatabaseComparisonResultResponse{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &resultResponse))
+	require.False(t, resultResponse.Identical)
+	require.Equal(t, []byte{2}, resultResponse.Report.BrokenPrefixes)
+
+	jobs.compareDataDirs = func(dataDir0 string, dataDir1 string,
+		onPrefixDone func(result *DatabaseComparisonPrefixResult)) (*DatabaseComparisonReport, error) {
+		return &DatabaseComparisonReport{}, nil
+	}
+	_, err := jobs.Start("/data/a", "/data/c")
+	require.NoError(t, err)
+
+	// Unknown jobs are not found.
+	response = postDatabaseComparisonRequest(t, apiServer.GetDatabaseComparisonStatus,
+		GetDatabaseComparisonRequest{JobID: "unknown"})
+	require.Equal(t, http.StatusNotFound, response.Code)
+}
+
+func postDatabaseComparisonRequest(
+	t *testing.T,
+	handler func(ww http.ResponseWriter, req *http.Request),
+	requestData interface{},
+) *httptest.ResponseRecorder {
+	t.Helper()
+	requestBody, err := json.Marshal(requestData)
+	require.NoError(t, err)
+	request, err := http.NewRequest("POST", RoutePathStartDatabaseComparison, bytes.NewBuffer(requestBody))
+	require.NoError(t, err)
+	response := httptest.NewRecorder()
+	handler(response, request)
+	return response
+}
+
+func TestCompareDatabasesWithContextCancelled(t *testing.T) {
+	db0, err := OpenBadgerDataDir(t.TempDir())
+	require.NoError(t, err)
+	defer db0.Close()
+	db1, err := OpenBadgerDataDir(t.TempDir())
+	require.NoError(t, err)
+	defer db1.Close()
+
+	// Cancel as soon as the first prefix has been compared, as the tool does on the first signal.
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	var prefixesDone []byte
+	report, err := CompareDatabasesWithContext(ctx, db0, db1, func(result *DatabaseComparisonPrefixResult) {
+		prefixesDone = append(prefixesDone, result.Prefix)
+		cancel()
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	require.True(t, report.Incomplete)
+	require.Len(t, report.PrefixResults, 1)
+	require.Equal(t, GetDatabaseComparisonStatePrefixes()[0], report.PrefixResults[0].Prefix)
+	require.Equal(t, []byte{report.PrefixResults[0].Prefix}, prefixesDone)
+	require.Empty(t, report.BrokenPrefixes)
+
+	// Without cancellation every prefix is compared.
+	report, err = CompareDatabases(db0, db1, nil)
+	require.NoError(t, err)
+	require.False(t, report.Incomplete)
+	require.Len(t, report.PrefixResults, len(GetDatabaseComparisonStatePrefixes()))
+}
@@ -28,7 +28,7 @@ type AdminPinPostResponse struct{}
 func (fes *APIServer) AdminPinPost(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminPinPostRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateGlobalFeed: Problem parsing request body: %v", err))
 		return
 	}
@@ -48,7 +48,7 @@ func (fes *APIServer) AdminPinPost(ww http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("AdminPinPost")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminPinPost: Problem fetching utxoView: %v", err))
 		return
@@ -103,7 +103,7 @@ type AdminUpdateGlobalFeedResponse struct{}
 func (fes *APIServer) AdminUpdateGlobalFeed(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminUpdateGlobalFeedRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateGlobalFeed: Problem parsing request body: %v", err))
 		return
 	}
@@ -123,7 +123,7 @@ func (fes *APIServer) AdminUpdateGlobalFeed(ww http.ResponseWriter, req *http.Re
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("AdminUpdateGlobalFeed")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateGlobalFeed: Problem fetching utxoView: %v", err))
 		return
@@ -190,13 +190,13 @@ func (fes *APIServer) AdminRemoveNilPosts(ww http.ResponseWriter, req *http.Requ
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminRemoveNilPostsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateGlobalFeed: Problem parsing request body: %v", err))
 		return
 	}
 
 	// Get a view with all the mempool transactions (used to get all posts / reader state).
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("AdminRemoveNilPosts")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"AdminRemoveNilPosts: Error getting augmented universal view: #{err}"))
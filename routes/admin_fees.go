@@ -42,7 +42,7 @@ type AdminSetTransactionFeeForTransactionTypeResponse struct {
 func (fes *APIServer) AdminSetTransactionFeeForTransactionType(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminSetTransactionFeeForTransactionTypeRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminSetTransactionFeeForTransactionType: Problem parsing request body: %v", err))
 		return
 	}
@@ -100,7 +100,7 @@ type AdminSetAllTransactionFeesResponse struct {
 func (fes *APIServer) AdminSetAllTransactionFees(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminSetAllTransactionFeesRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminSetAllTransactionFees: Problem parsing request body: %v", err))
 		return
 	}
@@ -199,7 +199,7 @@ func (fes *APIServer) TxnFeeMapToResponse(skipProfileEntryResponses bool) map[st
 	// If we're including ProfileEntryResponses, we need to get a utxoView.
 	if !skipProfileEntryResponses {
 		var err error
-		if utxoView, err = fes.backendServer.GetMempool().GetAugmentedUniversalView(); err != nil {
+		if utxoView, err = fes.getAugmentedUniversalView("TxnFeeMapToResponse"); err != nil {
 			// Since we only need ProfileEntryResponses in the admin panel, it's okay to swallow this errors. The admin
 			// will just see public keys instead of usernames + avatars.
 			glog.Errorf("TxnFeeMapToResponse: Unable to get utxoView - you won't be able to see usernames and avatars")
@@ -293,7 +293,7 @@ type AdminAddExemptPublicKey struct {
 func (fes *APIServer) AdminAddExemptPublicKey(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminAddExemptPublicKey{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminAddExemptPublicKey: Problem parsing request body: %v", err))
 		return
 	}
@@ -333,7 +333,7 @@ type AdminGetExemptPublicKeysResponse struct {
 // AdminGetExemptPublicKeys gets a map of public key to ProfileEntryResponse that represents the public keys that are
 // exempt from node fees.
 func (fes *APIServer) AdminGetExemptPublicKeys(ww http.ResponseWriter, req *http.Request) {
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("AdminGetExemptPublicKeys")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminGetExemptPublicKeys: Error getting utxoView: %v", err))
 		return
@@ -22,12 +22,12 @@ type AdminResetJumioRequest struct {
 func (fes *APIServer) AdminResetJumioForPublicKey(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminResetJumioRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminResetJumioForPublicKey: Problem parsing request body: %v", err))
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("AdminResetJumioForPublicKey")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminResetJumioForPublicKey: error getting utxoview: %v", err))
 		return
@@ -115,7 +115,7 @@ type AdminUpdateJumioDeSoResponse struct {
 func (fes *APIServer) AdminUpdateJumioDeSo(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminUpdateJumioDeSoRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateJumioDeSo: Problem parsing request body: %v", err))
 		return
 	}
@@ -148,7 +148,7 @@ type AdminUpdateJumioUSDCentsResponse struct {
 func (fes *APIServer) AdminUpdateJumioUSDCents(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminUpdateJumioUSDCentsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateJumioDeSo: Problem parsing request body: %v", err))
 		return
 	}
@@ -187,7 +187,7 @@ type AdminUpdateJumioKickbackUSDCentsResponse struct {
 func (fes *APIServer) AdminUpdateJumioKickbackUSDCents(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminUpdateJumioKickbackUSDCentsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateJumioKickbackUSDCents: Problem parsing request body: %v", err))
 		return
 	}
@@ -229,11 +229,11 @@ type AdminJumioCallback struct {
 func (fes *APIServer) AdminJumioCallback(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminJumioCallback{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminJumioCallback: Problem parsing request body: %v", err))
 		return
 	}
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("AdminJumioCallback")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("JumioCallback: error getting utxoview: %v", err))
 		return
@@ -301,7 +301,7 @@ type AdminUpdateJumioCountrySignUpBonusRequest struct {
 func (fes *APIServer) AdminUpdateJumioCountrySignUpBonus(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminUpdateJumioCountrySignUpBonusRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateJumioCountrySignUpBonusMetadata: "+
 			"Problem parsing request body: %v", err))
 		return
@@ -0,0 +1,322 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/pkg/errors"
+)
+
+// MessagingThroughputStatsCacheTTL bounds how often GetMessagingThroughputStats recounts the recent message index.
+const MessagingThroughputStatsCacheTTL = 30 * time.Second
+
+// MessagingThroughputStats counts the NewMessage transactions in blocks and in the mempool over trailing windows.
+// Mined messages are timestamped with their block and mempool messages with the time they were added to the mempool.
+type MessagingThroughputStats struct {
+	MessagesLastMinute int
+	MessagesLastHour   int
+	MessagesLastDay    int
+
+	ComputedAtTimestampNanos int64
+}
+
+// messagingThroughputStatsCache holds the last MessagingThroughputStats for up to ttl.
+type messagingThroughputStatsCache struct {
+	mtx   sync.Mutex
+	stats *MessagingThroughputStats
+	ttl   time.Duration
+}
+
+func newMessagingThroughputStatsCache(ttl time.Duration) *messagingThroughputStatsCache {
+	return &messagingThroughputStatsCache{ttl: ttl}
+}
+
+// GetOrCompute returns the cached stats if they're younger than the ttl, and otherwise replaces them with the result
+// of compute. The lock is held while computing so that concurrent requests share a single scan.
+func (cache *messagingThroughputStatsCache) GetOrCompute(
+	now time.Time,
+	compute func() (*MessagingThroughputStats, error),
+) (*MessagingThroughputStats, error) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if cache.stats != nil && now.Sub(time.Unix(0, cache.stats.ComputedAtTimestampNanos)) < cache.ttl {
+		return cache.stats, nil
+	}
+	stats, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	cache.stats = stats
+	return stats, nil
+}
+
+// Clear drops the cached stats so the next request recomputes them.
+func (cache *messagingThroughputStatsCache) Clear() {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	cache.stats = nil
+}
+
+// RecentMessageIndexTTL bounds how often the last day of blocks is rescanned for the recent message index.
+const RecentMessageIndexTTL = 10 * time.Second
+
+// recentMessageIndexWindow is how far back the recent message index reaches. It covers the longest window that
+// GetMessagingThroughputStats and GetPublicKeyMessageRate report on.
+const recentMessageIndexWindow = 24 * time.Hour
+
+// recentMessage is a NewMessage transaction in the recent message index. Mined messages are timestamped with their
+// block and mempool messages with the time they were added to the mempool.
+type recentMessage struct {
+	sentAtNanos int64
+	isMined     bool
+}
+
+// recentMessageIndex holds the NewMessage transactions from a single scan of recent blocks and the mempool, grouped by
+// the public key that sent them, so that both the node-wide and the per-key stats can be answered without rescanning.
+type recentMessageIndex struct {
+	// Keyed by the sender's public key bytes.
+	messagesBySender map[string][]recentMessage
+
+	computedAtTimestampNanos int64
+}
+
+func newRecentMessageIndex(now time.Time, blocks []*lib.MsgDeSoBlock, mempoolTxns []*lib.MempoolTx) *recentMessageIndex {
+	index := &recentMessageIndex{
+		messagesBySender:         make(map[string][]recentMessage),
+		computedAtTimestampNanos: now.UnixNano(),
+	}
+	addMessage := func(txn *lib.MsgDeSoTxn, sentAtNanos int64, isMined bool) {
+		if txn == nil || txn.TxnMeta == nil || txn.TxnMeta.GetTxnType() != lib.TxnTypeNewMessage {
+			return
+		}
+		sender := string(txn.PublicKey)
+		index.messagesBySender[sender] = append(index.messagesBySender[sender],
+			recentMessage{sentAtNanos: sentAtNanos, isMined: isMined})
+	}
+
+	for _, block := range blocks {
+		if block == nil || block.Header == nil {
+			continue
+		}
+		for _, txn := range block.Txns {
+			addMessage(txn, block.Header.TstampNanoSecs, true)
+		}
+	}
+	for _, mempoolTxn := range mempoolTxns {
+		if mempoolTxn != nil {
+			addMessage(mempoolTxn.Tx, mempoolTxn.Added.UnixNano(), false)
+		}
+	}
+	return index
+}
+
+// recentMessageIndexCache holds the last recentMessageIndex for up to ttl.
+type recentMessageIndexCache struct {
+	mtx   sync.Mutex
+	index *recentMessageIndex
+	ttl   time.Duration
+}
+
+func newRecentMessageIndexCache(ttl time.Duration) *recentMessageIndexCache {
+	return &recentMessageIndexCache{ttl: ttl}
+}
+
+// GetOrBuild returns the cached index if it's younger than the ttl, and otherwise replaces it with the result of
+// build. The lock is held while building so that concurrent requests, whatever key they ask about, share a single
+// scan.
+func (cache *recentMessageIndexCache) GetOrBuild(
+	now time.Time,
+	build func() (*recentMessageIndex, error),
+) (*recentMessageIndex, error) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if cache.index != nil && now.Sub(time.Unix(0, cache.index.computedAtTimestampNanos)) < cache.ttl {
+		return cache.index, nil
+	}
+	index, err := build()
+	if err != nil {
+		return nil, err
+	}
+	cache.index = index
+	return index, nil
+}
+
+// Clear drops the cached index so the next request rescans the blocks.
+func (cache *recentMessageIndexCache) Clear() {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	cache.index = nil
+}
+
+// getRecentMessageIndex returns the recent message index, scanning the blocks mined in the last day and the mempool
+// if the cached one has expired.
+func (fes *APIServer) getRecentMessageIndex(now time.Time) (*recentMessageIndex, error) {
+	return fes.recentMessageIndexCache.GetOrBuild(now, func() (*recentMessageIndex, error) {
+		recentBlocks, err := fes.getBlocksMinedSince(now.Add(-recentMessageIndexWindow))
+		if err != nil {
+			return nil, errors.Wrapf(err, "getRecentMessageIndex: ")
+		}
+		return newRecentMessageIndex(now, recentBlocks, fes.backendServer.GetMempool().GetOrderedTransactions()), nil
+	})
+}
+
+// GetMessagingThroughputStats returns how many messages were sent node-wide over the last minute, hour, and day.
+func (fes *APIServer) GetMessagingThroughputStats(ww http.ResponseWriter, req *http.Request) {
+	now := time.Now()
+	stats, err := fes.messagingStatsCache.GetOrCompute(now, func() (*MessagingThroughputStats, error) {
+		index, err := fes.getRecentMessageIndex(now)
+		if err != nil {
+			return nil, err
+		}
+		return countMessagingThroughput(now, index), nil
+	})
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessagingThroughputStats: %v", err))
+		return
+	}
+	if err = json.NewEncoder(ww).Encode(stats); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessagingThroughputStats: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getBlocksMinedSince returns the blocks on the best chain timestamped at or after since, newest first.
+func (fes *APIServer) getBlocksMinedSince(since time.Time) ([]*lib.MsgDeSoBlock, error) {
+	sinceNanos := since.UnixNano()
+	var recentBlocks []*lib.MsgDeSoBlock
+	bestChain := fes.blockchain.BestChain()
+	for ii := len(bestChain) - 1; ii >= 0; ii-- {
+		blockNode := bestChain[ii]
+		if blockNode.Header == nil || blockNode.Header.TstampNanoSecs < sinceNanos {
+			break
+		}
+		block, err := lib.GetBlock(blockNode.Hash, fes.blockchain.DB(), fes.blockchain.Snapshot())
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem fetching block %v", blockNode.Hash)
+		}
+		recentBlocks = append(recentBlocks, block)
+	}
+	return recentBlocks, nil
+}
+
+// countMessagingThroughput buckets the messages in index by how long before now they were sent. The windows are
+// nested, so a message from the last minute also counts towards the last hour and day.
+func countMessagingThroughput(now time.Time, index *recentMessageIndex) *MessagingThroughputStats {
+	stats := &MessagingThroughputStats{ComputedAtTimestampNanos: now.UnixNano()}
+	addMessage := func(sentAt time.Time) {
+		age := now.Sub(sentAt)
+		if age < 0 {
+			// Block timestamps can be slightly ahead of our clock.
+			age = 0
+		}
+		if age <= time.Minute {
+			stats.MessagesLastMinute++
+		}
+		if age <= time.Hour {
+			stats.MessagesLastHour++
+		}
+		if age <= 24*time.Hour {
+			stats.MessagesLastDay++
+		}
+	}
+
+	for _, messages := range index.messagesBySender {
+		for _, message := range messages {
+			addMessage(time.Unix(0, message.sentAtNanos))
+		}
+	}
+	return stats
+}
+
+// MaxPublicKeyMessageRateWindowSeconds is the longest window GetPublicKeyMessageRate accepts.
+const MaxPublicKeyMessageRateWindowSeconds = 24 * 60 * 60
+
+type GetPublicKeyMessageRateRequest struct {
+	PublicKeyBase58Check string `safeForLogging:"true"`
+	// How far back to count, up to MaxPublicKeyMessageRateWindowSeconds.
+	WindowSeconds uint64 `safeForLogging:"true"`
+}
+
+// PublicKeyMessageRate counts the NewMessage transactions a public key sent in a trailing window, timestamped the same
+// way as in MessagingThroughputStats.
+type PublicKeyMessageRate struct {
+	PublicKeyBase58Check string `safeForLogging:"true"`
+	WindowSeconds        uint64 `safeForLogging:"true"`
+
+	// How many of the messages were mined and how many are still in the mempool.
+	MinedMessageCount   int `safeForLogging:"true"`
+	MempoolMessageCount int `safeForLogging:"true"`
+	// The total of the two counts divided by the window.
+	MessagesPerMinute float64 `safeForLogging:"true"`
+
+	ComputedAtTimestampNanos int64 `safeForLogging:"true"`
+}
+
+// GetPublicKeyMessageRate returns how many messages a public key has sent over a trailing window, counting both mined
+// messages and those still in the mempool, so moderators can spot accounts sending at a spammy rate.
+func (fes *APIServer) GetPublicKeyMessageRate(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetPublicKeyMessageRateRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPublicKeyMessageRate: Problem parsing request body: %v", err))
+		return
+	}
+
+	publicKeyBytes, _, err := lib.Base58CheckDecode(requestData.PublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPublicKeyMessageRate: Problem decoding PublicKeyBase58Check %v: %v",
+			requestData.PublicKeyBase58Check, err))
+		return
+	}
+	if requestData.WindowSeconds == 0 || requestData.WindowSeconds > MaxPublicKeyMessageRateWindowSeconds {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPublicKeyMessageRate: WindowSeconds must be between 1 and %v, got %v",
+			MaxPublicKeyMessageRateWindowSeconds, requestData.WindowSeconds))
+		return
+	}
+
+	now := time.Now()
+	index, err := fes.getRecentMessageIndex(now)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetPublicKeyMessageRate: %v", err))
+		return
+	}
+	rate := countPublicKeyMessageRate(now, time.Duration(requestData.WindowSeconds)*time.Second, publicKeyBytes, index)
+	rate.PublicKeyBase58Check = lib.PkToString(publicKeyBytes, fes.Params)
+	if err = json.NewEncoder(ww).Encode(rate); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetPublicKeyMessageRate: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// countPublicKeyMessageRate counts the messages in index sent by publicKeyBytes within window before now.
+func countPublicKeyMessageRate(
+	now time.Time,
+	window time.Duration,
+	publicKeyBytes []byte,
+	index *recentMessageIndex,
+) *PublicKeyMessageRate {
+	rate := &PublicKeyMessageRate{
+		WindowSeconds:            uint64(window / time.Second),
+		ComputedAtTimestampNanos: now.UnixNano(),
+	}
+	for _, message := range index.messagesBySender[string(publicKeyBytes)] {
+		// Block timestamps can be slightly ahead of our clock, so only the start of the window is checked.
+		if now.Sub(time.Unix(0, message.sentAtNanos)) > window {
+			continue
+		}
+		if message.isMined {
+			rate.MinedMessageCount++
+		} else {
+			rate.MempoolMessageCount++
+		}
+	}
+	rate.MessagesPerMinute = float64(rate.MinedMessageCount+rate.MempoolMessageCount) / window.Minutes()
+	return rate
+}
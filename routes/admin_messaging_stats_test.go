@@ -0,0 +1,142 @@
+package routes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountMessagingThroughput(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	newMessageTxn := func() *lib.MsgDeSoTxn {
+		return &lib.MsgDeSoTxn{TxnMeta: &lib.NewMessageMetadata{}}
+	}
+	newBlock := func(age time.Duration, txns ...*lib.MsgDeSoTxn) *lib.MsgDeSoBlock {
+		return &lib.MsgDeSoBlock{
+			Header: &lib.MsgDeSoHeader{TstampNanoSecs: now.Add(-age).UnixNano()},
+			Txns:   txns,
+		}
+	}
+	blocks := []*lib.MsgDeSoBlock{
+		// Other transaction types are ignored.
+		newBlock(30*time.Second, newMessageTxn(), &lib.MsgDeSoTxn{TxnMeta: &lib.BasicTransferMetadata{}}),
+		newBlock(10*time.Minute, newMessageTxn(), newMessageTxn()),
+		newBlock(5*time.Hour, newMessageTxn()),
+		newBlock(25*time.Hour, newMessageTxn()),
+	}
+	mempoolTxns := []*lib.MempoolTx{
+		{Tx: newMessageTxn(), Added: now.Add(-5 * time.Second)},
+		{Tx: newMessageTxn(), Added: now.Add(-2 * time.Minute)},
+	}
+
+	stats := countMessagingThroughput(now, newRecentMessageIndex(now, blocks, mempoolTxns))
+	require.Equal(t, &MessagingThroughputStats{
+		MessagesLastMinute:       2,
+		MessagesLastHour:         5,
+		MessagesLastDay:          6,
+		ComputedAtTimestampNanos: now.UnixNano(),
+	}, stats)
+
+	// Results are served from the cache until the ttl passes.
+	cache := newMessagingThroughputStatsCache(MessagingThroughputStatsCacheTTL)
+	numComputations := 0
+	compute := func(computedAt time.Time) func() (*MessagingThroughputStats, error) {
+		return func() (*MessagingThroughputStats, error) {
+			numComputations++
+			return countMessagingThroughput(computedAt, newRecentMessageIndex(computedAt, blocks, mempoolTxns)), nil
+		}
+	}
+	_, err := cache.GetOrCompute(now, compute(now))
+	require.NoError(t, err)
+	later := now.Add(MessagingThroughputStatsCacheTTL / 2)
+	cachedStats, err := cache.GetOrCompute(later, compute(later))
+	require.NoError(t, err)
+	require.Equal(t, 1, numComputations)
+	require.Equal(t, stats, cachedStats)
+	muchLater := now.Add(2 * MessagingThroughputStatsCacheTTL)
+	_, err = cache.GetOrCompute(muchLater, compute(muchLater))
+	require.NoError(t, err)
+	require.Equal(t, 2, numComputations)
+}
+
+func TestCountPublicKeyMessageRate(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	spammerPkBytes := lib.MustBase58CheckDecode(senderPkString)
+	otherPkBytes := lib.MustBase58CheckDecode(recipientPkString)
+	newMessageTxn := func(publicKeyBytes []byte) *lib.MsgDeSoTxn {
+		return &lib.MsgDeSoTxn{PublicKey: publicKeyBytes, TxnMeta: &lib.NewMessageMetadata{}}
+	}
+	newBlock := func(age time.Duration, txns ...*lib.MsgDeSoTxn) *lib.MsgDeSoBlock {
+		return &lib.MsgDeSoBlock{
+			Header: &lib.MsgDeSoHeader{TstampNanoSecs: now.Add(-age).UnixNano()},
+			Txns:   txns,
+		}
+	}
+	blocks := []*lib.MsgDeSoBlock{
+		// Other senders and other transaction types are ignored.
+		newBlock(time.Minute, newMessageTxn(spammerPkBytes), newMessageTxn(spammerPkBytes),
+			newMessageTxn(otherPkBytes), &lib.MsgDeSoTxn{PublicKey: spammerPkBytes, TxnMeta: &lib.BasicTransferMetadata{}}),
+		newBlock(4*time.Minute, newMessageTxn(spammerPkBytes), newMessageTxn(spammerPkBytes)),
+		// Outside of the window.
+		newBlock(20*time.Minute, newMessageTxn(spammerPkBytes)),
+	}
+	mempoolTxns := []*lib.MempoolTx{
+		{Tx: newMessageTxn(spammerPkBytes), Added: now.Add(-5 * time.Second)},
+		{Tx: newMessageTxn(otherPkBytes), Added: now.Add(-5 * time.Second)},
+	}
+
+	index := newRecentMessageIndex(now, blocks, mempoolTxns)
+
+	// Five messages in the last ten minutes is a rate of 0.5 a minute.
+	rate := countPublicKeyMessageRate(now, 10*time.Minute, spammerPkBytes, index)
+	require.Equal(t, &PublicKeyMessageRate{
+		WindowSeconds:            600,
+		MinedMessageCount:        4,
+		MempoolMessageCount:      1,
+		MessagesPerMinute:        0.5,
+		ComputedAtTimestampNanos: now.UnixNano(),
+	}, rate)
+
+	// A longer window picks up the older block.
+	rate = countPublicKeyMessageRate(now, time.Hour, spammerPkBytes, index)
+	require.Equal(t, 5, rate.MinedMessageCount)
+	require.Equal(t, 0.1, rate.MessagesPerMinute)
+
+	// Other keys are answered from the same index.
+	rate = countPublicKeyMessageRate(now, time.Hour, otherPkBytes, index)
+	require.Equal(t, 1, rate.MinedMessageCount)
+	require.Equal(t, 1, rate.MempoolMessageCount)
+}
+
+func TestRecentMessageIndexCache(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	cache := newRecentMessageIndexCache(RecentMessageIndexTTL)
+	numBuilds := 0
+	build := func(builtAt time.Time) func() (*recentMessageIndex, error) {
+		return func() (*recentMessageIndex, error) {
+			numBuilds++
+			return newRecentMessageIndex(builtAt, nil, nil), nil
+		}
+	}
+
+	// The blocks are scanned once per ttl, however many keys and windows are asked about in between.
+	index, err := cache.GetOrBuild(now, build(now))
+	require.NoError(t, err)
+	later := now.Add(RecentMessageIndexTTL / 2)
+	cachedIndex, err := cache.GetOrBuild(later, build(later))
+	require.NoError(t, err)
+	require.Equal(t, 1, numBuilds)
+	require.Same(t, index, cachedIndex)
+	muchLater := now.Add(2 * RecentMessageIndexTTL)
+	_, err = cache.GetOrBuild(muchLater, build(muchLater))
+	require.NoError(t, err)
+	require.Equal(t, 2, numBuilds)
+
+	// Clearing the cache forces a rescan.
+	cache.Clear()
+	_, err = cache.GetOrBuild(muchLater, build(muchLater))
+	require.NoError(t, err)
+	require.Equal(t, 3, numBuilds)
+}
@@ -73,7 +73,7 @@ func (fes *APIServer) GetPostsForNFTDropEntry(dropEntryToReturn *NFTDropEntry,
 	var postEntryResponses []*PostEntryResponse
 
 	// Grab a view (needed for getting global params, etc).
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetPostsForNFTDropEntry")
 	if err != nil {
 		return nil, fmt.Errorf("AdminGetPostsForNFTDropEntry: Error getting utxoView: %v", err)
 	}
@@ -110,7 +110,7 @@ func (fes *APIServer) GetPostsForNFTDropEntry(dropEntryToReturn *NFTDropEntry,
 func (fes *APIServer) AdminGetNFTDrop(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminGetNFTDropRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminGetNFTDrop: Error parsing request body: %v", err))
 		return
 	}
@@ -171,7 +171,7 @@ type AdminUpdateNFTDropResponse struct {
 func (fes *APIServer) AdminUpdateNFTDrop(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminUpdateNFTDropRequest{}
-	err := decoder.Decode(&requestData)
+	err := describeDecodeError(decoder.Decode(&requestData))
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateNFTDrop: Error parsing request body: %v", err))
 		return
@@ -275,7 +275,7 @@ func (fes *APIServer) AdminUpdateNFTDrop(ww http.ResponseWriter, req *http.Reque
 			return
 		}
 
-		utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		utxoView, err := fes.getAugmentedUniversalView("AdminUpdateNFTDrop")
 		if err != nil {
 			_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateNFTDrop: Error getting utxoView: %v", err))
 			return
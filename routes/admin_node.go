@@ -419,7 +419,7 @@ func (fes *APIServer) NodeControl(ww http.ResponseWriter, req *http.Request) {
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := NodeControlRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("NodeControlRequest: Problem parsing request body: %v", err))
 		return
 	}
@@ -510,7 +510,7 @@ type AdminUpdateViewNumberRequest struct {
 func (fes *APIServer) AdminUpdateViewNumber(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminUpdateViewNumberRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateViewNumber: Problem parsing request body: %v", err))
 		return
 	}
@@ -521,3 +521,50 @@ func (fes *APIServer) AdminUpdateViewNumber(ww http.ResponseWriter, req *http.Re
 		return
 	}
 }
+
+// The names FlushViewCaches reports for the caches it flushes.
+const (
+	ViewCacheDAOCoinOrderBook         = "DAOCoinOrderBook"
+	ViewCacheMessagingThroughputStats = "MessagingThroughputStats"
+	ViewCacheRecentMessageIndex       = "RecentMessageIndex"
+	ViewCacheGlobalState              = "GlobalState"
+)
+
+type FlushViewCachesResponse struct {
+	// The caches that were flushed. Caches this node doesn't run, like the order book cache when the order book
+	// warmer is disabled, are left out.
+	FlushedCaches []string
+}
+
+// FlushViewCaches drops the handler-level caches computed from the view so that the next requests recompute them
+// from fresh state. It's an escape hatch for operators who suspect a cache has gone stale.
+func (fes *APIServer) FlushViewCaches(ww http.ResponseWriter, req *http.Request) {
+	res := FlushViewCachesResponse{FlushedCaches: fes.flushViewCaches()}
+	if err := json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("FlushViewCaches: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+func (fes *APIServer) flushViewCaches() []string {
+	flushedCaches := []string{}
+	if fes.DAOCoinOrderBookCache != nil {
+		// Evicting against a nil block tip drops every entry.
+		fes.DAOCoinOrderBookCache.Evict(nil)
+		flushedCaches = append(flushedCaches, ViewCacheDAOCoinOrderBook)
+	}
+	if fes.messagingStatsCache != nil {
+		fes.messagingStatsCache.Clear()
+		flushedCaches = append(flushedCaches, ViewCacheMessagingThroughputStats)
+	}
+	if fes.recentMessageIndexCache != nil {
+		fes.recentMessageIndexCache.Clear()
+		flushedCaches = append(flushedCaches, ViewCacheRecentMessageIndex)
+	}
+	// The global state cache is always populated, so rebuild it in place rather than clearing it.
+	if fes.backendServer != nil {
+		fes.SetGlobalStateCache()
+		flushedCaches = append(flushedCaches, ViewCacheGlobalState)
+	}
+	return flushedCaches
+}
@@ -0,0 +1,69 @@
+package routes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlushViewCaches(t *testing.T) {
+	apiServer := &APIServer{
+		Params:                  &lib.DeSoTestnetParams,
+		DAOCoinOrderBookCache:   NewDAOCoinOrderBookCache(time.Minute),
+		messagingStatsCache:     newMessagingThroughputStatsCache(time.Minute),
+		recentMessageIndexCache: newRecentMessageIndexCache(time.Minute),
+	}
+
+	// Populate the caches.
+	key := NewDAOCoinOrderBookCacheKey(DESOCoinIdentifierString, senderPkString, TxnStatusInMempool)
+	blockTipHash := &lib.BlockHash{1}
+	orders := []DAOCoinLimitOrderEntryResponse{{Price: "1.0"}}
+	apiServer.DAOCoinOrderBookCache.Put(key, blockTipHash, orders)
+	_, exists := apiServer.DAOCoinOrderBookCache.Get(key, blockTipHash)
+	require.True(t, exists)
+
+	numComputes := 0
+	compute := func() (*MessagingThroughputStats, error) {
+		numComputes++
+		return &MessagingThroughputStats{ComputedAtTimestampNanos: time.Now().UnixNano()}, nil
+	}
+	_, err := apiServer.messagingStatsCache.GetOrCompute(time.Now(), compute)
+	require.NoError(t, err)
+	_, err = apiServer.messagingStatsCache.GetOrCompute(time.Now(), compute)
+	require.NoError(t, err)
+	require.Equal(t, 1, numComputes)
+
+	numBuilds := 0
+	build := func() (*recentMessageIndex, error) {
+		numBuilds++
+		return newRecentMessageIndex(time.Now(), nil, nil), nil
+	}
+	_, err = apiServer.recentMessageIndexCache.GetOrBuild(time.Now(), build)
+	require.NoError(t, err)
+	require.Equal(t, 1, numBuilds)
+
+	// Without a backend server there's no global state cache to rebuild.
+	require.Equal(t,
+		[]string{ViewCacheDAOCoinOrderBook, ViewCacheMessagingThroughputStats, ViewCacheRecentMessageIndex},
+		apiServer.flushViewCaches())
+
+	// The caches are empty, and recompute on the next access.
+	_, exists = apiServer.DAOCoinOrderBookCache.Get(key, blockTipHash)
+	require.False(t, exists)
+	apiServer.DAOCoinOrderBookCache.Put(key, blockTipHash, orders)
+	cachedOrders, exists := apiServer.DAOCoinOrderBookCache.Get(key, blockTipHash)
+	require.True(t, exists)
+	require.Equal(t, orders, cachedOrders)
+
+	_, err = apiServer.messagingStatsCache.GetOrCompute(time.Now(), compute)
+	require.NoError(t, err)
+	require.Equal(t, 2, numComputes)
+	_, err = apiServer.recentMessageIndexCache.GetOrBuild(time.Now(), build)
+	require.NoError(t, err)
+	require.Equal(t, 2, numBuilds)
+
+	// Caches the node doesn't run aren't reported.
+	require.Empty(t, (&APIServer{Params: &lib.DeSoTestnetParams}).flushViewCaches())
+}
@@ -147,7 +147,7 @@ type AdminCreateReferralHashResponse struct {
 func (fes *APIServer) AdminCreateReferralHash(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminCreateReferralHashRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminCreateReferralHash: Problem parsing request body: %v", err))
 		return
 	}
@@ -179,7 +179,7 @@ func (fes *APIServer) AdminCreateReferralHash(ww http.ResponseWriter, req *http.
 
 	// If we didn't get a public key, try and get one for the username.
 	if userPublicKeyBytes == nil && requestData.Username != "" {
-		utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		utxoView, err := fes.getAugmentedUniversalView("AdminCreateReferralHash")
 		if err != nil {
 			_AddBadRequestError(ww, fmt.Sprintf("AdminCreateReferralHash: Problem fetching utxoView: %v", err))
 			return
@@ -194,7 +194,7 @@ func (fes *APIServer) AdminCreateReferralHash(ww http.ResponseWriter, req *http.
 	}
 
 	// Get the PKID for the pub key.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("AdminCreateReferralHash")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminCreateReferralHash: Problem getting utxoView: %v", err))
 		return
@@ -275,7 +275,7 @@ type AdminUpdateReferralHashResponse struct {
 func (fes *APIServer) AdminUpdateReferralHash(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminUpdateReferralHashRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminUpdateReferralHash: Problem parsing request body: %v", err))
 		return
 	}
@@ -362,7 +362,7 @@ func (fes *APIServer) getReferralInfoResponsesForPubKey(pkBytes []byte, includeR
 ) (_referralInfoResponses []ReferralInfoResponse, _err error) {
 
 	// Get the PKID for the pub key passed in.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("getReferralInfoResponsesForPubKey")
 	if err != nil {
 		return nil, fmt.Errorf("putReferralHashWithInfo: Problem getting utxoView: %v", err)
 	}
@@ -462,7 +462,7 @@ func (fes *APIServer) getReferralInfoResponsesForPubKey(pkBytes []byte, includeR
 func (fes *APIServer) AdminGetAllReferralInfoForUser(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminGetAllReferralInfoForUserRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"AdminGetAllReferralInfoForUser: Problem parsing request body: %v", err))
 		return
@@ -488,7 +488,7 @@ func (fes *APIServer) AdminGetAllReferralInfoForUser(ww http.ResponseWriter, req
 
 	// If we didn't get a public key, try and get one for the username.
 	if userPublicKeyBytes == nil && requestData.Username != "" {
-		utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		utxoView, err := fes.getAugmentedUniversalView("AdminGetAllReferralInfoForUser")
 		if err != nil {
 			_AddBadRequestError(ww, fmt.Sprintf("AdminGetAllReferralInfoForUser: Problem fetching utxoView: %v", err))
 			return
@@ -561,7 +561,7 @@ type AdminDownloadReferralCSVResponse struct {
 func (fes *APIServer) AdminDownloadReferralCSV(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminDownloadReferralCSVRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"AdminDownloadReferralCSV: Problem parsing request body: %v", err))
 		return
@@ -580,7 +580,7 @@ func (fes *APIServer) AdminDownloadReferralCSV(ww http.ResponseWriter, req *http
 			ww, fmt.Sprintf("AdminDownloadReferralCSV: problem getting referralInfos: %v", err))
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("AdminDownloadReferralCSV")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminDownloadReferralCSV: Problem fetching utxoView: %v", err))
 		return
@@ -886,7 +886,7 @@ type AdminDownloadRefereeCSVResponse struct {
 func (fes *APIServer) AdminDownloadRefereeCSV(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminDownloadRefereeCSVRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"AdminDownloadRefereeCSV: Problem parsing request body: %v", err))
 		return
@@ -906,7 +906,7 @@ func (fes *APIServer) AdminDownloadRefereeCSV(ww http.ResponseWriter, req *http.
 	}
 
 	// Grab a utxoView in preparation of fetching copious amounts of data.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("AdminDownloadRefereeCSV")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminDownloadRefereeCSV: Problem fetching utxoView: %v", err))
 		return
@@ -0,0 +1,154 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/dgraph-io/badger/v3"
+	"github.com/pkg/errors"
+)
+
+// StateSizeStatsCacheTTL bounds how often GetStateSizeStats rescans the db. A scan reads every entry, so it's kept
+// well above the polling interval of a typical disk usage dashboard.
+const StateSizeStatsCacheTTL = 10 * time.Minute
+
+// StateSizePrefixStats is the size of every entry under a single-byte db prefix.
+type StateSizePrefixStats struct {
+	Prefix     byte
+	NumEntries uint64
+	// The total length of the keys and values under the prefix. This is the uncompressed size, so it doesn't
+	// include badger's own overhead.
+	SizeBytes uint64
+}
+
+// StateSizeStats describes how much disk the node's db uses, broken down by prefix.
+type StateSizeStats struct {
+	DataDirectory     string
+	DatabaseDirectory string
+
+	// The size of badger's LSM tree and value log files on disk.
+	LSMSizeBytes      int64
+	ValueLogSizeBytes int64
+	OnDiskSizeBytes   int64
+
+	// The sum of SizeBytes across PrefixStats.
+	TotalSizeBytes uint64
+	// Only prefixes with at least one entry are listed, sorted by prefix.
+	PrefixStats []*StateSizePrefixStats
+
+	ComputedAtTimestampNanos int64
+}
+
+// ComputeStateSizeStats scans every prefix of db in batches, the same way CompareDatabases does.
+func ComputeStateSizeStats(db *badger.DB, now time.Time) (*StateSizeStats, error) {
+	databaseDirectory := db.Opts().Dir
+	lsmSizeBytes, valueLogSizeBytes := db.Size()
+	stats := &StateSizeStats{
+		DataDirectory:            filepath.Dir(databaseDirectory),
+		DatabaseDirectory:        databaseDirectory,
+		LSMSizeBytes:             lsmSizeBytes,
+		ValueLogSizeBytes:        valueLogSizeBytes,
+		OnDiskSizeBytes:          lsmSizeBytes + valueLogSizeBytes,
+		PrefixStats:              []*StateSizePrefixStats{},
+		ComputedAtTimestampNanos: now.UnixNano(),
+	}
+	for prefix := 0; prefix <= 255; prefix++ {
+		prefixStats, err := computeStateSizeOnPrefix(db, byte(prefix))
+		if err != nil {
+			return nil, errors.Wrapf(err, "ComputeStateSizeStats: Problem scanning prefix %v", prefix)
+		}
+		if prefixStats.NumEntries == 0 {
+			continue
+		}
+		stats.TotalSizeBytes += prefixStats.SizeBytes
+		stats.PrefixStats = append(stats.PrefixStats, prefixStats)
+	}
+	return stats, nil
+}
+
+func computeStateSizeOnPrefix(db *badger.DB, prefix byte) (*StateSizePrefixStats, error) {
+	prefixStats := &StateSizePrefixStats{Prefix: prefix}
+	prefixBytes := []byte{prefix}
+	lastPrefix := prefixBytes
+	for {
+		entries, full, err := lib.DBIteratePrefixKeys(db, prefixBytes, lastPrefix, databaseComparisonMaxBytesPerIteration)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			prefixStats.NumEntries++
+			prefixStats.SizeBytes += uint64(len(entry.Key) + len(entry.Value))
+		}
+		if len(entries) == 0 || !full {
+			break
+		}
+		lastPrefix = entries[len(entries)-1].Key
+	}
+	return prefixStats, nil
+}
+
+// stateSizeStatsCache holds the last StateSizeStats for up to ttl.
+type stateSizeStatsCache struct {
+	mtx   sync.Mutex
+	stats *StateSizeStats
+	ttl   time.Duration
+}
+
+func newStateSizeStatsCache(ttl time.Duration) *stateSizeStatsCache {
+	return &stateSizeStatsCache{ttl: ttl}
+}
+
+// GetOrCompute returns the cached stats if they're younger than the ttl and refresh isn't set, and otherwise replaces
+// them with the result of compute. The lock is held while computing so that concurrent requests share a single scan.
+func (cache *stateSizeStatsCache) GetOrCompute(
+	now time.Time,
+	refresh bool,
+	compute func() (*StateSizeStats, error),
+) (*StateSizeStats, error) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	if !refresh && cache.stats != nil && now.Sub(time.Unix(0, cache.stats.ComputedAtTimestampNanos)) < cache.ttl {
+		return cache.stats, nil
+	}
+	stats, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	cache.stats = stats
+	return stats, nil
+}
+
+type GetStateSizeStatsRequest struct {
+	// If set, the db is rescanned even if the cached stats haven't expired yet.
+	Refresh bool `safeForLogging:"true"`
+}
+
+// GetStateSizeStats returns where the node keeps its data and how much disk each db prefix takes up.
+func (fes *APIServer) GetStateSizeStats(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetStateSizeStatsRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetStateSizeStats: Problem parsing request body: %v", err))
+		return
+	}
+
+	now := time.Now()
+	stats, err := fes.stateSizeStatsCache.GetOrCompute(now, requestData.Refresh, func() (*StateSizeStats, error) {
+		return ComputeStateSizeStats(fes.blockchain.DB(), now)
+	})
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetStateSizeStats: %v", err))
+		return
+	}
+	if err = json.NewEncoder(ww).Encode(stats); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetStateSizeStats: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
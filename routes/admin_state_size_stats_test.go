@@ -0,0 +1,63 @@
+package routes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/stretchr/testify/require"
+)
+
+func TestComputeStateSizeStats(t *testing.T) {
+	db, dir := GetTestBadgerDb(t)
+
+	fixture := map[string][]byte{
+		string([]byte{5, 1}):       []byte("abc"),
+		string([]byte{5, 2, 3}):    []byte("defgh"),
+		string([]byte{9, 1, 2, 3}): make([]byte, 100),
+	}
+	require.NoError(t, db.Update(func(txn *badger.Txn) error {
+		for key, value := range fixture {
+			if err := txn.Set([]byte(key), value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}))
+
+	now := time.Unix(1_700_000_000, 0)
+	stats, err := ComputeStateSizeStats(db, now)
+	require.NoError(t, err)
+	require.Equal(t, dir, stats.DatabaseDirectory)
+	require.Equal(t, []*StateSizePrefixStats{
+		{Prefix: 5, NumEntries: 2, SizeBytes: 2 + 3 + 3 + 5},
+		{Prefix: 9, NumEntries: 1, SizeBytes: 4 + 100},
+	}, stats.PrefixStats)
+	require.Equal(t, uint64(117), stats.TotalSizeBytes)
+	require.Equal(t, stats.LSMSizeBytes+stats.ValueLogSizeBytes, stats.OnDiskSizeBytes)
+	require.Equal(t, now.UnixNano(), stats.ComputedAtTimestampNanos)
+
+	// Results are served from the cache until the ttl passes, unless a refresh is requested.
+	cache := newStateSizeStatsCache(StateSizeStatsCacheTTL)
+	numComputations := 0
+	compute := func(computedAt time.Time) func() (*StateSizeStats, error) {
+		return func() (*StateSizeStats, error) {
+			numComputations++
+			return ComputeStateSizeStats(db, computedAt)
+		}
+	}
+	_, err = cache.GetOrCompute(now, false, compute(now))
+	require.NoError(t, err)
+	later := now.Add(StateSizeStatsCacheTTL / 2)
+	cachedStats, err := cache.GetOrCompute(later, false, compute(later))
+	require.NoError(t, err)
+	require.Equal(t, 1, numComputations)
+	require.Equal(t, now.UnixNano(), cachedStats.ComputedAtTimestampNanos)
+	refreshedStats, err := cache.GetOrCompute(later, true, compute(later))
+	require.NoError(t, err)
+	require.Equal(t, 2, numComputations)
+	require.Equal(t, later.UnixNano(), refreshedStats.ComputedAtTimestampNanos)
+	_, err = cache.GetOrCompute(later.Add(StateSizeStatsCacheTTL), false, compute(later.Add(StateSizeStatsCacheTTL)))
+	require.NoError(t, err)
+	require.Equal(t, 3, numComputations)
+}
@@ -170,7 +170,7 @@ func (fes *APIServer) CreateUserAssociation(ww http.ResponseWriter, req *http.Re
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CreateUserAssociationRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, "CreateUserAssociation: problem parsing request body")
 		return
 	}
@@ -283,7 +283,7 @@ func (fes *APIServer) DeleteUserAssociation(ww http.ResponseWriter, req *http.Re
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := DeleteAssociationRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, "DeleteUserAssociation: problem parsing request body")
 		return
 	}
@@ -381,7 +381,7 @@ func (fes *APIServer) GetUserAssociationByID(ww http.ResponseWriter, req *http.R
 	}
 
 	// Create UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetUserAssociationByID")
 	if err != nil {
 		_AddInternalServerError(ww, "GetUserAssociationByID: problem getting UTXO view")
 		return
@@ -456,7 +456,7 @@ func (fes *APIServer) AddProfileEntryResponseToMap(
 
 func (fes *APIServer) GetUserAssociations(ww http.ResponseWriter, req *http.Request) {
 	// Create UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetUserAssociations")
 	if err != nil {
 		_AddInternalServerError(ww, "GetUserAssociations: problem getting UTXO view")
 		return
@@ -532,7 +532,7 @@ func (fes *APIServer) GetUserAssociations(ww http.ResponseWriter, req *http.Requ
 
 func (fes *APIServer) CountUserAssociations(ww http.ResponseWriter, req *http.Request) {
 	// Create UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("CountUserAssociations")
 	if err != nil {
 		_AddInternalServerError(ww, "CountUserAssociations: problem getting UTXO view")
 		return
@@ -562,7 +562,7 @@ func (fes *APIServer) CountUserAssociations(ww http.ResponseWriter, req *http.Re
 
 func (fes *APIServer) CountUserAssociationsByValue(ww http.ResponseWriter, req *http.Request) {
 	// Create UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("CountUserAssociationsByValue")
 	if err != nil {
 		_AddInternalServerError(ww, "CountUserAssociationsByValue: problem getting UTXO view")
 		return
@@ -621,7 +621,7 @@ func (fes *APIServer) _constructUserAssociationQueriesFromParams(
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(requestBody, MaxRequestBodySizeBytes))
 	requestData := UserAssociationQuery{}
-	if err = decoder.Decode(&requestData); err != nil {
+	if err = describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		return nil, nil, errors.New("problem parsing request body")
 	}
 
@@ -723,7 +723,7 @@ func (fes *APIServer) CreatePostAssociation(ww http.ResponseWriter, req *http.Re
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CreatePostAssociationRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, "CreatePostAssociation: problem parsing request body")
 		return
 	}
@@ -835,7 +835,7 @@ func (fes *APIServer) DeletePostAssociation(ww http.ResponseWriter, req *http.Re
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := DeleteAssociationRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, "DeletePostAssociation: problem parsing request body")
 		return
 	}
@@ -933,7 +933,7 @@ func (fes *APIServer) GetPostAssociationByID(ww http.ResponseWriter, req *http.R
 	}
 
 	// Create UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetPostAssociationByID")
 	if err != nil {
 		_AddInternalServerError(ww, "GetPostAssociationByID: problem getting UTXO view")
 		return
@@ -996,7 +996,7 @@ func (fes *APIServer) GetPostAssociationByID(ww http.ResponseWriter, req *http.R
 
 func (fes *APIServer) GetPostAssociations(ww http.ResponseWriter, req *http.Request) {
 	// Create UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetPostAssociations")
 	if err != nil {
 		_AddInternalServerError(ww, "GetPostAssociations: problem getting UTXO view")
 		return
@@ -1101,7 +1101,7 @@ func (fes *APIServer) GetPostAssociations(ww http.ResponseWriter, req *http.Requ
 
 func (fes *APIServer) CountPostAssociations(ww http.ResponseWriter, req *http.Request) {
 	// Create UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("CountPostAssociations")
 	if err != nil {
 		_AddInternalServerError(ww, "CountPostAssociations: problem getting UTXO view")
 		return
@@ -1131,7 +1131,7 @@ func (fes *APIServer) CountPostAssociations(ww http.ResponseWriter, req *http.Re
 
 func (fes *APIServer) CountPostAssociationsByValue(ww http.ResponseWriter, req *http.Request) {
 	// Create UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("CountPostAssociationsByValue")
 	if err != nil {
 		_AddInternalServerError(ww, "CountPostAssociationsByValue: problem getting UTXO view")
 		return
@@ -1190,7 +1190,7 @@ func (fes *APIServer) _constructPostAssociationQueriesFromParams(
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(requestBody, MaxRequestBodySizeBytes))
 	requestData := PostAssociationQuery{}
-	if err = decoder.Decode(&requestData); err != nil {
+	if err = describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		return nil, nil, errors.New("problem parsing request body")
 	}
 
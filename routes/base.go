@@ -8,6 +8,7 @@ import (
 	"io/ioutil"
 	"math/big"
 	"net/http"
+	"runtime/debug"
 	"strconv"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/deso-protocol/core/lib"
 	"github.com/golang/glog"
 	"github.com/montanaflynn/stats"
+	"github.com/pkg/errors"
 )
 
 // Index ...
@@ -83,8 +85,59 @@ type GetExchangeRateResponse struct {
 	USDCentsPerBitCloutReserveExchangeRate uint64 // Deprecated
 }
 
+// getAugmentedUniversalView returns a view with all mempool transactions applied. Any failure, including a node that
+// has no mempool to build the view from, is returned as a *utxoViewError so handlers report it as a 500. endpoint is
+// the name of the calling handler, which is logged if building the view is slow.
+func (fes *APIServer) getAugmentedUniversalView(endpoint string) (*lib.UtxoView, error) {
+	if fes.backendServer == nil || fes.backendServer.GetMempool() == nil {
+		return nil, &utxoViewError{err: errors.New("mempool is not available")}
+	}
+	utxoView, err := fes.utxoViewTimer.Time(endpoint, fes.backendServer.GetMempool().GetAugmentedUniversalView)
+	if err != nil {
+		return nil, &utxoViewError{err: err}
+	}
+	return utxoView, nil
+}
+
+// utxoViewTimer times UtxoView constructions and logs a warning for any that take longer than threshold. The view is
+// rebuilt from the mempool on most requests, so this lets operators tell whether a slow endpoint is slow because of it.
+type utxoViewTimer struct {
+	// A zero threshold disables the warning.
+	threshold time.Duration
+	now       func() time.Time
+	warningf  func(format string, args ...interface{})
+}
+
+func newUtxoViewTimer(threshold time.Duration) *utxoViewTimer {
+	return &utxoViewTimer{
+		threshold: threshold,
+		now:       time.Now,
+		warningf:  glog.Warningf,
+	}
+}
+
+// Time calls constructView on behalf of endpoint. A nil timer just calls constructView.
+func (timer *utxoViewTimer) Time(
+	endpoint string,
+	constructView func() (*lib.UtxoView, error),
+) (*lib.UtxoView, error) {
+	if timer == nil || timer.threshold == 0 {
+		return constructView()
+	}
+	start := timer.now()
+	utxoView, err := constructView()
+	if elapsed := timer.now().Sub(start); elapsed > timer.threshold {
+		timer.warningf("%v: Constructing UtxoView took %v, over the threshold of %v", endpoint, elapsed, timer.threshold)
+	}
+	return utxoView, err
+}
+
 func (fes *APIServer) GetExchangeRate(ww http.ResponseWriter, rr *http.Request) {
-	readUtxoView, _ := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	readUtxoView, err := fes.getAugmentedUniversalView("GetExchangeRate")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetExchangeRate: Error generating utxo view: %v", err))
+		return
+	}
 
 	// BTC
 	usdCentsPerBitcoin := fes.UsdCentsPerBitCoinExchangeRate
@@ -339,7 +392,7 @@ func (fes *APIServer) GetGateExchangeRate() (_exchangeRate float64, _err error)
 }
 
 func (fes *APIServer) GetExchangeRateFromDeSoDex() (float64, error) {
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetExchangeRateFromDeSoDex")
 	if err != nil {
 		return 0, err
 	}
@@ -503,16 +556,16 @@ type GetAppStateResponse struct {
 func (fes *APIServer) GetAppState(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetAppStateRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetAppState: Problem parsing request body: %v", err))
 		return
 	}
 
 	// Get a view with all the mempool transactions (used to get all posts / reader state).
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetAppState")
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetAppState: Error getting augmented universal view: %v", err))
+		_AddInternalServerError(ww, fmt.Sprintf("GetAppState: Error getting augmented universal view: %v", err))
 		return
 	}
 
@@ -564,6 +617,85 @@ func (fes *APIServer) GetAppState(ww http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// GetNodeInfoResponse only contains values that are safe to show publicly. Integrations are reported by whether
+// they're configured, never by their keys or credentials.
+type GetNodeInfoResponse struct {
+	NetworkType string
+
+	// The module version and VCS revision the binary was built from, when the build recorded them.
+	Version string
+	Commit  string
+
+	UptimeSeconds uint64
+	TipHeight     uint64
+
+	// Which optional features this node supports, keyed by feature name.
+	Features map[string]bool
+
+	// Operational limits clients may need to respect. Zero means the limit is disabled.
+	MinFeeRateNanosPerKB             uint64
+	MaxRequestBodySizeBytes          uint64
+	MaxOptionalPrecedingTransactions int
+	MaxExtraDataEntries              uint64
+	MaxExtraDataSizeBytes            uint64
+	IPRateLimitRequestsPerSecond     float64
+}
+
+// GetNodeInfo returns the node's network, version, uptime, tip height, supported features, and limits. It's a much
+// lighter alternative to GetAppState for status pages.
+func (fes *APIServer) GetNodeInfo(ww http.ResponseWriter, req *http.Request) {
+	res := fes.getNodeInfo(uint64(fes.blockchain.BlockTip().Height), time.Now())
+	if err := json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetNodeInfo: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+func (fes *APIServer) getNodeInfo(tipHeight uint64, now time.Time) *GetNodeInfoResponse {
+	version, commit := getBuildVersionAndCommit()
+	return &GetNodeInfoResponse{
+		NetworkType:   fes.Params.NetworkType.String(),
+		Version:       version,
+		Commit:        commit,
+		UptimeSeconds: uint64(now.Sub(fes.startTime).Seconds()),
+		TipHeight:     tipHeight,
+		Features: map[string]bool{
+			"TransactionIndex":      fes.TXIndex != nil,
+			"HotFeed":               fes.Config.RunHotFeedRoutine,
+			"DAOCoinOrderBookCache": fes.Config.RunDAOCoinOrderBookWarmer,
+			"ImageUploads":          fes.Config.GCPBucketName != "",
+			"VideoUploads":          fes.Config.CloudflareStreamToken != "" && fes.Config.CloudflareAccountId != "",
+			"PhoneVerification":     fes.Twilio != nil,
+			"EmailVerification":     fes.IsConfiguredForSendgrid(),
+			"Wyre":                  fes.IsConfiguredForWyre(),
+			"Jumio":                 fes.IsConfiguredForJumio(),
+			"BuyDeSoWithETH":        fes.IsConfiguredForETH(),
+			"StarterDeSo":           fes.Config.StarterDESOSeed != "",
+		},
+		MinFeeRateNanosPerKB:             fes.MinFeeRateNanosPerKB,
+		MaxRequestBodySizeBytes:          uint64(fes.maxRequestBodySizeBytesForRoute("")),
+		MaxOptionalPrecedingTransactions: fes.Config.MaxOptionalPrecedingTransactions,
+		MaxExtraDataEntries:              fes.Config.MaxExtraDataEntries,
+		MaxExtraDataSizeBytes:            fes.Config.MaxExtraDataSizeBytes,
+		IPRateLimitRequestsPerSecond:     fes.Config.IPRateLimitRequestsPerSecond,
+	}
+}
+
+// getBuildVersionAndCommit reads the module version and VCS revision that the Go toolchain embeds in the binary.
+func getBuildVersionAndCommit() (_version string, _commit string) {
+	buildInfo, ok := debug.ReadBuildInfo()
+	if !ok {
+		return "", ""
+	}
+	commit := ""
+	for _, setting := range buildInfo.Settings {
+		if setting.Key == "vcs.revision" {
+			commit = setting.Value
+		}
+	}
+	return buildInfo.Main.Version, commit
+}
+
 type GetIngressCookieResponse struct {
 	CookieValue string
 }
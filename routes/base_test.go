@@ -0,0 +1,110 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/deso-protocol/backend/config"
+	"github.com/deso-protocol/core/lib"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetNodeInfo(t *testing.T) {
+	startTime := time.Unix(1700000000, 0)
+	apiServer := &APIServer{
+		Params: &lib.DeSoTestnetParams,
+		Config: &config.Config{
+			GCPBucketName:                    "images",
+			CloudflareStreamToken:            "secret-cloudflare-token",
+			SendgridApiKey:                   "secret-sendgrid-key",
+			StarterDESOSeed:                  "secret starter seed words",
+			GlobalStateRemoteSecret:          "secret-global-state",
+			JumioToken:                       "secret-jumio-token",
+			MaxOptionalPrecedingTransactions: 3,
+			MaxExtraDataEntries:              64,
+			MaxExtraDataSizeBytes:            16384,
+			IPRateLimitRequestsPerSecond:     5,
+		},
+		MinFeeRateNanosPerKB: 1000,
+		startTime:            startTime,
+	}
+
+	nodeInfo := apiServer.getNodeInfo(1234, startTime.Add(90*time.Second))
+	require.Equal(t, lib.NetworkType_TESTNET.String(), nodeInfo.NetworkType)
+	require.Equal(t, uint64(90), nodeInfo.UptimeSeconds)
+	require.Equal(t, uint64(1234), nodeInfo.TipHeight)
+	require.Equal(t, uint64(1000), nodeInfo.MinFeeRateNanosPerKB)
+	require.Equal(t, uint64(MaxRequestBodySizeBytes), nodeInfo.MaxRequestBodySizeBytes)
+	require.Equal(t, 3, nodeInfo.MaxOptionalPrecedingTransactions)
+	require.Equal(t, uint64(64), nodeInfo.MaxExtraDataEntries)
+	require.Equal(t, uint64(16384), nodeInfo.MaxExtraDataSizeBytes)
+	require.Equal(t, float64(5), nodeInfo.IPRateLimitRequestsPerSecond)
+
+	// Features reflect what's configured.
+	require.True(t, nodeInfo.Features["ImageUploads"])
+	require.True(t, nodeInfo.Features["EmailVerification"])
+	require.True(t, nodeInfo.Features["StarterDeSo"])
+	require.False(t, nodeInfo.Features["VideoUploads"])
+	require.False(t, nodeInfo.Features["Jumio"])
+	require.False(t, nodeInfo.Features["TransactionIndex"])
+
+	// None of the configured secrets are exposed.
+	nodeInfoJSON, err := json.Marshal(nodeInfo)
+	require.NoError(t, err)
+	require.NotContains(t, string(nodeInfoJSON), "secret")
+}
+
+func TestUtxoViewTimer(t *testing.T) {
+	const viewConstructionTime = 50 * time.Millisecond
+	clock := time.Unix(1_700_000_000, 0)
+	var warnings []string
+	timer := &utxoViewTimer{
+		now: func() time.Time { return clock },
+		warningf: func(format string, args ...interface{}) {
+			warnings = append(warnings, fmt.Sprintf(format, args...))
+		},
+	}
+	slowView := &lib.UtxoView{}
+	constructSlowView := func() (*lib.UtxoView, error) {
+		clock = clock.Add(viewConstructionTime)
+		return slowView, nil
+	}
+
+	// Constructions under the threshold aren't logged.
+	timer.threshold = 2 * viewConstructionTime
+	utxoView, err := timer.Time("GetSinglePost", constructSlowView)
+	require.NoError(t, err)
+	require.Same(t, slowView, utxoView)
+	require.Empty(t, warnings)
+
+	// Constructions over it are, along with the endpoint.
+	timer.threshold = viewConstructionTime / 2
+	utxoView, err = timer.Time("GetSinglePost", constructSlowView)
+	require.NoError(t, err)
+	require.Same(t, slowView, utxoView)
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0], "GetSinglePost")
+	require.Contains(t, warnings[0], viewConstructionTime.String())
+
+	// A zero threshold disables the warning, and errors are passed through either way.
+	timer.threshold = 0
+	_, err = timer.Time("GetSinglePost", constructSlowView)
+	require.NoError(t, err)
+	require.Len(t, warnings, 1)
+	timer.threshold = viewConstructionTime / 2
+	_, err = timer.Time("GetSinglePost", func() (*lib.UtxoView, error) {
+		clock = clock.Add(viewConstructionTime)
+		return nil, errors.New("boom")
+	})
+	require.EqualError(t, err, "boom")
+	require.Len(t, warnings, 2)
+
+	// A nil timer just builds the view.
+	var nilTimer *utxoViewTimer
+	utxoView, err = nilTimer.Time("GetSinglePost", constructSlowView)
+	require.NoError(t, err)
+	require.Same(t, slowView, utxoView)
+}
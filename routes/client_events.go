@@ -0,0 +1,135 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/golang/glog"
+)
+
+const (
+	// MaxClientEventNameLength is the maximum length of the EventName on a client event.
+	MaxClientEventNameLength = 128
+	// MaxClientEventProperties is the maximum number of properties that can be attached to a client event.
+	MaxClientEventProperties = 50
+)
+
+// sensitiveClientEventPropertySubstrings are stripped from client event properties before they are forwarded. Any
+// property whose key contains one of these (case-insensitive) is dropped.
+var sensitiveClientEventPropertySubstrings = []string{
+	"seed",
+	"mnemonic",
+	"private",
+	"secret",
+	"password",
+	"jwt",
+	"token",
+	"email",
+	"phone",
+}
+
+type LogClientEventRequest struct {
+	// Name of the event, e.g. "post : submit : error".
+	EventName string `safeForLogging:"true"`
+	// Arbitrary properties describing the event. Properties that look sensitive are stripped.
+	EventProperties map[string]interface{}
+	// Optional. The public key of the user that triggered the event.
+	PublicKeyBase58Check string `safeForLogging:"true"`
+}
+
+type LogClientEventResponse struct {
+	// Whether the event was forwarded to the analytics sink. When false, the event was only logged.
+	ForwardedToAnalytics bool
+}
+
+// LogClientEvent accepts client-side errors and telemetry and forwards them to the node's analytics sink. This keeps
+// the analytics key on the node rather than exposing it to clients.
+func (fes *APIServer) LogClientEvent(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := LogClientEventRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("LogClientEvent: Problem parsing request body: %v", err))
+		return
+	}
+
+	if requestData.EventName == "" {
+		_AddBadRequestError(ww, "LogClientEvent: EventName is required")
+		return
+	}
+	if len(requestData.EventName) > MaxClientEventNameLength {
+		_AddBadRequestError(ww, fmt.Sprintf("LogClientEvent: EventName cannot be longer than %d characters",
+			MaxClientEventNameLength))
+		return
+	}
+	if len(requestData.EventProperties) > MaxClientEventProperties {
+		_AddBadRequestError(ww, fmt.Sprintf("LogClientEvent: Cannot attach more than %d EventProperties",
+			MaxClientEventProperties))
+		return
+	}
+
+	var publicKeyBytes []byte
+	if requestData.PublicKeyBase58Check != "" {
+		var err error
+		publicKeyBytes, _, err = lib.Base58CheckDecode(requestData.PublicKeyBase58Check)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("LogClientEvent: Problem decoding PublicKeyBase58Check: %v", err))
+			return
+		}
+	}
+	// The public key isn't authenticated, so events are always rate limited by IP, which stops a caller from dodging
+	// the limit by rotating keys. Events for a public key are also limited across IPs, so a single user can't flood
+	// the analytics by spreading their events over many IPs. The IP is checked first so that a caller over its own
+	// limit doesn't use up the budget of the key it claims.
+	if !fes.clientEventRateLimiter.Allow("ip:" + fes.ipRateLimiter.ClientIP(req)) {
+		_AddTooManyRequestsError(ww, "LogClientEvent: Too many events, please try again later")
+		return
+	}
+	if len(publicKeyBytes) != 0 && !fes.clientEventRateLimiter.Allow("pk:"+string(publicKeyBytes)) {
+		_AddTooManyRequestsError(ww, "LogClientEvent: Too many events for this public key, please try again later")
+		return
+	}
+
+	eventProperties := stripSensitiveClientEventProperties(requestData.EventProperties)
+
+	res := LogClientEventResponse{}
+	if fes.Config.AmplitudeKey == "" {
+		glog.V(1).Infof("LogClientEvent: %v %v %v", requestData.PublicKeyBase58Check, requestData.EventName, eventProperties)
+	} else {
+		if err := fes.logAmplitudeEvent(requestData.PublicKeyBase58Check, requestData.EventName, eventProperties); err != nil {
+			glog.Errorf("LogClientEvent: Problem forwarding event to Amplitude: %v", err)
+		} else {
+			res.ForwardedToAnalytics = true
+		}
+	}
+
+	if err := json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("LogClientEvent: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// stripSensitiveClientEventProperties returns a copy of eventProperties without any property that looks sensitive.
+func stripSensitiveClientEventProperties(eventProperties map[string]interface{}) map[string]interface{} {
+	strippedProperties := make(map[string]interface{})
+	for key, value := range eventProperties {
+		if isSensitiveClientEventProperty(key) {
+			continue
+		}
+		strippedProperties[key] = value
+	}
+	return strippedProperties
+}
+
+func isSensitiveClientEventProperty(key string) bool {
+	lowercaseKey := strings.ToLower(key)
+	for _, sensitiveSubstring := range sensitiveClientEventPropertySubstrings {
+		if strings.Contains(lowercaseKey, sensitiveSubstring) {
+			return true
+		}
+	}
+	return false
+}
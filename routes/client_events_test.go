@@ -0,0 +1,89 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/deso-protocol/backend/config"
+	"github.com/stretchr/testify/require"
+)
+
+func logClientEvent(
+	t *testing.T,
+	apiServer *APIServer,
+	remoteAddr string,
+	requestData LogClientEventRequest,
+) *httptest.ResponseRecorder {
+	t.Helper()
+	requestBody, err := json.Marshal(requestData)
+	require.NoError(t, err)
+	request, err := http.NewRequest("POST", RoutePathLogClientEvent, bytes.NewBuffer(requestBody))
+	require.NoError(t, err)
+	request.RemoteAddr = remoteAddr
+	// Clients can set any header they like, so this must not pick the rate limit bucket.
+	request.Header.Set("CF-Connecting-IP", "9.9.9.9")
+	response := httptest.NewRecorder()
+	apiServer.LogClientEvent(response, request)
+	return response
+}
+
+func TestLogClientEvent(t *testing.T) {
+	// Amplitude is not configured, so events are logged rather than forwarded.
+	apiServer := &APIServer{
+		Config:                 &config.Config{},
+		clientEventRateLimiter: NewKeyedRateLimiter(2, time.Minute),
+	}
+	event := LogClientEventRequest{
+		EventName:            "post : submit : error",
+		EventProperties:      map[string]interface{}{"error": "timeout"},
+		PublicKeyBase58Check: senderPkString,
+	}
+
+	// A valid event is accepted.
+	response := logClientEvent(t, apiServer, "1.1.1.1:1234", event)
+	require.Equal(t, http.StatusOK, response.Code)
+	res := LogClientEventResponse{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), &res))
+	require.False(t, res.ForwardedToAnalytics)
+
+	// Events without a name are rejected.
+	response = logClientEvent(t, apiServer, "1.1.1.1:1234",
+		LogClientEventRequest{PublicKeyBase58Check: senderPkString})
+	require.Equal(t, http.StatusBadRequest, response.Code)
+
+	// A burst from the same IP is rate limited even if it rotates public keys.
+	require.Equal(t, http.StatusOK, logClientEvent(t, apiServer, "1.1.1.1:1234", event).Code)
+	event.PublicKeyBase58Check = recipientPkString
+	require.Equal(t, http.StatusTooManyRequests, logClientEvent(t, apiServer, "1.1.1.1:1234", event).Code)
+
+	// A burst from the same public key is rate limited even if it rotates IPs.
+	event.PublicKeyBase58Check = senderPkString
+	response = logClientEvent(t, apiServer, "2.2.2.2:1234", event)
+	require.Equal(t, http.StatusTooManyRequests, response.Code)
+	require.Contains(t, response.Body.String(), "public key")
+
+	// Other public keys are not, including the one whose event was turned away by the IP limit.
+	event.PublicKeyBase58Check = recipientPkString
+	require.Equal(t, http.StatusOK, logClientEvent(t, apiServer, "3.3.3.3:1234", event).Code)
+	event.PublicKeyBase58Check = ""
+	require.Equal(t, http.StatusOK, logClientEvent(t, apiServer, "4.4.4.4:1234", event).Code)
+}
+
+func TestStripSensitiveClientEventProperties(t *testing.T) {
+	strippedProperties := stripSensitiveClientEventProperties(map[string]interface{}{
+		"error":       "timeout",
+		"seedHex":     "deadbeef",
+		"UserEmail":   "user@example.com",
+		"AdminJWT":    "jwt",
+		"routeName":   "submit-post",
+		"phoneNumber": "+15555555555",
+	})
+	require.Equal(t, map[string]interface{}{
+		"error":     "timeout",
+		"routeName": "submit-post",
+	}, strippedProperties)
+}
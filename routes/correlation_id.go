@@ -0,0 +1,83 @@
+package routes
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+)
+
+// DefaultCorrelationIDHeader is the header AddCorrelationID reads when the node doesn't configure one.
+const DefaultCorrelationIDHeader = "X-Request-ID"
+
+type correlationIDContextKey struct{}
+
+// correlationID is what AddCorrelationID stores in a request's context. The header name travels with the ID so that
+// outbound calls forward it under the same header it arrived in.
+type correlationID struct {
+	header string
+	id     string
+}
+
+// correlationIDResponseWriter carries the request's correlation ID to _AddHttpError, which only sees the
+// ResponseWriter.
+type correlationIDResponseWriter struct {
+	http.ResponseWriter
+	correlationID string
+}
+
+func (ww *correlationIDResponseWriter) CorrelationID() string {
+	return ww.correlationID
+}
+
+// AddCorrelationID is middleware that tags each request with the correlation ID in its header, or a random one if
+// the header is missing. The ID is stored in the request's context, echoed in the same response header, and included
+// in the errors logged while serving the request.
+func AddCorrelationID(inner http.Handler, header string) http.Handler {
+	if header == "" {
+		header = DefaultCorrelationIDHeader
+	}
+	return http.HandlerFunc(func(ww http.ResponseWriter, rr *http.Request) {
+		id := rr.Header.Get(header)
+		if id == "" {
+			id = newCorrelationID()
+		}
+		ww.Header().Set(header, id)
+		rr = rr.WithContext(context.WithValue(rr.Context(), correlationIDContextKey{}, correlationID{header: header, id: id}))
+		inner.ServeHTTP(&correlationIDResponseWriter{ResponseWriter: ww, correlationID: id}, rr)
+	})
+}
+
+// CorrelationIDFromContext returns the correlation ID AddCorrelationID stored in ctx, or "" if there isn't one.
+func CorrelationIDFromContext(ctx context.Context) string {
+	if value, ok := ctx.Value(correlationIDContextKey{}).(correlationID); ok {
+		return value.id
+	}
+	return ""
+}
+
+// forwardCorrelationID sets the correlation ID stored in ctx, if any, on an outbound request made while serving the
+// inbound request ctx came from.
+func forwardCorrelationID(ctx context.Context, outboundReq *http.Request) {
+	if value, ok := ctx.Value(correlationIDContextKey{}).(correlationID); ok {
+		outboundReq.Header.Set(value.header, value.id)
+	}
+}
+
+// correlationIDFromResponseWriter returns the correlation ID of the request ww is responding to, or "" if the request
+// didn't pass through AddCorrelationID.
+func correlationIDFromResponseWriter(ww http.ResponseWriter) string {
+	if correlationIDWriter, ok := ww.(interface{ CorrelationID() string }); ok {
+		return correlationIDWriter.CorrelationID()
+	}
+	return ""
+}
+
+func newCorrelationID() string {
+	idBytes := make([]byte, 16)
+	// crypto/rand only fails if the OS can't provide randomness, in which case an empty ID is the best we can do.
+	if _, err := rand.Read(idBytes); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(idBytes)
+}
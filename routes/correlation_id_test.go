@@ -0,0 +1,64 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAddCorrelationID(t *testing.T) {
+	var loggedErrors []string
+	originalLogHttpError := logHttpError
+	logHttpError = func(errorString string) {
+		loggedErrors = append(loggedErrors, errorString)
+	}
+	defer func() { logHttpError = originalLogHttpError }()
+
+	// A stub upstream that records the trace header it receives.
+	var upstreamTraceHeader string
+	upstream := httptest.NewServer(http.HandlerFunc(func(ww http.ResponseWriter, req *http.Request) {
+		upstreamTraceHeader = req.Header.Get("traceparent")
+	}))
+	defer upstream.Close()
+
+	// A handler that calls the upstream and then fails.
+	handler := AddCorrelationID(http.HandlerFunc(func(ww http.ResponseWriter, req *http.Request) {
+		outboundReq, err := http.NewRequest("GET", upstream.URL, nil)
+		require.NoError(t, err)
+		forwardCorrelationID(req.Context(), outboundReq)
+		resp, err := http.DefaultClient.Do(outboundReq)
+		require.NoError(t, err)
+		resp.Body.Close()
+		_AddBadRequestError(ww, "Handler: something went wrong")
+	}), "traceparent")
+
+	// An inbound trace ID is forwarded upstream, logged with the handler's error, and echoed on the response.
+	traceID := "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"
+	request, err := http.NewRequest("POST", "/api/v0/test", nil)
+	require.NoError(t, err)
+	request.Header.Set("traceparent", traceID)
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Equal(t, traceID, upstreamTraceHeader)
+	require.Equal(t, []string{"[" + traceID + "] Handler: something went wrong"}, loggedErrors)
+	require.Equal(t, traceID, response.Header().Get("traceparent"))
+
+	// Requests without one are assigned a fresh ID, which is used the same way.
+	loggedErrors = nil
+	request, err = http.NewRequest("POST", "/api/v0/test", nil)
+	require.NoError(t, err)
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	generatedID := response.Header().Get("traceparent")
+	require.Len(t, generatedID, 32)
+	require.Equal(t, generatedID, upstreamTraceHeader)
+	require.Equal(t, []string{"[" + generatedID + "] Handler: something went wrong"}, loggedErrors)
+
+	// Requests that don't pass through the middleware have no ID and log errors as before.
+	loggedErrors = nil
+	_AddBadRequestError(httptest.NewRecorder(), "Handler: something went wrong")
+	require.Equal(t, []string{"Handler: something went wrong"}, loggedErrors)
+}
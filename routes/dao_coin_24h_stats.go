@@ -0,0 +1,347 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/deso-protocol/uint256"
+	"github.com/pkg/errors"
+)
+
+const (
+	// The window GetDAOCoin24hStats reports on.
+	DAOCoin24hStatsWindow = 24 * time.Hour
+
+	// A spread history sample only stands in for the book 24h ago if it was taken within this long of the start of
+	// the window. Otherwise the first trade of the window is used.
+	DAOCoin24hStatsMaxReferenceSampleLag = 10 * DAOCoinSpreadHistorySampleInterval
+
+	// The most blocks GetDAOCoin24hStats scans for trades. If the window has more blocks than this, the volume only
+	// covers the most recent ones and IsVolumePartial is set.
+	MaxDAOCoin24hStatsBlocksScanned = 20000
+)
+
+const (
+	// The mid-price 24h ago came from the order book warmer's spread history.
+	DAOCoin24hReferencePriceSourceOrderBook = "OrderBook"
+	// No book was sampled near the start of the window, so the price of its first trade was used instead.
+	DAOCoin24hReferencePriceSourceFirstTrade = "FirstTrade"
+)
+
+// daoCoinPairTrade is one maker order filled on a coin1/coin2 pair, with its quantities in base units and its price
+// in whole coin2 per whole coin1.
+type daoCoinPairTrade struct {
+	TimestampNanos         int64
+	Coin1QuantityBaseUnits *big.Int
+	Coin2QuantityBaseUnits *big.Int
+	Price                  *big.Rat
+}
+
+type GetDAOCoin24hStatsRequest struct {
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+}
+
+type GetDAOCoin24hStatsResponse struct {
+	// Decimal strings in coin2 per coin1. Each mid-price is empty if its Has field is false.
+	HasCurrentMidPrice bool   `safeForLogging:"true"`
+	CurrentMidPrice    string `safeForLogging:"true"`
+	HasMidPrice24hAgo  bool   `safeForLogging:"true"`
+	MidPrice24hAgo     string `safeForLogging:"true"`
+	// One of DAOCoin24hReferencePriceSourceOrderBook or DAOCoin24hReferencePriceSourceFirstTrade. Empty if
+	// HasMidPrice24hAgo is false.
+	MidPrice24hAgoSource string `safeForLogging:"true"`
+
+	// False unless both mid-prices are known, in which case the changes are left empty rather than reported as zero.
+	IsChangeAvailable bool `safeForLogging:"true"`
+	// CurrentMidPrice - MidPrice24hAgo, in coin2 per coin1.
+	AbsoluteChange string `safeForLogging:"true"`
+	// The change relative to MidPrice24hAgo, rounded to two decimal places (ex: 5.20 for +5.2%).
+	PercentageChange string `safeForLogging:"true"`
+
+	// False if the node doesn't run with --txindex, in which case the volumes are empty.
+	IsVolumeAvailable bool `safeForLogging:"true"`
+	// True if the window had more blocks than MaxDAOCoin24hStatsBlocksScanned, so the volume undercounts.
+	IsVolumePartial bool `safeForLogging:"true"`
+	// Decimal strings. The total coin1 traded in the window, and the coin2 paid for it.
+	Coin1Volume string `safeForLogging:"true"`
+	Coin2Volume string `safeForLogging:"true"`
+	NumTrades   int    `safeForLogging:"true"`
+}
+
+// GetDAOCoin24hStats returns how a pair's mid-price changed over the last 24h along with its traded volume. The
+// mid-price 24h ago is read from the spread history the order book warmer records, falling back to the first trade
+// of the window, and trades are read from txindex. Any value the node doesn't have the history for is reported as
+// unavailable.
+func (fes *APIServer) GetDAOCoin24hStats(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDAOCoin24hStatsRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoin24hStats: Problem parsing request body: %v", err))
+		return
+	}
+
+	coin1PublicKeyBase58Check := requestData.DAOCoin1CreatorPublicKeyBase58Check
+	coin2PublicKeyBase58Check := requestData.DAOCoin2CreatorPublicKeyBase58Check
+	if IsDesoPkid(coin1PublicKeyBase58Check) && IsDesoPkid(coin2PublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetDAOCoin24hStats: Must provide either a "+
+			"DAOCoin1CreatorPublicKeyBase58Check or DAOCoin2CreatorPublicKeyBase58Check or both")
+		return
+	}
+
+	now := time.Now()
+	windowStartNanos := now.Add(-DAOCoin24hStatsWindow).UnixNano()
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(TxnStatusInMempool, "GetDAOCoin24hStats")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoin24hStats: Problem fetching utxoView: %v", err))
+		return
+	}
+	orders, err := fes.getDAOCoinLimitOrdersForCoinPair(utxoView, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoin24hStats: Error getting limit orders: %v", err))
+		return
+	}
+	currentTopOfBook, err := NewDAOCoinSpreadSample(now, orders, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoin24hStats: Problem reading top of book: %v", err))
+		return
+	}
+
+	var samples []DAOCoinSpreadSample
+	if fes.DAOCoinSpreadHistory != nil {
+		key := NewDAOCoinOrderBookCacheKey(coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, TxnStatusInMempool)
+		samples = fes.DAOCoinSpreadHistory.GetSamples(key, windowStartNanos, now.UnixNano())
+	}
+
+	var trades []daoCoinPairTrade
+	isVolumePartial := false
+	if fes.TXIndex != nil {
+		trades, isVolumePartial, err = fes.getDAOCoinPairTradesSince(
+			coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, windowStartNanos)
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoin24hStats: Problem fetching trades: %v", err))
+			return
+		}
+	}
+
+	res, err := ComputeDAOCoin24hStats(currentTopOfBook, samples, trades, fes.TXIndex != nil, windowStartNanos,
+		coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoin24hStats: Problem computing stats: %v", err))
+		return
+	}
+	res.IsVolumePartial = isVolumePartial
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoin24hStats: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getDAOCoinPairTradesSince scans the blocks txindex has processed, newest first, back to the first one mined before
+// windowStartNanos, and returns the coin1/coin2 trades in them oldest first. Stops early and reports the result as
+// partial after MaxDAOCoin24hStatsBlocksScanned blocks.
+func (fes *APIServer) getDAOCoinPairTradesSince(
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	windowStartNanos int64,
+) (_trades []daoCoinPairTrade, _isPartial bool, _err error) {
+	bestChain := fes.blockchain.BestChain()
+	endBlockHeight := int64(fes.TXIndex.TXIndexChain.BlockTip().Height)
+	if endBlockHeight >= int64(len(bestChain)) {
+		endBlockHeight = int64(len(bestChain)) - 1
+	}
+	var trades []daoCoinPairTrade
+	blocksScanned := 0
+	for blockHeight := endBlockHeight; blockHeight >= 0; blockHeight-- {
+		blockNode := bestChain[blockHeight]
+		if blockNode.Header == nil || blockNode.Header.TstampNanoSecs < windowStartNanos {
+			break
+		}
+		if blocksScanned == MaxDAOCoin24hStatsBlocksScanned {
+			sortDAOCoinPairTradesOldestFirst(trades)
+			return trades, true, nil
+		}
+		blocksScanned++
+
+		block, err := lib.GetBlock(blockNode.Hash, fes.blockchain.DB(), fes.blockchain.Snapshot())
+		if err != nil {
+			return nil, false, errors.Wrapf(err, "Problem fetching block %v", blockNode.Hash)
+		}
+		for _, txn := range block.Txns {
+			if txn.TxnMeta == nil || txn.TxnMeta.GetTxnType() != lib.TxnTypeDAOCoinLimitOrder {
+				continue
+			}
+			txnHash := txn.Hash()
+			txnMeta := lib.DbGetTxindexTransactionRefByTxID(fes.TXIndex.TXIndexChain.DB(), nil, txnHash)
+			if txnMeta == nil {
+				continue
+			}
+			txnTrades, err := getDAOCoinPairTradesFromTxnMetadata(
+				coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, txnMeta)
+			if err != nil {
+				return nil, false, errors.Wrapf(err, "Problem reading trades for txn %v", txnHash)
+			}
+			for _, trade := range txnTrades {
+				trade.TimestampNanos = block.Header.TstampNanoSecs
+				trades = append(trades, trade)
+			}
+		}
+	}
+	sortDAOCoinPairTradesOldestFirst(trades)
+	return trades, false, nil
+}
+
+func sortDAOCoinPairTradesOldestFirst(trades []daoCoinPairTrade) {
+	sort.SliceStable(trades, func(ii, jj int) bool {
+		return trades[ii].TimestampNanos < trades[jj].TimestampNanos
+	})
+}
+
+// getDAOCoinPairTradesFromTxnMetadata returns the trades on the coin1/coin2 pair in a DAO coin limit order txn. As in
+// getTransactorFillsFromTxnMetadata, each maker order the txn filled is one trade and the taker's own entry is skipped.
+// The txn's timestamp isn't in its metadata, so the caller sets it.
+func getDAOCoinPairTradesFromTxnMetadata(
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	txnMeta *lib.TransactionMetadata,
+) ([]daoCoinPairTrade, error) {
+	if txnMeta.DAOCoinLimitOrderTxindexMetadata == nil {
+		return nil, nil
+	}
+
+	var trades []daoCoinPairTrade
+	for _, filledOrder := range txnMeta.DAOCoinLimitOrderTxindexMetadata.FilledDAOCoinLimitOrdersMetadata {
+		if filledOrder.TransactorPublicKeyBase58Check == txnMeta.TransactorPublicKeyBase58Check {
+			continue
+		}
+		var coin1QuantityBaseUnits, coin2QuantityBaseUnits *uint256.Int
+		switch {
+		case isSameCoin(filledOrder.BuyingDAOCoinCreatorPublicKey, coin1PublicKeyBase58Check) &&
+			isSameCoin(filledOrder.SellingDAOCoinCreatorPublicKey, coin2PublicKeyBase58Check):
+			coin1QuantityBaseUnits = filledOrder.CoinQuantityInBaseUnitsBought
+			coin2QuantityBaseUnits = filledOrder.CoinQuantityInBaseUnitsSold
+		case isSameCoin(filledOrder.BuyingDAOCoinCreatorPublicKey, coin2PublicKeyBase58Check) &&
+			isSameCoin(filledOrder.SellingDAOCoinCreatorPublicKey, coin1PublicKeyBase58Check):
+			coin1QuantityBaseUnits = filledOrder.CoinQuantityInBaseUnitsSold
+			coin2QuantityBaseUnits = filledOrder.CoinQuantityInBaseUnitsBought
+		default:
+			continue
+		}
+		if coin1QuantityBaseUnits == nil || coin1QuantityBaseUnits.IsZero() || coin2QuantityBaseUnits == nil {
+			return nil, errors.New("Fill is missing its quantities")
+		}
+
+		// (coin2 / 10^coin2Decimals) / (coin1 / 10^coin1Decimals)
+		coinsToBaseUnits := func(publicKeyBase58Check string) *big.Int {
+			return new(big.Int).Exp(
+				big.NewInt(10), big.NewInt(int64(getCoinDecimals(IsDesoPkid(publicKeyBase58Check)))), nil)
+		}
+		price := new(big.Rat).SetFrac(
+			new(big.Int).Mul(coin2QuantityBaseUnits.ToBig(), coinsToBaseUnits(coin1PublicKeyBase58Check)),
+			new(big.Int).Mul(coin1QuantityBaseUnits.ToBig(), coinsToBaseUnits(coin2PublicKeyBase58Check)),
+		)
+		trades = append(trades, daoCoinPairTrade{
+			Coin1QuantityBaseUnits: coin1QuantityBaseUnits.ToBig(),
+			Coin2QuantityBaseUnits: coin2QuantityBaseUnits.ToBig(),
+			Price:                  price,
+		})
+	}
+	return trades, nil
+}
+
+// ComputeDAOCoin24hStats compares the current mid-price to the one at windowStartNanos and totals the volume of
+// trades, which must be sorted oldest first. The mid-price at the start of the window is that of the first spread
+// history sample with both sides of the book, if it was taken within DAOCoin24hStatsMaxReferenceSampleLag of the
+// start, and otherwise the price of the first trade.
+func ComputeDAOCoin24hStats(
+	currentTopOfBook DAOCoinSpreadSample,
+	samples []DAOCoinSpreadSample,
+	trades []daoCoinPairTrade,
+	isVolumeAvailable bool,
+	windowStartNanos int64,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) (*GetDAOCoin24hStatsResponse, error) {
+	midPrice := func(sample DAOCoinSpreadSample) *big.Rat {
+		if sample.BestBid == nil || sample.BestAsk == nil {
+			return nil
+		}
+		mid := new(big.Rat).Add(sample.BestBid, sample.BestAsk)
+		return mid.Quo(mid, big.NewRat(2, 1))
+	}
+
+	res := &GetDAOCoin24hStatsResponse{IsVolumeAvailable: isVolumeAvailable}
+	currentMidPrice := midPrice(currentTopOfBook)
+	if currentMidPrice != nil {
+		res.HasCurrentMidPrice = true
+		res.CurrentMidPrice = formatDAOCoinLimitOrderPriceRat(currentMidPrice)
+	}
+
+	var referencePrice *big.Rat
+	for _, sample := range samples {
+		if sample.TimestampNanos < windowStartNanos {
+			continue
+		}
+		if sample.TimestampNanos-windowStartNanos > DAOCoin24hStatsMaxReferenceSampleLag.Nanoseconds() {
+			break
+		}
+		if referencePrice = midPrice(sample); referencePrice != nil {
+			res.MidPrice24hAgoSource = DAOCoin24hReferencePriceSourceOrderBook
+			break
+		}
+	}
+	if referencePrice == nil && len(trades) > 0 {
+		referencePrice = trades[0].Price
+		res.MidPrice24hAgoSource = DAOCoin24hReferencePriceSourceFirstTrade
+	}
+	if referencePrice != nil {
+		res.HasMidPrice24hAgo = true
+		res.MidPrice24hAgo = formatDAOCoinLimitOrderPriceRat(referencePrice)
+	}
+
+	if currentMidPrice != nil && referencePrice != nil && referencePrice.Sign() > 0 {
+		change := new(big.Rat).Sub(currentMidPrice, referencePrice)
+		res.IsChangeAvailable = true
+		// Prices are formatted as unsigned decimals, so a drop gets its sign added back.
+		res.AbsoluteChange = formatDAOCoinLimitOrderPriceRat(new(big.Rat).Abs(change))
+		if change.Sign() < 0 {
+			res.AbsoluteChange = "-" + res.AbsoluteChange
+		}
+		res.PercentageChange = new(big.Rat).Mul(
+			new(big.Rat).Quo(change, referencePrice), big.NewRat(100, 1)).FloatString(2)
+	}
+
+	if !isVolumeAvailable {
+		return res, nil
+	}
+	coin1VolumeBaseUnits := big.NewInt(0)
+	coin2VolumeBaseUnits := big.NewInt(0)
+	for _, trade := range trades {
+		coin1VolumeBaseUnits.Add(coin1VolumeBaseUnits, trade.Coin1QuantityBaseUnits)
+		coin2VolumeBaseUnits.Add(coin2VolumeBaseUnits, trade.Coin2QuantityBaseUnits)
+	}
+	toDecimalString := func(publicKeyBase58Check string, baseUnits *big.Int) (string, error) {
+		baseUnitsInt, overflow := uint256.FromBig(baseUnits)
+		if overflow {
+			return "", errors.Errorf("%v base units overflows uint256", baseUnits.String())
+		}
+		return CalculateStringDecimalAmountFromBaseUnitsSimple(publicKeyBase58Check, baseUnitsInt)
+	}
+	var err error
+	if res.Coin1Volume, err = toDecimalString(coin1PublicKeyBase58Check, coin1VolumeBaseUnits); err != nil {
+		return nil, err
+	}
+	if res.Coin2Volume, err = toDecimalString(coin2PublicKeyBase58Check, coin2VolumeBaseUnits); err != nil {
+		return nil, err
+	}
+	res.NumTrades = len(trades)
+	return res, nil
+}
@@ -0,0 +1,145 @@
+package routes
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/deso-protocol/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetDAOCoinPairTradesFromTxnMetadata(t *testing.T) {
+	desoNanos := func(deso uint64) *uint256.Int {
+		return uint256.NewInt(deso * lib.NanosPerUnit)
+	}
+	daoCoinBaseUnits := func(coins uint64) *uint256.Int {
+		return uint256.NewInt(0).Mul(uint256.NewInt(coins), lib.BaseUnitsPerCoin)
+	}
+	// The sender bids for 10 DAO coins at 2 $DESO each, matching the recipient's ask for 4 and a third party's ask
+	// for 6. The sender's own order is listed too.
+	txnMeta := &lib.TransactionMetadata{
+		TransactorPublicKeyBase58Check: senderPkString,
+		DAOCoinLimitOrderTxindexMetadata: &lib.DAOCoinLimitOrderTxindexMetadata{
+			FilledDAOCoinLimitOrdersMetadata: []*lib.FilledDAOCoinLimitOrderMetadata{
+				{
+					TransactorPublicKeyBase58Check: recipientPkString,
+					BuyingDAOCoinCreatorPublicKey:  DeSoZeroPkidTestnetBase58,
+					SellingDAOCoinCreatorPublicKey: daoCoinPubKeyBase58Check,
+					CoinQuantityInBaseUnitsBought:  desoNanos(8),
+					CoinQuantityInBaseUnitsSold:    daoCoinBaseUnits(4),
+				},
+				{
+					TransactorPublicKeyBase58Check: moneyPkString,
+					BuyingDAOCoinCreatorPublicKey:  DeSoZeroPkidTestnetBase58,
+					SellingDAOCoinCreatorPublicKey: daoCoinPubKeyBase58Check,
+					CoinQuantityInBaseUnitsBought:  desoNanos(12),
+					CoinQuantityInBaseUnitsSold:    daoCoinBaseUnits(6),
+				},
+				{
+					TransactorPublicKeyBase58Check: senderPkString,
+					BuyingDAOCoinCreatorPublicKey:  daoCoinPubKeyBase58Check,
+					SellingDAOCoinCreatorPublicKey: DeSoZeroPkidTestnetBase58,
+					CoinQuantityInBaseUnitsBought:  daoCoinBaseUnits(10),
+					CoinQuantityInBaseUnitsSold:    desoNanos(20),
+				},
+			},
+		},
+	}
+
+	// One trade per maker order, priced in $DESO per DAO coin.
+	trades, err := getDAOCoinPairTradesFromTxnMetadata(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, txnMeta)
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+	require.Equal(t, daoCoinBaseUnits(4).ToBig(), trades[0].Coin1QuantityBaseUnits)
+	require.Equal(t, desoNanos(8).ToBig(), trades[0].Coin2QuantityBaseUnits)
+	require.Equal(t, big.NewRat(2, 1), trades[0].Price)
+	require.Equal(t, daoCoinBaseUnits(6).ToBig(), trades[1].Coin1QuantityBaseUnits)
+
+	// Flipping the pair flips the price.
+	trades, err = getDAOCoinPairTradesFromTxnMetadata(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, txnMeta)
+	require.NoError(t, err)
+	require.Len(t, trades, 2)
+	require.Equal(t, big.NewRat(1, 2), trades[0].Price)
+
+	// Trades on other pairs are left out.
+	trades, err = getDAOCoinPairTradesFromTxnMetadata(daoCoinPubKeyBase58Check, moneyPkString, txnMeta)
+	require.NoError(t, err)
+	require.Empty(t, trades)
+}
+
+func TestComputeDAOCoin24hStats(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	windowStartNanos := now.Add(-DAOCoin24hStatsWindow).UnixNano()
+	sample := func(timestamp time.Time, bestBid *big.Rat, bestAsk *big.Rat) DAOCoinSpreadSample {
+		return DAOCoinSpreadSample{TimestampNanos: timestamp.UnixNano(), BestBid: bestBid, BestAsk: bestAsk}
+	}
+	trade := func(timestamp time.Time, coin1Coins int64, coin2Coins int64) daoCoinPairTrade {
+		coin1BaseUnits := new(big.Int).Mul(big.NewInt(coin1Coins), lib.BaseUnitsPerCoin.ToBig())
+		coin2BaseUnits := new(big.Int).Mul(big.NewInt(coin2Coins), big.NewInt(int64(lib.NanosPerUnit)))
+		return daoCoinPairTrade{
+			TimestampNanos:         timestamp.UnixNano(),
+			Coin1QuantityBaseUnits: coin1BaseUnits,
+			Coin2QuantityBaseUnits: coin2BaseUnits,
+			Price:                  big.NewRat(coin2Coins, coin1Coins),
+		}
+	}
+	currentTopOfBook := sample(now, big.NewRat(20, 10), big.NewRat(22, 10))
+	trades := []daoCoinPairTrade{
+		trade(now.Add(-20*time.Hour), 4, 8),
+		trade(now.Add(-time.Hour), 6, 12),
+	}
+
+	t.Run("full 24h of history", func(t *testing.T) {
+		samples := []DAOCoinSpreadSample{
+			sample(now.Add(-DAOCoin24hStatsWindow+time.Minute), big.NewRat(19, 10), big.NewRat(21, 10)),
+			sample(now.Add(-12*time.Hour), big.NewRat(10, 1), big.NewRat(11, 1)),
+		}
+		res, err := ComputeDAOCoin24hStats(currentTopOfBook, samples, trades, true, windowStartNanos,
+			daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+		require.NoError(t, err)
+		require.Equal(t, &GetDAOCoin24hStatsResponse{
+			HasCurrentMidPrice:   true,
+			CurrentMidPrice:      "2.1",
+			HasMidPrice24hAgo:    true,
+			MidPrice24hAgo:       "2.0",
+			MidPrice24hAgoSource: DAOCoin24hReferencePriceSourceOrderBook,
+			IsChangeAvailable:    true,
+			AbsoluteChange:       "0.1",
+			PercentageChange:     "5.00",
+			IsVolumeAvailable:    true,
+			Coin1Volume:          "10.0",
+			Coin2Volume:          "20.0",
+			NumTrades:            2,
+		}, res)
+	})
+
+	t.Run("less than 24h of book history falls back to the first trade", func(t *testing.T) {
+		samples := []DAOCoinSpreadSample{
+			sample(now.Add(-12*time.Hour), big.NewRat(19, 10), big.NewRat(21, 10)),
+		}
+		res, err := ComputeDAOCoin24hStats(sample(now, big.NewRat(18, 10), big.NewRat(18, 10)), samples, trades,
+			true, windowStartNanos, daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+		require.NoError(t, err)
+		require.True(t, res.HasMidPrice24hAgo)
+		require.Equal(t, "2.0", res.MidPrice24hAgo)
+		require.Equal(t, DAOCoin24hReferencePriceSourceFirstTrade, res.MidPrice24hAgoSource)
+		require.True(t, res.IsChangeAvailable)
+		require.Equal(t, "-0.2", res.AbsoluteChange)
+		require.Equal(t, "-10.00", res.PercentageChange)
+	})
+
+	t.Run("no history reports the change as unavailable", func(t *testing.T) {
+		samples := []DAOCoinSpreadSample{
+			sample(now.Add(-12*time.Hour), big.NewRat(19, 10), big.NewRat(21, 10)),
+		}
+		res, err := ComputeDAOCoin24hStats(currentTopOfBook, samples, nil, false, windowStartNanos,
+			daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+		require.NoError(t, err)
+		require.Equal(t, &GetDAOCoin24hStatsResponse{
+			HasCurrentMidPrice: true,
+			CurrentMidPrice:    "2.1",
+		}, res)
+	})
+}
@@ -2,14 +2,18 @@ package routes
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
 	"math/big"
 	"net/http"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/deso-protocol/core/lib"
 	"github.com/deso-protocol/uint256"
@@ -25,10 +29,62 @@ type GetDAOCoinLimitOrdersRequest struct {
 	// consider all txns including those in the mempool. If set to "Committed" then
 	// we will only consider txns that have been committed according to consensus.
 	TxnStatus TxnStatus `safeForLogging:"true"`
+
+	// If set, each order is annotated with the usernames of the transactor and the
+	// buying and selling coin creators.
+	IncludeUsernames bool `safeForLogging:"true"`
+
+	// If set, orders are sorted by how far their price is from the mid-price, nearest
+	// first. Falls back to sorting by price when one side of the book is empty.
+	SortByDistanceFromMid bool `safeForLogging:"true"`
+
+	// If set, orders whose Quantity is below this decimal string (ex: 1.23) are left out. The threshold is in the
+	// same coin as each order's Quantity, i.e. the buying coin for BIDs and the selling coin for ASKs.
+	MinQuantity string `safeForLogging:"true"`
+
+	// Reserved for reading the book as of a past block. This node only keeps the state at its current block tip, so
+	// any non-zero value is rejected rather than silently answered with the current book.
+	BlockHeight uint32 `safeForLogging:"true"`
+
+	// If set, the deprecated ExchangeRateCoinsToSellPerCoinToBuy and QuantityToFill float fields are left out of each
+	// order, so only the exact Price and Quantity strings are returned.
+	OmitDeprecatedFloatFields bool `safeForLogging:"true"`
+
+	// If set, the orders are returned split into Bids and Asks for coin1, each sorted best price first, instead of
+	// as the flat Orders list.
+	GroupBySide bool `safeForLogging:"true"`
+
+	// If set, at most PageSize orders are returned, sorted by price in coin2 per coin1, lowest first, then by OrderID.
+	// Pass the NextPaginationToken from the previous response as PaginationToken to fetch the next page. Can't be
+	// combined with SortByDistanceFromMid or GroupBySide.
+	PageSize        int    `safeForLogging:"true"`
+	PaginationToken string `safeForLogging:"true"`
+
+	// If set, each order is annotated with a FillHeuristic. See AddFillHeuristicToDAOCoinLimitOrders.
+	IncludeFillHeuristic bool `safeForLogging:"true"`
 }
 
 type GetDAOCoinLimitOrdersResponse struct {
 	Orders []DAOCoinLimitOrderEntryResponse
+
+	// Only set by GetDAOCoinLimitOrders when GroupBySide is set, in which case Orders is left empty. Bids are the
+	// orders buying coin1, highest price first, and Asks are the orders selling coin1, lowest price first. Prices are
+	// compared as the amount of coin2 per coin1.
+	Bids []DAOCoinLimitOrderEntryResponse
+	Asks []DAOCoinLimitOrderEntryResponse
+
+	// A hash over the sorted order IDs and their quantities. It is identical for identical books, so clients can
+	// compare it against the value from their previous poll to cheaply detect whether the book changed. Only set by
+	// GetDAOCoinLimitOrders.
+	BookChecksum string `safeForLogging:"true"`
+
+	// Only set when paging with PageSize. An opaque token for the next page, or empty if this is the last page.
+	NextPaginationToken string `safeForLogging:"true"`
+	// Only set when paging with PageSize. True if the orders on the pages already returned have changed since the
+	// PaginationToken was issued, i.e. an order before the token's position was added, removed, or partially
+	// filled. No orders are returned, and the client should restart paging from the first page. Orders after the
+	// token's position can change freely without a restart since they haven't been returned yet.
+	ShouldRestart bool `safeForLogging:"true"`
 }
 
 type DAOCoinLimitOrderEntryResponse struct {
@@ -37,6 +93,16 @@ type DAOCoinLimitOrderEntryResponse struct {
 	BuyingDAOCoinCreatorPublicKeyBase58Check  string `safeForLogging:"true"`
 	SellingDAOCoinCreatorPublicKeyBase58Check string `safeForLogging:"true"`
 
+	// Whether $DESO is the coin being bought or sold. Clients should use these rather than comparing the creator
+	// public keys above against "DESO".
+	BuyingCoinIsDESO  bool `safeForLogging:"true"`
+	SellingCoinIsDESO bool `safeForLogging:"true"`
+
+	// The number of decimal places each coin's base units are scaled by: DESOCoinDecimals for $DESO and
+	// DAOCoinDecimals for DAO coins. Clients should use these rather than hardcoding the precision.
+	BuyingCoinDecimals  int `safeForLogging:"true"`
+	SellingCoinDecimals int `safeForLogging:"true"`
+
 	// A decimal string (ex: 1.23) that represents the exchange rate between the two coins. If operation type is BID
 	// then the denominator represents the coin being bought. If the operation type is ASK, then the denominator
 	// represents the coin being sold
@@ -55,18 +121,82 @@ type DAOCoinLimitOrderEntryResponse struct {
 	OperationType DAOCoinLimitOrderOperationTypeString
 
 	OrderID string
+
+	// Only populated when IncludeUsernames is set on the request. Left empty for $DESO and for public keys without a
+	// profile.
+	TransactorUsername     string `json:",omitempty" safeForLogging:"true"`
+	BuyingDAOCoinUsername  string `json:",omitempty" safeForLogging:"true"`
+	SellingDAOCoinUsername string `json:",omitempty" safeForLogging:"true"`
+
+	// Only populated when IncludeFillHeuristic is set on the request and both sides of the book are non-empty. A
+	// rough score between 0 and 1 of how likely the order is to fill soon relative to the rest of the book, based on
+	// its distance from the mid-price and how much is queued ahead of it. It's a heuristic, not a prediction or a
+	// guarantee.
+	FillHeuristic *float64 `json:",omitempty" safeForLogging:"true"`
+
+	// Set by omitDeprecatedFloatFieldsFromDAOCoinLimitOrders, or when the node skips computing them, to leave the
+	// deprecated float fields out of the JSON.
+	omitDeprecatedFloatFields bool
+}
+
+// MarshalJSON encodes the order with its default field names, leaving out ExchangeRateCoinsToSellPerCoinToBuy and
+// QuantityToFill if the order was passed through omitDeprecatedFloatFieldsFromDAOCoinLimitOrders.
+func (order DAOCoinLimitOrderEntryResponse) MarshalJSON() ([]byte, error) {
+	// A distinct type without this method, so encoding it doesn't recurse.
+	type daoCoinLimitOrderEntryResponseJSON DAOCoinLimitOrderEntryResponse
+	if !order.omitDeprecatedFloatFields {
+		return json.Marshal(daoCoinLimitOrderEntryResponseJSON(order))
+	}
+	// Fields of the outer struct take precedence over the embedded fields with the same name, and nil pointers are
+	// omitted.
+	return json.Marshal(struct {
+		daoCoinLimitOrderEntryResponseJSON
+		ExchangeRateCoinsToSellPerCoinToBuy *float64 `json:",omitempty"`
+		QuantityToFill                      *float64 `json:",omitempty"`
+	}{daoCoinLimitOrderEntryResponseJSON: daoCoinLimitOrderEntryResponseJSON(order)})
+}
+
+// omitDeprecatedFloatFieldsFromDAOCoinLimitOrders returns a copy of orders that encode without the deprecated float
+// fields. The input slice is left untouched since it may be shared with the order book cache.
+func omitDeprecatedFloatFieldsFromDAOCoinLimitOrders(
+	orders []DAOCoinLimitOrderEntryResponse,
+) []DAOCoinLimitOrderEntryResponse {
+	ordersWithoutFloats := make([]DAOCoinLimitOrderEntryResponse, len(orders))
+	for ii, order := range orders {
+		order.omitDeprecatedFloatFields = true
+		ordersWithoutFloats[ii] = order
+	}
+	return ordersWithoutFloats
 }
 
 const DESOCoinIdentifierString = "DESO"
 
+const (
+	// $DESO amounts are denominated in nanos.
+	DESOCoinDecimals = 9
+	// DAO coin amounts are denominated in base units of 1e-18 coins.
+	DAOCoinDecimals = 18
+)
+
+// getCoinDecimals returns the precision of $DESO or of a DAO coin.
+func getCoinDecimals(isDESO bool) int {
+	if isDESO {
+		return DESOCoinDecimals
+	}
+	return DAOCoinDecimals
+}
+
+// GetUtxoViewGivenTxnStatus returns a view of the committed state, or of the state with all mempool transactions
+// applied. endpoint is the name of the calling handler, which is logged if building a mempool view is slow.
 func (fes *APIServer) GetUtxoViewGivenTxnStatus(
 	txnStatus TxnStatus,
+	endpoint string,
 ) (
 	*lib.UtxoView,
 	error,
 ) {
 	if txnStatus == TxnStatusInMempool {
-		return fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		return fes.getAugmentedUniversalView(endpoint)
 	}
 	if txnStatus == TxnStatusCommitted {
 		return lib.NewUtxoView(
@@ -82,7 +212,7 @@ func (fes *APIServer) GetUtxoViewGivenTxnStatus(
 func (fes *APIServer) GetDAOCoinLimitOrders(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetDAOCoinLimitOrdersRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(
 			ww,
 			fmt.Sprintf("GetDAOCoinLimitOrders: Problem parsing request body: %v", err),
@@ -116,7 +246,53 @@ func (fes *APIServer) GetDAOCoinLimitOrders(ww http.ResponseWriter, req *http.Re
 		return
 	}
 
-	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus)
+	if requestData.MinQuantity != "" {
+		if err := validateNonNegativeDecimalString(requestData.MinQuantity); err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Invalid MinQuantity: %v", err))
+			return
+		}
+	}
+
+	if requestData.PageSize < 0 || requestData.PageSize > MaxDAOCoinLimitOrdersPageSize {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: PageSize must be between 0 and %v, got %v",
+			MaxDAOCoinLimitOrdersPageSize, requestData.PageSize))
+		return
+	}
+	if requestData.PageSize == 0 && requestData.PaginationToken != "" {
+		_AddBadRequestError(ww, "GetDAOCoinLimitOrders: PaginationToken can only be used with PageSize")
+		return
+	}
+	if requestData.PageSize > 0 && (requestData.SortByDistanceFromMid || requestData.GroupBySide) {
+		_AddBadRequestError(ww, "GetDAOCoinLimitOrders: PageSize can't be combined with SortByDistanceFromMid "+
+			"or GroupBySide")
+		return
+	}
+	if requestData.PaginationToken != "" {
+		if _, err := DecodeDAOCoinLimitOrdersPaginationToken(requestData.PaginationToken); err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Invalid PaginationToken: %v", err))
+			return
+		}
+	}
+	if requestData.BlockHeight != 0 {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Can't read the order book at BlockHeight %v: "+
+			"historical order book state is not available on this node", requestData.BlockHeight))
+		return
+	}
+
+	// Serve the order book from the warm cache if we can. The block tip is read before the view is built so that an
+	// entry computed here can never outlive the block it was computed against.
+	cacheKey := NewDAOCoinOrderBookCacheKey(
+		requestData.DAOCoin1CreatorPublicKeyBase58Check, requestData.DAOCoin2CreatorPublicKeyBase58Check, txnStatus)
+	blockTipHash := fes.blockchain.BlockTip().Hash
+	if fes.DAOCoinOrderBookCache != nil {
+		fes.DAOCoinOrderBookCache.RecordRequest(cacheKey)
+		if cachedOrders, exists := fes.DAOCoinOrderBookCache.Get(cacheKey, blockTipHash); exists {
+			fes.writeDAOCoinLimitOrdersResponse(ww, &requestData, txnStatus, nil, cachedOrders)
+			return
+		}
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetDAOCoinLimitOrders")
 	if err != nil {
 		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Problem fetching utxoView: %v", err))
 		return
@@ -153,251 +329,2819 @@ func (fes *APIServer) GetDAOCoinLimitOrders(ww http.ResponseWriter, req *http.Re
 		}
 	}
 
-	ordersBuyingCoin1, err := utxoView.GetAllDAOCoinLimitOrdersForThisDAOCoinPair(coin1PKID, coin2PKID)
+	responses, err := fes.getDAOCoinLimitOrdersForCoinPKIDs(
+		utxoView,
+		requestData.DAOCoin1CreatorPublicKeyBase58Check,
+		requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		coin1PKID,
+		coin2PKID,
+	)
 	if err != nil {
 		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Error getting limit orders: %v", err))
 		return
 	}
-
-	ordersBuyingCoin2, err := utxoView.GetAllDAOCoinLimitOrdersForThisDAOCoinPair(coin2PKID, coin1PKID)
-	if err != nil {
-		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Error getting limit orders: %v", err))
-		return
+	if fes.DAOCoinOrderBookCache != nil {
+		fes.DAOCoinOrderBookCache.Put(cacheKey, blockTipHash, responses)
 	}
 
-	responses := append(
-		fes.buildDAOCoinLimitOrderResponsesFromEntriesForCoinPair(
-			utxoView,
+	fes.writeDAOCoinLimitOrdersResponse(ww, &requestData, txnStatus, utxoView, responses)
+}
+
+// writeDAOCoinLimitOrdersResponse applies the optional request settings to orders and writes the response. utxoView
+// may be nil if the orders were served from the cache, in which case one is only built if it's needed. orders may be
+// shared with the cache, so it must not be modified.
+func (fes *APIServer) writeDAOCoinLimitOrdersResponse(
+	ww http.ResponseWriter,
+	requestData *GetDAOCoinLimitOrdersRequest,
+	txnStatus TxnStatus,
+	utxoView *lib.UtxoView,
+	orders []DAOCoinLimitOrderEntryResponse,
+) {
+	var err error
+	if requestData.IncludeUsernames {
+		if utxoView == nil {
+			utxoView, err = fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetDAOCoinLimitOrders")
+			if err != nil {
+				_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Problem fetching utxoView: %v", err))
+				return
+			}
+		}
+		orders = fes.addUsernamesToDAOCoinLimitOrders(utxoView, orders)
+	}
+	// The heuristic is computed over the whole book, before any orders are filtered out.
+	if requestData.IncludeFillHeuristic {
+		orders, err = AddFillHeuristicToDAOCoinLimitOrders(
+			orders,
 			requestData.DAOCoin1CreatorPublicKeyBase58Check,
 			requestData.DAOCoin2CreatorPublicKeyBase58Check,
-			ordersBuyingCoin1,
-		),
-		fes.buildDAOCoinLimitOrderResponsesFromEntriesForCoinPair(
-			utxoView,
-			requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		)
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Problem computing fill heuristic: %v", err))
+			return
+		}
+	}
+	if requestData.MinQuantity != "" {
+		orders, err = FilterDAOCoinLimitOrdersByMinQuantity(orders, requestData.MinQuantity)
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Problem filtering orders: %v", err))
+			return
+		}
+	}
+	if requestData.SortByDistanceFromMid {
+		orders, err = SortDAOCoinLimitOrdersByDistanceFromMid(
+			orders,
 			requestData.DAOCoin1CreatorPublicKeyBase58Check,
-			ordersBuyingCoin2,
-		)...,
-	)
-
-	if err = json.NewEncoder(ww).Encode(GetDAOCoinLimitOrdersResponse{Orders: responses}); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Problem encoding response as JSON: %v", err))
-		return
+			requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		)
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Problem sorting orders: %v", err))
+			return
+		}
 	}
-}
 
-type GetDAOCoinLimitOrdersByIdRequest struct {
-	// A list of hex OrderIds that we will fetch
-	OrderIds []string `safeForLogging:"true"`
-	// If unset, defaults to TxnStatusInMempool. If set to "InMempool" we will
-	// consider all txns including those in the mempool. If set to "Committed" then
-	// we will only consider txns that have been committed according to consensus.
-	TxnStatus TxnStatus `safeForLogging:"true"`
-}
+	if requestData.OmitDeprecatedFloatFields {
+		orders = omitDeprecatedFloatFieldsFromDAOCoinLimitOrders(orders)
+	}
 
-func (fes *APIServer) GetDAOCoinLimitOrdersById(ww http.ResponseWriter, req *http.Request) {
-	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
-	requestData := GetDAOCoinLimitOrdersByIdRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
-		_AddBadRequestError(
-			ww,
-			fmt.Sprintf("GetDAOCoinLimitOrdersById: Problem parsing request body: %v", err),
+	res := GetDAOCoinLimitOrdersResponse{
+		Orders:       orders,
+		BookChecksum: ComputeDAOCoinOrderBookChecksum(orders),
+	}
+	if requestData.PageSize > 0 {
+		var paginationToken *DAOCoinLimitOrdersPaginationToken
+		if requestData.PaginationToken != "" {
+			// The token was already validated by GetDAOCoinLimitOrders.
+			paginationToken, _ = DecodeDAOCoinLimitOrdersPaginationToken(requestData.PaginationToken)
+		}
+		res.Orders, res.NextPaginationToken, res.ShouldRestart, err = PaginateDAOCoinLimitOrders(
+			orders,
+			requestData.DAOCoin1CreatorPublicKeyBase58Check,
+			requestData.DAOCoin2CreatorPublicKeyBase58Check,
+			requestData.PageSize,
+			paginationToken,
 		)
-		return
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Problem paginating orders: %v", err))
+			return
+		}
 	}
-
-	txnStatus := requestData.TxnStatus
-	if txnStatus == "" {
-		txnStatus = TxnStatusInMempool
+	if requestData.GroupBySide {
+		res.Bids, res.Asks, err = GroupDAOCoinLimitOrdersBySide(
+			orders,
+			requestData.DAOCoin1CreatorPublicKeyBase58Check,
+			requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		)
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Problem grouping orders: %v", err))
+			return
+		}
+		res.Orders = nil
 	}
-	if txnStatus != TxnStatusInMempool &&
-		txnStatus != TxnStatusCommitted {
 
-		_AddBadRequestError(
-			ww,
-			fmt.Sprintf("GetDAOCoinLimitOrdersById: Invalid TxnStatus: %v. Options "+
-				"are {InMempool, Committed}.", txnStatus),
-		)
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrders: Problem encoding response as JSON: %v", err))
 		return
 	}
+}
 
-	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus)
+// FilterDAOCoinLimitOrdersByMinQuantity returns the orders whose Quantity is at least minQuantity, a decimal string in
+// the same coin as each order's Quantity. Quantities are compared in base units, so a DESO quantity is compared in
+// nanos and a DAO coin quantity in DAO coin base units.
+func FilterDAOCoinLimitOrdersByMinQuantity(
+	orders []DAOCoinLimitOrderEntryResponse,
+	minQuantity string,
+) ([]DAOCoinLimitOrderEntryResponse, error) {
+	if err := validateNonNegativeDecimalString(minQuantity); err != nil {
+		return nil, err
+	}
+	minDESONanos, err := lib.ScaleFloatFormatStringToUint256(minQuantity, uint256.NewInt(lib.NanosPerUnit))
 	if err != nil {
-		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrdersById: Problem fetching utxoView: %v", err))
-		return
+		return nil, err
 	}
-
-	// Don't allow fetching more than this many orders
-	maxOrdersToFetch := 10000
-	if len(requestData.OrderIds) > maxOrdersToFetch {
-		_AddBadRequestError(
-			ww,
-			fmt.Sprintf("GetDAOCoinLimitOrdersById: Cannot fetch more than %v orders at once", maxOrdersToFetch),
-		)
-		return
+	minDAOCoinBaseUnits, err := lib.ScaleFloatFormatStringToUint256(minQuantity, lib.BaseUnitsPerCoin)
+	if err != nil {
+		return nil, err
 	}
-	ordersToReturn := make([]DAOCoinLimitOrderEntryResponse, 0, len(requestData.OrderIds))
-	for _, orderIdHex := range requestData.OrderIds {
-		orderIdBytes, err := hex.DecodeString(orderIdHex)
+
+	filteredOrders := []DAOCoinLimitOrderEntryResponse{}
+	for _, order := range orders {
+		scalingFactor := lib.BaseUnitsPerCoin
+		minBaseUnits := minDAOCoinBaseUnits
+		if isCoinToFillDESO(
+			order.BuyingDAOCoinCreatorPublicKeyBase58Check,
+			order.SellingDAOCoinCreatorPublicKeyBase58Check,
+			order.OperationType,
+		) {
+			scalingFactor = uint256.NewInt(lib.NanosPerUnit)
+			minBaseUnits = minDESONanos
+		}
+		quantityBaseUnits, err := lib.ScaleFloatFormatStringToUint256(order.Quantity, scalingFactor)
 		if err != nil {
-			_AddBadRequestError(
-				ww,
-				fmt.Sprintf("GetDAOCoinLimitOrdersById: Invalid OrderId: %v", err),
-			)
-			return
+			return nil, errors.Errorf("Order %v has invalid quantity %v: %v", order.OrderID, order.Quantity, err)
 		}
-		if len(orderIdBytes) != 32 {
-			_AddBadRequestError(
-				ww,
-				fmt.Sprintf("GetDAOCoinLimitOrdersById: Block hash has length (%d) but should "+
-					"be (%d)", len(orderIdBytes), 32),
-			)
-			return
+		if quantityBaseUnits.Lt(minBaseUnits) {
+			continue
 		}
-		orderId := lib.BlockHash{}
-		copy(orderId[:], orderIdBytes)
-		orderEntry, err := utxoView.GetDAOCoinLimitOrderEntry(&orderId)
+		filteredOrders = append(filteredOrders, order)
+	}
+	return filteredOrders, nil
+}
+
+// SortDAOCoinLimitOrdersByDistanceFromMid returns a copy of the coin1/coin2 book's orders sorted by how far their
+// price is from the mid-price, nearest first. Prices are compared as the amount of coin2 per coin1, and the mid is
+// the average of the best bid and best ask for coin1. If one side of the book is empty there is no mid, and orders
+// are instead sorted by price, lowest first.
+func SortDAOCoinLimitOrdersByDistanceFromMid(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) ([]DAOCoinLimitOrderEntryResponse, error) {
+	prices := make([]*big.Float, len(orders))
+	var bestBid, bestAsk *big.Float
+	for ii, order := range orders {
+		price, err := getCoin1PriceInCoin2ForDAOCoinLimitOrder(order, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
 		if err != nil {
-			_AddBadRequestError(
-				ww,
-				fmt.Sprintf("GetDAOCoinLimitOrdersById: Error fetching order: %v", err),
-			)
-			return
+			return nil, err
 		}
-		if orderEntry == nil {
-			continue
+		prices[ii] = price
+		if isSameCoin(order.BuyingDAOCoinCreatorPublicKeyBase58Check, coin1PublicKeyBase58Check) {
+			if bestBid == nil || price.Cmp(bestBid) > 0 {
+				bestBid = price
+			}
+		} else if bestAsk == nil || price.Cmp(bestAsk) < 0 {
+			bestAsk = price
 		}
-		if orderEntry.IsDeleted() {
-			continue
+	}
+
+	sortKeys := prices
+	if bestBid != nil && bestAsk != nil {
+		mid := new(big.Float).Add(bestBid, bestAsk)
+		mid.Quo(mid, big.NewFloat(2))
+		sortKeys = make([]*big.Float, len(prices))
+		for ii, price := range prices {
+			distance := new(big.Float).Sub(price, mid)
+			sortKeys[ii] = distance.Abs(distance)
 		}
-		transactorPublicKey := utxoView.GetPublicKeyForPKID(orderEntry.TransactorPKID)
-		buyingCoinPublicKey := utxoView.GetPublicKeyForPKID(orderEntry.BuyingDAOCoinCreatorPKID)
-		sellingCoinPublicKey := utxoView.GetPublicKeyForPKID(orderEntry.SellingDAOCoinCreatorPKID)
-		orderRes, err := buildDAOCoinLimitOrderResponse(
-			lib.Base58CheckEncode(transactorPublicKey, false, fes.Params),
-			lib.Base58CheckEncode(buyingCoinPublicKey, false, fes.Params),
-			lib.Base58CheckEncode(sellingCoinPublicKey, false, fes.Params),
-			orderEntry,
-		)
+	}
+
+	indexes := make([]int, len(orders))
+	for ii := range indexes {
+		indexes[ii] = ii
+	}
+	sort.SliceStable(indexes, func(ii, jj int) bool {
+		return sortKeys[indexes[ii]].Cmp(sortKeys[indexes[jj]]) < 0
+	})
+	sortedOrders := make([]DAOCoinLimitOrderEntryResponse, len(orders))
+	for ii, index := range indexes {
+		sortedOrders[ii] = orders[index]
+	}
+	return sortedOrders, nil
+}
+
+// GroupDAOCoinLimitOrdersBySide splits the coin1/coin2 book's orders into bids, which buy coin1, and asks, which sell
+// it. Prices are compared as the amount of coin2 per coin1, and each side is sorted best price first: highest for
+// bids and lowest for asks. Orders at the same price keep their relative order.
+func GroupDAOCoinLimitOrdersBySide(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) (_bids []DAOCoinLimitOrderEntryResponse, _asks []DAOCoinLimitOrderEntryResponse, _err error) {
+	type pricedOrder struct {
+		order DAOCoinLimitOrderEntryResponse
+		price *big.Rat
+	}
+	var bids, asks []pricedOrder
+	for _, order := range orders {
+		price, err := getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder(order, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
 		if err != nil {
-			_AddBadRequestError(
-				ww,
-				fmt.Sprintf("GetDAOCoinLimitOrdersById: Error building order response: %v", err),
-			)
-			return
+			return nil, nil, err
 		}
-		if orderRes == nil {
-			_AddInternalServerError(ww, "GetDAOCoinLimitOrdersById: Error building order "+
-				"response: nil order response")
-			return
+		if isSameCoin(order.BuyingDAOCoinCreatorPublicKeyBase58Check, coin1PublicKeyBase58Check) {
+			bids = append(bids, pricedOrder{order: order, price: price})
+		} else {
+			asks = append(asks, pricedOrder{order: order, price: price})
 		}
-		ordersToReturn = append(ordersToReturn, *orderRes)
 	}
-	if err = json.NewEncoder(ww).Encode(GetDAOCoinLimitOrdersResponse{
-		Orders: ordersToReturn,
-	}); err != nil {
-		_AddBadRequestError(
-			ww,
-			fmt.Sprintf("GetDAOCoinLimitOrders: Problem encoding response as JSON: %v", err),
-		)
-		return
+
+	toSortedOrders := func(pricedOrders []pricedOrder, bestPriceIsHighest bool) []DAOCoinLimitOrderEntryResponse {
+		sort.SliceStable(pricedOrders, func(ii, jj int) bool {
+			cmp := pricedOrders[ii].price.Cmp(pricedOrders[jj].price)
+			if bestPriceIsHighest {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+		sortedOrders := []DAOCoinLimitOrderEntryResponse{}
+		for _, pricedOrder := range pricedOrders {
+			sortedOrders = append(sortedOrders, pricedOrder.order)
+		}
+		return sortedOrders
 	}
+	return toSortedOrders(bids, true), toSortedOrders(asks, false), nil
 }
 
-type GetTransactorDAOCoinLimitOrdersRequest struct {
-	TransactorPublicKeyBase58Check  string `safeForLogging:"true"`
-	BuyingCoinPublicKeyBase58Check  string `safeForLogging:"true"`
-	SellingCoinPublicKeyBase58Check string `safeForLogging:"true"`
-	// Defaults to TxnStatusInMempool. If set to "InMempool" we will consider all
-	// txns including those in the mempool.
-	TxnStatus TxnStatus `safeForLogging:"true"`
+// getCoin1PriceInCoin2ForDAOCoinLimitOrder converts an order's price into the amount of coin2 per coin1, regardless of
+// which coin the order is buying and whether it's a BID or an ASK.
+func getCoin1PriceInCoin2ForDAOCoinLimitOrder(
+	order DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) (*big.Float, error) {
+	price, err := getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder(order, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+	if err != nil {
+		return nil, err
+	}
+	return big.NewFloat(0).SetPrec(256).SetRat(price), nil
 }
 
-func (fes *APIServer) GetTransactorDAOCoinLimitOrders(ww http.ResponseWriter, req *http.Request) {
-	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
-	requestData := GetTransactorDAOCoinLimitOrdersRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
-		_AddBadRequestError(
-			ww,
-			fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Problem parsing request body: %v", err),
-		)
-		return
+// getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder is getCoin1PriceInCoin2ForDAOCoinLimitOrder without any rounding, for
+// callers that need to compare prices against exact decimal boundaries.
+func getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder(
+	order DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) (*big.Rat, error) {
+	buyingCoin1 := isSameCoin(order.BuyingDAOCoinCreatorPublicKeyBase58Check, coin1PublicKeyBase58Check) &&
+		isSameCoin(order.SellingDAOCoinCreatorPublicKeyBase58Check, coin2PublicKeyBase58Check)
+	buyingCoin2 := isSameCoin(order.BuyingDAOCoinCreatorPublicKeyBase58Check, coin2PublicKeyBase58Check) &&
+		isSameCoin(order.SellingDAOCoinCreatorPublicKeyBase58Check, coin1PublicKeyBase58Check)
+	if !buyingCoin1 && !buyingCoin2 {
+		return nil, fmt.Errorf("Order %v is not for the coin pair", order.OrderID)
 	}
 
-	txnStatus := requestData.TxnStatus
-	if txnStatus == "" {
-		txnStatus = TxnStatusInMempool
+	price, ok := new(big.Rat).SetString(order.Price)
+	if !ok {
+		return nil, fmt.Errorf("Order %v has invalid price %v", order.OrderID, order.Price)
+	}
+	if price.Sign() <= 0 {
+		return nil, fmt.Errorf("Order %v has non-positive price %v", order.OrderID, order.Price)
 	}
-	if txnStatus != TxnStatusInMempool &&
-		txnStatus != TxnStatusCommitted {
 
-		_AddBadRequestError(
-			ww,
-			fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Invalid TxnStatus: %v. Options "+
-				"are {InMempool, Committed}.", txnStatus),
-		)
-		return
+	// A BID's price is the amount of the selling coin per buying coin. An ASK's price is the inverse.
+	sellingCoinPerBuyingCoin := price
+	if order.OperationType == DAOCoinLimitOrderOperationTypeStringASK {
+		sellingCoinPerBuyingCoin = new(big.Rat).Inv(price)
 	}
+	if buyingCoin1 {
+		return sellingCoinPerBuyingCoin, nil
+	}
+	return new(big.Rat).Inv(sellingCoinPerBuyingCoin), nil
+}
 
-	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus)
+// isSameCoin returns true if both strings identify the same coin, treating all of the $DESO identifiers as equal.
+func isSameCoin(publicKeyBase58Check1 string, publicKeyBase58Check2 string) bool {
+	if IsDesoPkid(publicKeyBase58Check1) || IsDesoPkid(publicKeyBase58Check2) {
+		return IsDesoPkid(publicKeyBase58Check1) && IsDesoPkid(publicKeyBase58Check2)
+	}
+	return publicKeyBase58Check1 == publicKeyBase58Check2
+}
+
+type GetDAOCoinLimitOrderLadderRequest struct {
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// A positive decimal string (ex: 0.01) that sets the spacing of the price grid, in coin2 per coin1.
+	TickSize string `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type DAOCoinLimitOrderLadderLevel struct {
+	// A decimal string multiple of the tick size. The amount of coin2 per coin1.
+	Price string `safeForLogging:"true"`
+	// A decimal string. The total amount of coin1 across the orders in this tick.
+	Quantity string `safeForLogging:"true"`
+
+	NumOrders int `safeForLogging:"true"`
+}
+
+type GetDAOCoinLimitOrderLadderResponse struct {
+	// Orders buying coin1, best (highest) price first.
+	Bids []DAOCoinLimitOrderLadderLevel
+	// Orders selling coin1, best (lowest) price first.
+	Asks []DAOCoinLimitOrderLadderLevel
+}
+
+// GetDAOCoinLimitOrderLadder returns the coin1/coin2 order book aggregated onto a fixed price grid, for clients that
+// render a price ladder rather than individual orders.
+func (fes *APIServer) GetDAOCoinLimitOrderLadder(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDAOCoinLimitOrderLadderRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrderLadder: Problem parsing request body: %v", err))
+		return
+	}
+
+	if IsDesoPkid(requestData.DAOCoin1CreatorPublicKeyBase58Check) &&
+		IsDesoPkid(requestData.DAOCoin2CreatorPublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetDAOCoinLimitOrderLadder: Must provide either a "+
+			"DAOCoin1CreatorPublicKeyBase58Check or DAOCoin2CreatorPublicKeyBase58Check or both")
+		return
+	}
+
+	tickSize, err := parsePositiveDecimalString(requestData.TickSize)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrderLadder: Invalid TickSize: %v", err))
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrderLadder: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	// Equivalent tick sizes like 0.10 and 0.1 share a cache entry. The ladder's prices are formatted with the
+	// requested tick size's decimals, so the raw string is kept as well.
+	res, err := fes.getDAOCoinOrderBookAggregation(
+		requestData.DAOCoin1CreatorPublicKeyBase58Check,
+		requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		txnStatus,
+		DAOCoinOrderBookAggregationLadder,
+		tickSize.RatString()+":"+requestData.TickSize,
+		func(orders []DAOCoinLimitOrderEntryResponse) (interface{}, error) {
+			bids, asks, err := BuildDAOCoinLimitOrderLadder(
+				orders,
+				requestData.DAOCoin1CreatorPublicKeyBase58Check,
+				requestData.DAOCoin2CreatorPublicKeyBase58Check,
+				requestData.TickSize,
+			)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Problem building ladder")
+			}
+			return GetDAOCoinLimitOrderLadderResponse{Bids: bids, Asks: asks}, nil
+		},
+	)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLimitOrderLadder: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrderLadder: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// parsePositiveDecimalString parses str as an exact decimal and checks that it's positive.
+func parsePositiveDecimalString(str string) (*big.Rat, error) {
+	if err := validateNonNegativeDecimalString(str); err != nil {
+		return nil, err
+	}
+	rat, ok := new(big.Rat).SetString(str)
+	if !ok {
+		return nil, errors.Errorf("Error parsing %v as a decimal string", str)
+	}
+	if rat.Sign() <= 0 {
+		return nil, errors.Errorf("%v must be greater than 0", str)
+	}
+	return rat, nil
+}
+
+// BuildDAOCoinLimitOrderLadder aggregates the coin1/coin2 book's orders into price ticks that are multiples of
+// tickSize, with prices in coin2 per coin1 and quantities in coin1. Each order goes to the nearest tick at or worse
+// than its price, i.e. bids round down and asks round up, so a tick never advertises a better price than the orders
+// in it.
+func BuildDAOCoinLimitOrderLadder(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	tickSize string,
+) (_bids []DAOCoinLimitOrderLadderLevel, _asks []DAOCoinLimitOrderLadderLevel, _err error) {
+	tickSizeRat, err := parsePositiveDecimalString(tickSize)
+	if err != nil {
+		return nil, nil, err
+	}
+	coin1ScalingFactor := lib.BaseUnitsPerCoin.ToBig()
+	if IsDesoPkid(coin1PublicKeyBase58Check) {
+		coin1ScalingFactor = big.NewInt(int64(lib.NanosPerUnit))
+	}
+
+	type ladderTick struct {
+		index             *big.Int
+		quantityBaseUnits *big.Int
+		numOrders         int
+	}
+	bidTicks := make(map[string]*ladderTick)
+	askTicks := make(map[string]*ladderTick)
+	for _, order := range orders {
+		price, err := getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder(order, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+		if err != nil {
+			return nil, nil, err
+		}
+		quantity, ok := new(big.Rat).SetString(order.Quantity)
+		if !ok {
+			return nil, nil, errors.Errorf("Order %v has invalid quantity %v", order.OrderID, order.Quantity)
+		}
+
+		// A BID's quantity is in the coin it's buying and an ASK's is in the coin it's selling. Convert quantities in
+		// coin2 to coin1 at the order's price.
+		isBid := isSameCoin(order.BuyingDAOCoinCreatorPublicKeyBase58Check, coin1PublicKeyBase58Check)
+		quantityIsCoin1 := isBid == (order.OperationType == DAOCoinLimitOrderOperationTypeStringBID)
+		if !quantityIsCoin1 {
+			quantity.Quo(quantity, price)
+		}
+		quantity.Mul(quantity, new(big.Rat).SetInt(coin1ScalingFactor))
+		quantityBaseUnits := new(big.Int).Quo(quantity.Num(), quantity.Denom())
+
+		// Rounding down suits bids and rounding up suits asks. Prices are positive, so Div is a floor.
+		ticks := new(big.Rat).Quo(price, tickSizeRat)
+		tickIndex := new(big.Int).Div(ticks.Num(), ticks.Denom())
+		ladderTicks := bidTicks
+		if !isBid {
+			ladderTicks = askTicks
+			if !ticks.IsInt() {
+				tickIndex.Add(tickIndex, big.NewInt(1))
+			}
+		}
+
+		tick, exists := ladderTicks[tickIndex.String()]
+		if !exists {
+			tick = &ladderTick{index: tickIndex, quantityBaseUnits: big.NewInt(0)}
+			ladderTicks[tickIndex.String()] = tick
+		}
+		tick.quantityBaseUnits.Add(tick.quantityBaseUnits, quantityBaseUnits)
+		tick.numOrders++
+	}
+
+	tickSizeDecimals := 0
+	for scaledTickSize := new(big.Rat).Set(tickSizeRat); !scaledTickSize.IsInt(); tickSizeDecimals++ {
+		scaledTickSize.Mul(scaledTickSize, big.NewRat(10, 1))
+	}
+	toLevels := func(ladderTicks map[string]*ladderTick, bestPriceIsHighest bool) []DAOCoinLimitOrderLadderLevel {
+		sortedTicks := make([]*ladderTick, 0, len(ladderTicks))
+		for _, tick := range ladderTicks {
+			sortedTicks = append(sortedTicks, tick)
+		}
+		sort.Slice(sortedTicks, func(ii, jj int) bool {
+			return (sortedTicks[ii].index.Cmp(sortedTicks[jj].index) > 0) == bestPriceIsHighest
+		})
+		levels := []DAOCoinLimitOrderLadderLevel{}
+		for _, tick := range sortedTicks {
+			tickPrice := new(big.Rat).Mul(new(big.Rat).SetInt(tick.index), tickSizeRat)
+			levels = append(levels, DAOCoinLimitOrderLadderLevel{
+				Price:     tickPrice.FloatString(tickSizeDecimals),
+				Quantity:  lib.FormatScaledUint256AsDecimalString(tick.quantityBaseUnits, coin1ScalingFactor),
+				NumOrders: tick.numOrders,
+			})
+		}
+		return levels
+	}
+	return toLevels(bidTicks, true), toLevels(askTicks, false), nil
+}
+
+type GetOrderBookPlacementRequest struct {
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// The side of the proposed order relative to coin1. A BID buys coin1 and an ASK sells it.
+	OperationType DAOCoinLimitOrderOperationTypeString `safeForLogging:"true"`
+	// A positive decimal string. The proposed order's price in coin2 per coin1.
+	Price string `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetOrderBookPlacementResponse struct {
+	// True if the proposed order crosses the spread, i.e. it would match resting orders on the other side as soon as
+	// it's placed. Rank, OrdersAhead, and IsTopOfBook are only set when this is false.
+	IsMarketable bool `safeForLogging:"true"`
+
+	// The proposed order's 1-based position among orders on the same side, best price first. Orders already resting at
+	// the same price are ahead of it.
+	Rank int `safeForLogging:"true"`
+	// The number of same-side orders that would fill before the proposed order. Always Rank - 1.
+	OrdersAhead int `safeForLogging:"true"`
+	// True if no same-side order has a strictly better price.
+	IsTopOfBook bool `safeForLogging:"true"`
+
+	// Decimal strings in coin2 per coin1. Empty if that side of the book is empty.
+	BestBidPrice string `safeForLogging:"true"`
+	BestAskPrice string `safeForLogging:"true"`
+}
+
+// GetOrderBookPlacement returns where a proposed order would sit in the coin1/coin2 book without submitting it.
+func (fes *APIServer) GetOrderBookPlacement(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetOrderBookPlacementRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetOrderBookPlacement: Problem parsing request body: %v", err))
+		return
+	}
+
+	if IsDesoPkid(requestData.DAOCoin1CreatorPublicKeyBase58Check) &&
+		IsDesoPkid(requestData.DAOCoin2CreatorPublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetOrderBookPlacement: Must provide either a "+
+			"DAOCoin1CreatorPublicKeyBase58Check or DAOCoin2CreatorPublicKeyBase58Check or both")
+		return
+	}
+
+	if _, err := parsePositiveDecimalString(requestData.Price); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetOrderBookPlacement: Invalid Price: %v", err))
+		return
+	}
+	if requestData.OperationType != DAOCoinLimitOrderOperationTypeStringBID &&
+		requestData.OperationType != DAOCoinLimitOrderOperationTypeStringASK {
+		_AddBadRequestError(ww, fmt.Sprintf("GetOrderBookPlacement: Invalid OperationType: %v. Options "+
+			"are {%v, %v}.", requestData.OperationType,
+			DAOCoinLimitOrderOperationTypeStringBID, DAOCoinLimitOrderOperationTypeStringASK))
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetOrderBookPlacement: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetOrderBookPlacement")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetOrderBookPlacement: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	orders, err := fes.getDAOCoinLimitOrdersForCoinPair(
+		utxoView,
+		requestData.DAOCoin1CreatorPublicKeyBase58Check,
+		requestData.DAOCoin2CreatorPublicKeyBase58Check,
+	)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetOrderBookPlacement: Error getting limit orders: %v", err))
+		return
+	}
+
+	res, err := ComputeDAOCoinLimitOrderPlacement(
+		orders,
+		requestData.DAOCoin1CreatorPublicKeyBase58Check,
+		requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		requestData.OperationType,
+		requestData.Price,
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetOrderBookPlacement: Problem computing placement: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetOrderBookPlacement: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// ComputeDAOCoinLimitOrderPlacement ranks a proposed order with the given side and price, in coin2 per coin1, against
+// the resting coin1/coin2 orders. Prices are compared exactly, so an order at the same price as a resting order is
+// ranked behind it, matching the book's time priority.
+func ComputeDAOCoinLimitOrderPlacement(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	operationType DAOCoinLimitOrderOperationTypeString,
+	price string,
+) (*GetOrderBookPlacementResponse, error) {
+	proposedPrice, err := parsePositiveDecimalString(price)
+	if err != nil {
+		return nil, err
+	}
+	proposedIsBid := operationType == DAOCoinLimitOrderOperationTypeStringBID
+	if !proposedIsBid && operationType != DAOCoinLimitOrderOperationTypeStringASK {
+		return nil, errors.Errorf("Invalid operation type %v", operationType)
+	}
+
+	var bestBid, bestAsk *big.Rat
+	sameSideAtOrBetter := 0
+	sameSideStrictlyBetter := 0
+	for _, order := range orders {
+		orderPrice, err := getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder(
+			order, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+		if err != nil {
+			return nil, err
+		}
+		orderIsBid := isSameCoin(order.BuyingDAOCoinCreatorPublicKeyBase58Check, coin1PublicKeyBase58Check)
+		if orderIsBid {
+			if bestBid == nil || orderPrice.Cmp(bestBid) > 0 {
+				bestBid = orderPrice
+			}
+		} else if bestAsk == nil || orderPrice.Cmp(bestAsk) < 0 {
+			bestAsk = orderPrice
+		}
+		if orderIsBid != proposedIsBid {
+			continue
+		}
+
+		// A higher price is better for a bid and a lower price is better for an ask.
+		cmp := orderPrice.Cmp(proposedPrice)
+		if !proposedIsBid {
+			cmp = -cmp
+		}
+		if cmp >= 0 {
+			sameSideAtOrBetter++
+		}
+		if cmp > 0 {
+			sameSideStrictlyBetter++
+		}
+	}
+
+	res := &GetOrderBookPlacementResponse{}
+	if bestBid != nil {
+		res.BestBidPrice = formatDAOCoinLimitOrderPriceRat(bestBid)
+	}
+	if bestAsk != nil {
+		res.BestAskPrice = formatDAOCoinLimitOrderPriceRat(bestAsk)
+	}
+
+	// An order that crosses the spread doesn't rest in the book, at least not until it has matched the orders it
+	// crosses, so it has no rank.
+	if proposedIsBid {
+		res.IsMarketable = bestAsk != nil && proposedPrice.Cmp(bestAsk) >= 0
+	} else {
+		res.IsMarketable = bestBid != nil && proposedPrice.Cmp(bestBid) <= 0
+	}
+	if res.IsMarketable {
+		return res, nil
+	}
+
+	res.OrdersAhead = sameSideAtOrBetter
+	res.Rank = sameSideAtOrBetter + 1
+	res.IsTopOfBook = sameSideStrictlyBetter == 0
+	return res, nil
+}
+
+type GetDESOCostToBuyDAOCoinRequest struct {
+	DAOCoinCreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	// A positive decimal string. The quantity of the DAO coin to buy.
+	QuantityToBuy string `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetDESOCostToBuyDAOCoinResponse struct {
+	// Whether the asks on the book can fill all of QuantityToBuy.
+	HasSufficientLiquidity bool `safeForLogging:"true"`
+
+	// A decimal string. The quantity of the DAO coin the asks can fill, which is QuantityToBuy unless
+	// HasSufficientLiquidity is false.
+	QuantityBuyable string `safeForLogging:"true"`
+	// A decimal string. The $DESO it costs to buy QuantityBuyable, taking the cheapest asks first.
+	TotalDESOCost string `safeForLogging:"true"`
+	// A decimal string. The volume-weighted average price paid, in $DESO per DAO coin. Empty if nothing is buyable.
+	AveragePrice string `safeForLogging:"true"`
+}
+
+// GetDESOCostToBuyDAOCoin estimates how much $DESO a market order would spend to buy a quantity of a DAO coin right
+// now, by walking the DAO coin's asks from the cheapest up. Trading fees aren't included.
+func (fes *APIServer) GetDESOCostToBuyDAOCoin(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDESOCostToBuyDAOCoinRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDESOCostToBuyDAOCoin: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier(
+		"DAOCoinCreatorPublicKeyBase58Check", requestData.DAOCoinCreatorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDESOCostToBuyDAOCoin: %v", err))
+		return
+	}
+	quantityToBuyBaseUnits, err := CalculateBaseUnitsFromStringDecimalAmountSimple(
+		requestData.DAOCoinCreatorPublicKeyBase58Check, requestData.QuantityToBuy)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDESOCostToBuyDAOCoin: Invalid QuantityToBuy: %v", err))
+		return
+	}
+	if quantityToBuyBaseUnits.IsZero() {
+		_AddBadRequestError(ww, "GetDESOCostToBuyDAOCoin: QuantityToBuy must be greater than 0")
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDESOCostToBuyDAOCoin: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetDESOCostToBuyDAOCoin")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDESOCostToBuyDAOCoin: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	orders, err := fes.getDAOCoinLimitOrdersForCoinPair(
+		utxoView, requestData.DAOCoinCreatorPublicKeyBase58Check, DESOCoinIdentifierString)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDESOCostToBuyDAOCoin: Error getting limit orders: %v", err))
+		return
+	}
+
+	res, err := ComputeDESOCostToBuyDAOCoin(orders, requestData.DAOCoinCreatorPublicKeyBase58Check, quantityToBuyBaseUnits)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDESOCostToBuyDAOCoin: Problem computing cost: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDESOCostToBuyDAOCoin: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// ComputeDESOCostToBuyDAOCoin walks the asks among the DAO coin/$DESO orders from the lowest price up until
+// quantityToBuyBaseUnits of the DAO coin is filled or the asks run out. The total cost is rounded up to the next
+// nano, since a buyer can't pay a fraction of one.
+func ComputeDESOCostToBuyDAOCoin(
+	orders []DAOCoinLimitOrderEntryResponse,
+	daoCoinPublicKeyBase58Check string,
+	quantityToBuyBaseUnits *uint256.Int,
+) (*GetDESOCostToBuyDAOCoinResponse, error) {
+	asks, err := getDAOCoinLimitOrderBookLevelsForTaker(orders, daoCoinPublicKeyBase58Check, DESOCoinIdentifierString, true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cost accumulates in $DESO per DAO coin times DAO coin base units, and is converted to nanos at the end.
+	bought, cost, _ := walkDAOCoinLimitOrderBookLevels(asks, new(big.Rat).SetInt(quantityToBuyBaseUnits.ToBig()))
+
+	// bought never exceeds quantityToBuyBaseUnits, so it can't overflow.
+	boughtBaseUnits, _ := uint256.FromBig(new(big.Int).Quo(bought.Num(), bought.Denom()))
+	costNanosRat := new(big.Rat).Mul(cost, new(big.Rat).SetFrac(
+		big.NewInt(int64(lib.NanosPerUnit)), lib.BaseUnitsPerCoin.ToBig()))
+	costNanosBig := new(big.Int).Quo(costNanosRat.Num(), costNanosRat.Denom())
+	if !costNanosRat.IsInt() {
+		costNanosBig.Add(costNanosBig, big.NewInt(1))
+	}
+	costNanos, overflow := uint256.FromBig(costNanosBig)
+	if overflow {
+		return nil, errors.Errorf("Cost of %v nanos overflows uint256", costNanosBig)
+	}
+
+	quantityBuyable, err := CalculateStringDecimalAmountFromBaseUnitsSimple(daoCoinPublicKeyBase58Check, boughtBaseUnits)
+	if err != nil {
+		return nil, err
+	}
+	totalDESOCost, err := CalculateStringDecimalAmountFromBaseUnitsSimple(DESOCoinIdentifierString, costNanos)
+	if err != nil {
+		return nil, err
+	}
+	res := &GetDESOCostToBuyDAOCoinResponse{
+		HasSufficientLiquidity: boughtBaseUnits.Eq(quantityToBuyBaseUnits),
+		QuantityBuyable:        quantityBuyable,
+		TotalDESOCost:          totalDESOCost,
+	}
+	if bought.Sign() > 0 {
+		res.AveragePrice = formatDAOCoinLimitOrderPriceRat(new(big.Rat).Quo(cost, bought))
+	}
+	return res, nil
+}
+
+// daoCoinLimitOrderBookLevel is a resting order on the coin1/coin2 book as seen by a taker trading coin1: its price
+// in coin2 per coin1 and the quantity of coin1 it can fill, in coin1 base units.
+type daoCoinLimitOrderBookLevel struct {
+	price             *big.Rat
+	quantityBaseUnits *big.Rat
+	// The order the level was built from.
+	order *DAOCoinLimitOrderEntryResponse
+}
+
+// getDAOCoinLimitOrderBookLevelsForTaker returns the coin1/coin2 orders a taker buying coin1, or selling it if
+// takerIsBuyingCoin1 is false, would fill against, in the order they'd fill: lowest price first for a buyer and
+// highest first for a seller.
+func getDAOCoinLimitOrderBookLevelsForTaker(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	takerIsBuyingCoin1 bool,
+) ([]daoCoinLimitOrderBookLevel, error) {
+	coin1ScalingFactor := getCoinScalingFactor(coin1PublicKeyBase58Check)
+
+	var levels []daoCoinLimitOrderBookLevel
+	for ii := range orders {
+		order := orders[ii]
+		// A buyer of coin1 can only fill orders selling it, and a seller only orders buying it.
+		orderIsBuyingCoin1 := isSameCoin(order.BuyingDAOCoinCreatorPublicKeyBase58Check, coin1PublicKeyBase58Check)
+		if orderIsBuyingCoin1 == takerIsBuyingCoin1 {
+			continue
+		}
+		price, err := getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder(order, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+		if err != nil {
+			return nil, err
+		}
+		quantity, ok := new(big.Rat).SetString(order.Quantity)
+		if !ok {
+			return nil, errors.Errorf("Order %v has invalid quantity %v", order.OrderID, order.Quantity)
+		}
+		// A BID's quantity is in the coin it's buying and an ASK's is in the coin it's selling. Convert quantities in
+		// coin2 to coin1 at the order's price.
+		quantityIsCoin1 := orderIsBuyingCoin1 == (order.OperationType == DAOCoinLimitOrderOperationTypeStringBID)
+		if !quantityIsCoin1 {
+			quantity.Quo(quantity, price)
+		}
+		quantity.Mul(quantity, new(big.Rat).SetInt(coin1ScalingFactor))
+		levels = append(levels, daoCoinLimitOrderBookLevel{price: price, quantityBaseUnits: quantity, order: &orders[ii]})
+	}
+	sort.SliceStable(levels, func(ii, jj int) bool {
+		return (levels[ii].price.Cmp(levels[jj].price) < 0) == takerIsBuyingCoin1
+	})
+	return levels, nil
+}
+
+// walkDAOCoinLimitOrderBookLevels fills up to quantityBaseUnits of coin1 against levels in order. It returns the
+// quantity filled in coin1 base units, the total of price times quantity filled at each level, and the price of the
+// last level it filled against, which is nil if nothing was filled.
+func walkDAOCoinLimitOrderBookLevels(
+	levels []daoCoinLimitOrderBookLevel,
+	quantityBaseUnits *big.Rat,
+) (_filledBaseUnits *big.Rat, _cost *big.Rat, _lastPrice *big.Rat) {
+	remaining := new(big.Rat).Set(quantityBaseUnits)
+	filled := new(big.Rat)
+	cost := new(big.Rat)
+	var lastPrice *big.Rat
+	for _, level := range levels {
+		if remaining.Sign() == 0 {
+			break
+		}
+		fill := level.quantityBaseUnits
+		if fill.Cmp(remaining) > 0 {
+			fill = remaining
+		}
+		cost.Add(cost, new(big.Rat).Mul(fill, level.price))
+		filled.Add(filled, fill)
+		remaining = new(big.Rat).Sub(remaining, fill)
+		lastPrice = level.price
+	}
+	return filled, cost, lastPrice
+}
+
+type GetDAOCoinPriceImpactRequest struct {
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// BID for a market order buying coin1 with coin2, or ASK for one selling coin1 for coin2.
+	OperationType DAOCoinLimitOrderOperationTypeString `safeForLogging:"true"`
+	// A positive decimal string. The quantity of coin1 to buy or sell.
+	Quantity string `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetDAOCoinPriceImpactResponse struct {
+	// Whether the book can fill all of Quantity. If not, the rest of the response describes exhausting the book.
+	HasSufficientLiquidity bool `safeForLogging:"true"`
+	// A decimal string. The quantity of coin1 the book can fill, which is Quantity unless HasSufficientLiquidity is
+	// false.
+	QuantityFillable string `safeForLogging:"true"`
+
+	// Decimal strings, all in coin2 per coin1. StartingPrice is the best price on the side of the book the order fills
+	// against, EndingPrice is the price of the last order it touches, and AveragePrice is the volume-weighted average
+	// price it pays or receives. All are empty if nothing is fillable.
+	StartingPrice string `safeForLogging:"true"`
+	EndingPrice   string `safeForLogging:"true"`
+	AveragePrice  string `safeForLogging:"true"`
+
+	// A decimal string. How far the order moves the price, as a percentage of StartingPrice. It's never negative: a
+	// buy moves the price up and a sell moves it down. Empty if nothing is fillable.
+	PriceImpactPercentage string `safeForLogging:"true"`
+}
+
+// GetDAOCoinPriceImpact estimates how far a market order for a quantity of coin1 would move the coin1/coin2 price
+// right now, by walking the side of the book it would fill against. Trading fees aren't included.
+func (fes *APIServer) GetDAOCoinPriceImpact(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDAOCoinPriceImpactRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinPriceImpact: Problem parsing request body: %v", err))
+		return
+	}
+
+	if IsDesoPkid(requestData.DAOCoin1CreatorPublicKeyBase58Check) &&
+		IsDesoPkid(requestData.DAOCoin2CreatorPublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetDAOCoinPriceImpact: Must provide either a "+
+			"DAOCoin1CreatorPublicKeyBase58Check or DAOCoin2CreatorPublicKeyBase58Check or both")
+		return
+	}
+
+	if requestData.OperationType != DAOCoinLimitOrderOperationTypeStringBID &&
+		requestData.OperationType != DAOCoinLimitOrderOperationTypeStringASK {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinPriceImpact: Invalid OperationType %v. Options are {%v, %v}",
+			requestData.OperationType, DAOCoinLimitOrderOperationTypeStringBID, DAOCoinLimitOrderOperationTypeStringASK))
+		return
+	}
+
+	quantityBaseUnits, err := CalculateBaseUnitsFromStringDecimalAmountSimple(
+		requestData.DAOCoin1CreatorPublicKeyBase58Check, requestData.Quantity)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinPriceImpact: Invalid Quantity: %v", err))
+		return
+	}
+	if quantityBaseUnits.IsZero() {
+		_AddBadRequestError(ww, "GetDAOCoinPriceImpact: Quantity must be greater than 0")
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinPriceImpact: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	takerIsBuyingCoin1 := requestData.OperationType == DAOCoinLimitOrderOperationTypeStringBID
+	res, err := fes.getDAOCoinOrderBookAggregation(
+		requestData.DAOCoin1CreatorPublicKeyBase58Check,
+		requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		txnStatus,
+		DAOCoinOrderBookAggregationPriceImpact,
+		fmt.Sprintf("%v:%v", takerIsBuyingCoin1, quantityBaseUnits.ToBig()),
+		func(orders []DAOCoinLimitOrderEntryResponse) (interface{}, error) {
+			res, err := ComputeDAOCoinPriceImpact(
+				orders,
+				requestData.DAOCoin1CreatorPublicKeyBase58Check,
+				requestData.DAOCoin2CreatorPublicKeyBase58Check,
+				takerIsBuyingCoin1,
+				quantityBaseUnits,
+			)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Problem computing price impact")
+			}
+			return res, nil
+		},
+	)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinPriceImpact: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinPriceImpact: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// ComputeDAOCoinPriceImpact walks the coin1/coin2 orders a market order buying quantityBaseUnits of coin1, or selling
+// them if takerIsBuyingCoin1 is false, would fill against, and compares the price of the last order it touches to the
+// best price on the book.
+func ComputeDAOCoinPriceImpact(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	takerIsBuyingCoin1 bool,
+	quantityBaseUnits *uint256.Int,
+) (*GetDAOCoinPriceImpactResponse, error) {
+	levels, err := getDAOCoinLimitOrderBookLevelsForTaker(
+		orders, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, takerIsBuyingCoin1)
+	if err != nil {
+		return nil, err
+	}
+	filled, cost, endingPrice := walkDAOCoinLimitOrderBookLevels(levels, new(big.Rat).SetInt(quantityBaseUnits.ToBig()))
+
+	// filled never exceeds quantityBaseUnits, so it can't overflow.
+	filledBaseUnits, _ := uint256.FromBig(new(big.Int).Quo(filled.Num(), filled.Denom()))
+	quantityFillable, err := CalculateStringDecimalAmountFromBaseUnitsSimple(coin1PublicKeyBase58Check, filledBaseUnits)
+	if err != nil {
+		return nil, err
+	}
+	res := &GetDAOCoinPriceImpactResponse{
+		HasSufficientLiquidity: filledBaseUnits.Eq(quantityBaseUnits),
+		QuantityFillable:       quantityFillable,
+	}
+	if endingPrice == nil {
+		return res, nil
+	}
+
+	startingPrice := levels[0].price
+	priceImpact := new(big.Rat).Sub(endingPrice, startingPrice)
+	priceImpact.Abs(priceImpact)
+	priceImpact.Quo(priceImpact, startingPrice)
+	priceImpact.Mul(priceImpact, big.NewRat(100, 1))
+
+	res.StartingPrice = formatDAOCoinLimitOrderPriceRat(startingPrice)
+	res.EndingPrice = formatDAOCoinLimitOrderPriceRat(endingPrice)
+	res.AveragePrice = formatDAOCoinLimitOrderPriceRat(new(big.Rat).Quo(cost, filled))
+	res.PriceImpactPercentage = formatDAOCoinLimitOrderPriceRat(priceImpact)
+	return res, nil
+}
+
+// EstimatedDAOCoinLimitOrderTxnSizeBytes is a typical size of a signed DAO coin limit order transaction, used to
+// estimate the network fee it pays.
+const EstimatedDAOCoinLimitOrderTxnSizeBytes = 400
+
+type GetDAOCoinRoundTripBreakEvenRequest struct {
+	// The DAO coin to buy and then sell for $DESO. The pair is always quoted in $DESO since that's what network fees
+	// are paid in.
+	DAOCoinCreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	// A positive decimal string. The quantity of the DAO coin bought and sold.
+	Quantity string `safeForLogging:"true"`
+
+	// Optional. The fee rate each leg pays. If unset, the mempool's MediumFeeRateNanosPerKB from GetFeeRateEstimates
+	// is used.
+	FeeRateNanosPerKB uint64 `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetDAOCoinRoundTripBreakEvenResponse struct {
+	// Whether the asks on the book can fill all of Quantity. If not, only the fees are set.
+	HasSufficientLiquidity bool `safeForLogging:"true"`
+
+	// The fee rate the estimate assumes, and the estimated network fee of each leg and of both together, in nanos.
+	FeeRateNanosPerKB uint64 `safeForLogging:"true"`
+	FeeNanosPerLeg    uint64 `safeForLogging:"true"`
+	TotalFeeNanos     uint64 `safeForLogging:"true"`
+
+	// Decimal strings in $DESO per DAO coin. EntryPrice is the volume-weighted average price the buy leg pays, taking
+	// the cheapest asks first, and BreakEvenPrice is what the sell leg has to average to cover both legs' fees.
+	EntryPrice     string `safeForLogging:"true"`
+	BreakEvenPrice string `safeForLogging:"true"`
+	// A decimal string. How far the price has to move up from EntryPrice to break even, in basis points.
+	BreakEvenBasisPoints string `safeForLogging:"true"`
+}
+
+// GetDAOCoinRoundTripBreakEven estimates how far the price of a DAO coin has to rise for buying a quantity of it and
+// selling it back to cover the network fees of the two transactions. The buy is priced by walking the current asks.
+// Trading fees and the spread between the bids and asks aren't included.
+func (fes *APIServer) GetDAOCoinRoundTripBreakEven(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDAOCoinRoundTripBreakEvenRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinRoundTripBreakEven: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier(
+		"DAOCoinCreatorPublicKeyBase58Check", requestData.DAOCoinCreatorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinRoundTripBreakEven: %v", err))
+		return
+	}
+	quantityBaseUnits, err := CalculateBaseUnitsFromStringDecimalAmountSimple(
+		requestData.DAOCoinCreatorPublicKeyBase58Check, requestData.Quantity)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinRoundTripBreakEven: Invalid Quantity: %v", err))
+		return
+	}
+	if quantityBaseUnits.IsZero() {
+		_AddBadRequestError(ww, "GetDAOCoinRoundTripBreakEven: Quantity must be greater than 0")
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinRoundTripBreakEven: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetDAOCoinRoundTripBreakEven")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinRoundTripBreakEven: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	feeRateNanosPerKB := requestData.FeeRateNanosPerKB
+	if feeRateNanosPerKB == 0 {
+		feeRateNanosPerKB = fes.getFeeRateEstimates(
+			utxoView.GetCurrentGlobalParamsEntry(), fes.backendServer.GetMempool().GetOrderedTransactions(),
+		).MediumFeeRateNanosPerKB
+	}
+
+	orders, err := fes.getDAOCoinLimitOrdersForCoinPair(
+		utxoView, requestData.DAOCoinCreatorPublicKeyBase58Check, DESOCoinIdentifierString)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinRoundTripBreakEven: Error getting limit orders: %v", err))
+		return
+	}
+
+	res, err := ComputeDAOCoinRoundTripBreakEven(
+		orders, requestData.DAOCoinCreatorPublicKeyBase58Check, quantityBaseUnits, feeRateNanosPerKB)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinRoundTripBreakEven: Problem computing break-even: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinRoundTripBreakEven: Problem encoding response as JSON: %v",
+			err))
+		return
+	}
+}
+
+// ComputeDAOCoinRoundTripBreakEven prices buying quantityBaseUnits of the DAO coin against the DAO coin/$DESO asks, and
+// returns the price the coin has to be sold back at to cover a network fee of feeRateNanosPerKB on each of the two
+// legs. Each leg's fee is estimated from EstimatedDAOCoinLimitOrderTxnSizeBytes and rounded up to the next nano.
+func ComputeDAOCoinRoundTripBreakEven(
+	orders []DAOCoinLimitOrderEntryResponse,
+	daoCoinPublicKeyBase58Check string,
+	quantityBaseUnits *uint256.Int,
+	feeRateNanosPerKB uint64,
+) (*GetDAOCoinRoundTripBreakEvenResponse, error) {
+	feeNanosPerLegRat := big.NewRat(int64(EstimatedDAOCoinLimitOrderTxnSizeBytes), 1000)
+	feeNanosPerLegRat.Mul(feeNanosPerLegRat, new(big.Rat).SetInt(new(big.Int).SetUint64(feeRateNanosPerKB)))
+	feeNanosPerLeg := new(big.Int).Quo(feeNanosPerLegRat.Num(), feeNanosPerLegRat.Denom())
+	if !feeNanosPerLegRat.IsInt() {
+		feeNanosPerLeg.Add(feeNanosPerLeg, big.NewInt(1))
+	}
+	totalFeeNanos := new(big.Int).Mul(feeNanosPerLeg, big.NewInt(2))
+	if !totalFeeNanos.IsUint64() {
+		return nil, errors.Errorf("Fee of %v nanos overflows uint64", totalFeeNanos)
+	}
+	res := &GetDAOCoinRoundTripBreakEvenResponse{
+		FeeRateNanosPerKB: feeRateNanosPerKB,
+		FeeNanosPerLeg:    feeNanosPerLeg.Uint64(),
+		TotalFeeNanos:     totalFeeNanos.Uint64(),
+	}
+
+	asks, err := getDAOCoinLimitOrderBookLevelsForTaker(orders, daoCoinPublicKeyBase58Check, DESOCoinIdentifierString, true)
+	if err != nil {
+		return nil, err
+	}
+	quantity := new(big.Rat).SetInt(quantityBaseUnits.ToBig())
+	bought, cost, _ := walkDAOCoinLimitOrderBookLevels(asks, quantity)
+	res.HasSufficientLiquidity = bought.Cmp(quantity) == 0
+	if !res.HasSufficientLiquidity || cost.Sign() == 0 {
+		return res, nil
+	}
+
+	// cost is in $DESO per DAO coin times DAO coin base units, so the fees are converted to the same units: $DESO per
+	// DAO coin times base units is nanos times BaseUnitsPerCoin / NanosPerUnit.
+	totalFee := new(big.Rat).SetFrac(new(big.Int).Mul(totalFeeNanos, lib.BaseUnitsPerCoin.ToBig()),
+		big.NewInt(int64(lib.NanosPerUnit)))
+	entryPrice := new(big.Rat).Quo(cost, quantity)
+	breakEvenPrice := new(big.Rat).Add(cost, totalFee)
+	breakEvenPrice.Quo(breakEvenPrice, quantity)
+	breakEvenBasisPoints := new(big.Rat).Quo(totalFee, cost)
+	breakEvenBasisPoints.Mul(breakEvenBasisPoints, big.NewRat(10000, 1))
+
+	res.EntryPrice = formatDAOCoinLimitOrderPriceRat(entryPrice)
+	res.BreakEvenPrice = formatDAOCoinLimitOrderPriceRat(breakEvenPrice)
+	res.BreakEvenBasisPoints = formatDAOCoinLimitOrderPriceRat(breakEvenBasisPoints)
+	return res, nil
+}
+
+type GetDAOCoinLiquidityWithinBandRequest struct {
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// A positive decimal string (ex: 1 for 1%). Orders priced within this percentage of the mid-price are counted.
+	BandPercentage string `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetDAOCoinLiquidityWithinBandResponse struct {
+	// False if either side of the book is empty, in which case there's no mid-price and all the totals are zero.
+	HasMidPrice bool `safeForLogging:"true"`
+	// A decimal string in coin2 per coin1. The average of the best bid and best ask. Empty if HasMidPrice is false.
+	MidPrice string `safeForLogging:"true"`
+
+	// Decimal strings. The total quantity of coin1 on each side priced within the band, and its notional value in
+	// coin2 at each order's price. Bids count from the mid-price down to the bottom of the band, and asks from the
+	// mid-price up to the top.
+	BidQuantity string `safeForLogging:"true"`
+	BidNotional string `safeForLogging:"true"`
+	AskQuantity string `safeForLogging:"true"`
+	AskNotional string `safeForLogging:"true"`
+}
+
+// GetDAOCoinLiquidityWithinBand returns how much of a pair's order book is priced within a percentage of the
+// mid-price, a standard measure of how deep the market is near its current price.
+func (fes *APIServer) GetDAOCoinLiquidityWithinBand(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDAOCoinLiquidityWithinBandRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLiquidityWithinBand: Problem parsing request body: %v", err))
+		return
+	}
+
+	if IsDesoPkid(requestData.DAOCoin1CreatorPublicKeyBase58Check) &&
+		IsDesoPkid(requestData.DAOCoin2CreatorPublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetDAOCoinLiquidityWithinBand: Must provide either a "+
+			"DAOCoin1CreatorPublicKeyBase58Check or DAOCoin2CreatorPublicKeyBase58Check or both")
+		return
+	}
+
+	bandPercentage, err := parsePositiveDecimalString(requestData.BandPercentage)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLiquidityWithinBand: Invalid BandPercentage: %v", err))
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLiquidityWithinBand: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	res, err := fes.getDAOCoinOrderBookAggregation(
+		requestData.DAOCoin1CreatorPublicKeyBase58Check,
+		requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		txnStatus,
+		DAOCoinOrderBookAggregationLiquidityWithinBand,
+		bandPercentage.RatString(),
+		func(orders []DAOCoinLimitOrderEntryResponse) (interface{}, error) {
+			res, err := ComputeDAOCoinLiquidityWithinBand(
+				orders,
+				requestData.DAOCoin1CreatorPublicKeyBase58Check,
+				requestData.DAOCoin2CreatorPublicKeyBase58Check,
+				bandPercentage,
+			)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Problem computing liquidity")
+			}
+			return res, nil
+		},
+	)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinLiquidityWithinBand: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLiquidityWithinBand: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// ComputeDAOCoinLiquidityWithinBand sums the quantity and notional of the coin1/coin2 bids priced at or above
+// bandPercentage below the mid-price, and of the asks priced at or below bandPercentage above it.
+func ComputeDAOCoinLiquidityWithinBand(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	bandPercentage *big.Rat,
+) (*GetDAOCoinLiquidityWithinBandResponse, error) {
+	bids, asks, err := getDAOCoinLimitOrderBookSides(orders, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+	if err != nil {
+		return nil, err
+	}
+	midPrice := getDAOCoinLimitOrderBookMidPrice(bids, asks)
+	var bidsWithinBand, asksWithinBand []daoCoinLimitOrderBookLevel
+	if midPrice != nil {
+		bidsWithinBand, asksWithinBand = filterDAOCoinLimitOrderBookLevelsWithinBand(bids, asks, midPrice, bandPercentage)
+	}
+
+	res := &GetDAOCoinLiquidityWithinBandResponse{HasMidPrice: midPrice != nil}
+	if midPrice != nil {
+		res.MidPrice = formatDAOCoinLimitOrderPriceRat(midPrice)
+	}
+	if res.BidQuantity, res.BidNotional, err = sumDAOCoinLimitOrderBookLevels(
+		bidsWithinBand, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check); err != nil {
+		return nil, err
+	}
+	if res.AskQuantity, res.AskNotional, err = sumDAOCoinLimitOrderBookLevels(
+		asksWithinBand, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// getDAOCoinLimitOrderBookSides returns the coin1/coin2 bids and asks, each sorted best price first.
+func getDAOCoinLimitOrderBookSides(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) (_bids []daoCoinLimitOrderBookLevel, _asks []daoCoinLimitOrderBookLevel, _err error) {
+	// Sellers of coin1 fill against the bids, and buyers against the asks.
+	bids, err := getDAOCoinLimitOrderBookLevelsForTaker(orders, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	asks, err := getDAOCoinLimitOrderBookLevelsForTaker(orders, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	return bids, asks, nil
+}
+
+// getDAOCoinLimitOrderBookMidPrice returns the average of the best bid and best ask, or nil if either side is empty.
+func getDAOCoinLimitOrderBookMidPrice(bids []daoCoinLimitOrderBookLevel, asks []daoCoinLimitOrderBookLevel) *big.Rat {
+	if len(bids) == 0 || len(asks) == 0 {
+		return nil
+	}
+	midPrice := new(big.Rat).Add(bids[0].price, asks[0].price)
+	return midPrice.Quo(midPrice, big.NewRat(2, 1))
+}
+
+// filterDAOCoinLimitOrderBookLevelsWithinBand returns the bids priced at or above bandPercentage below midPrice, and
+// the asks priced at or below bandPercentage above it.
+func filterDAOCoinLimitOrderBookLevelsWithinBand(
+	bids []daoCoinLimitOrderBookLevel,
+	asks []daoCoinLimitOrderBookLevel,
+	midPrice *big.Rat,
+	bandPercentage *big.Rat,
+) (_bidsWithinBand []daoCoinLimitOrderBookLevel, _asksWithinBand []daoCoinLimitOrderBookLevel) {
+	band := new(big.Rat).Mul(midPrice, new(big.Rat).Quo(bandPercentage, big.NewRat(100, 1)))
+	bandBottom := new(big.Rat).Sub(midPrice, band)
+	bandTop := new(big.Rat).Add(midPrice, band)
+	// Levels are sorted best price first, so each side stops at the first order outside the band.
+	var bidsWithinBand, asksWithinBand []daoCoinLimitOrderBookLevel
+	for _, bid := range bids {
+		if bid.price.Cmp(bandBottom) < 0 {
+			break
+		}
+		bidsWithinBand = append(bidsWithinBand, bid)
+	}
+	for _, ask := range asks {
+		if ask.price.Cmp(bandTop) > 0 {
+			break
+		}
+		asksWithinBand = append(asksWithinBand, ask)
+	}
+	return bidsWithinBand, asksWithinBand
+}
+
+// FillHeuristicDistanceScale sets how quickly the FillHeuristic falls off with distance from the mid-price. An order
+// priced 1/FillHeuristicDistanceScale away from the mid, i.e. 10%, scores half as high as one at the mid with nothing
+// queued ahead of it.
+const FillHeuristicDistanceScale = 10
+
+// AddFillHeuristicToDAOCoinLimitOrders returns a copy of the coin1/coin2 book's orders with FillHeuristic set. The
+// score is the product of two factors between 0 and 1:
+//   - Proximity: 1 / (1 + FillHeuristicDistanceScale * |price - mid| / mid), so orders nearer the mid score higher.
+//   - Queue position: opposing / (opposing + ahead), where ahead is the quantity of coin1 on the order's own side at a
+//     better price and opposing is the total quantity of coin1 on the other side, so an order with a lot queued in
+//     front of it relative to the liquidity that could fill it scores lower.
+//
+// Prices are compared as the amount of coin2 per coin1. If either side of the book is empty there is no mid, and the
+// orders are returned without a FillHeuristic. orders is left untouched since it may be shared with the order book
+// cache.
+func AddFillHeuristicToDAOCoinLimitOrders(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) ([]DAOCoinLimitOrderEntryResponse, error) {
+	ordersWithHeuristic := make([]DAOCoinLimitOrderEntryResponse, len(orders))
+	copy(ordersWithHeuristic, orders)
+
+	// The levels point into ordersWithHeuristic, so the scores are set on the copy.
+	bids, asks, err := getDAOCoinLimitOrderBookSides(
+		ordersWithHeuristic, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+	if err != nil {
+		return nil, err
+	}
+	midPrice := getDAOCoinLimitOrderBookMidPrice(bids, asks)
+	if midPrice == nil || midPrice.Sign() == 0 {
+		return ordersWithHeuristic, nil
+	}
+
+	sumQuantity := func(levels []daoCoinLimitOrderBookLevel) *big.Rat {
+		total := new(big.Rat)
+		for _, level := range levels {
+			total.Add(total, level.quantityBaseUnits)
+		}
+		return total
+	}
+	// Levels are sorted best price first, so the quantity ahead of a level is the total of the levels priced better
+	// than it. Levels at the same price aren't counted, since their relative priority isn't known here.
+	setFillHeuristics := func(levels []daoCoinLimitOrderBookLevel, opposingQuantity *big.Rat) {
+		quantityAhead := new(big.Rat)
+		quantityAtBetterPrices := new(big.Rat)
+		for ii, level := range levels {
+			if ii > 0 && level.price.Cmp(levels[ii-1].price) != 0 {
+				quantityAhead.Set(quantityAtBetterPrices)
+			}
+			quantityAtBetterPrices.Add(quantityAtBetterPrices, level.quantityBaseUnits)
+
+			distance := new(big.Rat).Sub(level.price, midPrice)
+			distance.Abs(distance)
+			proximity := new(big.Rat).Quo(distance, midPrice)
+			proximity.Mul(proximity, big.NewRat(FillHeuristicDistanceScale, 1))
+			proximity.Inv(proximity.Add(proximity, big.NewRat(1, 1)))
+
+			score := proximity
+			if quantityAhead.Sign() > 0 {
+				queuePosition := new(big.Rat).Add(opposingQuantity, quantityAhead)
+				queuePosition.Quo(opposingQuantity, queuePosition)
+				score = new(big.Rat).Mul(proximity, queuePosition)
+			}
+			scoreFloat, _ := score.Float64()
+			level.order.FillHeuristic = &scoreFloat
+		}
+	}
+	setFillHeuristics(bids, sumQuantity(asks))
+	setFillHeuristics(asks, sumQuantity(bids))
+	return ordersWithHeuristic, nil
+}
+
+// sumDAOCoinLimitOrderBookLevels returns the total quantity of coin1 in levels and its notional in coin2 as decimal
+// strings, rounding both down to the nearest base unit.
+func sumDAOCoinLimitOrderBookLevels(
+	levels []daoCoinLimitOrderBookLevel,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) (_quantity string, _notional string, _err error) {
+	coin1BaseUnitsPerCoin := new(big.Rat).SetInt(lib.BaseUnitsPerCoin.ToBig())
+	if IsDesoPkid(coin1PublicKeyBase58Check) {
+		coin1BaseUnitsPerCoin = new(big.Rat).SetInt64(int64(lib.NanosPerUnit))
+	}
+	coin2BaseUnitsPerCoin := new(big.Rat).SetInt(lib.BaseUnitsPerCoin.ToBig())
+	if IsDesoPkid(coin2PublicKeyBase58Check) {
+		coin2BaseUnitsPerCoin = new(big.Rat).SetInt64(int64(lib.NanosPerUnit))
+	}
+	quantityBaseUnits := new(big.Rat)
+	notional := new(big.Rat)
+	for _, level := range levels {
+		quantityBaseUnits.Add(quantityBaseUnits, level.quantityBaseUnits)
+		notional.Add(notional, new(big.Rat).Mul(level.quantityBaseUnits, level.price))
+	}
+	notionalBaseUnits := notional.Mul(notional, new(big.Rat).Quo(coin2BaseUnitsPerCoin, coin1BaseUnitsPerCoin))
+	toDecimalString := func(publicKeyBase58Check string, baseUnits *big.Rat) (string, error) {
+		baseUnitsInt, overflow := uint256.FromBig(new(big.Int).Quo(baseUnits.Num(), baseUnits.Denom()))
+		if overflow {
+			return "", errors.Errorf("%v base units overflows uint256", baseUnits.FloatString(0))
+		}
+		return CalculateStringDecimalAmountFromBaseUnitsSimple(publicKeyBase58Check, baseUnitsInt)
+	}
+	quantity, err := toDecimalString(coin1PublicKeyBase58Check, quantityBaseUnits)
+	if err != nil {
+		return "", "", err
+	}
+	notionalString, err := toDecimalString(coin2PublicKeyBase58Check, notionalBaseUnits)
+	if err != nil {
+		return "", "", err
+	}
+	return quantity, notionalString, nil
+}
+
+type GetDAOCoinOrderBookImbalanceRequest struct {
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// Optional. A positive decimal string (ex: 1 for 1%). If set, only orders priced within this percentage of the
+	// mid-price are counted, as in GetDAOCoinLiquidityWithinBand. Otherwise the whole book is counted.
+	BandPercentage string `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetDAOCoinOrderBookImbalanceResponse struct {
+	// Decimal strings. The total quantity of coin1 on each side of the book.
+	BidQuantity string `safeForLogging:"true"`
+	AskQuantity string `safeForLogging:"true"`
+
+	// BidQuantity / (BidQuantity + AskQuantity), in [0, 1]. It's 1 if there are only bids, 0 if there are only asks,
+	// and 0.5 if the book is empty.
+	ImbalanceRatio float64 `safeForLogging:"true"`
+}
+
+// GetDAOCoinOrderBookImbalance returns how the quantity on a pair's order book splits between bids and asks, which
+// traders use as a signal of buying or selling pressure.
+func (fes *APIServer) GetDAOCoinOrderBookImbalance(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDAOCoinOrderBookImbalanceRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinOrderBookImbalance: Problem parsing request body: %v", err))
+		return
+	}
+
+	if IsDesoPkid(requestData.DAOCoin1CreatorPublicKeyBase58Check) &&
+		IsDesoPkid(requestData.DAOCoin2CreatorPublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetDAOCoinOrderBookImbalance: Must provide either a "+
+			"DAOCoin1CreatorPublicKeyBase58Check or DAOCoin2CreatorPublicKeyBase58Check or both")
+		return
+	}
+
+	var bandPercentage *big.Rat
+	aggregationParams := ""
+	if requestData.BandPercentage != "" {
+		var err error
+		bandPercentage, err = parsePositiveDecimalString(requestData.BandPercentage)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinOrderBookImbalance: Invalid BandPercentage: %v", err))
+			return
+		}
+		aggregationParams = bandPercentage.RatString()
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinOrderBookImbalance: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	res, err := fes.getDAOCoinOrderBookAggregation(
+		requestData.DAOCoin1CreatorPublicKeyBase58Check,
+		requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		txnStatus,
+		DAOCoinOrderBookAggregationImbalance,
+		aggregationParams,
+		func(orders []DAOCoinLimitOrderEntryResponse) (interface{}, error) {
+			res, err := ComputeDAOCoinOrderBookImbalance(
+				orders,
+				requestData.DAOCoin1CreatorPublicKeyBase58Check,
+				requestData.DAOCoin2CreatorPublicKeyBase58Check,
+				bandPercentage,
+			)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Problem computing imbalance")
+			}
+			return res, nil
+		},
+	)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinOrderBookImbalance: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinOrderBookImbalance: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// ComputeDAOCoinOrderBookImbalance sums the quantity of coin1 on each side of the coin1/coin2 book, restricted to
+// bandPercentage around the mid-price if it's non-nil. A band can't be centered when one side of the book is empty,
+// so the other side is then counted in full.
+func ComputeDAOCoinOrderBookImbalance(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	bandPercentage *big.Rat,
+) (*GetDAOCoinOrderBookImbalanceResponse, error) {
+	bids, asks, err := getDAOCoinLimitOrderBookSides(orders, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+	if err != nil {
+		return nil, err
+	}
+	if midPrice := getDAOCoinLimitOrderBookMidPrice(bids, asks); bandPercentage != nil && midPrice != nil {
+		bids, asks = filterDAOCoinLimitOrderBookLevelsWithinBand(bids, asks, midPrice, bandPercentage)
+	}
+
+	res := &GetDAOCoinOrderBookImbalanceResponse{}
+	if res.BidQuantity, _, err = sumDAOCoinLimitOrderBookLevels(
+		bids, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check); err != nil {
+		return nil, err
+	}
+	if res.AskQuantity, _, err = sumDAOCoinLimitOrderBookLevels(
+		asks, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check); err != nil {
+		return nil, err
+	}
+
+	sumQuantity := func(levels []daoCoinLimitOrderBookLevel) *big.Rat {
+		quantityBaseUnits := new(big.Rat)
+		for _, level := range levels {
+			quantityBaseUnits.Add(quantityBaseUnits, level.quantityBaseUnits)
+		}
+		return quantityBaseUnits
+	}
+	bidQuantityBaseUnits := sumQuantity(bids)
+	askQuantityBaseUnits := sumQuantity(asks)
+	switch {
+	case bidQuantityBaseUnits.Sign() == 0 && askQuantityBaseUnits.Sign() == 0:
+		res.ImbalanceRatio = 0.5
+	case askQuantityBaseUnits.Sign() == 0:
+		res.ImbalanceRatio = 1
+	case bidQuantityBaseUnits.Sign() == 0:
+		res.ImbalanceRatio = 0
+	default:
+		totalQuantityBaseUnits := new(big.Rat).Add(bidQuantityBaseUnits, askQuantityBaseUnits)
+		res.ImbalanceRatio, _ = new(big.Rat).Quo(bidQuantityBaseUnits, totalQuantityBaseUnits).Float64()
+	}
+	return res, nil
+}
+
+type GetDAOCoinCrossRateRequest struct {
+	// The DAO coin being sold and the DAO coin being bought. Neither can be $DESO.
+	DAOCoinACreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoinBCreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetDAOCoinCrossRateResponse struct {
+	// Always true. The rate is implied from two separate books, so there's no single order that executes at it, and
+	// the legs' prices only hold for their top orders.
+	IsIndicative bool `safeForLogging:"true"`
+
+	// A decimal string. The amount of coin B received per coin A when selling coin A for $DESO at the best bid on the
+	// A/DESO book and buying coin B with that $DESO at the best ask on the B/DESO book. Empty if either leg is missing.
+	CrossRate string `safeForLogging:"true"`
+
+	// Decimal strings in $DESO per coin. The best bid for coin A and the best ask for coin B. Each is empty if there
+	// are no such orders.
+	CoinABestBidInDESO string `safeForLogging:"true"`
+	CoinBBestAskInDESO string `safeForLogging:"true"`
+}
+
+// GetDAOCoinCrossRate returns an indicative exchange rate between two DAO coins by routing through $DESO, for pairs
+// that don't have a direct book.
+func (fes *APIServer) GetDAOCoinCrossRate(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDAOCoinCrossRateRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinCrossRate: Problem parsing request body: %v", err))
+		return
+	}
+
+	if IsDesoPkid(requestData.DAOCoinACreatorPublicKeyBase58Check) ||
+		IsDesoPkid(requestData.DAOCoinBCreatorPublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetDAOCoinCrossRate: DAOCoinACreatorPublicKeyBase58Check and "+
+			"DAOCoinBCreatorPublicKeyBase58Check must both be DAO coins")
+		return
+	}
+	if requestData.DAOCoinACreatorPublicKeyBase58Check == requestData.DAOCoinBCreatorPublicKeyBase58Check {
+		_AddBadRequestError(ww, "GetDAOCoinCrossRate: DAOCoinACreatorPublicKeyBase58Check and "+
+			"DAOCoinBCreatorPublicKeyBase58Check must be different coins")
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinCrossRate: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetDAOCoinCrossRate")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinCrossRate: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	coinAOrders, err := fes.getDAOCoinLimitOrdersForCoinPair(
+		utxoView, requestData.DAOCoinACreatorPublicKeyBase58Check, DESOCoinIdentifierString)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinCrossRate: Error getting limit orders for coin A: %v", err))
+		return
+	}
+	coinBOrders, err := fes.getDAOCoinLimitOrdersForCoinPair(
+		utxoView, requestData.DAOCoinBCreatorPublicKeyBase58Check, DESOCoinIdentifierString)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinCrossRate: Error getting limit orders for coin B: %v", err))
+		return
+	}
+
+	res, err := ComputeDAOCoinCrossRate(
+		coinAOrders,
+		coinBOrders,
+		requestData.DAOCoinACreatorPublicKeyBase58Check,
+		requestData.DAOCoinBCreatorPublicKeyBase58Check,
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinCrossRate: Problem computing cross rate: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinCrossRate: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// ComputeDAOCoinCrossRate implies the rate of coin B per coin A from the best bid on the coin A/$DESO book and the
+// best ask on the coin B/$DESO book. Both coins are DAO coins, so their base units are the same and the rate is just
+// the ratio of the two $DESO prices.
+func ComputeDAOCoinCrossRate(
+	coinAOrders []DAOCoinLimitOrderEntryResponse,
+	coinBOrders []DAOCoinLimitOrderEntryResponse,
+	coinAPublicKeyBase58Check string,
+	coinBPublicKeyBase58Check string,
+) (*GetDAOCoinCrossRateResponse, error) {
+	coinATopOfBook, err := NewDAOCoinSpreadSample(
+		time.Time{}, coinAOrders, coinAPublicKeyBase58Check, DESOCoinIdentifierString)
+	if err != nil {
+		return nil, err
+	}
+	coinBTopOfBook, err := NewDAOCoinSpreadSample(
+		time.Time{}, coinBOrders, coinBPublicKeyBase58Check, DESOCoinIdentifierString)
+	if err != nil {
+		return nil, err
+	}
+
+	res := &GetDAOCoinCrossRateResponse{IsIndicative: true}
+	if coinATopOfBook.BestBid != nil {
+		res.CoinABestBidInDESO = formatDAOCoinLimitOrderPriceRat(coinATopOfBook.BestBid)
+	}
+	if coinBTopOfBook.BestAsk != nil {
+		res.CoinBBestAskInDESO = formatDAOCoinLimitOrderPriceRat(coinBTopOfBook.BestAsk)
+	}
+	if coinATopOfBook.BestBid != nil && coinBTopOfBook.BestAsk != nil {
+		res.CrossRate = formatDAOCoinLimitOrderPriceRat(
+			new(big.Rat).Quo(coinATopOfBook.BestBid, coinBTopOfBook.BestAsk))
+	}
+	return res, nil
+}
+
+// formatDAOCoinLimitOrderPriceRat formats price with the same precision as CalculatePriceStringFromScaledExchangeRate,
+// truncating any digits past it.
+func formatDAOCoinLimitOrderPriceRat(price *big.Rat) string {
+	scaledPrice := new(big.Rat).Mul(price, new(big.Rat).SetInt(lib.OneE38.ToBig()))
+	return lib.FormatScaledUint256AsDecimalString(
+		new(big.Int).Quo(scaledPrice.Num(), scaledPrice.Denom()), lib.OneE38.ToBig())
+}
+
+// addUsernamesToDAOCoinLimitOrders returns a copy of orders with the transactor and coin creator usernames populated.
+// Each distinct public key is only looked up once. The input slice is left untouched since it may be shared with the
+// order book cache.
+func (fes *APIServer) addUsernamesToDAOCoinLimitOrders(
+	utxoView *lib.UtxoView,
+	orders []DAOCoinLimitOrderEntryResponse,
+) []DAOCoinLimitOrderEntryResponse {
+	usernamesByPublicKey := make(map[string]string)
+	getUsername := func(publicKeyBase58Check string) string {
+		if IsDesoPkid(publicKeyBase58Check) {
+			return ""
+		}
+		if username, exists := usernamesByPublicKey[publicKeyBase58Check]; exists {
+			return username
+		}
+		username := ""
+		if publicKeyBytes, _, err := lib.Base58CheckDecode(publicKeyBase58Check); err == nil {
+			profileEntry := utxoView.GetProfileEntryForPublicKey(publicKeyBytes)
+			if profileEntry != nil && !profileEntry.IsDeleted() {
+				username = string(profileEntry.Username)
+			}
+		}
+		usernamesByPublicKey[publicKeyBase58Check] = username
+		return username
+	}
+
+	ordersWithUsernames := make([]DAOCoinLimitOrderEntryResponse, len(orders))
+	for ii, order := range orders {
+		order.TransactorUsername = getUsername(order.TransactorPublicKeyBase58Check)
+		order.BuyingDAOCoinUsername = getUsername(order.BuyingDAOCoinCreatorPublicKeyBase58Check)
+		order.SellingDAOCoinUsername = getUsername(order.SellingDAOCoinCreatorPublicKeyBase58Check)
+		ordersWithUsernames[ii] = order
+	}
+	return ordersWithUsernames
+}
+
+// ComputeDAOCoinOrderBookChecksum returns a hex-encoded sha256 hash over the order IDs and quantities of the given
+// orders. Orders are sorted by ID first so the checksum does not depend on the order in which they were fetched.
+func ComputeDAOCoinOrderBookChecksum(orders []DAOCoinLimitOrderEntryResponse) string {
+	sortedOrders := append([]DAOCoinLimitOrderEntryResponse{}, orders...)
+	sort.Slice(sortedOrders, func(ii, jj int) bool {
+		return sortedOrders[ii].OrderID < sortedOrders[jj].OrderID
+	})
+
+	hasher := sha256.New()
+	for _, order := range sortedOrders {
+		hasher.Write([]byte(order.OrderID))
+		hasher.Write([]byte{':'})
+		hasher.Write([]byte(order.Quantity))
+		hasher.Write([]byte{'\n'})
+	}
+	return hex.EncodeToString(hasher.Sum(nil))
+}
+
+const (
+	// MaxDAOCoinLimitOrdersPageSize caps the PageSize accepted by GetDAOCoinLimitOrders.
+	MaxDAOCoinLimitOrdersPageSize = 1000
+
+	// daoCoinLimitOrdersPaginationTokenVersion is bumped whenever the token's contents or the paging order change, so
+	// tokens issued by older nodes are rejected rather than misread.
+	daoCoinLimitOrdersPaginationTokenVersion = 1
+)
+
+// DAOCoinLimitOrdersPaginationToken is the position of the last order returned on a page of GetDAOCoinLimitOrders.
+// Clients treat it as an opaque string.
+type DAOCoinLimitOrdersPaginationToken struct {
+	Version int
+	// The last order's price in coin2 per coin1 as an exact fraction, and its OrderID.
+	Price   string
+	OrderID string
+	// The ComputeDAOCoinOrderBookChecksum of every order up to and including the last one returned.
+	ReturnedOrdersChecksum string
+}
+
+func EncodeDAOCoinLimitOrdersPaginationToken(token *DAOCoinLimitOrdersPaginationToken) (string, error) {
+	tokenBytes, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenBytes), nil
+}
+
+func DecodeDAOCoinLimitOrdersPaginationToken(encodedToken string) (*DAOCoinLimitOrdersPaginationToken, error) {
+	tokenBytes, err := base64.RawURLEncoding.DecodeString(encodedToken)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Problem decoding token")
+	}
+	token := &DAOCoinLimitOrdersPaginationToken{}
+	if err = json.Unmarshal(tokenBytes, token); err != nil {
+		return nil, errors.Wrapf(err, "Problem decoding token")
+	}
+	if token.Version != daoCoinLimitOrdersPaginationTokenVersion {
+		return nil, errors.Errorf("Unsupported token version %v", token.Version)
+	}
+	if _, ok := new(big.Rat).SetString(token.Price); !ok {
+		return nil, errors.Errorf("Token has invalid price %v", token.Price)
+	}
+	return token, nil
+}
+
+// PaginateDAOCoinLimitOrders returns the page of up to pageSize coin1/coin2 orders that follows paginationToken, or
+// the first page if it's nil, along with the token for the next page. Orders are sorted by price in coin2 per coin1,
+// lowest first, then by OrderID, which is a total order that doesn't depend on the rest of the book. If any of the
+// orders that were already returned have changed since paginationToken was issued, shouldRestart is set and no
+// orders are returned.
+func PaginateDAOCoinLimitOrders(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	pageSize int,
+	paginationToken *DAOCoinLimitOrdersPaginationToken,
+) (_page []DAOCoinLimitOrderEntryResponse, _nextPaginationToken string, _shouldRestart bool, _err error) {
+	type pricedOrder struct {
+		order DAOCoinLimitOrderEntryResponse
+		price *big.Rat
+	}
+	pricedOrders := make([]pricedOrder, len(orders))
+	for ii, order := range orders {
+		price, err := getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder(order, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+		if err != nil {
+			return nil, "", false, err
+		}
+		pricedOrders[ii] = pricedOrder{order: order, price: price}
+	}
+	isBefore := func(price1 *big.Rat, orderID1 string, price2 *big.Rat, orderID2 string) bool {
+		if priceComparison := price1.Cmp(price2); priceComparison != 0 {
+			return priceComparison < 0
+		}
+		return orderID1 < orderID2
+	}
+	sort.Slice(pricedOrders, func(ii, jj int) bool {
+		return isBefore(pricedOrders[ii].price, pricedOrders[ii].order.OrderID,
+			pricedOrders[jj].price, pricedOrders[jj].order.OrderID)
+	})
+	sortedOrders := make([]DAOCoinLimitOrderEntryResponse, len(pricedOrders))
+	for ii, pricedOrder := range pricedOrders {
+		sortedOrders[ii] = pricedOrder.order
+	}
+
+	startIndex := 0
+	if paginationToken != nil {
+		tokenPrice, ok := new(big.Rat).SetString(paginationToken.Price)
+		if !ok {
+			return nil, "", false, errors.Errorf("Token has invalid price %v", paginationToken.Price)
+		}
+		startIndex = sort.Search(len(pricedOrders), func(ii int) bool {
+			return isBefore(tokenPrice, paginationToken.OrderID, pricedOrders[ii].price, pricedOrders[ii].order.OrderID)
+		})
+		if ComputeDAOCoinOrderBookChecksum(sortedOrders[:startIndex]) != paginationToken.ReturnedOrdersChecksum {
+			return []DAOCoinLimitOrderEntryResponse{}, "", true, nil
+		}
+	}
+
+	endIndex := startIndex + pageSize
+	if endIndex >= len(sortedOrders) {
+		return sortedOrders[startIndex:], "", false, nil
+	}
+	lastOrder := pricedOrders[endIndex-1]
+	nextPaginationToken, err := EncodeDAOCoinLimitOrdersPaginationToken(&DAOCoinLimitOrdersPaginationToken{
+		Version:                daoCoinLimitOrdersPaginationTokenVersion,
+		Price:                  lastOrder.price.RatString(),
+		OrderID:                lastOrder.order.OrderID,
+		ReturnedOrdersChecksum: ComputeDAOCoinOrderBookChecksum(sortedOrders[:endIndex]),
+	})
+	if err != nil {
+		return nil, "", false, err
+	}
+	return sortedOrders[startIndex:endIndex], nextPaginationToken, false, nil
+}
+
+type GetDAOCoinLimitOrdersByIdRequest struct {
+	// A list of hex OrderIds that we will fetch
+	OrderIds []string `safeForLogging:"true"`
+	// If unset, defaults to TxnStatusInMempool. If set to "InMempool" we will
+	// consider all txns including those in the mempool. If set to "Committed" then
+	// we will only consider txns that have been committed according to consensus.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+
+	// If set, the deprecated ExchangeRateCoinsToSellPerCoinToBuy and QuantityToFill float fields are left out of each
+	// order, so only the exact Price and Quantity strings are returned.
+	OmitDeprecatedFloatFields bool `safeForLogging:"true"`
+}
+
+func (fes *APIServer) GetDAOCoinLimitOrdersById(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDAOCoinLimitOrdersByIdRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(
+			ww,
+			fmt.Sprintf("GetDAOCoinLimitOrdersById: Problem parsing request body: %v", err),
+		)
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool &&
+		txnStatus != TxnStatusCommitted {
+
+		_AddBadRequestError(
+			ww,
+			fmt.Sprintf("GetDAOCoinLimitOrdersById: Invalid TxnStatus: %v. Options "+
+				"are {InMempool, Committed}.", txnStatus),
+		)
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetDAOCoinLimitOrdersById")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinLimitOrdersById: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	// Don't allow fetching more than this many orders
+	maxOrdersToFetch := 10000
+	if len(requestData.OrderIds) > maxOrdersToFetch {
+		_AddBadRequestError(
+			ww,
+			fmt.Sprintf("GetDAOCoinLimitOrdersById: Cannot fetch more than %v orders at once", maxOrdersToFetch),
+		)
+		return
+	}
+	ordersToReturn := make([]DAOCoinLimitOrderEntryResponse, 0, len(requestData.OrderIds))
+	for _, orderIdHex := range requestData.OrderIds {
+		orderIdBytes, err := hex.DecodeString(orderIdHex)
+		if err != nil {
+			_AddBadRequestError(
+				ww,
+				fmt.Sprintf("GetDAOCoinLimitOrdersById: Invalid OrderId: %v", err),
+			)
+			return
+		}
+		if len(orderIdBytes) != 32 {
+			_AddBadRequestError(
+				ww,
+				fmt.Sprintf("GetDAOCoinLimitOrdersById: Block hash has length (%d) but should "+
+					"be (%d)", len(orderIdBytes), 32),
+			)
+			return
+		}
+		orderId := lib.BlockHash{}
+		copy(orderId[:], orderIdBytes)
+		orderEntry, err := utxoView.GetDAOCoinLimitOrderEntry(&orderId)
+		if err != nil {
+			_AddBadRequestError(
+				ww,
+				fmt.Sprintf("GetDAOCoinLimitOrdersById: Error fetching order: %v", err),
+			)
+			return
+		}
+		if orderEntry == nil {
+			continue
+		}
+		if orderEntry.IsDeleted() {
+			continue
+		}
+		transactorPublicKey := utxoView.GetPublicKeyForPKID(orderEntry.TransactorPKID)
+		buyingCoinPublicKey := utxoView.GetPublicKeyForPKID(orderEntry.BuyingDAOCoinCreatorPKID)
+		sellingCoinPublicKey := utxoView.GetPublicKeyForPKID(orderEntry.SellingDAOCoinCreatorPKID)
+		orderRes, err := buildDAOCoinLimitOrderResponse(
+			lib.Base58CheckEncode(transactorPublicKey, false, fes.Params),
+			lib.Base58CheckEncode(buyingCoinPublicKey, false, fes.Params),
+			lib.Base58CheckEncode(sellingCoinPublicKey, false, fes.Params),
+			orderEntry,
+			fes.skipDeprecatedDAOCoinLimitOrderFloatFields(),
+		)
+		if err != nil {
+			_AddBadRequestError(
+				ww,
+				fmt.Sprintf("GetDAOCoinLimitOrdersById: Error building order response: %v", err),
+			)
+			return
+		}
+		if orderRes == nil {
+			_AddInternalServerError(ww, "GetDAOCoinLimitOrdersById: Error building order "+
+				"response: nil order response")
+			return
+		}
+		ordersToReturn = append(ordersToReturn, *orderRes)
+	}
+	if requestData.OmitDeprecatedFloatFields {
+		ordersToReturn = omitDeprecatedFloatFieldsFromDAOCoinLimitOrders(ordersToReturn)
+	}
+	if err = json.NewEncoder(ww).Encode(GetDAOCoinLimitOrdersResponse{
+		Orders: ordersToReturn,
+	}); err != nil {
+		_AddBadRequestError(
+			ww,
+			fmt.Sprintf("GetDAOCoinLimitOrders: Problem encoding response as JSON: %v", err),
+		)
+		return
+	}
+}
+
+type GetTransactorDAOCoinLimitOrdersRequest struct {
+	TransactorPublicKeyBase58Check  string `safeForLogging:"true"`
+	BuyingCoinPublicKeyBase58Check  string `safeForLogging:"true"`
+	SellingCoinPublicKeyBase58Check string `safeForLogging:"true"`
+	// Defaults to TxnStatusInMempool. If set to "InMempool" we will consider all
+	// txns including those in the mempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+
+	// If set, the deprecated ExchangeRateCoinsToSellPerCoinToBuy and QuantityToFill float fields are left out of each
+	// order, so only the exact Price and Quantity strings are returned.
+	OmitDeprecatedFloatFields bool `safeForLogging:"true"`
+}
+
+func (fes *APIServer) GetTransactorDAOCoinLimitOrders(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetTransactorDAOCoinLimitOrdersRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(
+			ww,
+			fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Problem parsing request body: %v", err),
+		)
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool &&
+		txnStatus != TxnStatusCommitted {
+
+		_AddBadRequestError(
+			ww,
+			fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Invalid TxnStatus: %v. Options "+
+				"are {InMempool, Committed}.", txnStatus),
+		)
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorDAOCoinLimitOrders: %v", err))
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetTransactorDAOCoinLimitOrders")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	transactorPKID, err := fes.getPKIDFromPublicKeyBase58Check(
+		utxoView,
+		requestData.TransactorPublicKeyBase58Check,
+	)
+	if err != nil {
+		_AddBadRequestError(
+			ww,
+			fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Invalid TransactorPublicKeyBase58Check: %v", err),
+		)
+		return
+	}
+	var buyingCoinPkid *lib.PKID
+	if requestData.BuyingCoinPublicKeyBase58Check != "" {
+		buyingCoinPkid, err = fes.getPKIDFromPublicKeyBase58CheckOrDESOString(
+			utxoView,
+			requestData.BuyingCoinPublicKeyBase58Check,
+		)
+		if err != nil {
+			_AddBadRequestError(
+				ww,
+				fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Invalid BuyingCoinPublicKeyBase58Check: %v", err),
+			)
+			return
+		}
+	}
+	var sellingCoinPkid *lib.PKID
+	if requestData.SellingCoinPublicKeyBase58Check != "" {
+		sellingCoinPkid, err = fes.getPKIDFromPublicKeyBase58CheckOrDESOString(
+			utxoView,
+			requestData.SellingCoinPublicKeyBase58Check,
+		)
+		if err != nil {
+			_AddBadRequestError(
+				ww,
+				fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Invalid SellingCoinPublicKeyBase58Check: %v", err),
+			)
+			return
+		}
+	}
+
+	orders, err := utxoView.GetAllDAOCoinLimitOrdersForThisTransactor(
+		transactorPKID, buyingCoinPkid, sellingCoinPkid)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Error getting limit orders: %v", err))
+		return
+	}
+
+	responses := fes.buildDAOCoinLimitOrderResponsesForTransactor(utxoView, requestData.TransactorPublicKeyBase58Check, orders)
+	if requestData.OmitDeprecatedFloatFields {
+		responses = omitDeprecatedFloatFieldsFromDAOCoinLimitOrders(responses)
+	}
+
+	if err = json.NewEncoder(ww).Encode(GetDAOCoinLimitOrdersResponse{Orders: responses}); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+type GetUserCoinInvolvementRequest struct {
+	UserPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// Defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+// UserCoinInvolvement is a DAO coin the user holds a balance in, has open limit orders for, or both.
+type UserCoinInvolvement struct {
+	CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	// Empty if the creator has no profile.
+	CreatorUsername string `safeForLogging:"true"`
+
+	HasBalance    bool `safeForLogging:"true"`
+	HasOpenOrders bool `safeForLogging:"true"`
+}
+
+type GetUserCoinInvolvementResponse struct {
+	// Each DAO coin appears once, sorted by creator public key. $DESO is never included.
+	Coins []UserCoinInvolvement
+}
+
+// GetUserCoinInvolvement returns every DAO coin a user holds a non-zero balance in or has open limit orders buying or
+// selling, so portfolio views can list them without merging the user's balances and orders themselves.
+func (fes *APIServer) GetUserCoinInvolvement(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetUserCoinInvolvementRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetUserCoinInvolvement: Problem parsing request body: %v", err))
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetUserCoinInvolvement: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("UserPublicKeyBase58Check", requestData.UserPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetUserCoinInvolvement: %v", err))
+		return
+	}
+	userPublicKeyBytes, _, err := lib.Base58CheckDecode(requestData.UserPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetUserCoinInvolvement: Problem decoding UserPublicKeyBase58Check: %v", err))
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetUserCoinInvolvement")
 	if err != nil {
-		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Problem fetching utxoView: %v", err))
+		_AddInternalServerError(ww, fmt.Sprintf("GetUserCoinInvolvement: Problem fetching utxoView: %v", err))
 		return
 	}
 
-	transactorPKID, err := fes.getPKIDFromPublicKeyBase58Check(
+	userPKIDEntry := utxoView.GetPKIDForPublicKey(userPublicKeyBytes)
+	youHodlMap, err := fes.GetYouHodlMap(userPKIDEntry, false, true, utxoView)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetUserCoinInvolvement: Problem getting balances: %v", err))
+		return
+	}
+
+	orders, err := utxoView.GetAllDAOCoinLimitOrdersForThisTransactor(userPKIDEntry.PKID, nil, nil)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetUserCoinInvolvement: Error getting limit orders: %v", err))
+		return
+	}
+	orderResponses := fes.buildDAOCoinLimitOrderResponsesForTransactor(
+		utxoView, requestData.UserPublicKeyBase58Check, orders)
+
+	coins := getUserCoinInvolvement(youHodlMap, orderResponses)
+	for ii := range coins {
+		creatorPublicKeyBytes, _, err := lib.Base58CheckDecode(coins[ii].CreatorPublicKeyBase58Check)
+		if err != nil {
+			continue
+		}
+		profileEntry := utxoView.GetProfileEntryForPublicKey(creatorPublicKeyBytes)
+		if profileEntry != nil && !profileEntry.IsDeleted() {
+			coins[ii].CreatorUsername = string(profileEntry.Username)
+		}
+	}
+
+	if err = json.NewEncoder(ww).Encode(GetUserCoinInvolvementResponse{Coins: coins}); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetUserCoinInvolvement: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getUserCoinInvolvement merges the DAO coins a user holds, keyed by creator public key as returned by GetYouHodlMap,
+// with the DAO coins on either side of their open orders. Zero balances and $DESO are left out.
+func getUserCoinInvolvement(
+	youHodlMap map[string]*BalanceEntryResponse,
+	orders []DAOCoinLimitOrderEntryResponse,
+) []UserCoinInvolvement {
+	coinsByCreatorPublicKey := make(map[string]*UserCoinInvolvement)
+	getCoin := func(creatorPublicKeyBase58Check string) *UserCoinInvolvement {
+		coin, exists := coinsByCreatorPublicKey[creatorPublicKeyBase58Check]
+		if !exists {
+			coin = &UserCoinInvolvement{CreatorPublicKeyBase58Check: creatorPublicKeyBase58Check}
+			coinsByCreatorPublicKey[creatorPublicKeyBase58Check] = coin
+		}
+		return coin
+	}
+
+	for creatorPublicKeyBase58Check, balanceEntry := range youHodlMap {
+		// The view can hold balances that have been sold down to zero.
+		if balanceEntry == nil || balanceEntry.BalanceNanosUint256 == nil || balanceEntry.BalanceNanosUint256.IsZero() {
+			continue
+		}
+		getCoin(creatorPublicKeyBase58Check).HasBalance = true
+	}
+	for _, order := range orders {
+		for _, creatorPublicKeyBase58Check := range []string{
+			order.BuyingDAOCoinCreatorPublicKeyBase58Check,
+			order.SellingDAOCoinCreatorPublicKeyBase58Check,
+		} {
+			if IsDesoPkid(creatorPublicKeyBase58Check) {
+				continue
+			}
+			getCoin(creatorPublicKeyBase58Check).HasOpenOrders = true
+		}
+	}
+
+	coins := []UserCoinInvolvement{}
+	for _, coin := range coinsByCreatorPublicKey {
+		coins = append(coins, *coin)
+	}
+	sort.Slice(coins, func(ii, jj int) bool {
+		return coins[ii].CreatorPublicKeyBase58Check < coins[jj].CreatorPublicKeyBase58Check
+	})
+	return coins
+}
+
+type GetTransactorOpenOrderCountRequest struct {
+	TransactorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// Optional. If both are set, only the transactor's orders between these two coins are counted, in either
+	// direction. Either may be "DESO".
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// Defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetTransactorOpenOrderCountResponse struct {
+	OpenOrderCount int `safeForLogging:"true"`
+}
+
+// GetTransactorOpenOrderCount returns how many open DAO coin limit orders a transactor has. It's much cheaper than
+// GetTransactorDAOCoinLimitOrders since no order responses are built, so it suits pre-flight checks against
+// per-account order limits.
+func (fes *APIServer) GetTransactorOpenOrderCount(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetTransactorOpenOrderCountRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorOpenOrderCount: Problem parsing request body: %v", err))
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorOpenOrderCount: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorOpenOrderCount: %v", err))
+		return
+	}
+
+	filterByPair := requestData.DAOCoin1CreatorPublicKeyBase58Check != "" ||
+		requestData.DAOCoin2CreatorPublicKeyBase58Check != ""
+	if filterByPair && (requestData.DAOCoin1CreatorPublicKeyBase58Check == "" ||
+		requestData.DAOCoin2CreatorPublicKeyBase58Check == "") {
+		_AddBadRequestError(ww, "GetTransactorOpenOrderCount: Must provide both DAOCoin1CreatorPublicKeyBase58Check "+
+			"and DAOCoin2CreatorPublicKeyBase58Check to filter by coin pair")
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetTransactorOpenOrderCount")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorOpenOrderCount: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	transactorPKID, err := fes.getPKIDFromPublicKeyBase58Check(utxoView, requestData.TransactorPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorOpenOrderCount: Invalid "+
+			"TransactorPublicKeyBase58Check: %v", err))
+		return
+	}
+	var coin1PKID, coin2PKID *lib.PKID
+	if filterByPair {
+		coin1PKID, err = fes.getPKIDFromPublicKeyBase58CheckOrDESOString(
+			utxoView, requestData.DAOCoin1CreatorPublicKeyBase58Check)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetTransactorOpenOrderCount: Invalid "+
+				"DAOCoin1CreatorPublicKeyBase58Check: %v", err))
+			return
+		}
+		coin2PKID, err = fes.getPKIDFromPublicKeyBase58CheckOrDESOString(
+			utxoView, requestData.DAOCoin2CreatorPublicKeyBase58Check)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetTransactorOpenOrderCount: Invalid "+
+				"DAOCoin2CreatorPublicKeyBase58Check: %v", err))
+			return
+		}
+	}
+
+	openOrderCount, err := countTransactorOpenDAOCoinLimitOrders(
+		func(buyingCoinPKID *lib.PKID, sellingCoinPKID *lib.PKID) ([]*lib.DAOCoinLimitOrderEntry, error) {
+			return utxoView.GetAllDAOCoinLimitOrdersForThisTransactor(transactorPKID, buyingCoinPKID, sellingCoinPKID)
+		},
+		coin1PKID,
+		coin2PKID,
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorOpenOrderCount: Error getting limit orders: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(GetTransactorOpenOrderCountResponse{OpenOrderCount: openOrderCount}); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorOpenOrderCount: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// countTransactorOpenDAOCoinLimitOrders counts a transactor's open orders using getOrders, which returns the
+// transactor's orders buying buyingCoinPKID with sellingCoinPKID, or all of their orders if both are nil. If the
+// coin PKIDs are set, orders in both directions of the pair are counted.
+func countTransactorOpenDAOCoinLimitOrders(
+	getOrders func(buyingCoinPKID *lib.PKID, sellingCoinPKID *lib.PKID) ([]*lib.DAOCoinLimitOrderEntry, error),
+	coin1PKID *lib.PKID,
+	coin2PKID *lib.PKID,
+) (int, error) {
+	if coin1PKID == nil || coin2PKID == nil {
+		orders, err := getOrders(nil, nil)
+		if err != nil {
+			return 0, err
+		}
+		return len(orders), nil
+	}
+	ordersBuyingCoin1, err := getOrders(coin1PKID, coin2PKID)
+	if err != nil {
+		return 0, err
+	}
+	ordersBuyingCoin2, err := getOrders(coin2PKID, coin1PKID)
+	if err != nil {
+		return 0, err
+	}
+	return len(ordersBuyingCoin1) + len(ordersBuyingCoin2), nil
+}
+
+type GetTransactorCrossingOrdersRequest struct {
+	TransactorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// The side of the proposed order relative to coin1. A BID buys coin1 and an ASK sells it.
+	OperationType DAOCoinLimitOrderOperationTypeString `safeForLogging:"true"`
+	// A positive decimal string. The proposed order's price in coin2 per coin1.
+	Price string `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetTransactorCrossingOrdersResponse struct {
+	// The transactor's own resting orders the proposed order would match against, in the order it would match them.
+	// Empty if placing the proposed order wouldn't be a self-trade.
+	CrossingOrders []DAOCoinLimitOrderEntryResponse
+}
+
+// GetTransactorCrossingOrders is a pre-flight self-trade check. It returns the transactor's orders on the other side
+// of the coin1/coin2 book that a proposed order at the given side and price would cross.
+func (fes *APIServer) GetTransactorCrossingOrders(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetTransactorCrossingOrdersRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorCrossingOrders: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorCrossingOrders: %v", err))
+		return
+	}
+	if IsDesoPkid(requestData.DAOCoin1CreatorPublicKeyBase58Check) &&
+		IsDesoPkid(requestData.DAOCoin2CreatorPublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetTransactorCrossingOrders: Must provide either a "+
+			"DAOCoin1CreatorPublicKeyBase58Check or DAOCoin2CreatorPublicKeyBase58Check or both")
+		return
+	}
+	if _, err := parsePositiveDecimalString(requestData.Price); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorCrossingOrders: Invalid Price: %v", err))
+		return
+	}
+	if requestData.OperationType != DAOCoinLimitOrderOperationTypeStringBID &&
+		requestData.OperationType != DAOCoinLimitOrderOperationTypeStringASK {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorCrossingOrders: Invalid OperationType: %v. Options "+
+			"are {%v, %v}.", requestData.OperationType,
+			DAOCoinLimitOrderOperationTypeStringBID, DAOCoinLimitOrderOperationTypeStringASK))
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorCrossingOrders: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetTransactorCrossingOrders")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorCrossingOrders: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	crossingOrders, err := fes.getTransactorCrossingDAOCoinLimitOrders(
 		utxoView,
 		requestData.TransactorPublicKeyBase58Check,
+		requestData.DAOCoin1CreatorPublicKeyBase58Check,
+		requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		requestData.OperationType,
+		requestData.Price,
 	)
 	if err != nil {
-		_AddBadRequestError(
-			ww,
-			fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Invalid TransactorPublicKeyBase58Check: %v", err),
-		)
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorCrossingOrders: %v", err))
 		return
 	}
-	var buyingCoinPkid *lib.PKID
-	if requestData.BuyingCoinPublicKeyBase58Check != "" {
-		buyingCoinPkid, err = fes.getPKIDFromPublicKeyBase58CheckOrDESOString(
-			utxoView,
-			requestData.BuyingCoinPublicKeyBase58Check,
-		)
+
+	if err = json.NewEncoder(ww).Encode(GetTransactorCrossingOrdersResponse{CrossingOrders: crossingOrders}); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorCrossingOrders: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getTransactorCrossingDAOCoinLimitOrders returns the transactor's open orders that a proposed coin1/coin2 order with
+// the given side and price, in coin2 per coin1, would match against. See FilterCrossingDAOCoinLimitOrders.
+func (fes *APIServer) getTransactorCrossingDAOCoinLimitOrders(
+	utxoView *lib.UtxoView,
+	transactorPublicKeyBase58Check string,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	operationType DAOCoinLimitOrderOperationTypeString,
+	price string,
+) ([]DAOCoinLimitOrderEntryResponse, error) {
+	transactorPKID, err := fes.getPKIDFromPublicKeyBase58Check(utxoView, transactorPublicKeyBase58Check)
+	if err != nil {
+		return nil, errors.Errorf("Invalid TransactorPublicKeyBase58Check: %v", err)
+	}
+	coin1PKID, err := fes.getPKIDFromPublicKeyBase58CheckOrDESOString(utxoView, coin1PublicKeyBase58Check)
+	if err != nil {
+		return nil, errors.Errorf("Invalid DAOCoin1CreatorPublicKeyBase58Check: %v", err)
+	}
+	coin2PKID, err := fes.getPKIDFromPublicKeyBase58CheckOrDESOString(utxoView, coin2PublicKeyBase58Check)
+	if err != nil {
+		return nil, errors.Errorf("Invalid DAOCoin2CreatorPublicKeyBase58Check: %v", err)
+	}
+
+	// Only orders on the other side of the book can be crossed: a BID for coin1 matches orders buying coin2 with
+	// coin1, and vice versa.
+	oppositeSideBuyingPKID, oppositeSideSellingPKID := coin2PKID, coin1PKID
+	if operationType == DAOCoinLimitOrderOperationTypeStringASK {
+		oppositeSideBuyingPKID, oppositeSideSellingPKID = coin1PKID, coin2PKID
+	}
+	orders, err := utxoView.GetAllDAOCoinLimitOrdersForThisTransactor(
+		transactorPKID, oppositeSideBuyingPKID, oppositeSideSellingPKID)
+	if err != nil {
+		return nil, errors.Errorf("Error getting limit orders: %v", err)
+	}
+	responses := fes.buildDAOCoinLimitOrderResponsesForTransactor(utxoView, transactorPublicKeyBase58Check, orders)
+
+	return FilterCrossingDAOCoinLimitOrders(
+		responses, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, operationType, price)
+}
+
+// FilterCrossingDAOCoinLimitOrders returns the orders a proposed coin1/coin2 order with the given side and price, in
+// coin2 per coin1, would match against: the orders on the other side at the proposed price or better. They're sorted
+// best price first, which is the order they'd be matched in. As in ComputeDAOCoinLimitOrderPlacement, prices are
+// compared exactly.
+func FilterCrossingDAOCoinLimitOrders(
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	operationType DAOCoinLimitOrderOperationTypeString,
+	price string,
+) ([]DAOCoinLimitOrderEntryResponse, error) {
+	proposedPrice, err := parsePositiveDecimalString(price)
+	if err != nil {
+		return nil, err
+	}
+	proposedIsBid := operationType == DAOCoinLimitOrderOperationTypeStringBID
+	if !proposedIsBid && operationType != DAOCoinLimitOrderOperationTypeStringASK {
+		return nil, errors.Errorf("Invalid operation type %v", operationType)
+	}
+
+	type crossingOrder struct {
+		order DAOCoinLimitOrderEntryResponse
+		price *big.Rat
+	}
+	var crossingOrders []crossingOrder
+	for _, order := range orders {
+		orderPrice, err := getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder(
+			order, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
 		if err != nil {
-			_AddBadRequestError(
-				ww,
-				fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Invalid BuyingCoinPublicKeyBase58Check: %v", err),
-			)
-			return
+			return nil, err
+		}
+		orderIsBid := isSameCoin(order.BuyingDAOCoinCreatorPublicKeyBase58Check, coin1PublicKeyBase58Check)
+		if orderIsBid == proposedIsBid {
+			continue
+		}
+		// A bid crosses asks at or below its price, and an ask crosses bids at or above its price.
+		cmp := orderPrice.Cmp(proposedPrice)
+		if (proposedIsBid && cmp <= 0) || (!proposedIsBid && cmp >= 0) {
+			crossingOrders = append(crossingOrders, crossingOrder{order: order, price: orderPrice})
 		}
 	}
-	var sellingCoinPkid *lib.PKID
-	if requestData.SellingCoinPublicKeyBase58Check != "" {
-		sellingCoinPkid, err = fes.getPKIDFromPublicKeyBase58CheckOrDESOString(
-			utxoView,
-			requestData.SellingCoinPublicKeyBase58Check,
-		)
-		if err != nil {
-			_AddBadRequestError(
-				ww,
-				fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Invalid SellingCoinPublicKeyBase58Check: %v", err),
+	sort.SliceStable(crossingOrders, func(ii, jj int) bool {
+		if proposedIsBid {
+			return crossingOrders[ii].price.Cmp(crossingOrders[jj].price) < 0
+		}
+		return crossingOrders[ii].price.Cmp(crossingOrders[jj].price) > 0
+	})
+
+	filteredOrders := []DAOCoinLimitOrderEntryResponse{}
+	for _, crossingOrder := range crossingOrders {
+		filteredOrders = append(filteredOrders, crossingOrder.order)
+	}
+	return filteredOrders, nil
+}
+
+// The maximum number of orders ValidateDAOCoinLimitOrdersBatch accepts in one request.
+const MaxDAOCoinLimitOrdersPerValidationBatch = 100
+
+type ValidateDAOCoinLimitOrdersBatchRequest struct {
+	TransactorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// The proposed orders, in the order they'll be submitted. Each order's TransactorPublicKeyBase58Check is ignored in
+	// favor of the one above.
+	Orders []DAOCoinLimitOrderCreationRequest `safeForLogging:"true"`
+}
+
+type DAOCoinLimitOrderValidationResult struct {
+	IsValid bool
+	// Why the order is invalid. Only set when IsValid is false.
+	Error string `json:",omitempty"`
+}
+
+type ValidateDAOCoinLimitOrdersBatchResponse struct {
+	AllValid bool
+	// One result per order, in the same order as the request.
+	Results []DAOCoinLimitOrderValidationResult
+}
+
+// ValidateDAOCoinLimitOrdersBatch checks a set of proposed limit orders before any of them is signed. Each order's
+// price, quantity, self-trade, transfer restriction, and selling balance are validated as though the valid orders
+// before it in the batch had already been placed, so an order that only fits on its own is reported as invalid.
+// Self-trades are only checked for orders that set Price.
+func (fes *APIServer) ValidateDAOCoinLimitOrdersBatch(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := ValidateDAOCoinLimitOrdersBatchRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("ValidateDAOCoinLimitOrdersBatch: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("ValidateDAOCoinLimitOrdersBatch: %v", err))
+		return
+	}
+	if len(requestData.Orders) == 0 || len(requestData.Orders) > MaxDAOCoinLimitOrdersPerValidationBatch {
+		_AddBadRequestError(ww, fmt.Sprintf("ValidateDAOCoinLimitOrdersBatch: Must provide between 1 and %v Orders",
+			MaxDAOCoinLimitOrdersPerValidationBatch))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("ValidateDAOCoinLimitOrdersBatch")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("ValidateDAOCoinLimitOrdersBatch: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	transactorPublicKeyBase58Check := requestData.TransactorPublicKeyBase58Check
+	results := validateDAOCoinLimitOrdersBatch(requestData.Orders,
+		func(order *DAOCoinLimitOrderCreationRequest, pendingSellingBaseUnits *uint256.Int) (*uint256.Int, error) {
+			_, _, scaledExchangeRateCoinsToSellPerCoinToBuy, quantityToFillInBaseUnits, err :=
+				parseDAOCoinLimitOrderCreationRequest(order)
+			if err != nil {
+				return nil, err
+			}
+
+			if order.Price != "" {
+				// Price is denominated in the coin the order's side refers to: the buying coin for a BID and the
+				// selling coin for an ASK. That coin is coin1 from the order book's point of view.
+				coin1, coin2 := order.BuyingDAOCoinCreatorPublicKeyBase58Check,
+					order.SellingDAOCoinCreatorPublicKeyBase58Check
+				if order.OperationType == DAOCoinLimitOrderOperationTypeStringASK {
+					coin1, coin2 = coin2, coin1
+				}
+				crossingOrders, err := fes.getTransactorCrossingDAOCoinLimitOrders(
+					utxoView, transactorPublicKeyBase58Check, coin1, coin2, order.OperationType, order.Price)
+				if err != nil {
+					return nil, err
+				}
+				if len(crossingOrders) > 0 {
+					return nil, errors.Errorf("Order would match %v of the transactor's own open orders",
+						len(crossingOrders))
+				}
+			}
+
+			if err = fes.validateDAOCoinOrderTransferRestriction(
+				transactorPublicKeyBase58Check, order.BuyingDAOCoinCreatorPublicKeyBase58Check); err != nil {
+				return nil, err
+			}
+
+			return fes.validateTransactorSellingCoinBalanceGivenPendingOrders(
+				transactorPublicKeyBase58Check,
+				order.BuyingDAOCoinCreatorPublicKeyBase58Check,
+				order.SellingDAOCoinCreatorPublicKeyBase58Check,
+				order.OperationType,
+				scaledExchangeRateCoinsToSellPerCoinToBuy,
+				quantityToFillInBaseUnits,
+				pendingSellingBaseUnits,
 			)
-			return
+		})
+
+	res := ValidateDAOCoinLimitOrdersBatchResponse{
+		AllValid: true,
+		Results:  results,
+	}
+	for _, result := range results {
+		res.AllValid = res.AllValid && result.IsValid
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("ValidateDAOCoinLimitOrdersBatch: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// validateDAOCoinLimitOrdersBatch runs validateOrder over the orders in sequence. validateOrder is passed the amount
+// of the order's selling coin committed by the valid orders before it, and returns the amount the order itself
+// commits. Invalid orders don't commit anything since they won't be submitted.
+func validateDAOCoinLimitOrdersBatch(
+	orders []DAOCoinLimitOrderCreationRequest,
+	validateOrder func(order *DAOCoinLimitOrderCreationRequest, pendingSellingBaseUnits *uint256.Int) (
+		_sellingBaseUnits *uint256.Int, _err error),
+) []DAOCoinLimitOrderValidationResult {
+	pendingSellingBaseUnitsByCoin := make(map[string]*uint256.Int)
+	results := []DAOCoinLimitOrderValidationResult{}
+	for ii := range orders {
+		order := orders[ii]
+		sellingCoin := order.SellingDAOCoinCreatorPublicKeyBase58Check
+		if IsDesoPkid(sellingCoin) {
+			sellingCoin = DESOCoinIdentifierString
+		}
+		pendingSellingBaseUnits, exists := pendingSellingBaseUnitsByCoin[sellingCoin]
+		if !exists {
+			pendingSellingBaseUnits = uint256.NewInt(0)
+		}
+
+		sellingBaseUnits, err := validateOrder(&order, pendingSellingBaseUnits)
+		if err == nil {
+			pendingSellingBaseUnits, err = lib.SafeUint256().Add(pendingSellingBaseUnits, sellingBaseUnits)
+		}
+		if err != nil {
+			results = append(results, DAOCoinLimitOrderValidationResult{IsValid: false, Error: err.Error()})
+			continue
 		}
+		pendingSellingBaseUnitsByCoin[sellingCoin] = pendingSellingBaseUnits
+		results = append(results, DAOCoinLimitOrderValidationResult{IsValid: true})
+	}
+	return results
+}
+
+// getDAOCoinLimitOrdersForCoinPair returns both sides of the order book for the given coin pair.
+func (fes *APIServer) getDAOCoinLimitOrdersForCoinPair(
+	utxoView *lib.UtxoView,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) ([]DAOCoinLimitOrderEntryResponse, error) {
+	coin1PKID, err := fes.getPKIDFromPublicKeyBase58CheckOrDESOString(utxoView, coin1PublicKeyBase58Check)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid coin1 public key")
+	}
+	coin2PKID, err := fes.getPKIDFromPublicKeyBase58CheckOrDESOString(utxoView, coin2PublicKeyBase58Check)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid coin2 public key")
+	}
+	return fes.getDAOCoinLimitOrdersForCoinPKIDs(
+		utxoView, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, coin1PKID, coin2PKID)
+}
+
+func (fes *APIServer) getDAOCoinLimitOrdersForCoinPKIDs(
+	utxoView *lib.UtxoView,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	coin1PKID *lib.PKID,
+	coin2PKID *lib.PKID,
+) ([]DAOCoinLimitOrderEntryResponse, error) {
+	ordersBuyingCoin1, err := utxoView.GetAllDAOCoinLimitOrdersForThisDAOCoinPair(coin1PKID, coin2PKID)
+	if err != nil {
+		return nil, err
 	}
 
-	orders, err := utxoView.GetAllDAOCoinLimitOrdersForThisTransactor(
-		transactorPKID, buyingCoinPkid, sellingCoinPkid)
+	ordersBuyingCoin2, err := utxoView.GetAllDAOCoinLimitOrdersForThisDAOCoinPair(coin2PKID, coin1PKID)
 	if err != nil {
-		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Error getting limit orders: %v", err))
-		return
+		return nil, err
 	}
 
-	responses := fes.buildDAOCoinLimitOrderResponsesForTransactor(utxoView, requestData.TransactorPublicKeyBase58Check, orders)
-
-	if err = json.NewEncoder(ww).Encode(GetDAOCoinLimitOrdersResponse{Orders: responses}); err != nil {
-		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorDAOCoinLimitOrders: Problem encoding response as JSON: %v", err))
-		return
-	}
+	return append(
+		fes.buildDAOCoinLimitOrderResponsesFromEntriesForCoinPair(
+			utxoView,
+			coin1PublicKeyBase58Check,
+			coin2PublicKeyBase58Check,
+			ordersBuyingCoin1,
+		),
+		fes.buildDAOCoinLimitOrderResponsesFromEntriesForCoinPair(
+			utxoView,
+			coin2PublicKeyBase58Check,
+			coin1PublicKeyBase58Check,
+			ordersBuyingCoin2,
+		)...,
+	), nil
 }
 
 func (fes *APIServer) getPKIDFromPublicKeyBase58CheckOrDESOString(
@@ -414,6 +3158,10 @@ func (fes *APIServer) getPKIDFromPublicKeyBase58Check(
 	utxoView *lib.UtxoView,
 	publicKeyBase58Check string,
 ) (*lib.PKID, error) {
+	// Callers that accept DESO in a coin position go through getPKIDFromPublicKeyBase58CheckOrDESOString instead.
+	if err := ValidateNotDESOIdentifier("PublicKeyBase58Check", publicKeyBase58Check); err != nil {
+		return nil, err
+	}
 	publicKeyBytes, err := GetPubKeyBytesFromBase58Check(publicKeyBase58Check)
 	if err != nil {
 		return nil, err
@@ -440,6 +3188,7 @@ func (fes *APIServer) buildDAOCoinLimitOrderResponsesFromEntriesForCoinPair(
 			buyingCoinPublicKeyBase58Check,
 			sellingCoinPublicKeyBase58Check,
 			order,
+			fes.skipDeprecatedDAOCoinLimitOrderFloatFields(),
 		)
 		if err != nil {
 			continue
@@ -467,6 +3216,7 @@ func (fes *APIServer) buildDAOCoinLimitOrderResponsesForTransactor(
 			buyingCoinPublicKeyBase58Check,
 			sellingCoinPublicKeyBase58Check,
 			order,
+			fes.skipDeprecatedDAOCoinLimitOrderFloatFields(),
 		)
 		if err != nil {
 			glog.Errorf(
@@ -490,11 +3240,20 @@ func (fes *APIServer) getPublicKeyBase58CheckOrCoinIdentifierForPKID(utxoView *l
 	return base58Check
 }
 
+// skipDeprecatedDAOCoinLimitOrderFloatFields is whether the node operator has turned off the deprecated float fields
+// of DAOCoinLimitOrderEntryResponse for every request.
+func (fes *APIServer) skipDeprecatedDAOCoinLimitOrderFloatFields() bool {
+	return fes.Config != nil && fes.Config.SkipDeprecatedDAOCoinLimitOrderFloatFields
+}
+
+// buildDAOCoinLimitOrderResponse converts an order entry to its API representation. If skipDeprecatedFloatFields is
+// set, ExchangeRateCoinsToSellPerCoinToBuy and QuantityToFill aren't computed and are left out of the JSON.
 func buildDAOCoinLimitOrderResponse(
 	transactorPublicKeyBase58Check string,
 	buyingCoinPublicKeyBase58Check string,
 	sellingCoinPublicKeyBase58Check string,
 	order *lib.DAOCoinLimitOrderEntry,
+	skipDeprecatedFloatFields bool,
 ) (*DAOCoinLimitOrderEntryResponse, error) {
 	// It should not be possible to hit errors in this function. If we do hit them, it means an order with invalid
 	// values made it through all validations during order creation, and was placed on the book. In
@@ -524,30 +3283,35 @@ func buildDAOCoinLimitOrderResponse(
 		return nil, err
 	}
 
-	exchangeRate, err := CalculateFloatFromScaledExchangeRate(
-		buyingCoinPublicKeyBase58Check,
-		sellingCoinPublicKeyBase58Check,
-		order.ScaledExchangeRateCoinsToSellPerCoinToBuy,
-	)
-	if err != nil {
-		return nil, err
-	}
+	var exchangeRate, quantityToFill float64
+	if !skipDeprecatedFloatFields {
+		exchangeRate, err = CalculateFloatFromScaledExchangeRate(
+			buyingCoinPublicKeyBase58Check,
+			sellingCoinPublicKeyBase58Check,
+			order.ScaledExchangeRateCoinsToSellPerCoinToBuy,
+		)
+		if err != nil {
+			return nil, err
+		}
 
-	quantityToFill, err := CalculateFloatQuantityFromBaseUnits(
-		buyingCoinPublicKeyBase58Check,
-		sellingCoinPublicKeyBase58Check,
-		operationTypeString,
-		order.QuantityToFillInBaseUnits,
-	)
-	if err != nil {
-		return nil, err
+		quantityToFill, err = CalculateFloatQuantityFromBaseUnits(
+			buyingCoinPublicKeyBase58Check,
+			sellingCoinPublicKeyBase58Check,
+			operationTypeString,
+			order.QuantityToFillInBaseUnits,
+		)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// We always want to return the identifier string for DESO coins in the API response
-	if IsDesoPkid(buyingCoinPublicKeyBase58Check) {
+	buyingCoinIsDESO := IsDesoPkid(buyingCoinPublicKeyBase58Check)
+	if buyingCoinIsDESO {
 		buyingCoinPublicKeyBase58Check = DESOCoinIdentifierString
 	}
-	if IsDesoPkid(sellingCoinPublicKeyBase58Check) {
+	sellingCoinIsDESO := IsDesoPkid(sellingCoinPublicKeyBase58Check)
+	if sellingCoinIsDESO {
 		sellingCoinPublicKeyBase58Check = DESOCoinIdentifierString
 	}
 
@@ -557,6 +3321,12 @@ func buildDAOCoinLimitOrderResponse(
 		BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoinPublicKeyBase58Check,
 		SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoinPublicKeyBase58Check,
 
+		BuyingCoinIsDESO:  buyingCoinIsDESO,
+		SellingCoinIsDESO: sellingCoinIsDESO,
+
+		BuyingCoinDecimals:  getCoinDecimals(buyingCoinIsDESO),
+		SellingCoinDecimals: getCoinDecimals(sellingCoinIsDESO),
+
 		Price:    price,
 		Quantity: quantity,
 
@@ -566,6 +3336,8 @@ func buildDAOCoinLimitOrderResponse(
 		OperationType: operationTypeString,
 
 		OrderID: order.OrderID.String(),
+
+		omitDeprecatedFloatFields: skipDeprecatedFloatFields,
 	}, nil
 }
 
@@ -977,6 +3749,17 @@ func IsDesoPkid(pk string) bool {
 		pk == DeSoZeroPkidTestnetBase58)
 }
 
+// ValidateNotDESOIdentifier rejects the DESO identifier in a field that must hold a real user's public key, such as a
+// transactor or an access group owner. The identifier is only meaningful as a buying or selling coin, and without this
+// check it surfaces later as a confusing base58 decode error.
+func ValidateNotDESOIdentifier(fieldName string, publicKeyBase58Check string) error {
+	if IsDesoPkid(publicKeyBase58Check) {
+		return errors.Errorf("%v must be a user public key, but got the DESO identifier %v. DESO is only "+
+			"valid as a buying or selling coin", fieldName, publicKeyBase58Check)
+	}
+	return nil
+}
+
 // given a buying coin, selling coin, and operation type, this determines if the QuantityToFill field
 // for the coin the quantity field refers to is $DESO. If it's not $DESO, then it's assumed to be a DAO coin
 func isCoinToFillDESO(
@@ -1113,24 +3896,49 @@ func (fes *APIServer) validateTransactorSellingCoinBalance(
 	operationType DAOCoinLimitOrderOperationTypeString,
 	scaledExchangeRateCoinsToSellPerCoinToBuy *uint256.Int,
 	quantityToFillInBaseUnits *uint256.Int) error {
+	_, err := fes.validateTransactorSellingCoinBalanceGivenPendingOrders(
+		transactorPublicKeyBase58Check,
+		buyingDAOCoinCreatorPublicKeyBase58Check,
+		sellingDAOCoinCreatorPublicKeyBase58Check,
+		operationType,
+		scaledExchangeRateCoinsToSellPerCoinToBuy,
+		quantityToFillInBaseUnits,
+		uint256.NewInt(0),
+	)
+	return err
+}
+
+// validateTransactorSellingCoinBalanceGivenPendingOrders is validateTransactorSellingCoinBalance for an order that
+// will be placed after other orders that haven't been submitted yet. pendingSellingBaseUnits is the amount of the
+// selling coin those orders commit, which is treated as unavailable. Returns the amount of the selling coin this
+// order commits so callers can carry it into the next order.
+func (fes *APIServer) validateTransactorSellingCoinBalanceGivenPendingOrders(
+	transactorPublicKeyBase58Check string,
+	buyingDAOCoinCreatorPublicKeyBase58Check string,
+	sellingDAOCoinCreatorPublicKeyBase58Check string,
+	operationType DAOCoinLimitOrderOperationTypeString,
+	scaledExchangeRateCoinsToSellPerCoinToBuy *uint256.Int,
+	quantityToFillInBaseUnits *uint256.Int,
+	pendingSellingBaseUnits *uint256.Int,
+) (_sellingBaseUnits *uint256.Int, _err error) {
 	// Validate transactor has sufficient selling coins to place
 	// this new order incorporating all of their open orders.
 
 	// Get UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("validateTransactorSellingCoinBalanceGivenPendingOrders")
 	if err != nil {
-		return errors.Errorf("Problem fetching UTXOView: %v", err)
+		return nil, errors.Errorf("Problem fetching UTXOView: %v", err)
 	}
 
 	// Get transactor PKID and public key from public key base58 check.
 	transactorPKID, err := fes.getPKIDFromPublicKeyBase58Check(
 		utxoView, transactorPublicKeyBase58Check)
 	if err != nil {
-		return errors.Errorf("Invalid TransactorPublicKeyBase58Check: %v", err)
+		return nil, errors.Errorf("Invalid TransactorPublicKeyBase58Check: %v", err)
 	}
 	transactorPublicKey, _, err := lib.Base58CheckDecode(transactorPublicKeyBase58Check)
 	if err != nil {
-		return errors.Errorf("Error decoding transactor public key: %v", err)
+		return nil, errors.Errorf("Error decoding transactor public key: %v", err)
 	}
 
 	// If buying $DESO, the buying PKID is the ZeroPKID. Else it's the DAO coin's PKID.
@@ -1139,7 +3947,7 @@ func (fes *APIServer) validateTransactorSellingCoinBalance(
 		buyingCoinPKID, err = fes.getPKIDFromPublicKeyBase58Check(
 			utxoView, buyingDAOCoinCreatorPublicKeyBase58Check)
 		if err != nil {
-			return errors.Errorf("Invalid BuyingDAOCoinCreatorPublicKeyBase58Check: %v", err)
+			return nil, errors.Errorf("Invalid BuyingDAOCoinCreatorPublicKeyBase58Check: %v", err)
 		}
 	}
 
@@ -1153,7 +3961,7 @@ func (fes *APIServer) validateTransactorSellingCoinBalance(
 		// Get $DESO balance nanos.
 		desoBalanceNanos, err := utxoView.GetDeSoBalanceNanosForPublicKey(transactorPublicKey)
 		if err != nil {
-			return errors.Errorf("Error getting transactor DESO balance: %v", err)
+			return nil, errors.Errorf("Error getting transactor DESO balance: %v", err)
 		}
 		transactorSellingBalanceBaseUnits = uint256.NewInt(desoBalanceNanos)
 	} else {
@@ -1161,17 +3969,17 @@ func (fes *APIServer) validateTransactorSellingCoinBalance(
 		sellingCoinPKID, err = fes.getPKIDFromPublicKeyBase58Check(
 			utxoView, sellingDAOCoinCreatorPublicKeyBase58Check)
 		if err != nil {
-			return errors.Errorf("Invalid SellingDAOCoinCreatorPublicKeyBase58Check: %v", err)
+			return nil, errors.Errorf("Invalid SellingDAOCoinCreatorPublicKeyBase58Check: %v", err)
 		}
 		sellingPublicKey, _, err := lib.Base58CheckDecode(sellingDAOCoinCreatorPublicKeyBase58Check)
 		if err != nil {
-			return errors.Errorf("Error decoding selling public key: %v", err)
+			return nil, errors.Errorf("Error decoding selling public key: %v", err)
 		}
 
 		// Get DAO coin balance base units.
 		balanceEntry, _, _ := utxoView.GetBalanceEntryForHODLerPubKeyAndCreatorPubKey(transactorPublicKey, sellingPublicKey, true)
 		if balanceEntry == nil || balanceEntry.IsDeleted() {
-			return errors.New("Error getting transactor DAO coin balance not found")
+			return nil, errors.New("Error getting transactor DAO coin balance not found")
 		}
 		transactorSellingBalanceBaseUnits = &balanceEntry.BalanceNanos
 	}
@@ -1179,7 +3987,7 @@ func (fes *APIServer) validateTransactorSellingCoinBalance(
 	// Get open orders for this transactor
 	orders, err := utxoView.GetAllDAOCoinLimitOrdersForThisTransactor(transactorPKID, nil, nil)
 	if err != nil {
-		return errors.Errorf("Error getting limit orders: %v", err)
+		return nil, errors.Errorf("Error getting limit orders: %v", err)
 	}
 
 	// Calculate total selling quantity for current order.
@@ -1190,38 +3998,415 @@ func (fes *APIServer) validateTransactorSellingCoinBalance(
 		totalSellingBaseUnits, err = lib.ComputeBaseUnitsToSellUint256(
 			scaledExchangeRateCoinsToSellPerCoinToBuy, quantityToFillInBaseUnits)
 		if err != nil {
-			return errors.Errorf("Error calculating new order selling quantity: %v", err)
+			return nil, errors.Errorf("Error calculating new order selling quantity: %v", err)
 		}
 	} else {
-		return errors.Errorf("Invalid operation type: %s", operationType)
+		return nil, errors.Errorf("Invalid operation type: %s", operationType)
 	}
 
-	// Add total selling quantity for existing/open orders.
-	for _, order := range orders {
-		if buyingCoinPKID.Eq(order.BuyingDAOCoinCreatorPKID) &&
-			sellingCoinPKID.Eq(order.SellingDAOCoinCreatorPKID) {
-			// Calculate selling quantity.
-			orderSellingBaseUnits, err := order.BaseUnitsToSellUint256()
-			if err != nil {
-				return errors.Errorf("Error calculating open order selling quantity: %v", err)
-			}
+	newOrderSellingBaseUnits := totalSellingBaseUnits
 
-			// Sum selling quantity.
-			totalSellingBaseUnits, err = lib.SafeUint256().Add(totalSellingBaseUnits, orderSellingBaseUnits)
-			if err != nil {
-				return errors.Errorf("Error adding open order selling quantity: %v", err)
-			}
-		}
+	// Add the selling quantity committed by pending orders that will be placed first.
+	totalSellingBaseUnits, err = lib.SafeUint256().Add(totalSellingBaseUnits, pendingSellingBaseUnits)
+	if err != nil {
+		return nil, errors.Errorf("Error adding pending order selling quantity: %v", err)
+	}
+
+	// Add total selling quantity for existing/open orders.
+	openOrdersSellingBaseUnits, err := SumDAOCoinLimitOrderSellingBaseUnits(orders, sellingCoinPKID, buyingCoinPKID)
+	if err != nil {
+		return nil, err
+	}
+	totalSellingBaseUnits, err = lib.SafeUint256().Add(totalSellingBaseUnits, openOrdersSellingBaseUnits)
+	if err != nil {
+		return nil, errors.Errorf("Error adding open order selling quantity: %v", err)
 	}
 
 	// Compare transactor selling balance to total selling quantity.
 	if transactorSellingBalanceBaseUnits.Lt(totalSellingBaseUnits) {
-		return errors.Errorf("Insufficient balance to open order: Need %v but have %v",
+		return nil, errors.Errorf("Insufficient balance to open order: Need %v but have %v",
 			totalSellingBaseUnits, transactorSellingBalanceBaseUnits)
 	}
 
 	// Happy path. No error. Transactor has sufficient balance to cover their selling quantity.
-	return nil
+	return newOrderSellingBaseUnits, nil
+}
+
+// SumDAOCoinLimitOrderSellingBaseUnits returns the total amount of the selling coin committed by the orders that sell
+// sellingCoinPKID. If buyingCoinPKID is non-nil, only the orders buying that coin are counted.
+func SumDAOCoinLimitOrderSellingBaseUnits(
+	orders []*lib.DAOCoinLimitOrderEntry,
+	sellingCoinPKID *lib.PKID,
+	buyingCoinPKID *lib.PKID,
+) (*uint256.Int, error) {
+	totalSellingBaseUnits := uint256.NewInt(0)
+	for _, order := range orders {
+		if !sellingCoinPKID.Eq(order.SellingDAOCoinCreatorPKID) ||
+			(buyingCoinPKID != nil && !buyingCoinPKID.Eq(order.BuyingDAOCoinCreatorPKID)) {
+			continue
+		}
+		// Calculate selling quantity.
+		orderSellingBaseUnits, err := order.BaseUnitsToSellUint256()
+		if err != nil {
+			return nil, errors.Errorf("Error calculating open order selling quantity: %v", err)
+		}
+
+		// Sum selling quantity.
+		totalSellingBaseUnits, err = lib.SafeUint256().Add(totalSellingBaseUnits, orderSellingBaseUnits)
+		if err != nil {
+			return nil, errors.Errorf("Error adding open order selling quantity: %v", err)
+		}
+	}
+	return totalSellingBaseUnits, nil
+}
+
+type GetAvailableBalanceForOrderRequest struct {
+	TransactorPublicKeyBase58Check string `safeForLogging:"true"`
+	// The coin the proposed order would sell. Either a DAO coin creator's public key or DESO.
+	SellingDAOCoinCreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetAvailableBalanceForOrderResponse struct {
+	// The transactor's balance of the selling coin. The BaseUnits fields are base-10 strings of the amounts in base
+	// units, and the others are decimal strings in whole coins.
+	TotalBalanceBaseUnits string `safeForLogging:"true"`
+	TotalBalance          string `safeForLogging:"true"`
+	// The amount of the selling coin committed to the transactor's open orders selling it, for any buying coin.
+	CommittedToOpenOrdersBaseUnits string `safeForLogging:"true"`
+	CommittedToOpenOrders          string `safeForLogging:"true"`
+	// The balance left for a new order: TotalBalance minus CommittedToOpenOrders, or zero if the open orders already
+	// commit more than the balance.
+	AvailableBalanceBaseUnits string `safeForLogging:"true"`
+	AvailableBalance          string `safeForLogging:"true"`
+}
+
+// GetAvailableBalanceForOrder returns how much of a coin a transactor can still sell in a new order once their open
+// orders selling it are accounted for, so UIs can show it before the order is placed.
+func (fes *APIServer) GetAvailableBalanceForOrder(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetAvailableBalanceForOrderRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetAvailableBalanceForOrder: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier(
+		"TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetAvailableBalanceForOrder: %v", err))
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetAvailableBalanceForOrder: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetAvailableBalanceForOrder")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetAvailableBalanceForOrder: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	transactorPKID, err := fes.getPKIDFromPublicKeyBase58Check(utxoView, requestData.TransactorPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetAvailableBalanceForOrder: Invalid TransactorPublicKeyBase58Check: %v", err))
+		return
+	}
+	sellingCoinPKID, err := fes.getPKIDFromPublicKeyBase58CheckOrDESOString(
+		utxoView, requestData.SellingDAOCoinCreatorPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetAvailableBalanceForOrder: Invalid "+
+			"SellingDAOCoinCreatorPublicKeyBase58Check: %v", err))
+		return
+	}
+
+	totalBalanceBaseUnits, committedBaseUnits, err := fes.getTransactorBalanceAndCommittedBaseUnits(
+		utxoView, requestData.TransactorPublicKeyBase58Check, transactorPKID,
+		requestData.SellingDAOCoinCreatorPublicKeyBase58Check, sellingCoinPKID)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetAvailableBalanceForOrder: %v", err))
+		return
+	}
+
+	res, err := buildAvailableBalanceForOrderResponse(
+		requestData.SellingDAOCoinCreatorPublicKeyBase58Check, totalBalanceBaseUnits, committedBaseUnits)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetAvailableBalanceForOrder: %v", err))
+		return
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetAvailableBalanceForOrder: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getTransactorBalanceAndCommittedBaseUnits returns the transactor's balance of the selling coin and the amount of it
+// committed to their open orders selling it, for any buying coin.
+func (fes *APIServer) getTransactorBalanceAndCommittedBaseUnits(
+	utxoView *lib.UtxoView,
+	transactorPublicKeyBase58Check string,
+	transactorPKID *lib.PKID,
+	sellingCoinPublicKeyBase58Check string,
+	sellingCoinPKID *lib.PKID,
+) (_totalBalanceBaseUnits *uint256.Int, _committedBaseUnits *uint256.Int, _err error) {
+	totalBalanceBaseUnits, err := fes.getTransactorDesoOrDaoCoinBalance(
+		utxoView, transactorPublicKeyBase58Check, sellingCoinPublicKeyBase58Check)
+	if err != nil {
+		return nil, nil, err
+	}
+	orders, err := utxoView.GetAllDAOCoinLimitOrdersForThisTransactor(transactorPKID, nil, sellingCoinPKID)
+	if err != nil {
+		return nil, nil, errors.Errorf("Error getting limit orders: %v", err)
+	}
+	committedBaseUnits, err := SumDAOCoinLimitOrderSellingBaseUnits(orders, sellingCoinPKID, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return totalBalanceBaseUnits, committedBaseUnits, nil
+}
+
+// getAvailableBaseUnits is totalBalanceBaseUnits minus committedBaseUnits, or zero if the open orders already commit
+// more than the balance.
+func getAvailableBaseUnits(totalBalanceBaseUnits *uint256.Int, committedBaseUnits *uint256.Int) *uint256.Int {
+	if committedBaseUnits.Lt(totalBalanceBaseUnits) {
+		return new(uint256.Int).Sub(totalBalanceBaseUnits, committedBaseUnits)
+	}
+	return uint256.NewInt(0)
+}
+
+// buildAvailableBalanceForOrderResponse formats the transactor's balance of the selling coin and the amount their open
+// orders commit, along with what's left available.
+func buildAvailableBalanceForOrderResponse(
+	sellingCoinPublicKeyBase58Check string,
+	totalBalanceBaseUnits *uint256.Int,
+	committedBaseUnits *uint256.Int,
+) (*GetAvailableBalanceForOrderResponse, error) {
+	availableBaseUnits := getAvailableBaseUnits(totalBalanceBaseUnits, committedBaseUnits)
+	res := &GetAvailableBalanceForOrderResponse{
+		TotalBalanceBaseUnits:          totalBalanceBaseUnits.ToBig().Text(10),
+		CommittedToOpenOrdersBaseUnits: committedBaseUnits.ToBig().Text(10),
+		AvailableBalanceBaseUnits:      availableBaseUnits.ToBig().Text(10),
+	}
+	var err error
+	if res.TotalBalance, err = CalculateStringDecimalAmountFromBaseUnitsSimple(
+		sellingCoinPublicKeyBase58Check, totalBalanceBaseUnits); err != nil {
+		return nil, err
+	}
+	if res.CommittedToOpenOrders, err = CalculateStringDecimalAmountFromBaseUnitsSimple(
+		sellingCoinPublicKeyBase58Check, committedBaseUnits); err != nil {
+		return nil, err
+	}
+	if res.AvailableBalance, err = CalculateStringDecimalAmountFromBaseUnitsSimple(
+		sellingCoinPublicKeyBase58Check, availableBaseUnits); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+type GetBestAffordableOrderRequest struct {
+	ViewerPublicKeyBase58Check string `safeForLogging:"true"`
+
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	// BID if the viewer wants to buy coin1 with coin2, in which case the asks are searched, or ASK if they want to
+	// sell coin1 for coin2, in which case the bids are.
+	OperationType DAOCoinLimitOrderOperationTypeString `safeForLogging:"true"`
+
+	// If unset, defaults to TxnStatusInMempool.
+	TxnStatus TxnStatus `safeForLogging:"true"`
+}
+
+type GetBestAffordableOrderResponse struct {
+	// Whether any order on the opposing side of the book is affordable. If not, Order is nil and the cost fields are
+	// empty.
+	HasAffordableOrder bool `safeForLogging:"true"`
+	// The best-priced opposing order the viewer can fully match.
+	Order *DAOCoinLimitOrderEntryResponse `safeForLogging:"true"`
+
+	// The amount of the viewer's selling coin, coin2 for a BID and coin1 for an ASK, it takes to fully match Order.
+	// The BaseUnits fields are base-10 strings of the amounts in base units, and the others are decimal strings in
+	// whole coins.
+	CostToFillBaseUnits string `safeForLogging:"true"`
+	CostToFill          string `safeForLogging:"true"`
+	// The viewer's balance of their selling coin net of their open orders selling it. See GetAvailableBalanceForOrder.
+	AvailableBalanceBaseUnits string `safeForLogging:"true"`
+	AvailableBalance          string `safeForLogging:"true"`
+}
+
+// GetBestAffordableOrder walks the side of the coin1/coin2 book opposing the viewer from the best price and returns
+// the first order the viewer has enough of their selling coin to fully match, so takers with a limited balance can
+// pick an order they can actually fill. Trading fees aren't included.
+func (fes *APIServer) GetBestAffordableOrder(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetBestAffordableOrderRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetBestAffordableOrder: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("ViewerPublicKeyBase58Check", requestData.ViewerPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetBestAffordableOrder: %v", err))
+		return
+	}
+
+	if IsDesoPkid(requestData.DAOCoin1CreatorPublicKeyBase58Check) &&
+		IsDesoPkid(requestData.DAOCoin2CreatorPublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetBestAffordableOrder: Must provide either a "+
+			"DAOCoin1CreatorPublicKeyBase58Check or DAOCoin2CreatorPublicKeyBase58Check or both")
+		return
+	}
+
+	if requestData.OperationType != DAOCoinLimitOrderOperationTypeStringBID &&
+		requestData.OperationType != DAOCoinLimitOrderOperationTypeStringASK {
+		_AddBadRequestError(ww, fmt.Sprintf("GetBestAffordableOrder: Invalid OperationType %v. Options are {%v, %v}",
+			requestData.OperationType, DAOCoinLimitOrderOperationTypeStringBID, DAOCoinLimitOrderOperationTypeStringASK))
+		return
+	}
+
+	txnStatus := requestData.TxnStatus
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	if txnStatus != TxnStatusInMempool && txnStatus != TxnStatusCommitted {
+		_AddBadRequestError(ww, fmt.Sprintf("GetBestAffordableOrder: Invalid TxnStatus: %v. Options "+
+			"are {InMempool, Committed}.", txnStatus))
+		return
+	}
+
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetBestAffordableOrder")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetBestAffordableOrder: Problem fetching utxoView: %v", err))
+		return
+	}
+
+	// A buyer of coin1 pays in coin2, and a seller pays in coin1.
+	viewerIsBuyingCoin1 := requestData.OperationType == DAOCoinLimitOrderOperationTypeStringBID
+	sellingCoinPublicKeyBase58Check := requestData.DAOCoin2CreatorPublicKeyBase58Check
+	if !viewerIsBuyingCoin1 {
+		sellingCoinPublicKeyBase58Check = requestData.DAOCoin1CreatorPublicKeyBase58Check
+	}
+
+	viewerPKID, err := fes.getPKIDFromPublicKeyBase58Check(utxoView, requestData.ViewerPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetBestAffordableOrder: Invalid ViewerPublicKeyBase58Check: %v", err))
+		return
+	}
+	sellingCoinPKID, err := fes.getPKIDFromPublicKeyBase58CheckOrDESOString(utxoView, sellingCoinPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetBestAffordableOrder: Invalid selling coin public key: %v", err))
+		return
+	}
+	totalBalanceBaseUnits, committedBaseUnits, err := fes.getTransactorBalanceAndCommittedBaseUnits(
+		utxoView, requestData.ViewerPublicKeyBase58Check, viewerPKID, sellingCoinPublicKeyBase58Check, sellingCoinPKID)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetBestAffordableOrder: %v", err))
+		return
+	}
+
+	orders, err := fes.getDAOCoinLimitOrdersForCoinPair(
+		utxoView, requestData.DAOCoin1CreatorPublicKeyBase58Check, requestData.DAOCoin2CreatorPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetBestAffordableOrder: Error getting limit orders: %v", err))
+		return
+	}
+
+	res, err := FindBestAffordableDAOCoinLimitOrder(
+		orders,
+		requestData.ViewerPublicKeyBase58Check,
+		requestData.DAOCoin1CreatorPublicKeyBase58Check,
+		requestData.DAOCoin2CreatorPublicKeyBase58Check,
+		viewerIsBuyingCoin1,
+		getAvailableBaseUnits(totalBalanceBaseUnits, committedBaseUnits),
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetBestAffordableOrder: Problem finding order: %v", err))
+		return
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetBestAffordableOrder: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// FindBestAffordableDAOCoinLimitOrder walks the coin1/coin2 orders a viewer buying coin1, or selling it if
+// viewerIsBuyingCoin1 is false, would fill against from the best price, and returns the first one that costs no more
+// than availableBaseUnits of the viewer's selling coin to fully match. The viewer's own orders are skipped, since
+// matching them would be a self-trade. Costs that come to a fraction of a base unit round up.
+func FindBestAffordableDAOCoinLimitOrder(
+	orders []DAOCoinLimitOrderEntryResponse,
+	viewerPublicKeyBase58Check string,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	viewerIsBuyingCoin1 bool,
+	availableBaseUnits *uint256.Int,
+) (*GetBestAffordableOrderResponse, error) {
+	levels, err := getDAOCoinLimitOrderBookLevelsForTaker(
+		orders, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, viewerIsBuyingCoin1)
+	if err != nil {
+		return nil, err
+	}
+
+	// Levels are in coin1 base units. A buyer pays for them in coin2, so they're converted at the level's price and
+	// rescaled to coin2 base units.
+	sellingCoinPublicKeyBase58Check := coin1PublicKeyBase58Check
+	coin1BaseUnitsToSellingBaseUnits := big.NewRat(1, 1)
+	if viewerIsBuyingCoin1 {
+		sellingCoinPublicKeyBase58Check = coin2PublicKeyBase58Check
+		coin1BaseUnitsToSellingBaseUnits = new(big.Rat).SetFrac(
+			getCoinScalingFactor(coin2PublicKeyBase58Check), getCoinScalingFactor(coin1PublicKeyBase58Check))
+	}
+
+	res := &GetBestAffordableOrderResponse{
+		AvailableBalanceBaseUnits: availableBaseUnits.ToBig().Text(10),
+	}
+	if res.AvailableBalance, err = CalculateStringDecimalAmountFromBaseUnitsSimple(
+		sellingCoinPublicKeyBase58Check, availableBaseUnits); err != nil {
+		return nil, err
+	}
+	for _, level := range levels {
+		if level.order.TransactorPublicKeyBase58Check == viewerPublicKeyBase58Check {
+			continue
+		}
+		costRat := new(big.Rat).Mul(level.quantityBaseUnits, coin1BaseUnitsToSellingBaseUnits)
+		if viewerIsBuyingCoin1 {
+			costRat.Mul(costRat, level.price)
+		}
+		costBig := new(big.Int).Quo(costRat.Num(), costRat.Denom())
+		if !costRat.IsInt() {
+			costBig.Add(costBig, big.NewInt(1))
+		}
+		if costBig.Cmp(availableBaseUnits.ToBig()) > 0 {
+			continue
+		}
+		// costBig is at most availableBaseUnits, so it can't overflow.
+		costBaseUnits, _ := uint256.FromBig(costBig)
+		if res.CostToFill, err = CalculateStringDecimalAmountFromBaseUnitsSimple(
+			sellingCoinPublicKeyBase58Check, costBaseUnits); err != nil {
+			return nil, err
+		}
+		res.HasAffordableOrder = true
+		res.Order = level.order
+		res.CostToFillBaseUnits = costBig.Text(10)
+		return res, nil
+	}
+	return res, nil
+}
+
+// getCoinScalingFactor returns the number of base units per whole coin: NanosPerUnit for $DESO and BaseUnitsPerCoin
+// for DAO coins.
+func getCoinScalingFactor(publicKeyBase58Check string) *big.Int {
+	if IsDesoPkid(publicKeyBase58Check) {
+		return big.NewInt(int64(lib.NanosPerUnit))
+	}
+	return lib.BaseUnitsPerCoin.ToBig()
 }
 
 func (fes *APIServer) validateDAOCoinOrderTransferRestriction(
@@ -1234,7 +4419,7 @@ func (fes *APIServer) validateDAOCoinOrderTransferRestriction(
 	}
 
 	// Get UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("validateDAOCoinOrderTransferRestriction")
 	if err != nil {
 		return errors.Errorf("Problem fetching UTXOView: %v", err)
 	}
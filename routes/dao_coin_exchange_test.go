@@ -1,10 +1,17 @@
 package routes
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"github.com/deso-protocol/backend/config"
 	"github.com/deso-protocol/core/lib"
 	"github.com/deso-protocol/uint256"
 	"github.com/stretchr/testify/require"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sort"
 	"testing"
 )
 
@@ -551,3 +558,1584 @@ func TestCalculateStringQuantityFromBaseUnits(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestComputeDAOCoinOrderBookChecksum(t *testing.T) {
+	orders := []DAOCoinLimitOrderEntryResponse{
+		{OrderID: "order1", Quantity: "1.0"},
+		{OrderID: "order2", Quantity: "2.5"},
+	}
+
+	// Identical books produce identical checksums, regardless of the order in which orders are returned.
+	checksum := ComputeDAOCoinOrderBookChecksum(orders)
+	require.Equal(t, checksum, ComputeDAOCoinOrderBookChecksum([]DAOCoinLimitOrderEntryResponse{orders[1], orders[0]}))
+
+	// A partially filled order changes the checksum.
+	require.NotEqual(t, checksum, ComputeDAOCoinOrderBookChecksum([]DAOCoinLimitOrderEntryResponse{
+		{OrderID: "order1", Quantity: "0.5"},
+		{OrderID: "order2", Quantity: "2.5"},
+	}))
+
+	// A removed order changes the checksum.
+	require.NotEqual(t, checksum, ComputeDAOCoinOrderBookChecksum(orders[:1]))
+}
+
+func TestAddUsernamesToDAOCoinLimitOrders(t *testing.T) {
+	apiServer := newTestApiServer(t)
+
+	// Give the sender a profile. The recipient has none.
+	{
+		body := &UpdateProfileRequest{
+			UpdaterPublicKeyBase58Check: senderPkString,
+			NewUsername:                 "sender",
+			NewStakeMultipleBasisPoints: 1e5,
+			MinFeeRateNanosPerKB:        apiServer.MinFeeRateNanosPerKB,
+		}
+		bodyJSON, err := json.Marshal(body)
+		require.NoError(t, err)
+		request, _ := http.NewRequest("POST", RoutePathUpdateProfile, bytes.NewBuffer(bodyJSON))
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		apiServer.router.ServeHTTP(response, request)
+		require.NotContains(t, string(response.Body.Bytes()), "error")
+
+		updateProfileResponse := UpdateProfileResponse{}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), &updateProfileResponse))
+		txn := updateProfileResponse.Transaction
+		signTxn(t, txn, senderPrivString)
+		_, err = submitTxn(t, apiServer, txn)
+		require.NoError(t, err)
+	}
+
+	utxoView, err := apiServer.GetUtxoViewGivenTxnStatus(TxnStatusInMempool, "test")
+	require.NoError(t, err)
+
+	orders := []DAOCoinLimitOrderEntryResponse{
+		{
+			TransactorPublicKeyBase58Check:            recipientPkString,
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  senderPkString,
+			SellingDAOCoinCreatorPublicKeyBase58Check: DESOCoinIdentifierString,
+		},
+		{
+			TransactorPublicKeyBase58Check:            senderPkString,
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  DESOCoinIdentifierString,
+			SellingDAOCoinCreatorPublicKeyBase58Check: senderPkString,
+		},
+	}
+	ordersWithUsernames := apiServer.addUsernamesToDAOCoinLimitOrders(utxoView, orders)
+
+	// Keys without a profile and $DESO are left empty.
+	require.Equal(t, "", ordersWithUsernames[0].TransactorUsername)
+	require.Equal(t, "sender", ordersWithUsernames[0].BuyingDAOCoinUsername)
+	require.Equal(t, "", ordersWithUsernames[0].SellingDAOCoinUsername)
+	require.Equal(t, "sender", ordersWithUsernames[1].TransactorUsername)
+	require.Equal(t, "", ordersWithUsernames[1].BuyingDAOCoinUsername)
+	require.Equal(t, "sender", ordersWithUsernames[1].SellingDAOCoinUsername)
+
+	// The input orders are not modified.
+	require.Equal(t, "", orders[0].BuyingDAOCoinUsername)
+}
+
+func TestBuildDAOCoinLimitOrderResponseDESOSide(t *testing.T) {
+	order := &lib.DAOCoinLimitOrderEntry{
+		OrderID:       &lib.BlockHash{0x01},
+		OperationType: lib.DAOCoinLimitOrderOperationTypeBID,
+		ScaledExchangeRateCoinsToSellPerCoinToBuy: lib.OneE38,
+		QuantityToFillInBaseUnits:                 uint256.NewInt(1e9),
+	}
+
+	// $DESO on the buying side. The zero PKID public key is reported as DESO.
+	{
+		response, err := buildDAOCoinLimitOrderResponse(
+			senderPkString, DeSoZeroPkidTestnetBase58, daoCoinPubKeyBase58Check, order, false)
+		require.NoError(t, err)
+		require.True(t, response.BuyingCoinIsDESO)
+		require.False(t, response.SellingCoinIsDESO)
+		require.Equal(t, DESOCoinIdentifierString, response.BuyingDAOCoinCreatorPublicKeyBase58Check)
+		require.Equal(t, 9, response.BuyingCoinDecimals)
+		require.Equal(t, 18, response.SellingCoinDecimals)
+	}
+
+	// $DESO on the selling side.
+	{
+		response, err := buildDAOCoinLimitOrderResponse(
+			senderPkString, daoCoinPubKeyBase58Check, DESOCoinIdentifierString, order, false)
+		require.NoError(t, err)
+		require.False(t, response.BuyingCoinIsDESO)
+		require.True(t, response.SellingCoinIsDESO)
+		require.Equal(t, 18, response.BuyingCoinDecimals)
+		require.Equal(t, 9, response.SellingCoinDecimals)
+	}
+
+	// DAO coin to DAO coin.
+	{
+		response, err := buildDAOCoinLimitOrderResponse(
+			senderPkString, daoCoinPubKeyBase58Check, recipientPkString, order, false)
+		require.NoError(t, err)
+		require.False(t, response.BuyingCoinIsDESO)
+		require.False(t, response.SellingCoinIsDESO)
+		require.Equal(t, 18, response.BuyingCoinDecimals)
+		require.Equal(t, 18, response.SellingCoinDecimals)
+	}
+}
+
+func TestBuildDAOCoinLimitOrderResponseSkipDeprecatedFloatFields(t *testing.T) {
+	order := &lib.DAOCoinLimitOrderEntry{
+		OrderID:       &lib.BlockHash{0x01},
+		OperationType: lib.DAOCoinLimitOrderOperationTypeBID,
+		ScaledExchangeRateCoinsToSellPerCoinToBuy: lib.OneE38,
+		QuantityToFillInBaseUnits:                 uint256.NewInt(1e9),
+	}
+
+	response, err := buildDAOCoinLimitOrderResponse(
+		senderPkString, daoCoinPubKeyBase58Check, DESOCoinIdentifierString, order, false)
+	require.NoError(t, err)
+	require.NotZero(t, response.ExchangeRateCoinsToSellPerCoinToBuy)
+	require.NotZero(t, response.QuantityToFill)
+
+	skippedResponse, err := buildDAOCoinLimitOrderResponse(
+		senderPkString, daoCoinPubKeyBase58Check, DESOCoinIdentifierString, order, true)
+	require.NoError(t, err)
+	require.Zero(t, skippedResponse.ExchangeRateCoinsToSellPerCoinToBuy)
+	require.Zero(t, skippedResponse.QuantityToFill)
+	require.Equal(t, response.Price, skippedResponse.Price)
+	require.Equal(t, response.Quantity, skippedResponse.Quantity)
+
+	// The skipped fields are left out of the JSON rather than reported as zero.
+	responseJSON, err := json.Marshal(skippedResponse)
+	require.NoError(t, err)
+	require.NotContains(t, string(responseJSON), "ExchangeRateCoinsToSellPerCoinToBuy")
+	require.NotContains(t, string(responseJSON), "QuantityToFill")
+
+	// The node-wide flag is off unless configured.
+	require.False(t, (&APIServer{}).skipDeprecatedDAOCoinLimitOrderFloatFields())
+	require.True(t, (&APIServer{Config: &config.Config{SkipDeprecatedDAOCoinLimitOrderFloatFields: true}}).
+		skipDeprecatedDAOCoinLimitOrderFloatFields())
+}
+
+func BenchmarkBuildDAOCoinLimitOrderResponse(b *testing.B) {
+	order := &lib.DAOCoinLimitOrderEntry{
+		OrderID:       &lib.BlockHash{0x01},
+		OperationType: lib.DAOCoinLimitOrderOperationTypeBID,
+		ScaledExchangeRateCoinsToSellPerCoinToBuy: lib.OneE38,
+		QuantityToFillInBaseUnits:                 uint256.NewInt(1e9),
+	}
+	for _, skipDeprecatedFloatFields := range []bool{false, true} {
+		b.Run(fmt.Sprintf("SkipDeprecatedFloatFields=%v", skipDeprecatedFloatFields), func(b *testing.B) {
+			for ii := 0; ii < b.N; ii++ {
+				if _, err := buildDAOCoinLimitOrderResponse(senderPkString, daoCoinPubKeyBase58Check,
+					DESOCoinIdentifierString, order, skipDeprecatedFloatFields); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestSortDAOCoinLimitOrdersByDistanceFromMid(t *testing.T) {
+	// All prices are 10, 8, 12, and 20 $DESO per DAO coin, expressed from each order's point of view.
+	bid10 := DAOCoinLimitOrderEntryResponse{
+		OrderID:                                  "bid10",
+		BuyingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+		SellingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+		OperationType: DAOCoinLimitOrderOperationTypeStringBID,
+		Price:         "10",
+	}
+	bid8 := DAOCoinLimitOrderEntryResponse{
+		OrderID:                                  "bid8",
+		BuyingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+		SellingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+		OperationType: DAOCoinLimitOrderOperationTypeStringBID,
+		Price:         "8",
+	}
+	ask12 := DAOCoinLimitOrderEntryResponse{
+		OrderID:                                  "ask12",
+		BuyingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+		SellingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+		OperationType: DAOCoinLimitOrderOperationTypeStringASK,
+		Price:         "12",
+	}
+	// A BID for $DESO is priced in DAO coins per $DESO.
+	ask20 := DAOCoinLimitOrderEntryResponse{
+		OrderID:                                  "ask20",
+		BuyingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+		SellingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+		OperationType: DAOCoinLimitOrderOperationTypeStringBID,
+		Price:         "0.05",
+	}
+
+	// The mid is 11, so the orders at 10 and 12 come first and the order at 20 comes last.
+	orders := []DAOCoinLimitOrderEntryResponse{ask20, bid8, ask12, bid10}
+	sortedOrders, err := SortDAOCoinLimitOrdersByDistanceFromMid(orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+	require.NoError(t, err)
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{ask12, bid10, bid8, ask20}, sortedOrders)
+	// The input is left untouched.
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{ask20, bid8, ask12, bid10}, orders)
+
+	// A one-sided book has no mid and is sorted by price.
+	sortedOrders, err = SortDAOCoinLimitOrdersByDistanceFromMid(
+		[]DAOCoinLimitOrderEntryResponse{bid10, bid8}, daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+	require.NoError(t, err)
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{bid8, bid10}, sortedOrders)
+}
+
+func TestGroupDAOCoinLimitOrdersBySide(t *testing.T) {
+	newOrder := func(orderID string, buyingCoin string, sellingCoin string, operationType DAOCoinLimitOrderOperationTypeString, price string) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			OrderID:                                  orderID,
+			BuyingDAOCoinCreatorPublicKeyBase58Check: buyingCoin,
+			SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoin,
+			OperationType: operationType,
+			Price:         price,
+		}
+	}
+	// All prices are in $DESO per DAO coin, expressed from each order's point of view.
+	bid10 := newOrder("bid10", daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "10")
+	bid8 := newOrder("bid8", daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "8")
+	// An ASK selling $DESO for the DAO coin is priced in DAO coins per $DESO.
+	bid9 := newOrder("bid9", daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "0.1111")
+	ask12 := newOrder("ask12", desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "12")
+	// A BID for $DESO is priced in DAO coins per $DESO.
+	ask20 := newOrder("ask20", desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0.05")
+	secondAsk12 := newOrder("secondAsk12", desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "12")
+
+	orders := []DAOCoinLimitOrderEntryResponse{ask20, bid8, ask12, bid10, secondAsk12, bid9}
+	bids, asks, err := GroupDAOCoinLimitOrdersBySide(orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+	require.NoError(t, err)
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{bid10, bid9, bid8}, bids)
+	// Orders at the same price keep their relative order.
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{ask12, secondAsk12, ask20}, asks)
+	// The input is left untouched.
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{ask20, bid8, ask12, bid10, secondAsk12, bid9}, orders)
+
+	// Swapping the coins swaps the sides, with prices now in DAO coins per $DESO.
+	bids, asks, err = GroupDAOCoinLimitOrdersBySide(orders, desoPubKeyBase58Check, daoCoinPubKeyBase58Check)
+	require.NoError(t, err)
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{ask12, secondAsk12, ask20}, bids)
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{bid10, bid9, bid8}, asks)
+
+	// An empty side is an empty list rather than nil, so it encodes as [].
+	bids, asks, err = GroupDAOCoinLimitOrdersBySide(
+		[]DAOCoinLimitOrderEntryResponse{bid10}, daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+	require.NoError(t, err)
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{bid10}, bids)
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{}, asks)
+}
+
+func TestBuildMatchingDAOCoinLimitOrder(t *testing.T) {
+	// A resting BID for 2 DAO coins at 0.1 DESO each is matched by an ASK selling 2 DAO coins at 0.1 DESO each.
+	{
+		scaledExchangeRate, err := CalculateScaledExchangeRateFromPriceString(
+			daoCoinPubKeyBase58Check, desoPubKeyBase58Check, "0.1", lib.DAOCoinLimitOrderOperationTypeBID)
+		require.NoError(t, err)
+		quantity, err := CalculateQuantityToFillAsBaseUnits(
+			daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "2")
+		require.NoError(t, err)
+		restingOrder := &lib.DAOCoinLimitOrderEntry{
+			OrderID:       &lib.BlockHash{0x01},
+			OperationType: lib.DAOCoinLimitOrderOperationTypeBID,
+			ScaledExchangeRateCoinsToSellPerCoinToBuy: scaledExchangeRate,
+			QuantityToFillInBaseUnits:                 quantity,
+		}
+
+		matchingRate, matchingQuantity, operationType, err := BuildMatchingDAOCoinLimitOrder(restingOrder)
+		require.NoError(t, err)
+		require.Equal(t, lib.DAOCoinLimitOrderOperationTypeASK, operationType)
+		require.Equal(t, quantity, matchingQuantity)
+
+		// The matching order buys DESO and sells the DAO coin.
+		price, err := CalculatePriceStringFromScaledExchangeRate(
+			desoPubKeyBase58Check, daoCoinPubKeyBase58Check, matchingRate, DAOCoinLimitOrderOperationTypeStringASK)
+		require.NoError(t, err)
+		require.Equal(t, "0.1", price)
+		quantityString, err := CalculateStringQuantityFromBaseUnits(
+			desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, matchingQuantity)
+		require.NoError(t, err)
+		require.Equal(t, "2.0", quantityString)
+	}
+
+	// A resting ASK at a rate with no exact inverse is matched by a BID whose rate is rounded up so the two cross.
+	{
+		scaledExchangeRate := uint256.NewInt(0).Mul(lib.OneE38, uint256.NewInt(3))
+		restingOrder := &lib.DAOCoinLimitOrderEntry{
+			OrderID:       &lib.BlockHash{0x02},
+			OperationType: lib.DAOCoinLimitOrderOperationTypeASK,
+			ScaledExchangeRateCoinsToSellPerCoinToBuy: scaledExchangeRate,
+			QuantityToFillInBaseUnits:                 uint256.NewInt(1e18),
+		}
+
+		matchingRate, matchingQuantity, operationType, err := BuildMatchingDAOCoinLimitOrder(restingOrder)
+		require.NoError(t, err)
+		require.Equal(t, lib.DAOCoinLimitOrderOperationTypeBID, operationType)
+		require.Equal(t, uint256.NewInt(1e18), matchingQuantity)
+
+		oneE76 := big.NewInt(0).Mul(lib.OneE38.ToBig(), lib.OneE38.ToBig())
+		product := big.NewInt(0).Mul(matchingRate.ToBig(), scaledExchangeRate.ToBig())
+		require.True(t, product.Cmp(oneE76) >= 0)
+		productBelow := big.NewInt(0).Mul(big.NewInt(0).Sub(matchingRate.ToBig(), big.NewInt(1)), scaledExchangeRate.ToBig())
+		require.True(t, productBelow.Cmp(oneE76) < 0)
+	}
+
+	// Orders with nothing left to fill can't be matched.
+	{
+		restingOrder := &lib.DAOCoinLimitOrderEntry{
+			OrderID:       &lib.BlockHash{0x03},
+			OperationType: lib.DAOCoinLimitOrderOperationTypeBID,
+			ScaledExchangeRateCoinsToSellPerCoinToBuy: lib.OneE38,
+			QuantityToFillInBaseUnits:                 uint256.NewInt(0),
+		}
+		_, _, _, err := BuildMatchingDAOCoinLimitOrder(restingOrder)
+		require.Error(t, err)
+	}
+}
+
+func TestFilterDAOCoinLimitOrdersByMinQuantity(t *testing.T) {
+	buildOrder := func(
+		orderIDByte byte,
+		buyingCoin string,
+		sellingCoin string,
+		operationType lib.DAOCoinLimitOrderOperationType,
+		quantity string,
+	) DAOCoinLimitOrderEntryResponse {
+		operationTypeString, err := orderOperationTypeToString(operationType)
+		require.NoError(t, err)
+		quantityBaseUnits, err := CalculateQuantityToFillAsBaseUnits(buyingCoin, sellingCoin, operationTypeString, quantity)
+		require.NoError(t, err)
+		order, err := buildDAOCoinLimitOrderResponse(senderPkString, buyingCoin, sellingCoin, &lib.DAOCoinLimitOrderEntry{
+			OrderID:       &lib.BlockHash{orderIDByte},
+			OperationType: operationType,
+			ScaledExchangeRateCoinsToSellPerCoinToBuy: lib.OneE38,
+			QuantityToFillInBaseUnits:                 quantityBaseUnits,
+		}, false)
+		require.NoError(t, err)
+		return *order
+	}
+
+	// A DAO coin <> DESO book with dust and real orders on both sides.
+	book := []DAOCoinLimitOrderEntryResponse{
+		// Bids for the DAO coin. Quantity is in DAO coins.
+		buildOrder(1, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, lib.DAOCoinLimitOrderOperationTypeBID, "0.000001"),
+		buildOrder(2, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, lib.DAOCoinLimitOrderOperationTypeBID, "5"),
+		// Asks selling the DAO coin. Quantity is in DAO coins. One base unit below the threshold is still dust.
+		buildOrder(3, desoPubKeyBase58Check, daoCoinPubKeyBase58Check, lib.DAOCoinLimitOrderOperationTypeASK, "0.999999999999999999"),
+		buildOrder(4, desoPubKeyBase58Check, daoCoinPubKeyBase58Check, lib.DAOCoinLimitOrderOperationTypeASK, "1"),
+		// Bids for DESO. Quantity is in DESO.
+		buildOrder(5, desoPubKeyBase58Check, daoCoinPubKeyBase58Check, lib.DAOCoinLimitOrderOperationTypeBID, "0.5"),
+		buildOrder(6, desoPubKeyBase58Check, daoCoinPubKeyBase58Check, lib.DAOCoinLimitOrderOperationTypeBID, "10.000000001"),
+	}
+
+	getOrderIDs := func(orders []DAOCoinLimitOrderEntryResponse) []string {
+		orderIDs := []string{}
+		for _, order := range orders {
+			orderIDs = append(orderIDs, order.OrderID)
+		}
+		return orderIDs
+	}
+
+	filteredOrders, err := FilterDAOCoinLimitOrdersByMinQuantity(book, "1")
+	require.NoError(t, err)
+	require.Equal(t, getOrderIDs([]DAOCoinLimitOrderEntryResponse{book[1], book[3], book[5]}), getOrderIDs(filteredOrders))
+
+	// The comparison is numeric, so "10.000000001" is above "9" even though it sorts before it as a string.
+	filteredOrders, err = FilterDAOCoinLimitOrdersByMinQuantity(book, "9")
+	require.NoError(t, err)
+	require.Equal(t, getOrderIDs([]DAOCoinLimitOrderEntryResponse{book[5]}), getOrderIDs(filteredOrders))
+
+	// A zero threshold keeps every order, and the input isn't modified.
+	filteredOrders, err = FilterDAOCoinLimitOrdersByMinQuantity(book, "0")
+	require.NoError(t, err)
+	require.Equal(t, getOrderIDs(book), getOrderIDs(filteredOrders))
+	require.Len(t, book, 6)
+
+	_, err = FilterDAOCoinLimitOrdersByMinQuantity(book, "-1")
+	require.Error(t, err)
+}
+
+func TestDESOIdentifierAsTransactorPublicKey(t *testing.T) {
+	// Every spelling of the DESO identifier is rejected where a user public key is required.
+	for _, desoIdentifier := range []string{DESOCoinIdentifierString, DeSoZeroPkidMainnetBase58, DeSoZeroPkidTestnetBase58} {
+		err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", desoIdentifier)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "DESO identifier")
+	}
+	require.NoError(t, ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", senderPkString))
+
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams}
+
+	// DESO is still valid in a coin position, but not where a PKID must be looked up.
+	coinPKID, err := apiServer.getPKIDFromPublicKeyBase58CheckOrDESOString(nil, DESOCoinIdentifierString)
+	require.NoError(t, err)
+	require.Equal(t, lib.ZeroPKID, *coinPKID)
+	_, err = apiServer.getPKIDFromPublicKeyBase58Check(nil, DESOCoinIdentifierString)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "DESO identifier")
+
+	// Each DAO coin exchange endpoint rejects DESO as the transactor before touching any state.
+	handlers := map[string]http.HandlerFunc{
+		RoutePathCreateDAOCoinLimitOrder:         apiServer.CreateDAOCoinLimitOrder,
+		RoutePathCreateDAOCoinMarketOrder:        apiServer.CreateDAOCoinMarketOrder,
+		RoutePathCancelDAOCoinLimitOrder:         apiServer.CancelDAOCoinLimitOrder,
+		RoutePathBuildMatchingOrderForOrderID:    apiServer.BuildMatchingOrderForOrderID,
+		RoutePathGetTransactorDaoCoinLimitOrders: apiServer.GetTransactorDAOCoinLimitOrders,
+	}
+	requestBody := []byte(fmt.Sprintf(`{"TransactorPublicKeyBase58Check": "%v"}`, DESOCoinIdentifierString))
+	for routePath, handler := range handlers {
+		request, err := http.NewRequest("POST", routePath, bytes.NewBuffer(requestBody))
+		require.NoError(t, err)
+		response := httptest.NewRecorder()
+		handler(response, request)
+		require.Equal(t, http.StatusBadRequest, response.Code, routePath)
+		require.Contains(t, response.Body.String(), "TransactorPublicKeyBase58Check must be a user public key", routePath)
+	}
+}
+
+func TestBuildDAOCoinLimitOrderLadder(t *testing.T) {
+	newOrder := func(
+		buyingCoin string, sellingCoin string, operationType DAOCoinLimitOrderOperationTypeString, price string, quantity string,
+	) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoin,
+			SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoin,
+			OperationType: operationType,
+			Price:         price,
+			Quantity:      quantity,
+		}
+	}
+	orders := []DAOCoinLimitOrderEntryResponse{
+		// Bids for the DAO coin round down to the tick at or below their price.
+		newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "10.2", "3"),
+		newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "10.4", "1"),
+		newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "9.5", "2"),
+		// Asks for the DAO coin round up to the tick at or above their price.
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "10.6", "5"),
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "11", "1"),
+		// A BID for 25 $DESO at 0.08 DAO coins per $DESO sells 2 DAO coins at 12.5 $DESO each.
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0.08", "25"),
+	}
+
+	bids, asks, err := BuildDAOCoinLimitOrderLadder(orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, "0.5")
+	require.NoError(t, err)
+	require.Equal(t, []DAOCoinLimitOrderLadderLevel{
+		{Price: "10.0", Quantity: "4.0", NumOrders: 2},
+		{Price: "9.5", Quantity: "2.0", NumOrders: 1},
+	}, bids)
+	require.Equal(t, []DAOCoinLimitOrderLadderLevel{
+		{Price: "11.0", Quantity: "6.0", NumOrders: 2},
+		{Price: "12.5", Quantity: "2.0", NumOrders: 1},
+	}, asks)
+
+	// An empty book has empty sides.
+	bids, asks, err = BuildDAOCoinLimitOrderLadder(nil, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, "1")
+	require.NoError(t, err)
+	require.Empty(t, bids)
+	require.Empty(t, asks)
+
+	// The tick size must be positive.
+	for _, tickSize := range []string{"0", "-1", "abc", ""} {
+		_, _, err = BuildDAOCoinLimitOrderLadder(orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, tickSize)
+		require.Error(t, err, tickSize)
+	}
+}
+
+func TestCountTransactorOpenDAOCoinLimitOrders(t *testing.T) {
+	daoCoin1PKID := lib.NewPKID([]byte{1})
+	daoCoin2PKID := lib.NewPKID([]byte{2})
+	newOrder := func(buyingCoinPKID *lib.PKID, sellingCoinPKID *lib.PKID) *lib.DAOCoinLimitOrderEntry {
+		return &lib.DAOCoinLimitOrderEntry{BuyingDAOCoinCreatorPKID: buyingCoinPKID, SellingDAOCoinCreatorPKID: sellingCoinPKID}
+	}
+	// The transactor's open orders: three on the DAO coin 1 <> DESO pair, in both directions, and two elsewhere.
+	transactorOrders := []*lib.DAOCoinLimitOrderEntry{
+		newOrder(daoCoin1PKID, &lib.ZeroPKID),
+		newOrder(daoCoin1PKID, &lib.ZeroPKID),
+		newOrder(&lib.ZeroPKID, daoCoin1PKID),
+		newOrder(daoCoin2PKID, &lib.ZeroPKID),
+		newOrder(daoCoin2PKID, daoCoin1PKID),
+	}
+	// Mimics GetAllDAOCoinLimitOrdersForThisTransactor.
+	getOrders := func(buyingCoinPKID *lib.PKID, sellingCoinPKID *lib.PKID) ([]*lib.DAOCoinLimitOrderEntry, error) {
+		var orders []*lib.DAOCoinLimitOrderEntry
+		for _, order := range transactorOrders {
+			if buyingCoinPKID != nil && sellingCoinPKID != nil && (!buyingCoinPKID.Eq(order.BuyingDAOCoinCreatorPKID) ||
+				!sellingCoinPKID.Eq(order.SellingDAOCoinCreatorPKID)) {
+				continue
+			}
+			orders = append(orders, order)
+		}
+		return orders, nil
+	}
+
+	openOrderCount, err := countTransactorOpenDAOCoinLimitOrders(getOrders, nil, nil)
+	require.NoError(t, err)
+	require.Equal(t, len(transactorOrders), openOrderCount)
+
+	// The pair filter counts both directions, regardless of which coin is passed first.
+	openOrderCount, err = countTransactorOpenDAOCoinLimitOrders(getOrders, daoCoin1PKID, &lib.ZeroPKID)
+	require.NoError(t, err)
+	require.Equal(t, 3, openOrderCount)
+	openOrderCount, err = countTransactorOpenDAOCoinLimitOrders(getOrders, &lib.ZeroPKID, daoCoin1PKID)
+	require.NoError(t, err)
+	require.Equal(t, 3, openOrderCount)
+
+	openOrderCount, err = countTransactorOpenDAOCoinLimitOrders(getOrders, daoCoin1PKID, daoCoin2PKID)
+	require.NoError(t, err)
+	require.Equal(t, 1, openOrderCount)
+
+	_, err = countTransactorOpenDAOCoinLimitOrders(func(*lib.PKID, *lib.PKID) ([]*lib.DAOCoinLimitOrderEntry, error) {
+		return nil, fmt.Errorf("view error")
+	}, nil, nil)
+	require.Error(t, err)
+}
+
+func TestComputeDAOCoinLimitOrderPlacement(t *testing.T) {
+	newOrder := func(
+		buyingCoin string, sellingCoin string, operationType DAOCoinLimitOrderOperationTypeString, price string,
+	) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoin,
+			SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoin,
+			OperationType: operationType,
+			Price:         price,
+			Quantity:      "1",
+		}
+	}
+	orders := []DAOCoinLimitOrderEntryResponse{
+		newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "10"),
+		newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "10"),
+		newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "9"),
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "11"),
+		// A BID for $DESO at 0.08 DAO coins per $DESO is an ask for the DAO coin at 12.5 $DESO.
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0.08"),
+	}
+	placement := func(operationType DAOCoinLimitOrderOperationTypeString, price string) *GetOrderBookPlacementResponse {
+		res, err := ComputeDAOCoinLimitOrderPlacement(
+			orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, operationType, price)
+		require.NoError(t, err)
+		return res
+	}
+
+	// Top of book: a bid above every resting bid but below the best ask.
+	res := placement(DAOCoinLimitOrderOperationTypeStringBID, "10.5")
+	require.False(t, res.IsMarketable)
+	require.True(t, res.IsTopOfBook)
+	require.Equal(t, 1, res.Rank)
+	require.Equal(t, 0, res.OrdersAhead)
+	require.Equal(t, "10.0", res.BestBidPrice)
+	require.Equal(t, "11.0", res.BestAskPrice)
+
+	// Joining the best bid puts the order behind the two bids already at that price.
+	res = placement(DAOCoinLimitOrderOperationTypeStringBID, "10")
+	require.False(t, res.IsMarketable)
+	require.True(t, res.IsTopOfBook)
+	require.Equal(t, 3, res.Rank)
+	require.Equal(t, 2, res.OrdersAhead)
+
+	// Mid-queue: between the resting bids.
+	res = placement(DAOCoinLimitOrderOperationTypeStringBID, "9.5")
+	require.False(t, res.IsMarketable)
+	require.False(t, res.IsTopOfBook)
+	require.Equal(t, 3, res.Rank)
+	require.Equal(t, 2, res.OrdersAhead)
+
+	// Mid-queue on the ask side, behind the ask at 11 and ahead of the one at 12.5.
+	res = placement(DAOCoinLimitOrderOperationTypeStringASK, "12")
+	require.False(t, res.IsMarketable)
+	require.False(t, res.IsTopOfBook)
+	require.Equal(t, 2, res.Rank)
+
+	// Marketable: orders that reach the other side of the spread have no rank.
+	res = placement(DAOCoinLimitOrderOperationTypeStringBID, "11")
+	require.True(t, res.IsMarketable)
+	require.False(t, res.IsTopOfBook)
+	require.Equal(t, 0, res.Rank)
+	res = placement(DAOCoinLimitOrderOperationTypeStringASK, "9.99")
+	require.True(t, res.IsMarketable)
+	require.Equal(t, 0, res.Rank)
+
+	// Against an empty book every order is top of book and nothing is marketable.
+	res, err := ComputeDAOCoinLimitOrderPlacement(
+		nil, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "1")
+	require.NoError(t, err)
+	require.False(t, res.IsMarketable)
+	require.True(t, res.IsTopOfBook)
+	require.Equal(t, 1, res.Rank)
+	require.Empty(t, res.BestBidPrice)
+	require.Empty(t, res.BestAskPrice)
+
+	_, err = ComputeDAOCoinLimitOrderPlacement(
+		orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0")
+	require.Error(t, err)
+}
+
+func TestFilterCrossingDAOCoinLimitOrders(t *testing.T) {
+	newOrder := func(
+		buyingCoin string, sellingCoin string, operationType DAOCoinLimitOrderOperationTypeString, price string,
+	) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoin,
+			SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoin,
+			OperationType: operationType,
+			Price:         price,
+			Quantity:      "1",
+		}
+	}
+	// The transactor's resting orders: a bid for the DAO coin and two asks.
+	bid := newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "9")
+	askAt11 := newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "11")
+	// A BID for $DESO at 0.1 DAO coins per $DESO is an ask for the DAO coin at 10 $DESO.
+	askAt10 := newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0.1")
+	orders := []DAOCoinLimitOrderEntryResponse{bid, askAt11, askAt10}
+	crossingOrders := func(operationType DAOCoinLimitOrderOperationTypeString, price string) []DAOCoinLimitOrderEntryResponse {
+		res, err := FilterCrossingDAOCoinLimitOrders(
+			orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, operationType, price)
+		require.NoError(t, err)
+		return res
+	}
+
+	// Self-crossing: a bid at 11 would match both of the transactor's asks, the cheaper one first. Its own bid is
+	// on the same side and can't be crossed.
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{askAt10, askAt11},
+		crossingOrders(DAOCoinLimitOrderOperationTypeStringBID, "11"))
+	// An ask at exactly the resting bid's price crosses it.
+	require.Equal(t, []DAOCoinLimitOrderEntryResponse{bid},
+		crossingOrders(DAOCoinLimitOrderOperationTypeStringASK, "9"))
+
+	// Non-crossing: orders inside the transactor's own spread don't match anything.
+	require.Empty(t, crossingOrders(DAOCoinLimitOrderOperationTypeStringBID, "9.99"))
+	require.NotNil(t, crossingOrders(DAOCoinLimitOrderOperationTypeStringBID, "9.99"))
+	require.Empty(t, crossingOrders(DAOCoinLimitOrderOperationTypeStringASK, "9.01"))
+
+	_, err := FilterCrossingDAOCoinLimitOrders(
+		orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "-1")
+	require.Error(t, err)
+}
+
+func TestValidateDAOCoinLimitOrdersBatch(t *testing.T) {
+	// The transactor holds 100 DAO coins and 100 $DESO.
+	balance, err := CalculateBaseUnitsFromStringDecimalAmountSimple(daoCoinPubKeyBase58Check, "100")
+	require.NoError(t, err)
+	// Mimics validateTransactorSellingCoinBalanceGivenPendingOrders for ASKs, which sell exactly their quantity.
+	validateOrder := func(order *DAOCoinLimitOrderCreationRequest, pendingSellingBaseUnits *uint256.Int) (*uint256.Int, error) {
+		_, _, _, quantityToFillInBaseUnits, err := parseDAOCoinLimitOrderCreationRequest(order)
+		if err != nil {
+			return nil, err
+		}
+		totalSellingBaseUnits := uint256.NewInt(0).Add(pendingSellingBaseUnits, quantityToFillInBaseUnits)
+		if balance.Lt(totalSellingBaseUnits) {
+			return nil, fmt.Errorf("Insufficient balance")
+		}
+		return quantityToFillInBaseUnits, nil
+	}
+	askOrder := func(sellingCoin string, buyingCoin string, quantity string) DAOCoinLimitOrderCreationRequest {
+		return DAOCoinLimitOrderCreationRequest{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoin,
+			SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoin,
+			OperationType: DAOCoinLimitOrderOperationTypeStringASK,
+			Price:         "1",
+			Quantity:      quantity,
+		}
+	}
+	sellDAOCoin := askOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, "60")
+
+	// The order fits on its own.
+	results := validateDAOCoinLimitOrdersBatch([]DAOCoinLimitOrderCreationRequest{sellDAOCoin}, validateOrder)
+	require.Equal(t, []DAOCoinLimitOrderValidationResult{{IsValid: true}}, results)
+
+	// Together, the second sale of 60 DAO coins exceeds the 40 left after the first. Selling $DESO draws on a
+	// separate balance, so it's unaffected, and the failed order doesn't count against the one after it.
+	results = validateDAOCoinLimitOrdersBatch([]DAOCoinLimitOrderCreationRequest{
+		sellDAOCoin,
+		sellDAOCoin,
+		askOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, "60"),
+		askOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, "40"),
+	}, validateOrder)
+	require.Len(t, results, 4)
+	require.True(t, results[0].IsValid)
+	require.False(t, results[1].IsValid)
+	require.Equal(t, "Insufficient balance", results[1].Error)
+	require.True(t, results[2].IsValid)
+	require.True(t, results[3].IsValid)
+
+	// Orders that fail to parse are reported individually.
+	results = validateDAOCoinLimitOrdersBatch([]DAOCoinLimitOrderCreationRequest{
+		askOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, ""),
+	}, validateOrder)
+	require.False(t, results[0].IsValid)
+	require.NotEmpty(t, results[0].Error)
+}
+
+func TestOmitDeprecatedFloatFieldsFromDAOCoinLimitOrders(t *testing.T) {
+	orders := []DAOCoinLimitOrderEntryResponse{{
+		TransactorPublicKeyBase58Check:      senderPkString,
+		Price:                               "0.1",
+		Quantity:                            "2.5",
+		ExchangeRateCoinsToSellPerCoinToBuy: 0.1,
+		QuantityToFill:                      2.5,
+		OperationType:                       DAOCoinLimitOrderOperationTypeStringBID,
+		OrderID:                             "orderID",
+	}}
+	encodeOrder := func(order DAOCoinLimitOrderEntryResponse) map[string]interface{} {
+		orderJSON, err := json.Marshal(order)
+		require.NoError(t, err)
+		decodedOrder := make(map[string]interface{})
+		require.NoError(t, json.Unmarshal(orderJSON, &decodedOrder))
+		return decodedOrder
+	}
+
+	// By default the float fields are present alongside the strings.
+	decodedOrder := encodeOrder(orders[0])
+	require.Equal(t, 0.1, decodedOrder["ExchangeRateCoinsToSellPerCoinToBuy"])
+	require.Equal(t, 2.5, decodedOrder["QuantityToFill"])
+	require.Equal(t, "0.1", decodedOrder["Price"])
+
+	// With the option the float fields are absent and everything else is unchanged.
+	ordersWithoutFloats := omitDeprecatedFloatFieldsFromDAOCoinLimitOrders(orders)
+	decodedOrderWithoutFloats := encodeOrder(ordersWithoutFloats[0])
+	require.NotContains(t, decodedOrderWithoutFloats, "ExchangeRateCoinsToSellPerCoinToBuy")
+	require.NotContains(t, decodedOrderWithoutFloats, "QuantityToFill")
+	delete(decodedOrder, "ExchangeRateCoinsToSellPerCoinToBuy")
+	delete(decodedOrder, "QuantityToFill")
+	require.Equal(t, decodedOrder, decodedOrderWithoutFloats)
+
+	// The input is left untouched.
+	require.Contains(t, encodeOrder(orders[0]), "QuantityToFill")
+}
+
+func TestGetDAOCoinLimitOrdersRejectsBlockHeight(t *testing.T) {
+	apiServer := newTestApiServer(t)
+	getOrders := func(requestData GetDAOCoinLimitOrdersRequest) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(requestData)
+		require.NoError(t, err)
+		request, err := http.NewRequest("POST", RoutePathGetDaoCoinLimitOrders, bytes.NewBuffer(requestBody))
+		require.NoError(t, err)
+		response := httptest.NewRecorder()
+		apiServer.GetDAOCoinLimitOrders(response, request)
+		return response
+	}
+
+	// Even the current tip height is rejected, so a client can't mistake the current book for a historical one.
+	for _, blockHeight := range []uint32{1, apiServer.blockchain.BlockTip().Height} {
+		response := getOrders(GetDAOCoinLimitOrdersRequest{
+			DAOCoin1CreatorPublicKeyBase58Check: senderPkString,
+			DAOCoin2CreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+			BlockHeight:                         blockHeight,
+		})
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "historical order book state is not available on this node")
+	}
+
+	response := getOrders(GetDAOCoinLimitOrdersRequest{
+		DAOCoin1CreatorPublicKeyBase58Check: senderPkString,
+		DAOCoin2CreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+	})
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+}
+
+func TestComputeDESOCostToBuyDAOCoin(t *testing.T) {
+	newOrder := func(
+		buyingCoin string, sellingCoin string, operationType DAOCoinLimitOrderOperationTypeString, price string,
+		quantity string,
+	) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoin,
+			SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoin,
+			OperationType: operationType,
+			Price:         price,
+			Quantity:      quantity,
+		}
+	}
+	orders := []DAOCoinLimitOrderEntryResponse{
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "1.5", "10"),
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "1", "5"),
+		// A BID for 4 $DESO at 0.5 DAO coins per $DESO sells 2 DAO coins at 2 $DESO each.
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0.5", "4"),
+		// Bids for the DAO coin can't fill a buy.
+		newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0.9", "100"),
+	}
+	costToBuy := func(quantity string) *GetDESOCostToBuyDAOCoinResponse {
+		quantityBaseUnits, err := CalculateBaseUnitsFromStringDecimalAmountSimple(daoCoinPubKeyBase58Check, quantity)
+		require.NoError(t, err)
+		res, err := ComputeDESOCostToBuyDAOCoin(orders, daoCoinPubKeyBase58Check, quantityBaseUnits)
+		require.NoError(t, err)
+		return res
+	}
+
+	// Sufficient liquidity: the cheapest ask fills first, then part of the next.
+	res := costToBuy("8")
+	require.True(t, res.HasSufficientLiquidity)
+	require.Equal(t, "8.0", res.QuantityBuyable)
+	require.Equal(t, "9.5", res.TotalDESOCost)
+	require.Equal(t, "1.1875", res.AveragePrice)
+
+	// Insufficient liquidity: everything on the book is bought.
+	res = costToBuy("20")
+	require.False(t, res.HasSufficientLiquidity)
+	require.Equal(t, "17.0", res.QuantityBuyable)
+	require.Equal(t, "24.0", res.TotalDESOCost)
+
+	// Costs that come to a fraction of a nano round up.
+	res, err := ComputeDESOCostToBuyDAOCoin(orders, daoCoinPubKeyBase58Check, uint256.NewInt(1))
+	require.NoError(t, err)
+	require.True(t, res.HasSufficientLiquidity)
+	require.Equal(t, "0.000000001", res.TotalDESOCost)
+
+	// An empty book has no liquidity at all.
+	res, err = ComputeDESOCostToBuyDAOCoin(nil, daoCoinPubKeyBase58Check, uint256.NewInt(1))
+	require.NoError(t, err)
+	require.False(t, res.HasSufficientLiquidity)
+	require.Equal(t, "0.0", res.TotalDESOCost)
+	require.Empty(t, res.AveragePrice)
+}
+
+func TestFindBestAffordableDAOCoinLimitOrder(t *testing.T) {
+	newOrder := func(
+		transactor string, buyingCoin string, sellingCoin string, operationType DAOCoinLimitOrderOperationTypeString,
+		price string, quantity string, orderID string,
+	) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			TransactorPublicKeyBase58Check:            transactor,
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoin,
+			SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoin,
+			OperationType: operationType,
+			Price:         price,
+			Quantity:      quantity,
+			OrderID:       orderID,
+		}
+	}
+	orders := []DAOCoinLimitOrderEntryResponse{
+		// Costs 15 $DESO to fully match.
+		newOrder(moneyPkString, desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK,
+			"1.5", "10", "ask-1.5"),
+		// The best ask. Costs 5 $DESO to fully match.
+		newOrder(moneyPkString, desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK,
+			"1", "5", "ask-1"),
+		// A BID for 4 $DESO at 0.5 DAO coins per $DESO sells 2 DAO coins at 2 $DESO each, so it costs 4 $DESO.
+		newOrder(moneyPkString, desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID,
+			"0.5", "4", "ask-2"),
+		// Bids for 100 DAO coins, which only a seller can match.
+		newOrder(moneyPkString, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID,
+			"0.9", "100", "bid-0.9"),
+	}
+	findBuy := func(orders []DAOCoinLimitOrderEntryResponse, availableDESO uint64) *GetBestAffordableOrderResponse {
+		res, err := FindBestAffordableDAOCoinLimitOrder(orders, senderPkString, daoCoinPubKeyBase58Check,
+			desoPubKeyBase58Check, true, uint256.NewInt(availableDESO*lib.NanosPerUnit))
+		require.NoError(t, err)
+		return res
+	}
+
+	// A viewer who can afford the best ask gets it.
+	res := findBuy(orders, 5)
+	require.True(t, res.HasAffordableOrder)
+	require.Equal(t, "ask-1", res.Order.OrderID)
+	require.Equal(t, "5000000000", res.CostToFillBaseUnits)
+	require.Equal(t, "5.0", res.CostToFill)
+	require.Equal(t, "5.0", res.AvailableBalance)
+
+	// A viewer who can't afford it, or the next best, gets the worse-priced ask they can afford.
+	res = findBuy(orders, 4)
+	require.True(t, res.HasAffordableOrder)
+	require.Equal(t, "ask-2", res.Order.OrderID)
+	require.Equal(t, "4.0", res.CostToFill)
+
+	// A viewer who can't afford any ask gets nothing.
+	res = findBuy(orders, 3)
+	require.False(t, res.HasAffordableOrder)
+	require.Nil(t, res.Order)
+	require.Empty(t, res.CostToFill)
+	require.Equal(t, "3000000000", res.AvailableBalanceBaseUnits)
+
+	// The viewer's own orders are skipped.
+	ownOrders := append([]DAOCoinLimitOrderEntryResponse{}, orders...)
+	ownOrders[1].TransactorPublicKeyBase58Check = senderPkString
+	res = findBuy(ownOrders, 20)
+	require.True(t, res.HasAffordableOrder)
+	require.Equal(t, "ask-1.5", res.Order.OrderID)
+	require.Equal(t, "15.0", res.CostToFill)
+
+	// A seller pays in the DAO coin, and can only match the bids.
+	coins := func(numCoins uint64) *uint256.Int {
+		return new(uint256.Int).Mul(uint256.NewInt(numCoins), lib.BaseUnitsPerCoin)
+	}
+	res, err := FindBestAffordableDAOCoinLimitOrder(orders, senderPkString, daoCoinPubKeyBase58Check,
+		desoPubKeyBase58Check, false, coins(100))
+	require.NoError(t, err)
+	require.True(t, res.HasAffordableOrder)
+	require.Equal(t, "bid-0.9", res.Order.OrderID)
+	require.Equal(t, "100.0", res.CostToFill)
+	res, err = FindBestAffordableDAOCoinLimitOrder(orders, senderPkString, daoCoinPubKeyBase58Check,
+		desoPubKeyBase58Check, false, coins(99))
+	require.NoError(t, err)
+	require.False(t, res.HasAffordableOrder)
+}
+
+func TestGetUserCoinInvolvement(t *testing.T) {
+	balance := func(balanceBaseUnits uint64) *BalanceEntryResponse {
+		return &BalanceEntryResponse{BalanceNanosUint256: uint256.NewInt(balanceBaseUnits)}
+	}
+	// The user holds the sender's and recipient's coins, and has sold all of their money coin.
+	youHodlMap := map[string]*BalanceEntryResponse{
+		senderPkString:    balance(100),
+		recipientPkString: balance(1),
+		moneyPkString:     balance(0),
+	}
+	// They're selling the recipient's coin for $DESO, and buying the DAO coin with the money coin.
+	orders := []DAOCoinLimitOrderEntryResponse{
+		{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  DESOCoinIdentifierString,
+			SellingDAOCoinCreatorPublicKeyBase58Check: recipientPkString,
+		},
+		{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  daoCoinPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: moneyPkString,
+		},
+	}
+
+	coins := getUserCoinInvolvement(youHodlMap, orders)
+	expectedCoins := []UserCoinInvolvement{
+		{CreatorPublicKeyBase58Check: senderPkString, HasBalance: true},
+		{CreatorPublicKeyBase58Check: recipientPkString, HasBalance: true, HasOpenOrders: true},
+		{CreatorPublicKeyBase58Check: moneyPkString, HasOpenOrders: true},
+		{CreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check, HasOpenOrders: true},
+	}
+	sort.Slice(expectedCoins, func(ii, jj int) bool {
+		return expectedCoins[ii].CreatorPublicKeyBase58Check < expectedCoins[jj].CreatorPublicKeyBase58Check
+	})
+	require.Equal(t, expectedCoins, coins)
+
+	// A user with neither gets an empty list.
+	require.Equal(t, []UserCoinInvolvement{}, getUserCoinInvolvement(nil, nil))
+}
+
+func TestComputeDAOCoinRoundTripBreakEven(t *testing.T) {
+	// Asks for 100 DAO coins at 2 $DESO each.
+	orders := []DAOCoinLimitOrderEntryResponse{{
+		BuyingDAOCoinCreatorPublicKeyBase58Check:  desoPubKeyBase58Check,
+		SellingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+		OperationType: DAOCoinLimitOrderOperationTypeStringASK,
+		Price:         "2",
+		Quantity:      "100",
+	}}
+	breakEven := func(quantity string, feeRateNanosPerKB uint64) *GetDAOCoinRoundTripBreakEvenResponse {
+		quantityBaseUnits, err := CalculateBaseUnitsFromStringDecimalAmountSimple(daoCoinPubKeyBase58Check, quantity)
+		require.NoError(t, err)
+		res, err := ComputeDAOCoinRoundTripBreakEven(orders, daoCoinPubKeyBase58Check, quantityBaseUnits, feeRateNanosPerKB)
+		require.NoError(t, err)
+		return res
+	}
+	basisPoints := func(res *GetDAOCoinRoundTripBreakEvenResponse) *big.Rat {
+		require.True(t, res.HasSufficientLiquidity)
+		return mustParseRat(t, res.BreakEvenBasisPoints)
+	}
+
+	// Buying 10 DAO coins costs 20 $DESO. Each leg pays 1000 nanos per KB on 400 bytes, so the round trip pays 800
+	// nanos, or 0.0004 basis points of the 2e10 nanos spent.
+	res := breakEven("10", 1000)
+	require.Equal(t, uint64(400), res.FeeNanosPerLeg)
+	require.Equal(t, uint64(800), res.TotalFeeNanos)
+	require.Zero(t, big.NewRat(2, 1).Cmp(mustParseRat(t, res.EntryPrice)))
+	require.Zero(t, big.NewRat(4, 10000).Cmp(basisPoints(res)))
+	// The sell leg has to recoup the 800 nanos over the 10 coins.
+	require.Zero(t, big.NewRat(2_000_000_080, 1_000_000_000).Cmp(mustParseRat(t, res.BreakEvenPrice)))
+
+	// Doubling the fee rate doubles the break-even, and doubling the quantity halves it.
+	require.Zero(t, big.NewRat(8, 10000).Cmp(basisPoints(breakEven("10", 2000))))
+	require.Zero(t, big.NewRat(2, 10000).Cmp(basisPoints(breakEven("20", 1000))))
+
+	// Fees are rounded up to the next nano.
+	res = breakEven("10", 1)
+	require.Equal(t, uint64(1), res.FeeNanosPerLeg)
+
+	// A quantity the asks can't fill only gets the fees.
+	res = breakEven("200", 1000)
+	require.False(t, res.HasSufficientLiquidity)
+	require.Equal(t, uint64(800), res.TotalFeeNanos)
+	require.Empty(t, res.BreakEvenBasisPoints)
+}
+
+func mustParseRat(t *testing.T, decimal string) *big.Rat {
+	rat, ok := new(big.Rat).SetString(decimal)
+	require.True(t, ok, "invalid decimal %v", decimal)
+	return rat
+}
+
+func TestComputeDAOCoinPriceImpact(t *testing.T) {
+	newOrder := func(
+		buyingCoin string, sellingCoin string, operationType DAOCoinLimitOrderOperationTypeString, price string,
+		quantity string,
+	) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoin,
+			SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoin,
+			OperationType: operationType,
+			Price:         price,
+			Quantity:      quantity,
+		}
+	}
+	// Asks for 5 DAO coins at 1 $DESO, 10 at 1.5, and 2 at 2. Bids for 100 DAO coins at 0.8 and 10 at 0.6.
+	orders := []DAOCoinLimitOrderEntryResponse{
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "1.5", "10"),
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "1", "5"),
+		// A BID for 4 $DESO at 0.5 DAO coins per $DESO sells 2 DAO coins at 2 $DESO each.
+		newOrder(desoPubKeyBase58Check, daoCoinPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0.5", "4"),
+		newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0.6", "10"),
+		// An ASK selling 80 $DESO at 1.25 DAO coins per $DESO buys 100 DAO coins at 0.8 $DESO each.
+		newOrder(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringASK, "1.25", "80"),
+	}
+	priceImpact := func(takerIsBuying bool, quantity string) *GetDAOCoinPriceImpactResponse {
+		quantityBaseUnits, err := CalculateBaseUnitsFromStringDecimalAmountSimple(daoCoinPubKeyBase58Check, quantity)
+		require.NoError(t, err)
+		res, err := ComputeDAOCoinPriceImpact(
+			orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, takerIsBuying, quantityBaseUnits)
+		require.NoError(t, err)
+		return res
+	}
+	requireDecimal := func(expected string, actual string) {
+		expectedRat, ok := new(big.Rat).SetString(expected)
+		require.True(t, ok)
+		actualRat, ok := new(big.Rat).SetString(actual)
+		require.True(t, ok, actual)
+		require.Zero(t, expectedRat.Cmp(actualRat), "expected %v, got %v", expected, actual)
+	}
+
+	// A small buy fills within the best ask and doesn't move the price.
+	res := priceImpact(true, "4")
+	require.True(t, res.HasSufficientLiquidity)
+	require.Equal(t, "4.0", res.QuantityFillable)
+	requireDecimal("1", res.StartingPrice)
+	requireDecimal("1", res.EndingPrice)
+	requireDecimal("1", res.AveragePrice)
+	requireDecimal("0", res.PriceImpactPercentage)
+
+	// A large buy walks up to the 1.5 ask.
+	res = priceImpact(true, "13")
+	require.True(t, res.HasSufficientLiquidity)
+	requireDecimal("1", res.StartingPrice)
+	requireDecimal("1.5", res.EndingPrice)
+	requireDecimal("50", res.PriceImpactPercentage)
+
+	// A large sell walks down from the 0.8 bid to the 0.6 one.
+	res = priceImpact(false, "105")
+	require.True(t, res.HasSufficientLiquidity)
+	requireDecimal("0.8", res.StartingPrice)
+	requireDecimal("0.6", res.EndingPrice)
+	requireDecimal("25", res.PriceImpactPercentage)
+
+	// A buy bigger than the book exhausts it and reports the shortfall.
+	res = priceImpact(true, "20")
+	require.False(t, res.HasSufficientLiquidity)
+	require.Equal(t, "17.0", res.QuantityFillable)
+	requireDecimal("2", res.EndingPrice)
+	requireDecimal("100", res.PriceImpactPercentage)
+
+	// An empty book has nothing to fill against.
+	res, err := ComputeDAOCoinPriceImpact(nil, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, true, uint256.NewInt(1))
+	require.NoError(t, err)
+	require.False(t, res.HasSufficientLiquidity)
+	require.Empty(t, res.StartingPrice)
+	require.Empty(t, res.PriceImpactPercentage)
+}
+
+func TestComputeDAOCoinLiquidityWithinBand(t *testing.T) {
+	newOrder := func(operationType DAOCoinLimitOrderOperationTypeString, price string, quantity string,
+	) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  daoCoinPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+			OperationType: operationType,
+			Price:         price,
+			Quantity:      quantity,
+		}
+	}
+	// Bids for 10 DAO coins at 0.95 $DESO and 20 at 0.8. Asks for 5 DAO coins at 1.05 and 10 at 1.5, so the mid-price
+	// is 1.
+	orders := []DAOCoinLimitOrderEntryResponse{
+		newOrder(DAOCoinLimitOrderOperationTypeStringBID, "0.8", "20"),
+		newOrder(DAOCoinLimitOrderOperationTypeStringBID, "0.95", "10"),
+		// The asks sell DAO coins for $DESO, priced in $DESO per DAO coin.
+		{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  desoPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+			OperationType: DAOCoinLimitOrderOperationTypeStringASK,
+			Price:         "1.05",
+			Quantity:      "5",
+		},
+		{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  desoPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+			OperationType: DAOCoinLimitOrderOperationTypeStringASK,
+			Price:         "1.5",
+			Quantity:      "10",
+		},
+	}
+	liquidityWithinBand := func(orders []DAOCoinLimitOrderEntryResponse, bandPercentage string,
+	) *GetDAOCoinLiquidityWithinBandResponse {
+		band, err := parsePositiveDecimalString(bandPercentage)
+		require.NoError(t, err)
+		res, err := ComputeDAOCoinLiquidityWithinBand(orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, band)
+		require.NoError(t, err)
+		return res
+	}
+	requireDecimal := func(expected string, actual string) {
+		expectedRat, ok := new(big.Rat).SetString(expected)
+		require.True(t, ok)
+		actualRat, ok := new(big.Rat).SetString(actual)
+		require.True(t, ok, actual)
+		require.Zero(t, expectedRat.Cmp(actualRat), "expected %v, got %v", expected, actual)
+	}
+
+	// A 10% band only covers the best bid and ask.
+	res := liquidityWithinBand(orders, "10")
+	require.True(t, res.HasMidPrice)
+	requireDecimal("1", res.MidPrice)
+	requireDecimal("10", res.BidQuantity)
+	requireDecimal("9.5", res.BidNotional)
+	requireDecimal("5", res.AskQuantity)
+	requireDecimal("5.25", res.AskNotional)
+
+	// A 60% band covers the whole book.
+	res = liquidityWithinBand(orders, "60")
+	requireDecimal("30", res.BidQuantity)
+	requireDecimal("25.5", res.BidNotional)
+	requireDecimal("15", res.AskQuantity)
+	requireDecimal("20.25", res.AskNotional)
+
+	// Without asks there's no mid-price, so nothing is counted.
+	res = liquidityWithinBand(orders[:2], "60")
+	require.False(t, res.HasMidPrice)
+	require.Empty(t, res.MidPrice)
+	requireDecimal("0", res.BidQuantity)
+	requireDecimal("0", res.BidNotional)
+	requireDecimal("0", res.AskQuantity)
+	requireDecimal("0", res.AskNotional)
+
+	res = liquidityWithinBand(nil, "60")
+	require.False(t, res.HasMidPrice)
+	requireDecimal("0", res.AskQuantity)
+}
+
+func TestAddFillHeuristicToDAOCoinLimitOrders(t *testing.T) {
+	newOrder := func(orderID string, operationType DAOCoinLimitOrderOperationTypeString, price string,
+		quantity string) DAOCoinLimitOrderEntryResponse {
+		order := DAOCoinLimitOrderEntryResponse{
+			OrderID:                                  orderID,
+			BuyingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+			OperationType: operationType,
+			Price:         price,
+			Quantity:      quantity,
+		}
+		// The asks sell DAO coins for $DESO, priced in $DESO per DAO coin.
+		if operationType == DAOCoinLimitOrderOperationTypeStringASK {
+			order.BuyingDAOCoinCreatorPublicKeyBase58Check = desoPubKeyBase58Check
+			order.SellingDAOCoinCreatorPublicKeyBase58Check = daoCoinPubKeyBase58Check
+		}
+		return order
+	}
+	// Bids for 10 DAO coins at 0.95 $DESO and 20 at 0.8. Asks for 5 DAO coins at 1.05 and 10 at 1.5, so the mid-price
+	// is 1.
+	orders := []DAOCoinLimitOrderEntryResponse{
+		newOrder("bid0.8", DAOCoinLimitOrderOperationTypeStringBID, "0.8", "20"),
+		newOrder("ask1.5", DAOCoinLimitOrderOperationTypeStringASK, "1.5", "10"),
+		newOrder("bid0.95", DAOCoinLimitOrderOperationTypeStringBID, "0.95", "10"),
+		newOrder("ask1.05", DAOCoinLimitOrderOperationTypeStringASK, "1.05", "5"),
+	}
+	ordersWithHeuristic, err := AddFillHeuristicToDAOCoinLimitOrders(
+		orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+	require.NoError(t, err)
+	require.Len(t, ordersWithHeuristic, 4)
+	heuristics := make(map[string]float64)
+	for _, order := range ordersWithHeuristic {
+		require.NotNil(t, order.FillHeuristic)
+		heuristics[order.OrderID] = *order.FillHeuristic
+	}
+
+	// The best bid and ask are 5% from the mid with nothing ahead of them.
+	require.InDelta(t, 1.0/1.5, heuristics["bid0.95"], 1e-9)
+	require.InDelta(t, 1.0/1.5, heuristics["ask1.05"], 1e-9)
+	// The bid at 0.8 is 20% from the mid, with 10 DAO coins of bids ahead of it against 15 of asks.
+	require.InDelta(t, 1.0/3*15/25, heuristics["bid0.8"], 1e-9)
+	// The ask at 1.5 is 50% from the mid, with 5 DAO coins of asks ahead of it against 30 of bids.
+	require.InDelta(t, 1.0/6*30/35, heuristics["ask1.5"], 1e-9)
+	// Orders closer to the mid score higher than far ones.
+	require.Greater(t, heuristics["bid0.95"], heuristics["bid0.8"])
+	require.Greater(t, heuristics["ask1.05"], heuristics["ask1.5"])
+	for _, heuristic := range heuristics {
+		require.True(t, heuristic > 0 && heuristic <= 1)
+	}
+
+	// The input is left untouched since it may be shared with the cache.
+	for _, order := range orders {
+		require.Nil(t, order.FillHeuristic)
+	}
+
+	// A one-sided book has no mid, so no order is scored.
+	ordersWithHeuristic, err = AddFillHeuristicToDAOCoinLimitOrders(
+		orders[:1], daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+	require.NoError(t, err)
+	require.Nil(t, ordersWithHeuristic[0].FillHeuristic)
+	orderJSON, err := json.Marshal(ordersWithHeuristic[0])
+	require.NoError(t, err)
+	require.NotContains(t, string(orderJSON), "FillHeuristic")
+}
+
+func TestComputeDAOCoinOrderBookImbalance(t *testing.T) {
+	bid := func(price string, quantity string) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  daoCoinPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+			OperationType: DAOCoinLimitOrderOperationTypeStringBID,
+			Price:         price,
+			Quantity:      quantity,
+		}
+	}
+	ask := func(price string, quantity string) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  desoPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+			OperationType: DAOCoinLimitOrderOperationTypeStringASK,
+			Price:         price,
+			Quantity:      quantity,
+		}
+	}
+	imbalance := func(orders []DAOCoinLimitOrderEntryResponse, bandPercentage string,
+	) *GetDAOCoinOrderBookImbalanceResponse {
+		var band *big.Rat
+		if bandPercentage != "" {
+			var err error
+			band, err = parsePositiveDecimalString(bandPercentage)
+			require.NoError(t, err)
+		}
+		res, err := ComputeDAOCoinOrderBookImbalance(orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, band)
+		require.NoError(t, err)
+		return res
+	}
+	requireDecimal := func(expected string, actual string) {
+		expectedRat, ok := new(big.Rat).SetString(expected)
+		require.True(t, ok)
+		actualRat, ok := new(big.Rat).SetString(actual)
+		require.True(t, ok, actual)
+		require.Zero(t, expectedRat.Cmp(actualRat), "expected %v, got %v", expected, actual)
+	}
+
+	// Balanced book.
+	res := imbalance([]DAOCoinLimitOrderEntryResponse{bid("0.9", "10"), ask("1.1", "10")}, "")
+	requireDecimal("10", res.BidQuantity)
+	requireDecimal("10", res.AskQuantity)
+	require.Equal(t, 0.5, res.ImbalanceRatio)
+
+	// Bid-heavy book.
+	bidHeavy := []DAOCoinLimitOrderEntryResponse{bid("0.9", "20"), bid("0.5", "10"), ask("1.1", "10")}
+	res = imbalance(bidHeavy, "")
+	requireDecimal("30", res.BidQuantity)
+	require.Equal(t, 0.75, res.ImbalanceRatio)
+	// The bid at 0.5 is outside a 20% band around the mid-price of 1.
+	res = imbalance(bidHeavy, "20")
+	requireDecimal("20", res.BidQuantity)
+	require.InDelta(t, 2.0/3.0, res.ImbalanceRatio, 1e-9)
+
+	// Ask-heavy book.
+	res = imbalance([]DAOCoinLimitOrderEntryResponse{bid("0.9", "10"), ask("1.1", "20"), ask("1.2", "10")}, "")
+	requireDecimal("30", res.AskQuantity)
+	require.Equal(t, 0.25, res.ImbalanceRatio)
+
+	// Empty sides.
+	res = imbalance([]DAOCoinLimitOrderEntryResponse{bid("0.9", "10")}, "20")
+	requireDecimal("10", res.BidQuantity)
+	requireDecimal("0", res.AskQuantity)
+	require.Equal(t, 1.0, res.ImbalanceRatio)
+	res = imbalance([]DAOCoinLimitOrderEntryResponse{ask("1.1", "10")}, "")
+	require.Equal(t, 0.0, res.ImbalanceRatio)
+	res = imbalance(nil, "")
+	require.Equal(t, 0.5, res.ImbalanceRatio)
+}
+
+func TestComputeDAOCoinCrossRate(t *testing.T) {
+	coinA := daoCoinPubKeyBase58Check
+	coinB := senderPkString
+	newOrder := func(buyingCoin string, sellingCoin string, operationType DAOCoinLimitOrderOperationTypeString,
+		price string) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoin,
+			SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoin,
+			OperationType: operationType,
+			Price:         price,
+			Quantity:      "10",
+		}
+	}
+	// Coin A is bid at 2 $DESO and offered at 3. Coin B is bid at 0.4 $DESO and offered at 0.5 and 0.8.
+	coinAOrders := []DAOCoinLimitOrderEntryResponse{
+		newOrder(coinA, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "2"),
+		newOrder(coinA, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "1.5"),
+		newOrder(desoPubKeyBase58Check, coinA, DAOCoinLimitOrderOperationTypeStringASK, "3"),
+	}
+	coinBOrders := []DAOCoinLimitOrderEntryResponse{
+		newOrder(coinB, desoPubKeyBase58Check, DAOCoinLimitOrderOperationTypeStringBID, "0.4"),
+		newOrder(desoPubKeyBase58Check, coinB, DAOCoinLimitOrderOperationTypeStringASK, "0.8"),
+		newOrder(desoPubKeyBase58Check, coinB, DAOCoinLimitOrderOperationTypeStringASK, "0.5"),
+	}
+	requireDecimal := func(expected string, actual string) {
+		expectedRat, ok := new(big.Rat).SetString(expected)
+		require.True(t, ok)
+		actualRat, ok := new(big.Rat).SetString(actual)
+		require.True(t, ok, actual)
+		require.Zero(t, expectedRat.Cmp(actualRat), "expected %v, got %v", expected, actual)
+	}
+
+	// Selling 1 coin A at 2 $DESO buys 4 coin B at 0.5 $DESO each.
+	res, err := ComputeDAOCoinCrossRate(coinAOrders, coinBOrders, coinA, coinB)
+	require.NoError(t, err)
+	require.True(t, res.IsIndicative)
+	requireDecimal("2", res.CoinABestBidInDESO)
+	requireDecimal("0.5", res.CoinBBestAskInDESO)
+	requireDecimal("4", res.CrossRate)
+
+	// Routing the other way uses coin B's bid and coin A's ask.
+	res, err = ComputeDAOCoinCrossRate(coinBOrders, coinAOrders, coinB, coinA)
+	require.NoError(t, err)
+	requireDecimal("0.4", res.CoinABestBidInDESO)
+	requireDecimal("3", res.CoinBBestAskInDESO)
+	requireDecimal("0.13333333333333333333333333333333333333", res.CrossRate)
+
+	// Without any asks for coin B there's no rate, but coin A's leg is still reported.
+	res, err = ComputeDAOCoinCrossRate(coinAOrders, coinBOrders[:1], coinA, coinB)
+	require.NoError(t, err)
+	require.True(t, res.IsIndicative)
+	requireDecimal("2", res.CoinABestBidInDESO)
+	require.Empty(t, res.CoinBBestAskInDESO)
+	require.Empty(t, res.CrossRate)
+
+	// Nor is there one if coin A has no book at all.
+	res, err = ComputeDAOCoinCrossRate(nil, coinBOrders, coinA, coinB)
+	require.NoError(t, err)
+	require.Empty(t, res.CoinABestBidInDESO)
+	require.Empty(t, res.CrossRate)
+}
+
+func TestPaginateDAOCoinLimitOrders(t *testing.T) {
+	newOrder := func(orderID string, price string) DAOCoinLimitOrderEntryResponse {
+		return DAOCoinLimitOrderEntryResponse{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  daoCoinPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+			OperationType: DAOCoinLimitOrderOperationTypeStringBID,
+			Price:         price,
+			Quantity:      "1",
+			OrderID:       orderID,
+		}
+	}
+	// Sorted by price, then OrderID: a, b, c, d, e.
+	orders := []DAOCoinLimitOrderEntryResponse{
+		newOrder("e", "3"), newOrder("c", "2"), newOrder("a", "1"), newOrder("d", "2.5"), newOrder("b", "2"),
+	}
+	paginate := func(orders []DAOCoinLimitOrderEntryResponse, encodedToken string) (
+		_orderIDs []string, _nextPaginationToken string, _shouldRestart bool) {
+		var token *DAOCoinLimitOrdersPaginationToken
+		if encodedToken != "" {
+			var err error
+			token, err = DecodeDAOCoinLimitOrdersPaginationToken(encodedToken)
+			require.NoError(t, err)
+		}
+		page, nextPaginationToken, shouldRestart, err := PaginateDAOCoinLimitOrders(
+			orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, 2, token)
+		require.NoError(t, err)
+		orderIDs := []string{}
+		for _, order := range page {
+			orderIDs = append(orderIDs, order.OrderID)
+		}
+		return orderIDs, nextPaginationToken, shouldRestart
+	}
+
+	// Paging through a static book returns every order once, in order.
+	orderIDs, firstToken, shouldRestart := paginate(orders, "")
+	require.False(t, shouldRestart)
+	require.Equal(t, []string{"a", "b"}, orderIDs)
+	orderIDs, secondToken, shouldRestart := paginate(orders, firstToken)
+	require.False(t, shouldRestart)
+	require.Equal(t, []string{"c", "d"}, orderIDs)
+	orderIDs, lastToken, shouldRestart := paginate(orders, secondToken)
+	require.False(t, shouldRestart)
+	require.Equal(t, []string{"e"}, orderIDs)
+	require.Empty(t, lastToken)
+
+	// Orders added or removed after the token's position don't disrupt paging.
+	changedAfterToken := append([]DAOCoinLimitOrderEntryResponse{newOrder("f", "2.2")}, orders[1:]...)
+	orderIDs, _, shouldRestart = paginate(changedAfterToken, firstToken)
+	require.False(t, shouldRestart)
+	require.Equal(t, []string{"c", "f"}, orderIDs)
+
+	// Removing, adding, or partially filling an order that was already returned signals a restart.
+	removedOrder := append([]DAOCoinLimitOrderEntryResponse{}, orders[:2]...)
+	removedOrder = append(removedOrder, orders[3:]...)
+	partiallyFilledOrder := append([]DAOCoinLimitOrderEntryResponse{}, orders...)
+	partiallyFilledOrder[4].Quantity = "0.5"
+	for _, changedBook := range [][]DAOCoinLimitOrderEntryResponse{
+		removedOrder,
+		append([]DAOCoinLimitOrderEntryResponse{newOrder("0", "0.5")}, orders...),
+		partiallyFilledOrder,
+	} {
+		orderIDs, nextPaginationToken, shouldRestart := paginate(changedBook, secondToken)
+		require.True(t, shouldRestart)
+		require.Empty(t, orderIDs)
+		require.Empty(t, nextPaginationToken)
+	}
+
+	// Malformed and unversioned tokens are rejected.
+	_, err := DecodeDAOCoinLimitOrdersPaginationToken("not a token")
+	require.Error(t, err)
+	unversionedToken, err := EncodeDAOCoinLimitOrdersPaginationToken(&DAOCoinLimitOrdersPaginationToken{Price: "1"})
+	require.NoError(t, err)
+	_, err = DecodeDAOCoinLimitOrdersPaginationToken(unversionedToken)
+	require.Error(t, err)
+}
+
+func TestGetAvailableBalanceForOrder(t *testing.T) {
+	daoCoinPKID := &lib.PKID{0x01}
+	otherDAOCoinPKID := &lib.PKID{0x02}
+	coins := func(numCoins uint64) *uint256.Int {
+		return new(uint256.Int).Mul(uint256.NewInt(numCoins), lib.BaseUnitsPerCoin)
+	}
+	orders := []*lib.DAOCoinLimitOrderEntry{
+		// Sells 3 DAO coins for $DESO.
+		{
+			BuyingDAOCoinCreatorPKID:                  &lib.ZeroPKID,
+			SellingDAOCoinCreatorPKID:                 daoCoinPKID,
+			OperationType:                             lib.DAOCoinLimitOrderOperationTypeASK,
+			ScaledExchangeRateCoinsToSellPerCoinToBuy: lib.OneE38,
+			QuantityToFillInBaseUnits:                 coins(3),
+		},
+		// Sells 2 DAO coins for 2 of another DAO coin.
+		{
+			BuyingDAOCoinCreatorPKID:                  otherDAOCoinPKID,
+			SellingDAOCoinCreatorPKID:                 daoCoinPKID,
+			OperationType:                             lib.DAOCoinLimitOrderOperationTypeBID,
+			ScaledExchangeRateCoinsToSellPerCoinToBuy: lib.OneE38,
+			QuantityToFillInBaseUnits:                 coins(2),
+		},
+		// Sells the other DAO coin, so it doesn't commit any of the first.
+		{
+			BuyingDAOCoinCreatorPKID:                  daoCoinPKID,
+			SellingDAOCoinCreatorPKID:                 otherDAOCoinPKID,
+			OperationType:                             lib.DAOCoinLimitOrderOperationTypeASK,
+			ScaledExchangeRateCoinsToSellPerCoinToBuy: lib.OneE38,
+			QuantityToFillInBaseUnits:                 coins(7),
+		},
+	}
+
+	// Every order selling the coin counts, whatever it's buying.
+	committedBaseUnits, err := SumDAOCoinLimitOrderSellingBaseUnits(orders, daoCoinPKID, nil)
+	require.NoError(t, err)
+	require.Equal(t, coins(5), committedBaseUnits)
+	// The order validators only count the orders for the same pair.
+	pairCommittedBaseUnits, err := SumDAOCoinLimitOrderSellingBaseUnits(orders, daoCoinPKID, &lib.ZeroPKID)
+	require.NoError(t, err)
+	require.Equal(t, coins(3), pairCommittedBaseUnits)
+
+	// Open orders reduce the available balance.
+	res, err := buildAvailableBalanceForOrderResponse(daoCoinPubKeyBase58Check, coins(12), committedBaseUnits)
+	require.NoError(t, err)
+	require.Equal(t, &GetAvailableBalanceForOrderResponse{
+		TotalBalanceBaseUnits:          "12000000000000000000",
+		TotalBalance:                   "12.0",
+		CommittedToOpenOrdersBaseUnits: "5000000000000000000",
+		CommittedToOpenOrders:          "5.0",
+		AvailableBalanceBaseUnits:      "7000000000000000000",
+		AvailableBalance:               "7.0",
+	}, res)
+
+	// The available balance never goes negative.
+	res, err = buildAvailableBalanceForOrderResponse(daoCoinPubKeyBase58Check, coins(4), committedBaseUnits)
+	require.NoError(t, err)
+	require.Equal(t, "0", res.AvailableBalanceBaseUnits)
+
+	// $DESO balances are formatted in nanos.
+	res, err = buildAvailableBalanceForOrderResponse(
+		DESOCoinIdentifierString, uint256.NewInt(3*lib.NanosPerUnit), uint256.NewInt(lib.NanosPerUnit/2))
+	require.NoError(t, err)
+	require.Equal(t, "2500000000", res.AvailableBalanceBaseUnits)
+	require.Equal(t, "2.5", res.AvailableBalance)
+}
+
+func TestParseDAOCoinLimitOrderCreationRequest(t *testing.T) {
+	type testCaseType struct {
+		name                              string
+		buyingCoin                        string
+		sellingCoin                       string
+		operationType                     DAOCoinLimitOrderOperationTypeString
+		price                             string
+		quantity                          string
+		expectedScaledExchangeRate        string
+		expectedQuantityToFillInBaseUnits string
+	}
+	testCases := []testCaseType{
+		{
+			// 2 $DESO per DAO coin is 2e38 scaled, divided by 1e9 since DESO nanos are 1e9 times larger than DAO coin
+			// base units.
+			name:                              "BID for a DAO coin with $DESO",
+			buyingCoin:                        daoCoinPubKeyBase58Check,
+			sellingCoin:                       desoPubKeyBase58Check,
+			operationType:                     DAOCoinLimitOrderOperationTypeStringBID,
+			price:                             "2",
+			quantity:                          "3",
+			expectedScaledExchangeRate:        "200000000000000000000000000000",
+			expectedQuantityToFillInBaseUnits: "3000000000000000000",
+		},
+		{
+			// The inverse, 0.5 DAO coins per $DESO, is 5e37 scaled, multiplied by 1e9.
+			name:                              "ASK of a DAO coin for $DESO",
+			buyingCoin:                        desoPubKeyBase58Check,
+			sellingCoin:                       daoCoinPubKeyBase58Check,
+			operationType:                     DAOCoinLimitOrderOperationTypeStringASK,
+			price:                             "2",
+			quantity:                          "3",
+			expectedScaledExchangeRate:        "50000000000000000000000000000000000000000000000",
+			expectedQuantityToFillInBaseUnits: "3000000000000000000",
+		},
+		{
+			// The quantity is in $DESO nanos since $DESO is being bought.
+			name:                              "BID for $DESO with a DAO coin",
+			buyingCoin:                        desoPubKeyBase58Check,
+			sellingCoin:                       daoCoinPubKeyBase58Check,
+			operationType:                     DAOCoinLimitOrderOperationTypeStringBID,
+			price:                             "0.5",
+			quantity:                          "10",
+			expectedScaledExchangeRate:        "50000000000000000000000000000000000000000000000",
+			expectedQuantityToFillInBaseUnits: "10000000000",
+		},
+		{
+			name:                              "DAO-to-DAO BID",
+			buyingCoin:                        daoCoinPubKeyBase58Check,
+			sellingCoin:                       moneyPkString,
+			operationType:                     DAOCoinLimitOrderOperationTypeStringBID,
+			price:                             "4",
+			quantity:                          "1.5",
+			expectedScaledExchangeRate:        "400000000000000000000000000000000000000",
+			expectedQuantityToFillInBaseUnits: "1500000000000000000",
+		},
+		{
+			name:                              "DAO-to-DAO ASK",
+			buyingCoin:                        moneyPkString,
+			sellingCoin:                       daoCoinPubKeyBase58Check,
+			operationType:                     DAOCoinLimitOrderOperationTypeStringASK,
+			price:                             "4",
+			quantity:                          "1.5",
+			expectedScaledExchangeRate:        "25000000000000000000000000000000000000",
+			expectedQuantityToFillInBaseUnits: "1500000000000000000",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			// The deprecated float fields are ignored when the strings are set.
+			_, fillType, scaledExchangeRate, quantityToFillInBaseUnits, err := parseDAOCoinLimitOrderCreationRequest(
+				&DAOCoinLimitOrderCreationRequest{
+					BuyingDAOCoinCreatorPublicKeyBase58Check:  testCase.buyingCoin,
+					SellingDAOCoinCreatorPublicKeyBase58Check: testCase.sellingCoin,
+					OperationType:                       testCase.operationType,
+					Price:                               testCase.price,
+					Quantity:                            testCase.quantity,
+					ExchangeRateCoinsToSellPerCoinToBuy: 99,
+					QuantityToFill:                      99,
+				})
+			require.NoError(t, err)
+			require.Equal(t, lib.DAOCoinLimitOrderFillTypeGoodTillCancelled, fillType)
+			require.Equal(t, testCase.expectedScaledExchangeRate, scaledExchangeRate.ToBig().Text(10))
+			require.Equal(t, testCase.expectedQuantityToFillInBaseUnits, quantityToFillInBaseUnits.ToBig().Text(10))
+		})
+	}
+}
@@ -112,7 +112,7 @@ func (fes *APIServer) UpdateDaoCoinMarketFees(ww http.ResponseWriter, req *http.
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UpdateDaoCoinMarketFeesRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("UpdateDaoCoinMarketFees: Problem parsing request body: %v", err))
 		return
 	}
@@ -413,12 +413,12 @@ func (fes *APIServer) GetDaoCoinMarketFees(ww http.ResponseWriter, req *http.Req
 	}
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetDaoCoinMarketFeesRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetDaoCoinMarketFees: Problem parsing request body: %v", err))
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetDaoCoinMarketFees")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetDaoCoinMarketFees: Error fetching mempool view: %v", err))
 		return
@@ -875,12 +875,12 @@ func (fes *APIServer) GetBaseCurrencyPriceForEntry(
 func (fes *APIServer) GetBaseCurrencyPriceEndpoint(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetBaseCurrencyPriceRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetBaseCurrencyPrice: Problem parsing request body: %v", err))
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetBaseCurrencyPriceEndpoint")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetBaseCurrencyPrice: Error fetching mempool view: %v", err))
 		return
@@ -918,7 +918,7 @@ type GetQuoteCurrencyPriceInUsdResponse struct {
 func (fes *APIServer) GetQuoteCurrencyPriceInUsdEndpoint(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetQuoteCurrencyPriceInUsdRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetQuoteCurrencyPriceInUsd: Problem parsing request body: %v", err))
 		return
 	}
@@ -956,7 +956,7 @@ func GetFocusFloorPriceDESONanos(isTestnet bool) uint64 {
 
 func (fes *APIServer) GetQuoteCurrencyPriceInUsd(
 	quoteCurrencyPublicKey string) (_midmarket string, _bid string, _ask string, _err error) {
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetQuoteCurrencyPriceInUsd")
 	if err != nil {
 		return "", "", "", fmt.Errorf(
 			"GetQuoteCurrencyPriceInUsd: Error fetching mempool view: %v", err)
@@ -1189,7 +1189,7 @@ func (fes *APIServer) MaybeCreateTokenWhitelistAssociation(
 	utxoView := optionalUtxoView
 	if utxoView == nil {
 		var err error
-		utxoView, err = fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		utxoView, err = fes.getAugmentedUniversalView("MaybeCreateTokenWhitelistAssociation")
 		if err != nil {
 			return nil, fmt.Errorf("MaybeCreateTokenWhitelistAssociation: Error fetching mempool view: %v", err)
 		}
@@ -1532,7 +1532,7 @@ func (fes *APIServer) HandleMarketOrder(
 	// user's balance after the order has been executed.
 	//
 	// Get a universal view to validate as we go
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("HandleMarketOrder")
 	if err != nil {
 		return nil, fmt.Errorf("HandleMarketOrder: Error fetching mempool view: %v", err)
 	}
@@ -2297,7 +2297,7 @@ func (fes *APIServer) CreateDAOCoinLimitOrderWithFee(ww http.ResponseWriter, req
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := DAOCoinLimitOrderWithFeeRequest{}
 
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateDAOCoinLimitOrderWithFee: Problem parsing request body: %v", err))
 		return
 	}
@@ -2352,7 +2352,7 @@ func (fes *APIServer) CreateDAOCoinLimitOrderWithFee(ww http.ResponseWriter, req
 	}
 
 	// Get a universal view to do more sophisticated validation
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("CreateDAOCoinLimitOrderWithFee")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateDAOCoinLimitOrderWithFee: Error fetching mempool view: %v", err))
 		return
@@ -0,0 +1,300 @@
+package routes
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/deso-protocol/uint256"
+	"github.com/pkg/errors"
+)
+
+const (
+	// The number of fills GetTransactorFillHistory returns when a request omits NumToFetch, and the most it returns.
+	DefaultDAOCoinFillHistoryNumToFetch = 50
+	MaxDAOCoinFillHistoryNumToFetch     = 500
+
+	// The most blocks GetTransactorFillHistory scans per request. A transactor who rarely trades may get a page with
+	// few or no fills and a cursor to keep scanning from.
+	MaxDAOCoinFillHistoryBlocksScanned = 1000
+)
+
+type DAOCoinFillRole string
+
+const (
+	// The transactor's order was placed by the txn that matched it.
+	DAOCoinFillRoleTaker DAOCoinFillRole = "Taker"
+	// The transactor's order was resting on the book and another transactor's txn matched it.
+	DAOCoinFillRoleMaker DAOCoinFillRole = "Maker"
+)
+
+// DAOCoinFill is one match between the transactor's order and a counterparty's, from the transactor's side.
+type DAOCoinFill struct {
+	TxnHashHex     string `safeForLogging:"true"`
+	BlockHeight    uint64 `safeForLogging:"true"`
+	TimestampNanos int64  `safeForLogging:"true"`
+
+	Role                             DAOCoinFillRole `safeForLogging:"true"`
+	CounterpartyPublicKeyBase58Check string          `safeForLogging:"true"`
+
+	// The coins the transactor bought and sold in the fill. "DESO" for $DESO.
+	BuyingDAOCoinCreatorPublicKeyBase58Check  string `safeForLogging:"true"`
+	SellingDAOCoinCreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// Decimal strings (ex: 1.23) in whole coins.
+	QuantityBought string `safeForLogging:"true"`
+	QuantitySold   string `safeForLogging:"true"`
+	// The selling coin paid per buying coin received, i.e. QuantitySold / QuantityBought.
+	Price string `safeForLogging:"true"`
+}
+
+type GetTransactorFillHistoryRequest struct {
+	TransactorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// The window of block heights to scan, inclusive. StartBlockHeight defaults to 0 and EndBlockHeight to the
+	// latest block txindex has processed. To page backwards, pass the previous response's NextEndBlockHeight as
+	// EndBlockHeight.
+	StartBlockHeight uint64 `safeForLogging:"true"`
+	EndBlockHeight   uint64 `safeForLogging:"true"`
+
+	// Defaults to DefaultDAOCoinFillHistoryNumToFetch. Fills from the same block are never split across pages, so a
+	// page can have a few more.
+	NumToFetch int `safeForLogging:"true"`
+}
+
+type GetTransactorFillHistoryResponse struct {
+	// Newest first.
+	Fills []DAOCoinFill
+
+	// The EndBlockHeight to request the next page with. Unset once the whole window has been scanned.
+	NextEndBlockHeight *uint64 `json:",omitempty" safeForLogging:"true"`
+}
+
+// GetTransactorFillHistory returns a transactor's DAO coin limit order fills in a window of blocks, whether they
+// placed the matching order or it was matched while resting on the book. Fills are read from txindex, so the node
+// must run with --txindex, and fills still in the mempool aren't included.
+func (fes *APIServer) GetTransactorFillHistory(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetTransactorFillHistoryRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorFillHistory: Problem parsing request body: %v", err))
+		return
+	}
+
+	if fes.TXIndex == nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorFillHistory: Cannot be called when TXIndexChain "+
+			"is nil. This error occurs when --txindex was not passed to the program on startup"))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorFillHistory: %v", err))
+		return
+	}
+	transactorPkBytes, _, err := lib.Base58CheckDecode(requestData.TransactorPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorFillHistory: Problem decoding public key %v: %v",
+			requestData.TransactorPublicKeyBase58Check, err))
+		return
+	}
+	// Fill metadata identifies transactors by the public key encoded for this network.
+	transactorPublicKeyBase58Check := lib.PkToString(transactorPkBytes, fes.Params)
+
+	numToFetch := requestData.NumToFetch
+	if numToFetch == 0 {
+		numToFetch = DefaultDAOCoinFillHistoryNumToFetch
+	}
+	if numToFetch < 0 || numToFetch > MaxDAOCoinFillHistoryNumToFetch {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorFillHistory: NumToFetch must be between 1 and %v",
+			MaxDAOCoinFillHistoryNumToFetch))
+		return
+	}
+
+	txindexTipHeight := uint64(fes.TXIndex.TXIndexChain.BlockTip().Height)
+	endBlockHeight := requestData.EndBlockHeight
+	if endBlockHeight == 0 || endBlockHeight > txindexTipHeight {
+		endBlockHeight = txindexTipHeight
+	}
+	if requestData.StartBlockHeight > endBlockHeight {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactorFillHistory: StartBlockHeight %v is after "+
+			"EndBlockHeight %v", requestData.StartBlockHeight, endBlockHeight))
+		return
+	}
+
+	bestChain := fes.blockchain.BestChain()
+	fills, nextEndBlockHeight, err := collectTransactorFills(
+		requestData.StartBlockHeight, endBlockHeight, numToFetch,
+		func(blockHeight uint64) ([]DAOCoinFill, error) {
+			if blockHeight >= uint64(len(bestChain)) {
+				return nil, nil
+			}
+			blockNode := bestChain[blockHeight]
+			block, err := lib.GetBlock(blockNode.Hash, fes.blockchain.DB(), fes.blockchain.Snapshot())
+			if err != nil {
+				return nil, errors.Wrapf(err, "Problem fetching block %v", blockNode.Hash)
+			}
+			var blockFills []DAOCoinFill
+			for _, txn := range block.Txns {
+				if txn.TxnMeta == nil || txn.TxnMeta.GetTxnType() != lib.TxnTypeDAOCoinLimitOrder {
+					continue
+				}
+				txnHash := txn.Hash()
+				txnMeta := lib.DbGetTxindexTransactionRefByTxID(fes.TXIndex.TXIndexChain.DB(), nil, txnHash)
+				if txnMeta == nil {
+					continue
+				}
+				txnFills, err := getTransactorFillsFromTxnMetadata(transactorPublicKeyBase58Check, txnMeta)
+				if err != nil {
+					return nil, errors.Wrapf(err, "Problem reading fills for txn %v", txnHash)
+				}
+				for _, fill := range txnFills {
+					fill.TxnHashHex = hex.EncodeToString(txnHash[:])
+					fill.BlockHeight = blockHeight
+					fill.TimestampNanos = block.Header.TstampNanoSecs
+					blockFills = append(blockFills, fill)
+				}
+			}
+			return blockFills, nil
+		})
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorFillHistory: %v", err))
+		return
+	}
+
+	res := GetTransactorFillHistoryResponse{
+		Fills:              fills,
+		NextEndBlockHeight: nextEndBlockHeight,
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactorFillHistory: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// collectTransactorFills scans blocks from endBlockHeight down to startBlockHeight, stopping at the end of the first
+// block that brings the total to numToFetch fills, or after MaxDAOCoinFillHistoryBlocksScanned blocks. Fills within a
+// block are returned newest first. Returns the height to resume the scan from, or nil if the window is exhausted.
+func collectTransactorFills(
+	startBlockHeight uint64,
+	endBlockHeight uint64,
+	numToFetch int,
+	getFillsInBlock func(blockHeight uint64) ([]DAOCoinFill, error),
+) (_fills []DAOCoinFill, _nextEndBlockHeight *uint64, _err error) {
+	fills := []DAOCoinFill{}
+	blockHeight := endBlockHeight
+	for blocksScanned := 0; blocksScanned < MaxDAOCoinFillHistoryBlocksScanned; blocksScanned++ {
+		blockFills, err := getFillsInBlock(blockHeight)
+		if err != nil {
+			return nil, nil, err
+		}
+		for ii := len(blockFills) - 1; ii >= 0; ii-- {
+			fills = append(fills, blockFills[ii])
+		}
+		if blockHeight == startBlockHeight {
+			return fills, nil, nil
+		}
+		blockHeight--
+		if len(fills) >= numToFetch {
+			break
+		}
+	}
+	return fills, &blockHeight, nil
+}
+
+// getTransactorFillsFromTxnMetadata returns the fills in a DAO coin limit order txn that involve the transactor.
+// The txn's filled orders include the taker's own order alongside the makers' orders it matched. Each maker order is
+// one fill between that maker and the taker, and the taker's own entry is skipped since it just sums those up.
+func getTransactorFillsFromTxnMetadata(
+	transactorPublicKeyBase58Check string,
+	txnMeta *lib.TransactionMetadata,
+) ([]DAOCoinFill, error) {
+	if txnMeta.DAOCoinLimitOrderTxindexMetadata == nil {
+		return nil, nil
+	}
+	takerPublicKeyBase58Check := txnMeta.TransactorPublicKeyBase58Check
+
+	var fills []DAOCoinFill
+	for _, filledOrder := range txnMeta.DAOCoinLimitOrderTxindexMetadata.FilledDAOCoinLimitOrdersMetadata {
+		makerPublicKeyBase58Check := filledOrder.TransactorPublicKeyBase58Check
+		if makerPublicKeyBase58Check == takerPublicKeyBase58Check {
+			continue
+		}
+
+		var fill *DAOCoinFill
+		var err error
+		switch transactorPublicKeyBase58Check {
+		case makerPublicKeyBase58Check:
+			fill, err = newDAOCoinFill(DAOCoinFillRoleMaker, takerPublicKeyBase58Check,
+				filledOrder.BuyingDAOCoinCreatorPublicKey, filledOrder.CoinQuantityInBaseUnitsBought,
+				filledOrder.SellingDAOCoinCreatorPublicKey, filledOrder.CoinQuantityInBaseUnitsSold)
+		case takerPublicKeyBase58Check:
+			// The taker received what the maker sold and paid what the maker bought.
+			fill, err = newDAOCoinFill(DAOCoinFillRoleTaker, makerPublicKeyBase58Check,
+				filledOrder.SellingDAOCoinCreatorPublicKey, filledOrder.CoinQuantityInBaseUnitsSold,
+				filledOrder.BuyingDAOCoinCreatorPublicKey, filledOrder.CoinQuantityInBaseUnitsBought)
+		default:
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		fills = append(fills, *fill)
+	}
+	return fills, nil
+}
+
+func newDAOCoinFill(
+	role DAOCoinFillRole,
+	counterpartyPublicKeyBase58Check string,
+	buyingCoinPublicKeyBase58Check string,
+	quantityBoughtBaseUnits *uint256.Int,
+	sellingCoinPublicKeyBase58Check string,
+	quantitySoldBaseUnits *uint256.Int,
+) (*DAOCoinFill, error) {
+	if quantityBoughtBaseUnits == nil || quantityBoughtBaseUnits.IsZero() || quantitySoldBaseUnits == nil {
+		return nil, errors.New("Fill is missing its quantities")
+	}
+	// We always want to return the identifier string for DESO coins in the API response
+	if IsDesoPkid(buyingCoinPublicKeyBase58Check) {
+		buyingCoinPublicKeyBase58Check = DESOCoinIdentifierString
+	}
+	if IsDesoPkid(sellingCoinPublicKeyBase58Check) {
+		sellingCoinPublicKeyBase58Check = DESOCoinIdentifierString
+	}
+
+	quantityBought, err := CalculateStringDecimalAmountFromBaseUnitsSimple(
+		buyingCoinPublicKeyBase58Check, quantityBoughtBaseUnits)
+	if err != nil {
+		return nil, err
+	}
+	quantitySold, err := CalculateStringDecimalAmountFromBaseUnitsSimple(
+		sellingCoinPublicKeyBase58Check, quantitySoldBaseUnits)
+	if err != nil {
+		return nil, err
+	}
+
+	// (sold / 10^sellingDecimals) / (bought / 10^buyingDecimals)
+	coinsToBaseUnits := func(decimals int) *big.Int {
+		return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	}
+	price := new(big.Rat).SetFrac(
+		new(big.Int).Mul(quantitySoldBaseUnits.ToBig(),
+			coinsToBaseUnits(getCoinDecimals(IsDesoPkid(buyingCoinPublicKeyBase58Check)))),
+		new(big.Int).Mul(quantityBoughtBaseUnits.ToBig(),
+			coinsToBaseUnits(getCoinDecimals(IsDesoPkid(sellingCoinPublicKeyBase58Check)))),
+	)
+
+	return &DAOCoinFill{
+		Role:                                      role,
+		CounterpartyPublicKeyBase58Check:          counterpartyPublicKeyBase58Check,
+		BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoinPublicKeyBase58Check,
+		SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoinPublicKeyBase58Check,
+		QuantityBought:                            quantityBought,
+		QuantitySold:                              quantitySold,
+		Price:                                     formatDAOCoinLimitOrderPriceRat(price),
+	}, nil
+}
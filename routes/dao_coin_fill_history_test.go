@@ -0,0 +1,128 @@
+package routes
+
+import (
+	"testing"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/deso-protocol/uint256"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetTransactorFillsFromTxnMetadata(t *testing.T) {
+	desoNanos := func(deso uint64) *uint256.Int {
+		return uint256.NewInt(deso * lib.NanosPerUnit)
+	}
+	daoCoinBaseUnits := func(coins uint64) *uint256.Int {
+		return uint256.NewInt(0).Mul(uint256.NewInt(coins), lib.BaseUnitsPerCoin)
+	}
+	// The sender bids for 10 DAO coins at 2 $DESO each, matching the recipient's ask for 4 and a third party's ask
+	// for 6. The sender's own order is listed too.
+	txnMeta := &lib.TransactionMetadata{
+		TransactorPublicKeyBase58Check: senderPkString,
+		DAOCoinLimitOrderTxindexMetadata: &lib.DAOCoinLimitOrderTxindexMetadata{
+			FilledDAOCoinLimitOrdersMetadata: []*lib.FilledDAOCoinLimitOrderMetadata{
+				{
+					TransactorPublicKeyBase58Check: recipientPkString,
+					BuyingDAOCoinCreatorPublicKey:  DeSoZeroPkidTestnetBase58,
+					SellingDAOCoinCreatorPublicKey: daoCoinPubKeyBase58Check,
+					CoinQuantityInBaseUnitsBought:  desoNanos(8),
+					CoinQuantityInBaseUnitsSold:    daoCoinBaseUnits(4),
+				},
+				{
+					TransactorPublicKeyBase58Check: moneyPkString,
+					BuyingDAOCoinCreatorPublicKey:  DeSoZeroPkidTestnetBase58,
+					SellingDAOCoinCreatorPublicKey: daoCoinPubKeyBase58Check,
+					CoinQuantityInBaseUnitsBought:  desoNanos(12),
+					CoinQuantityInBaseUnitsSold:    daoCoinBaseUnits(6),
+				},
+				{
+					TransactorPublicKeyBase58Check: senderPkString,
+					BuyingDAOCoinCreatorPublicKey:  daoCoinPubKeyBase58Check,
+					SellingDAOCoinCreatorPublicKey: DeSoZeroPkidTestnetBase58,
+					CoinQuantityInBaseUnitsBought:  daoCoinBaseUnits(10),
+					CoinQuantityInBaseUnitsSold:    desoNanos(20),
+				},
+			},
+		},
+	}
+
+	// The taker gets one fill per maker, and not one for their own order.
+	fills, err := getTransactorFillsFromTxnMetadata(senderPkString, txnMeta)
+	require.NoError(t, err)
+	require.Len(t, fills, 2)
+	require.Equal(t, DAOCoinFill{
+		Role:                                      DAOCoinFillRoleTaker,
+		CounterpartyPublicKeyBase58Check:          recipientPkString,
+		BuyingDAOCoinCreatorPublicKeyBase58Check:  daoCoinPubKeyBase58Check,
+		SellingDAOCoinCreatorPublicKeyBase58Check: DESOCoinIdentifierString,
+		QuantityBought:                            "4.0",
+		QuantitySold:                              "8.0",
+		Price:                                     "2.0",
+	}, fills[0])
+	require.Equal(t, moneyPkString, fills[1].CounterpartyPublicKeyBase58Check)
+	require.Equal(t, "6.0", fills[1].QuantityBought)
+
+	// A maker only sees their own fill.
+	fills, err = getTransactorFillsFromTxnMetadata(recipientPkString, txnMeta)
+	require.NoError(t, err)
+	require.Equal(t, []DAOCoinFill{{
+		Role:                                      DAOCoinFillRoleMaker,
+		CounterpartyPublicKeyBase58Check:          senderPkString,
+		BuyingDAOCoinCreatorPublicKeyBase58Check:  DESOCoinIdentifierString,
+		SellingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+		QuantityBought:                            "8.0",
+		QuantitySold:                              "4.0",
+		Price:                                     "0.5",
+	}}, fills)
+
+	// Someone who wasn't part of the txn has no fills in it.
+	fills, err = getTransactorFillsFromTxnMetadata(DeSoZeroPkidTestnetBase58, txnMeta)
+	require.NoError(t, err)
+	require.Empty(t, fills)
+}
+
+func TestCollectTransactorFills(t *testing.T) {
+	// Two fills at heights 3 and 7, one at height 5.
+	getFillsInBlock := func(blockHeight uint64) ([]DAOCoinFill, error) {
+		switch blockHeight {
+		case 3, 7:
+			return []DAOCoinFill{
+				{BlockHeight: blockHeight, TxnHashHex: "first"},
+				{BlockHeight: blockHeight, TxnHashHex: "second"},
+			}, nil
+		case 5:
+			return []DAOCoinFill{{BlockHeight: blockHeight}}, nil
+		}
+		return nil, nil
+	}
+	heights := func(fills []DAOCoinFill) []uint64 {
+		var blockHeights []uint64
+		for _, fill := range fills {
+			blockHeights = append(blockHeights, fill.BlockHeight)
+		}
+		return blockHeights
+	}
+
+	// Only fills within the window are returned, newest first.
+	fills, nextEndBlockHeight, err := collectTransactorFills(4, 6, 10, getFillsInBlock)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5}, heights(fills))
+	require.Nil(t, nextEndBlockHeight)
+
+	fills, nextEndBlockHeight, err = collectTransactorFills(0, 10, 10, getFillsInBlock)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{7, 7, 5, 3, 3}, heights(fills))
+	require.Equal(t, "second", fills[0].TxnHashHex)
+	require.Nil(t, nextEndBlockHeight)
+
+	// Pages end on a block boundary, and the cursor resumes below the last block scanned.
+	fills, nextEndBlockHeight, err = collectTransactorFills(0, 10, 1, getFillsInBlock)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{7, 7}, heights(fills))
+	require.Equal(t, uint64(6), *nextEndBlockHeight)
+
+	fills, nextEndBlockHeight, err = collectTransactorFills(0, *nextEndBlockHeight, 1, getFillsInBlock)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{5}, heights(fills))
+	require.Equal(t, uint64(4), *nextEndBlockHeight)
+}
@@ -0,0 +1,353 @@
+package routes
+
+import (
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/golang/glog"
+	"github.com/pkg/errors"
+)
+
+// DAOCoinOrderBookCacheKey identifies a cached order book. DESO is always represented by DESOCoinIdentifierString so
+// that requests using the ZeroPKID encoding and requests using the identifier string share the same entry.
+type DAOCoinOrderBookCacheKey struct {
+	DAOCoin1CreatorPublicKeyBase58Check string
+	DAOCoin2CreatorPublicKeyBase58Check string
+	TxnStatus                           TxnStatus
+}
+
+func NewDAOCoinOrderBookCacheKey(coin1 string, coin2 string, txnStatus TxnStatus) DAOCoinOrderBookCacheKey {
+	if IsDesoPkid(coin1) {
+		coin1 = DESOCoinIdentifierString
+	}
+	if IsDesoPkid(coin2) {
+		coin2 = DESOCoinIdentifierString
+	}
+	if txnStatus == "" {
+		txnStatus = TxnStatusInMempool
+	}
+	return DAOCoinOrderBookCacheKey{
+		DAOCoin1CreatorPublicKeyBase58Check: coin1,
+		DAOCoin2CreatorPublicKeyBase58Check: coin2,
+		TxnStatus:                           txnStatus,
+	}
+}
+
+const (
+	DAOCoinOrderBookAggregationLadder              = "Ladder"
+	DAOCoinOrderBookAggregationPriceImpact         = "PriceImpact"
+	DAOCoinOrderBookAggregationLiquidityWithinBand = "LiquidityWithinBand"
+	DAOCoinOrderBookAggregationImbalance           = "Imbalance"
+)
+
+// DAOCoinOrderBookAggregationCacheKey identifies an aggregated view of a cached order book. AggregationParams must
+// be a canonical encoding of the parameters, so that equivalent requests share the same entry.
+type DAOCoinOrderBookAggregationCacheKey struct {
+	DAOCoinOrderBookCacheKey
+	AggregationType   string
+	AggregationParams string
+}
+
+type daoCoinOrderBookCacheEntry struct {
+	Orders []DAOCoinLimitOrderEntryResponse
+	// The block tip the orders were computed against. An entry is never served once the tip has moved on.
+	BlockTipHash lib.BlockHash
+	ComputedAt   time.Time
+}
+
+type daoCoinOrderBookAggregationCacheEntry struct {
+	Aggregation  interface{}
+	BlockTipHash lib.BlockHash
+	ComputedAt   time.Time
+}
+
+// DAOCoinOrderBookCache holds precomputed GetDAOCoinLimitOrders responses for popular pairs, along with the
+// aggregated views computed from them. Entries are invalidated as soon as a new block is connected, and expire after
+// ttl to bound how stale mempool orders can get in between.
+type DAOCoinOrderBookCache struct {
+	mtx sync.RWMutex
+
+	entries      map[DAOCoinOrderBookCacheKey]*daoCoinOrderBookCacheEntry
+	aggregations map[DAOCoinOrderBookAggregationCacheKey]*daoCoinOrderBookAggregationCacheEntry
+	// Number of reads per pair since the warmer last ran. Used to auto-detect hot pairs.
+	requestCounts map[DAOCoinOrderBookCacheKey]uint64
+
+	ttl time.Duration
+}
+
+func NewDAOCoinOrderBookCache(ttl time.Duration) *DAOCoinOrderBookCache {
+	return &DAOCoinOrderBookCache{
+		entries:       make(map[DAOCoinOrderBookCacheKey]*daoCoinOrderBookCacheEntry),
+		aggregations:  make(map[DAOCoinOrderBookAggregationCacheKey]*daoCoinOrderBookAggregationCacheEntry),
+		requestCounts: make(map[DAOCoinOrderBookCacheKey]uint64),
+		ttl:           ttl,
+	}
+}
+
+// Get returns the cached orders for key if they were computed against blockTipHash and have not expired.
+func (cache *DAOCoinOrderBookCache) Get(
+	key DAOCoinOrderBookCacheKey,
+	blockTipHash *lib.BlockHash,
+) ([]DAOCoinLimitOrderEntryResponse, bool) {
+	cache.mtx.RLock()
+	defer cache.mtx.RUnlock()
+
+	entry, exists := cache.entries[key]
+	if !exists || blockTipHash == nil || entry.BlockTipHash != *blockTipHash {
+		return nil, false
+	}
+	if time.Since(entry.ComputedAt) > cache.ttl {
+		return nil, false
+	}
+	return entry.Orders, true
+}
+
+func (cache *DAOCoinOrderBookCache) Put(
+	key DAOCoinOrderBookCacheKey,
+	blockTipHash *lib.BlockHash,
+	orders []DAOCoinLimitOrderEntryResponse,
+) {
+	if blockTipHash == nil {
+		return
+	}
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	cache.entries[key] = &daoCoinOrderBookCacheEntry{
+		Orders:       orders,
+		BlockTipHash: *blockTipHash,
+		ComputedAt:   time.Now(),
+	}
+}
+
+// GetOrComputeAggregation returns the cached aggregation for key if it was computed against blockTipHash and has not
+// expired. Otherwise it calls compute and caches the result. The result may be shared between requests, so it must
+// not be modified. compute is called without holding the lock, so concurrent misses may each compute the aggregation.
+func (cache *DAOCoinOrderBookCache) GetOrComputeAggregation(
+	key DAOCoinOrderBookAggregationCacheKey,
+	blockTipHash *lib.BlockHash,
+	compute func() (interface{}, error),
+) (interface{}, error) {
+	cache.mtx.RLock()
+	entry, exists := cache.aggregations[key]
+	cache.mtx.RUnlock()
+	if exists && blockTipHash != nil && entry.BlockTipHash == *blockTipHash && time.Since(entry.ComputedAt) <= cache.ttl {
+		return entry.Aggregation, nil
+	}
+
+	aggregation, err := compute()
+	if err != nil {
+		return nil, err
+	}
+	if blockTipHash == nil {
+		return aggregation, nil
+	}
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	cache.aggregations[key] = &daoCoinOrderBookAggregationCacheEntry{
+		Aggregation:  aggregation,
+		BlockTipHash: *blockTipHash,
+		ComputedAt:   time.Now(),
+	}
+	return aggregation, nil
+}
+
+// RecordRequest bumps the request counter used to auto-detect hot pairs.
+func (cache *DAOCoinOrderBookCache) RecordRequest(key DAOCoinOrderBookCacheKey) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	cache.requestCounts[key]++
+}
+
+// PopMostRequestedKeys returns up to maxKeys of the most requested pairs since the last call and resets the counters.
+func (cache *DAOCoinOrderBookCache) PopMostRequestedKeys(maxKeys int) []DAOCoinOrderBookCacheKey {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+
+	keys := make([]DAOCoinOrderBookCacheKey, 0, len(cache.requestCounts))
+	for key := range cache.requestCounts {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(ii, jj int) bool {
+		return cache.requestCounts[keys[ii]] > cache.requestCounts[keys[jj]]
+	})
+	if len(keys) > maxKeys {
+		keys = keys[:maxKeys]
+	}
+	cache.requestCounts = make(map[DAOCoinOrderBookCacheKey]uint64)
+	return keys
+}
+
+// Evict drops every entry and aggregation that was not computed against blockTipHash.
+func (cache *DAOCoinOrderBookCache) Evict(blockTipHash *lib.BlockHash) {
+	cache.mtx.Lock()
+	defer cache.mtx.Unlock()
+	for key, entry := range cache.entries {
+		if blockTipHash == nil || entry.BlockTipHash != *blockTipHash {
+			delete(cache.entries, key)
+		}
+	}
+	for key, entry := range cache.aggregations {
+		if blockTipHash == nil || entry.BlockTipHash != *blockTipHash {
+			delete(cache.aggregations, key)
+		}
+	}
+}
+
+// getDAOCoinOrderBookAggregation returns aggregate applied to the coin1/coin2 book, sharing both the book and the
+// result with other requests for the same aggregation through the order book cache when it's enabled.
+func (fes *APIServer) getDAOCoinOrderBookAggregation(
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+	txnStatus TxnStatus,
+	aggregationType string,
+	aggregationParams string,
+	aggregate func(orders []DAOCoinLimitOrderEntryResponse) (interface{}, error),
+) (interface{}, error) {
+	if fes.DAOCoinOrderBookCache == nil {
+		utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "getDAOCoinOrderBookAggregation")
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem fetching utxoView")
+		}
+		orders, err := fes.getDAOCoinLimitOrdersForCoinPair(utxoView, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error getting limit orders")
+		}
+		return aggregate(orders)
+	}
+
+	// As in GetDAOCoinLimitOrders, the block tip is read before the view is built so that nothing computed here can
+	// outlive the block it was computed against.
+	blockTipHash := fes.blockchain.BlockTip().Hash
+	pairKey := NewDAOCoinOrderBookCacheKey(coin1PublicKeyBase58Check, coin2PublicKeyBase58Check, txnStatus)
+	fes.DAOCoinOrderBookCache.RecordRequest(pairKey)
+	aggregationKey := DAOCoinOrderBookAggregationCacheKey{
+		DAOCoinOrderBookCacheKey: pairKey,
+		AggregationType:          aggregationType,
+		AggregationParams:        aggregationParams,
+	}
+	return fes.DAOCoinOrderBookCache.GetOrComputeAggregation(aggregationKey, blockTipHash, func() (interface{}, error) {
+		orders, exists := fes.DAOCoinOrderBookCache.Get(pairKey, blockTipHash)
+		if !exists {
+			utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "getDAOCoinOrderBookAggregation")
+			if err != nil {
+				return nil, errors.Wrapf(err, "Problem fetching utxoView")
+			}
+			orders, err = fes.getDAOCoinLimitOrdersForCoinPair(
+				utxoView, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Error getting limit orders")
+			}
+			fes.DAOCoinOrderBookCache.Put(pairKey, blockTipHash, orders)
+		}
+		return aggregate(orders)
+	})
+}
+
+// ParseDAOCoinOrderBookHotPairs parses the dao-coin-order-book-hot-pairs flag. Each pair is of the form
+// "<coin1>:<coin2>" where each coin is either a base58 public key or DESO.
+func ParseDAOCoinOrderBookHotPairs(hotPairs []string) ([]DAOCoinOrderBookCacheKey, error) {
+	var keys []DAOCoinOrderBookCacheKey
+	for _, hotPair := range hotPairs {
+		coins := strings.Split(strings.TrimSpace(hotPair), ":")
+		if len(coins) != 2 || coins[0] == "" || coins[1] == "" {
+			return nil, errors.Errorf("ParseDAOCoinOrderBookHotPairs: Invalid pair %v, expected <coin1>:<coin2>", hotPair)
+		}
+		if IsDesoPkid(coins[0]) && IsDesoPkid(coins[1]) {
+			return nil, errors.Errorf("ParseDAOCoinOrderBookHotPairs: Pair %v must include at least one DAO coin", hotPair)
+		}
+		keys = append(keys, NewDAOCoinOrderBookCacheKey(coins[0], coins[1], TxnStatusInMempool))
+	}
+	return keys, nil
+}
+
+// StartDAOCoinOrderBookWarmer periodically recomputes the order book for the configured hot pairs, plus the most
+// requested pairs since the previous run, so that client reads are served from a warm cache.
+func (fes *APIServer) StartDAOCoinOrderBookWarmer() {
+	hotPairs, err := ParseDAOCoinOrderBookHotPairs(fes.Config.DAOCoinOrderBookHotPairs)
+	if err != nil {
+		glog.Errorf("StartDAOCoinOrderBookWarmer: %v", err)
+		return
+	}
+	glog.Infof("Starting DAO coin order book warmer for %d configured pairs.", len(hotPairs))
+
+	go func() {
+	out:
+		for {
+			select {
+			case <-time.After(fes.getDAOCoinOrderBookWarmInterval()):
+				fes.WarmDAOCoinOrderBooks(hotPairs)
+			case <-fes.quit:
+				break out
+			}
+		}
+	}()
+}
+
+func (fes *APIServer) getDAOCoinOrderBookWarmInterval() time.Duration {
+	if fes.Config.DAOCoinOrderBookWarmIntervalMillis == 0 {
+		return time.Second
+	}
+	return time.Duration(fes.Config.DAOCoinOrderBookWarmIntervalMillis) * time.Millisecond
+}
+
+// WarmDAOCoinOrderBooks recomputes and caches the order books for the given pairs along with the most requested pairs.
+func (fes *APIServer) WarmDAOCoinOrderBooks(hotPairs []DAOCoinOrderBookCacheKey) {
+	if fes.DAOCoinOrderBookCache == nil {
+		return
+	}
+	// Prevent access to the DB while it's reset. This only happens when we're syncing a snapshot.
+	if fes.backendServer.GetBlockchain().ChainState() == lib.SyncStateSyncingSnapshot {
+		return
+	}
+
+	keysToWarm := append([]DAOCoinOrderBookCacheKey{}, hotPairs...)
+	if fes.Config.DAOCoinOrderBookAutoWarmPairs > 0 {
+		keysToWarm = append(keysToWarm, fes.DAOCoinOrderBookCache.PopMostRequestedKeys(fes.Config.DAOCoinOrderBookAutoWarmPairs)...)
+	}
+
+	blockTipHash := fes.blockchain.BlockTip().Hash
+	fes.DAOCoinOrderBookCache.Evict(blockTipHash)
+
+	utxoViews := make(map[TxnStatus]*lib.UtxoView)
+	warmedKeys := make(map[DAOCoinOrderBookCacheKey]bool)
+	for _, key := range keysToWarm {
+		if warmedKeys[key] {
+			continue
+		}
+		warmedKeys[key] = true
+
+		utxoView, exists := utxoViews[key.TxnStatus]
+		if !exists {
+			var err error
+			utxoView, err = fes.GetUtxoViewGivenTxnStatus(key.TxnStatus, "WarmDAOCoinOrderBooks")
+			if err != nil {
+				glog.Errorf("WarmDAOCoinOrderBooks: Problem fetching utxoView: %v", err)
+				return
+			}
+			utxoViews[key.TxnStatus] = utxoView
+		}
+
+		orders, err := fes.getDAOCoinLimitOrdersForCoinPair(
+			utxoView, key.DAOCoin1CreatorPublicKeyBase58Check, key.DAOCoin2CreatorPublicKeyBase58Check)
+		if err != nil {
+			glog.Errorf("WarmDAOCoinOrderBooks: Problem warming pair %v: %v", key, err)
+			continue
+		}
+		fes.DAOCoinOrderBookCache.Put(key, blockTipHash, orders)
+
+		// Spread history is only served for the in-mempool book.
+		if fes.DAOCoinSpreadHistory != nil && key.TxnStatus == TxnStatusInMempool {
+			sample, err := NewDAOCoinSpreadSample(
+				time.Now(), orders, key.DAOCoin1CreatorPublicKeyBase58Check, key.DAOCoin2CreatorPublicKeyBase58Check)
+			if err != nil {
+				glog.Errorf("WarmDAOCoinOrderBooks: Problem sampling spread for pair %v: %v", key, err)
+				continue
+			}
+			fes.DAOCoinSpreadHistory.Record(key, sample)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package routes
+
+import (
+	"testing"
+	"time"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDAOCoinOrderBookCache(t *testing.T) {
+	key := NewDAOCoinOrderBookCacheKey(DeSoZeroPkidTestnetBase58, senderPkString, "")
+	require.Equal(t, DESOCoinIdentifierString, key.DAOCoin1CreatorPublicKeyBase58Check)
+	require.Equal(t, TxnStatusInMempool, key.TxnStatus)
+
+	blockTipHash := &lib.BlockHash{0x01}
+	nextBlockTipHash := &lib.BlockHash{0x02}
+	orders := []DAOCoinLimitOrderEntryResponse{{OrderID: "order"}}
+
+	// Entries are served for the block they were computed against.
+	cache := NewDAOCoinOrderBookCache(time.Minute)
+	cache.Put(key, blockTipHash, orders)
+	cachedOrders, exists := cache.Get(key, blockTipHash)
+	require.True(t, exists)
+	require.Equal(t, orders, cachedOrders)
+
+	// A new block invalidates the entry.
+	_, exists = cache.Get(key, nextBlockTipHash)
+	require.False(t, exists)
+	cache.Evict(nextBlockTipHash)
+	_, exists = cache.Get(key, blockTipHash)
+	require.False(t, exists)
+
+	// Entries expire after the ttl even if the tip has not moved.
+	cache = NewDAOCoinOrderBookCache(time.Nanosecond)
+	cache.Put(key, blockTipHash, orders)
+	time.Sleep(time.Millisecond)
+	_, exists = cache.Get(key, blockTipHash)
+	require.False(t, exists)
+
+	// The most requested pairs are reported first and the counters are reset.
+	otherKey := NewDAOCoinOrderBookCacheKey(DESOCoinIdentifierString, recipientPkString, TxnStatusInMempool)
+	cache.RecordRequest(key)
+	cache.RecordRequest(otherKey)
+	cache.RecordRequest(otherKey)
+	require.Equal(t, []DAOCoinOrderBookCacheKey{otherKey}, cache.PopMostRequestedKeys(1))
+	require.Empty(t, cache.PopMostRequestedKeys(1))
+}
+
+func TestDAOCoinOrderBookAggregationCache(t *testing.T) {
+	pairKey := NewDAOCoinOrderBookCacheKey(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, TxnStatusInMempool)
+	blockTipHash := &lib.BlockHash{0x01}
+	orders := []DAOCoinLimitOrderEntryResponse{{
+		BuyingDAOCoinCreatorPublicKeyBase58Check:  daoCoinPubKeyBase58Check,
+		SellingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+		OperationType: DAOCoinLimitOrderOperationTypeStringBID,
+		Price:         "1.5",
+		Quantity:      "10",
+	}}
+	numComputations := 0
+	ladderRequest := func(cache *DAOCoinOrderBookCache, tickSize string, blockTipHash *lib.BlockHash) interface{} {
+		aggregation, err := cache.GetOrComputeAggregation(DAOCoinOrderBookAggregationCacheKey{
+			DAOCoinOrderBookCacheKey: pairKey,
+			AggregationType:          DAOCoinOrderBookAggregationLadder,
+			AggregationParams:        tickSize,
+		}, blockTipHash, func() (interface{}, error) {
+			numComputations++
+			bids, asks, err := BuildDAOCoinLimitOrderLadder(
+				orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check, tickSize)
+			if err != nil {
+				return nil, err
+			}
+			return GetDAOCoinLimitOrderLadderResponse{Bids: bids, Asks: asks}, nil
+		})
+		require.NoError(t, err)
+		return aggregation
+	}
+
+	// Two identical requests within the ttl share the computation.
+	cache := NewDAOCoinOrderBookCache(time.Minute)
+	firstResponse := ladderRequest(cache, "1", blockTipHash)
+	require.Equal(t, firstResponse, ladderRequest(cache, "1", blockTipHash))
+	require.Equal(t, 1, numComputations)
+	require.Equal(t, "1", firstResponse.(GetDAOCoinLimitOrderLadderResponse).Bids[0].Price)
+
+	// Different parameters are computed separately.
+	require.Equal(t, "1.5", ladderRequest(cache, "0.5", blockTipHash).(GetDAOCoinLimitOrderLadderResponse).Bids[0].Price)
+	require.Equal(t, 2, numComputations)
+
+	// A new block invalidates the aggregations along with the book.
+	nextBlockTipHash := &lib.BlockHash{0x02}
+	ladderRequest(cache, "1", nextBlockTipHash)
+	require.Equal(t, 3, numComputations)
+	cache.Evict(nextBlockTipHash)
+	ladderRequest(cache, "0.5", nextBlockTipHash)
+	require.Equal(t, 4, numComputations)
+
+	// Aggregations expire after the ttl.
+	cache = NewDAOCoinOrderBookCache(time.Nanosecond)
+	ladderRequest(cache, "1", blockTipHash)
+	time.Sleep(time.Millisecond)
+	ladderRequest(cache, "1", blockTipHash)
+	require.Equal(t, 6, numComputations)
+}
+
+func TestParseDAOCoinOrderBookHotPairs(t *testing.T) {
+	keys, err := ParseDAOCoinOrderBookHotPairs([]string{"DESO:" + senderPkString})
+	require.NoError(t, err)
+	require.Equal(t, []DAOCoinOrderBookCacheKey{
+		NewDAOCoinOrderBookCacheKey(DESOCoinIdentifierString, senderPkString, TxnStatusInMempool),
+	}, keys)
+
+	_, err = ParseDAOCoinOrderBookHotPairs([]string{senderPkString})
+	require.Error(t, err)
+	_, err = ParseDAOCoinOrderBookHotPairs([]string{"DESO:DESO"})
+	require.Error(t, err)
+}
+
+func TestWarmDAOCoinOrderBooks(t *testing.T) {
+	apiServer := newTestApiServer(t)
+	apiServer.DAOCoinOrderBookCache = NewDAOCoinOrderBookCache(time.Minute)
+
+	hotPairs, err := ParseDAOCoinOrderBookHotPairs([]string{"DESO:" + senderPkString})
+	require.NoError(t, err)
+
+	// The configured hot pair is cached without any client request for it.
+	apiServer.WarmDAOCoinOrderBooks(hotPairs)
+	_, exists := apiServer.DAOCoinOrderBookCache.Get(hotPairs[0], apiServer.blockchain.BlockTip().Hash)
+	require.True(t, exists)
+}
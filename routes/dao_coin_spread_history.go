@@ -0,0 +1,245 @@
+package routes
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// The order book warmer records at most one top-of-book sample per pair per DAOCoinSpreadHistorySampleInterval,
+	// and keeps the most recent DAOCoinSpreadHistoryMaxSamplesPerPair of them, i.e. about a week.
+	DAOCoinSpreadHistorySampleInterval    = time.Minute
+	DAOCoinSpreadHistoryMaxSamplesPerPair = 7 * 24 * 60
+
+	// The maximum number of intervals returned by GetDAOCoinSpreadHistory.
+	MaxDAOCoinSpreadHistoryIntervals = 1000
+)
+
+// DAOCoinSpreadSample is the top of a pair's book at one point in time, in coin2 per coin1. BestBid or BestAsk is nil
+// if that side of the book was empty.
+type DAOCoinSpreadSample struct {
+	TimestampNanos int64
+	BestBid        *big.Rat
+	BestAsk        *big.Rat
+}
+
+// DAOCoinSpreadHistory keeps recent top-of-book samples for the pairs warmed by the order book warmer. Nothing is
+// persisted, so the history starts over when the node restarts.
+type DAOCoinSpreadHistory struct {
+	mtx sync.RWMutex
+
+	samples map[DAOCoinOrderBookCacheKey][]DAOCoinSpreadSample
+
+	sampleInterval    time.Duration
+	maxSamplesPerPair int
+}
+
+func NewDAOCoinSpreadHistory(sampleInterval time.Duration, maxSamplesPerPair int) *DAOCoinSpreadHistory {
+	return &DAOCoinSpreadHistory{
+		samples:           make(map[DAOCoinOrderBookCacheKey][]DAOCoinSpreadSample),
+		sampleInterval:    sampleInterval,
+		maxSamplesPerPair: maxSamplesPerPair,
+	}
+}
+
+// Record adds a sample for key unless the previous one was taken less than the sample interval ago.
+func (history *DAOCoinSpreadHistory) Record(key DAOCoinOrderBookCacheKey, sample DAOCoinSpreadSample) {
+	history.mtx.Lock()
+	defer history.mtx.Unlock()
+
+	samples := history.samples[key]
+	if len(samples) > 0 &&
+		sample.TimestampNanos-samples[len(samples)-1].TimestampNanos < history.sampleInterval.Nanoseconds() {
+		return
+	}
+	samples = append(samples, sample)
+	if len(samples) > history.maxSamplesPerPair {
+		samples = samples[len(samples)-history.maxSamplesPerPair:]
+	}
+	history.samples[key] = samples
+}
+
+// GetSamples returns the samples for key taken in [startTimestampNanos, endTimestampNanos), oldest first.
+func (history *DAOCoinSpreadHistory) GetSamples(
+	key DAOCoinOrderBookCacheKey,
+	startTimestampNanos int64,
+	endTimestampNanos int64,
+) []DAOCoinSpreadSample {
+	history.mtx.RLock()
+	defer history.mtx.RUnlock()
+
+	var samples []DAOCoinSpreadSample
+	for _, sample := range history.samples[key] {
+		if sample.TimestampNanos >= startTimestampNanos && sample.TimestampNanos < endTimestampNanos {
+			samples = append(samples, sample)
+		}
+	}
+	return samples
+}
+
+// NewDAOCoinSpreadSample finds the best bid and ask among orders, which must all be for the coin1/coin2 pair.
+func NewDAOCoinSpreadSample(
+	timestamp time.Time,
+	orders []DAOCoinLimitOrderEntryResponse,
+	coin1PublicKeyBase58Check string,
+	coin2PublicKeyBase58Check string,
+) (DAOCoinSpreadSample, error) {
+	sample := DAOCoinSpreadSample{TimestampNanos: timestamp.UnixNano()}
+	for _, order := range orders {
+		price, err := getCoin1PriceInCoin2AsRatForDAOCoinLimitOrder(order, coin1PublicKeyBase58Check, coin2PublicKeyBase58Check)
+		if err != nil {
+			return DAOCoinSpreadSample{}, err
+		}
+		if isSameCoin(order.BuyingDAOCoinCreatorPublicKeyBase58Check, coin1PublicKeyBase58Check) {
+			if sample.BestBid == nil || price.Cmp(sample.BestBid) > 0 {
+				sample.BestBid = price
+			}
+		} else if sample.BestAsk == nil || price.Cmp(sample.BestAsk) < 0 {
+			sample.BestAsk = price
+		}
+	}
+	return sample, nil
+}
+
+type GetDAOCoinSpreadHistoryRequest struct {
+	DAOCoin1CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+	DAOCoin2CreatorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// The range [StartTimestampNanos, EndTimestampNanos) is split into intervals of IntervalSeconds.
+	StartTimestampNanos uint64 `safeForLogging:"true"`
+	EndTimestampNanos   uint64 `safeForLogging:"true"`
+	IntervalSeconds     uint64 `safeForLogging:"true"`
+}
+
+type DAOCoinSpreadHistoryInterval struct {
+	IntervalStartTimestampNanos uint64 `safeForLogging:"true"`
+
+	// Decimal strings in coin2 per coin1. A price is empty if that side of the book was empty, and the spread is
+	// empty unless both sides had orders.
+	BestBidPrice string `safeForLogging:"true"`
+	BestAskPrice string `safeForLogging:"true"`
+	Spread       string `safeForLogging:"true"`
+	// The spread relative to the mid-price, rounded to two decimal places.
+	SpreadBasisPoints string `safeForLogging:"true"`
+}
+
+type GetDAOCoinSpreadHistoryResponse struct {
+	// Oldest first. Intervals in which the book wasn't sampled are left out.
+	Intervals []DAOCoinSpreadHistoryInterval
+}
+
+// GetDAOCoinSpreadHistory returns how a pair's best bid, best ask, and spread evolved over a time range. The node
+// doesn't keep historical order books, so the history is approximated from the top-of-book samples the order book
+// warmer records while it keeps the pair warm: each interval reports the last sample taken in it. Only pairs the
+// warmer has been warming have any history.
+func (fes *APIServer) GetDAOCoinSpreadHistory(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDAOCoinSpreadHistoryRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinSpreadHistory: Problem parsing request body: %v", err))
+		return
+	}
+
+	if IsDesoPkid(requestData.DAOCoin1CreatorPublicKeyBase58Check) &&
+		IsDesoPkid(requestData.DAOCoin2CreatorPublicKeyBase58Check) {
+		_AddBadRequestError(ww, "GetDAOCoinSpreadHistory: Must provide either a "+
+			"DAOCoin1CreatorPublicKeyBase58Check or DAOCoin2CreatorPublicKeyBase58Check or both")
+		return
+	}
+	intervalNanos, err := validateDAOCoinSpreadHistoryRange(
+		requestData.StartTimestampNanos, requestData.EndTimestampNanos, requestData.IntervalSeconds)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDAOCoinSpreadHistory: %v", err))
+		return
+	}
+	if fes.DAOCoinSpreadHistory == nil {
+		_AddBadRequestError(ww, "GetDAOCoinSpreadHistory: This node doesn't record spread history because its "+
+			"order book warmer is disabled")
+		return
+	}
+
+	key := NewDAOCoinOrderBookCacheKey(
+		requestData.DAOCoin1CreatorPublicKeyBase58Check, requestData.DAOCoin2CreatorPublicKeyBase58Check, TxnStatusInMempool)
+	samples := fes.DAOCoinSpreadHistory.GetSamples(
+		key, int64(requestData.StartTimestampNanos), int64(requestData.EndTimestampNanos))
+	intervals := BuildDAOCoinSpreadHistoryIntervals(samples, requestData.StartTimestampNanos, intervalNanos)
+
+	if err := json.NewEncoder(ww).Encode(GetDAOCoinSpreadHistoryResponse{Intervals: intervals}); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDAOCoinSpreadHistory: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// validateDAOCoinSpreadHistoryRange checks the requested range and returns the interval length in nanoseconds.
+func validateDAOCoinSpreadHistoryRange(startTimestampNanos uint64, endTimestampNanos uint64, intervalSeconds uint64) (
+	_intervalNanos uint64, _err error) {
+	if endTimestampNanos <= startTimestampNanos {
+		return 0, errors.Errorf("EndTimestampNanos %v must be after StartTimestampNanos %v",
+			endTimestampNanos, startTimestampNanos)
+	}
+	if endTimestampNanos > uint64(time.Now().Add(time.Hour).UnixNano()) {
+		return 0, errors.Errorf("EndTimestampNanos %v is too far in the future", endTimestampNanos)
+	}
+	if intervalSeconds == 0 {
+		return 0, errors.New("IntervalSeconds must be greater than 0")
+	}
+	// Any interval longer than the range is equivalent to one as long as the range, and capping it keeps the
+	// conversion to nanoseconds from overflowing.
+	intervalNanos := endTimestampNanos - startTimestampNanos
+	if intervalSeconds < intervalNanos/uint64(time.Second) {
+		intervalNanos = intervalSeconds * uint64(time.Second)
+	}
+	numIntervals := (endTimestampNanos - startTimestampNanos + intervalNanos - 1) / intervalNanos
+	if numIntervals > MaxDAOCoinSpreadHistoryIntervals {
+		return 0, errors.Errorf("The range covers more than %v intervals of %v seconds",
+			MaxDAOCoinSpreadHistoryIntervals, intervalSeconds)
+	}
+	return intervalNanos, nil
+}
+
+// BuildDAOCoinSpreadHistoryIntervals buckets samples, which must be sorted oldest first, into intervals of
+// intervalNanos starting at startTimestampNanos. Each interval reports its last sample.
+func BuildDAOCoinSpreadHistoryIntervals(
+	samples []DAOCoinSpreadSample,
+	startTimestampNanos uint64,
+	intervalNanos uint64,
+) []DAOCoinSpreadHistoryInterval {
+	intervals := []DAOCoinSpreadHistoryInterval{}
+	for _, sample := range samples {
+		if sample.TimestampNanos < int64(startTimestampNanos) {
+			continue
+		}
+		intervalIndex := (uint64(sample.TimestampNanos) - startTimestampNanos) / intervalNanos
+		interval := DAOCoinSpreadHistoryInterval{
+			IntervalStartTimestampNanos: startTimestampNanos + intervalIndex*intervalNanos,
+		}
+		if sample.BestBid != nil {
+			interval.BestBidPrice = formatDAOCoinLimitOrderPriceRat(sample.BestBid)
+		}
+		if sample.BestAsk != nil {
+			interval.BestAskPrice = formatDAOCoinLimitOrderPriceRat(sample.BestAsk)
+		}
+		if sample.BestBid != nil && sample.BestAsk != nil {
+			spread := new(big.Rat).Sub(sample.BestAsk, sample.BestBid)
+			mid := new(big.Rat).Quo(new(big.Rat).Add(sample.BestAsk, sample.BestBid), big.NewRat(2, 1))
+			interval.Spread = formatDAOCoinLimitOrderPriceRat(spread)
+			interval.SpreadBasisPoints = new(big.Rat).Mul(new(big.Rat).Quo(spread, mid), big.NewRat(10000, 1)).FloatString(2)
+		}
+
+		// Later samples in the same interval replace earlier ones.
+		if len(intervals) > 0 &&
+			intervals[len(intervals)-1].IntervalStartTimestampNanos == interval.IntervalStartTimestampNanos {
+			intervals[len(intervals)-1] = interval
+			continue
+		}
+		intervals = append(intervals, interval)
+	}
+	return intervals
+}
@@ -0,0 +1,124 @@
+package routes
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewDAOCoinSpreadSample(t *testing.T) {
+	orders := []DAOCoinLimitOrderEntryResponse{
+		{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  daoCoinPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+			OperationType: DAOCoinLimitOrderOperationTypeStringBID,
+			Price:         "9.5",
+			Quantity:      "1",
+		},
+		{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  daoCoinPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: desoPubKeyBase58Check,
+			OperationType: DAOCoinLimitOrderOperationTypeStringBID,
+			Price:         "9",
+			Quantity:      "1",
+		},
+		{
+			BuyingDAOCoinCreatorPublicKeyBase58Check:  desoPubKeyBase58Check,
+			SellingDAOCoinCreatorPublicKeyBase58Check: daoCoinPubKeyBase58Check,
+			OperationType: DAOCoinLimitOrderOperationTypeStringASK,
+			Price:         "10.5",
+			Quantity:      "1",
+		},
+	}
+	timestamp := time.Unix(100, 0)
+	sample, err := NewDAOCoinSpreadSample(timestamp, orders, daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+	require.NoError(t, err)
+	require.Equal(t, timestamp.UnixNano(), sample.TimestampNanos)
+	require.Equal(t, 0, sample.BestBid.Cmp(big.NewRat(19, 2)))
+	require.Equal(t, 0, sample.BestAsk.Cmp(big.NewRat(21, 2)))
+
+	// An empty book has neither side.
+	sample, err = NewDAOCoinSpreadSample(timestamp, nil, daoCoinPubKeyBase58Check, desoPubKeyBase58Check)
+	require.NoError(t, err)
+	require.Nil(t, sample.BestBid)
+	require.Nil(t, sample.BestAsk)
+}
+
+func TestDAOCoinSpreadHistory(t *testing.T) {
+	key := NewDAOCoinOrderBookCacheKey(daoCoinPubKeyBase58Check, desoPubKeyBase58Check, TxnStatusInMempool)
+	history := NewDAOCoinSpreadHistory(time.Minute, 3)
+	for ii := int64(0); ii < 5; ii++ {
+		history.Record(key, DAOCoinSpreadSample{TimestampNanos: ii * time.Minute.Nanoseconds()})
+		// Samples taken before the sample interval has passed are dropped.
+		history.Record(key, DAOCoinSpreadSample{TimestampNanos: ii*time.Minute.Nanoseconds() + 1})
+	}
+
+	// Only the most recent samples are kept.
+	samples := history.GetSamples(key, 0, 10*time.Minute.Nanoseconds())
+	require.Len(t, samples, 3)
+	for ii, sample := range samples {
+		require.Equal(t, int64(ii+2)*time.Minute.Nanoseconds(), sample.TimestampNanos)
+	}
+	samples = history.GetSamples(key, 3*time.Minute.Nanoseconds(), 4*time.Minute.Nanoseconds())
+	require.Len(t, samples, 1)
+	require.Equal(t, 3*time.Minute.Nanoseconds(), samples[0].TimestampNanos)
+}
+
+func TestBuildDAOCoinSpreadHistoryIntervals(t *testing.T) {
+	sample := func(timestampNanos int64, bid *big.Rat, ask *big.Rat) DAOCoinSpreadSample {
+		return DAOCoinSpreadSample{TimestampNanos: timestampNanos, BestBid: bid, BestAsk: ask}
+	}
+	samples := []DAOCoinSpreadSample{
+		sample(1000, big.NewRat(9, 1), big.NewRat(11, 1)),
+		// The last sample in an interval wins.
+		sample(1050, big.NewRat(19, 2), big.NewRat(21, 2)),
+		// Nothing is sampled in [1100, 1200).
+		sample(1250, big.NewRat(10, 1), nil),
+		sample(1300, big.NewRat(99, 10), big.NewRat(101, 10)),
+	}
+	intervals := BuildDAOCoinSpreadHistoryIntervals(samples, 1000, 100)
+	require.Equal(t, []DAOCoinSpreadHistoryInterval{
+		{
+			IntervalStartTimestampNanos: 1000,
+			BestBidPrice:                "9.5",
+			BestAskPrice:                "10.5",
+			Spread:                      "1.0",
+			SpreadBasisPoints:           "1000.00",
+		},
+		{
+			IntervalStartTimestampNanos: 1200,
+			BestBidPrice:                "10.0",
+		},
+		{
+			IntervalStartTimestampNanos: 1300,
+			BestBidPrice:                "9.9",
+			BestAskPrice:                "10.1",
+			Spread:                      "0.2",
+			SpreadBasisPoints:           "200.00",
+		},
+	}, intervals)
+
+	require.Empty(t, BuildDAOCoinSpreadHistoryIntervals(nil, 1000, 100))
+}
+
+func TestValidateDAOCoinSpreadHistoryRange(t *testing.T) {
+	start := uint64(time.Now().Add(-time.Hour).UnixNano())
+	end := uint64(time.Now().UnixNano())
+	intervalNanos, err := validateDAOCoinSpreadHistoryRange(start, end, 60)
+	require.NoError(t, err)
+	require.Equal(t, uint64(time.Minute), intervalNanos)
+	// An interval longer than the range is capped to the range.
+	intervalNanos, err = validateDAOCoinSpreadHistoryRange(start, end, 1<<62)
+	require.NoError(t, err)
+	require.Equal(t, end-start, intervalNanos)
+
+	_, err = validateDAOCoinSpreadHistoryRange(end, start, 60)
+	require.Error(t, err)
+	_, err = validateDAOCoinSpreadHistoryRange(start, end, 0)
+	require.Error(t, err)
+	// An hour of one-second intervals is too many.
+	_, err = validateDAOCoinSpreadHistoryRange(start, end, 1)
+	require.Error(t, err)
+}
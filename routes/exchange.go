@@ -176,7 +176,7 @@ func (fes *APIServer) APIBase(ww http.ResponseWriter, rr *http.Request) {
 		Header: _headerToResponse(blockMsg.Header, blockNode.Hash.String()),
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("APIBase")
 	if err != nil {
 		APIAddError(ww, fmt.Sprintf("APIBase: Problem fetching utxoView: %v", err))
 		return
@@ -286,7 +286,7 @@ func (fes *APIServer) APIKeyPair(ww http.ResponseWriter, rr *http.Request) {
 	// Decode the request data.
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	apiKeyPairRequest := APIKeyPairRequest{}
-	if err := decoder.Decode(&apiKeyPairRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&apiKeyPairRequest)); err != nil {
 		APIAddError(ww, fmt.Sprintf("APIKeyPair: Problem parsing request body: %v", err))
 		return
 	}
@@ -377,7 +377,7 @@ func (fes *APIServer) APIBalance(ww http.ResponseWriter, rr *http.Request) {
 	// Decode the request data.
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	balanceRequest := APIBalanceRequest{}
-	if err := decoder.Decode(&balanceRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&balanceRequest)); err != nil {
 		APIAddError(ww, fmt.Sprintf("APIBalanceRequest: Problem parsing request body: %v", err))
 		return
 	}
@@ -751,7 +751,7 @@ func APITransactionToResponse(
 func (fes *APIServer) APITransferDeSo(ww http.ResponseWriter, rr *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	transferDeSoRequest := APITransferDeSoRequest{}
-	if err := decoder.Decode(&transferDeSoRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&transferDeSoRequest)); err != nil {
 		APIAddError(ww, fmt.Sprintf("APITransferDeSo: Problem parsing request body: %v", err))
 		return
 	}
@@ -885,7 +885,7 @@ func (fes *APIServer) APITransferDeSo(ww http.ResponseWriter, rr *http.Request)
 	// Return the transaction in the response.
 	res := APITransferDeSoResponse{}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("APITransferDeSo")
 	if err != nil {
 		APIAddError(ww, fmt.Sprintf("APITransferDeSo: Problem fetching utxoView: %v", err))
 		return
@@ -989,7 +989,7 @@ func (fes *APIServer) APITransactionInfo(ww http.ResponseWriter, rr *http.Reques
 	// Decode the request
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	transactionInfoRequest := APITransactionInfoRequest{}
-	if err := decoder.Decode(&transactionInfoRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&transactionInfoRequest)); err != nil {
 		APIAddError(ww, fmt.Sprintf("APITransactionInfo: Problem parsing request body: %v", err))
 		return
 	}
@@ -1015,7 +1015,7 @@ func (fes *APIServer) APITransactionInfo(ww http.ResponseWriter, rr *http.Reques
 		limit = 1000
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("APITransactionInfo")
 	if err != nil {
 		APIAddError(ww, fmt.Sprintf("APITransactionInfo: Problem fetching utxoView: %v", err))
 		return
@@ -1391,7 +1391,7 @@ func (fes *APIServer) APIBlock(ww http.ResponseWriter, rr *http.Request) {
 	// Decode the request
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	blockRequest := APIBlockRequest{}
-	if err := decoder.Decode(&blockRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&blockRequest)); err != nil {
 		APIAddError(ww, fmt.Sprintf("APIBlockRequest: Problem parsing request body: %v", err))
 		return
 	}
@@ -1447,7 +1447,7 @@ func (fes *APIServer) APIBlock(ww http.ResponseWriter, rr *http.Request) {
 		Header: _headerToResponse(blockMsg.Header, blockHash.String()),
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("APIBlock")
 	if err != nil {
 		APIAddError(ww, fmt.Sprintf("APIBlockRequest: Problem fetching utxoView: %v", err))
 		return
@@ -54,7 +54,8 @@ var specialExtraDataKeysToEncoding = map[string]ExtraDataEncoding{
 	lib.CoinRoyaltiesMapKey:          {Decode: DecodePubKeyToUint64MapString, Encode: ReservedFieldCannotEncode},
 	lib.TokenTradingFeesByPkidMapKey: {Decode: DecodePubKeyToUint64MapString, Encode: ReservedFieldCannotEncode},
 
-	lib.MessagesVersionString: {Decode: Decode64BitUintString, Encode: Encode64BitUintString},
+	lib.MessagesVersionString:         {Decode: Decode64BitUintString, Encode: Encode64BitUintString},
+	MessageExpiresAtNanosExtraDataKey: {Decode: Decode64BitUintString, Encode: Encode64BitUintString},
 
 	lib.NodeSourceMapKey: {Decode: Decode64BitUintString, Encode: Encode64BitUintString},
 
@@ -75,6 +76,31 @@ func EncodeExtraDataMap(extraData map[string]string) (map[string][]byte, error)
 	return extraDataProcessed, nil
 }
 
+// ValidateExtraDataLimits returns an error if the encoded extraData has more than maxEntries entries or if its keys
+// and values add up to more than maxSizeBytes. A limit of zero disables that check.
+func ValidateExtraDataLimits(extraData map[string][]byte, maxEntries uint64, maxSizeBytes uint64) error {
+	if maxEntries > 0 && uint64(len(extraData)) > maxEntries {
+		return fmt.Errorf("ExtraData has %d entries, which exceeds the maximum of %d entries",
+			len(extraData), maxEntries)
+	}
+	if maxSizeBytes > 0 {
+		totalSizeBytes := uint64(0)
+		for k, v := range extraData {
+			totalSizeBytes += uint64(len(k) + len(v))
+		}
+		if totalSizeBytes > maxSizeBytes {
+			return fmt.Errorf("ExtraData is %d bytes, which exceeds the maximum of %d bytes",
+				totalSizeBytes, maxSizeBytes)
+		}
+	}
+	return nil
+}
+
+// validateExtraDataLimits checks extraData against the node's configured ExtraData limits.
+func (fes *APIServer) validateExtraDataLimits(extraData map[string][]byte) error {
+	return ValidateExtraDataLimits(extraData, fes.Config.MaxExtraDataEntries, fes.Config.MaxExtraDataSizeBytes)
+}
+
 func DecodeExtraDataMap(params *lib.DeSoParams, utxoView *lib.UtxoView, extraData map[string][]byte) map[string]string {
 	if extraData == nil || len(extraData) == 0 {
 		return nil
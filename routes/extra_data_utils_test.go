@@ -73,3 +73,24 @@ func TestExtraDataEncodingDecodingErrors(t *testing.T) {
 		require.Error(t, err)
 	}
 }
+
+func TestValidateExtraDataLimits(t *testing.T) {
+	extraData := map[string][]byte{
+		"a": []byte("1234"),
+		"b": []byte("5678"),
+	}
+
+	// Valid within both limits, and when the limits are disabled.
+	require.NoError(t, ValidateExtraDataLimits(extraData, 2, 10))
+	require.NoError(t, ValidateExtraDataLimits(extraData, 0, 0))
+
+	// Too many entries.
+	err := ValidateExtraDataLimits(extraData, 1, 0)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maximum of 1 entries")
+
+	// Too large in total.
+	err = ValidateExtraDataLimits(extraData, 0, 9)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "maximum of 9 bytes")
+}
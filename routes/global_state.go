@@ -244,7 +244,11 @@ var (
 	// <prefix> -> <uint64>
 	_GlobalStatePrefixToCaptchaReward = []byte{48}
 
-	// NEXT_TAG: 49
+	// The prefix for the history of changes to the reserve exchange rate and buy DeSo fee basis points.
+	// <prefix, TimestampNanos, ParameterName> -> <ExchangeRateParameterChange>
+	_GlobalStatePrefixForExchangeRateParameterHistory = []byte{49}
+
+	// NEXT_TAG: 50
 )
 
 type HotFeedApprovedPostOp struct {
@@ -733,6 +737,13 @@ func GlobalStateKeyForBuyDeSoFeeBasisPoints() []byte {
 	return prefixCopy
 }
 
+func GlobalStateKeyForExchangeRateParameterChange(timestampNanos uint64, parameterName string) []byte {
+	prefixCopy := append([]byte{}, _GlobalStatePrefixForExchangeRateParameterHistory...)
+	key := append(prefixCopy, lib.EncodeUint64(timestampNanos)...)
+	key = append(key, []byte(parameterName)...)
+	return key
+}
+
 func GlobalStateKeyForPKIDTstampnanosToJumioTransaction(pkid *lib.PKID, timestampNanos uint64) []byte {
 	prefixCopy := append([]byte{}, _GlobalStatePrefixPKIDTstampNanosToJumioTransaction...)
 	key := append(prefixCopy, pkid[:]...)
@@ -824,7 +835,7 @@ func (gs *GlobalState) PutRemote(ww http.ResponseWriter, rr *http.Request) {
 	// Parse the request.
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	requestData := PutRemoteRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("PutRemote: Problem parsing request body: %v", err))
 		return
 	}
@@ -907,7 +918,7 @@ func (gs *GlobalState) GetRemote(ww http.ResponseWriter, rr *http.Request) {
 	// Parse the request.
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	requestData := GetRemoteRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetRemote: Problem parsing request body: %v", err))
 		return
 	}
@@ -1011,7 +1022,7 @@ func (gs *GlobalState) BatchGetRemote(ww http.ResponseWriter, rr *http.Request)
 	// Parse the request.
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	requestData := BatchGetRemoteRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("BatchGetRemote: Problem parsing request body: %v", err))
 		return
 	}
@@ -1134,7 +1145,7 @@ func (gs *GlobalState) DeleteRemote(ww http.ResponseWriter, rr *http.Request) {
 	// Parse the request.
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	requestData := DeleteRemoteRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("DeleteRemote: Problem parsing request body: %v", err))
 		return
 	}
@@ -1228,7 +1239,7 @@ func (gs *GlobalState) GlobalStateSeekRemote(ww http.ResponseWriter, rr *http.Re
 	// Parse the request.
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	requestData := SeekRemoteRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GlobalStateSeekRemote: Problem parsing request body: %v", err))
 		return
 	}
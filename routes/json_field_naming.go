@@ -0,0 +1,286 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/deso-protocol/core/lib"
+)
+
+const (
+	// JSONFieldNamingConventionPascalCase leaves responses with the Go field names, ex: PostHashHex. This is the
+	// default.
+	JSONFieldNamingConventionPascalCase = "PascalCase"
+	// JSONFieldNamingConventionSnakeCase converts the field names in responses to snake_case, ex: post_hash_hex.
+	JSONFieldNamingConventionSnakeCase = "snake_case"
+)
+
+// ValidateJSONFieldNamingConvention returns an error unless convention is empty or one of the supported conventions.
+func ValidateJSONFieldNamingConvention(convention string) error {
+	if convention != "" && convention != JSONFieldNamingConventionPascalCase &&
+		convention != JSONFieldNamingConventionSnakeCase {
+		return fmt.Errorf("ValidateJSONFieldNamingConvention: Invalid JSON field naming convention %v. Options are "+
+			"{%v, %v}", convention, JSONFieldNamingConventionPascalCase, JSONFieldNamingConventionSnakeCase)
+	}
+	return nil
+}
+
+// jsonFieldNamingResponseWriter holds on to the response body so ConvertJSONFieldNames can rewrite it once the
+// handler is done.
+type jsonFieldNamingResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (ww *jsonFieldNamingResponseWriter) WriteHeader(statusCode int) {
+	if ww.statusCode == 0 {
+		ww.statusCode = statusCode
+	}
+}
+
+func (ww *jsonFieldNamingResponseWriter) Write(data []byte) (int, error) {
+	return ww.body.Write(data)
+}
+
+// CorrelationID passes through the correlation ID of the wrapped writer, so errors logged by the handler keep it.
+func (ww *jsonFieldNamingResponseWriter) CorrelationID() string {
+	return correlationIDFromResponseWriter(ww.ResponseWriter)
+}
+
+// ConvertJSONFieldNames is middleware that rewrites the field names of JSON responses to the node's naming
+// convention. Handlers keep encoding their responses with the Go field names, so it's a no-op for the default
+// PascalCase convention. Responses that aren't JSON are passed through untouched.
+//
+// JSON doesn't distinguish struct fields from map keys, so the keys of the map-typed fields in jsonMapFieldDepths,
+// such as ExtraData, are never converted. Other keys are only converted if they look like Go field names, which leaves
+// alone the hashes and public keys that key maps from core types.
+func ConvertJSONFieldNames(inner http.Handler, convention string) http.Handler {
+	if convention != JSONFieldNamingConventionSnakeCase {
+		return inner
+	}
+	return http.HandlerFunc(func(ww http.ResponseWriter, rr *http.Request) {
+		namingWriter := &jsonFieldNamingResponseWriter{ResponseWriter: ww}
+		inner.ServeHTTP(namingWriter, rr)
+
+		body := namingWriter.body.Bytes()
+		if convertedBody, err := convertJSONFieldNames(body, toSnakeCaseFieldName); err == nil {
+			body = convertedBody
+		}
+		if namingWriter.statusCode != 0 {
+			ww.WriteHeader(namingWriter.statusCode)
+		}
+		ww.Write(body)
+	})
+}
+
+// convertJSONFieldNames re-encodes the JSON value in data with convertFieldName applied to its field names, keeping
+// the order of the fields and the exact text of numbers. It returns an error if data isn't a single JSON value.
+func convertJSONFieldNames(data []byte, convertFieldName func(string) string) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var converted bytes.Buffer
+	if err := convertJSONValueFieldNames(decoder, &converted, convertFieldName, 0); err != nil {
+		return nil, err
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("convertJSONFieldNames: Unexpected data after JSON value")
+	}
+	// Handlers encode with json.Encoder, which ends the response with a newline.
+	if bytes.HasSuffix(data, []byte("\n")) {
+		converted.WriteByte('\n')
+	}
+	return converted.Bytes(), nil
+}
+
+// convertJSONValueFieldNames converts the next JSON value in decoder. The keys of the outermost mapDepth levels of
+// objects in it are map keys, so they're copied as is.
+func convertJSONValueFieldNames(
+	decoder *json.Decoder,
+	out *bytes.Buffer,
+	convertFieldName func(string) string,
+	mapDepth int,
+) error {
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	delim, isDelim := token.(json.Delim)
+	if !isDelim {
+		tokenBytes, err := json.Marshal(token)
+		if err != nil {
+			return err
+		}
+		out.Write(tokenBytes)
+		return nil
+	}
+
+	// Arrays don't add a level of keys, so their elements are at the same map depth.
+	elementMapDepth := mapDepth
+
+	isObject := delim == '{'
+	out.WriteRune(rune(delim))
+	for ii := 0; decoder.More(); ii++ {
+		if ii > 0 {
+			out.WriteByte(',')
+		}
+		if isObject {
+			keyToken, err := decoder.Token()
+			if err != nil {
+				return err
+			}
+			key, ok := keyToken.(string)
+			if !ok {
+				return fmt.Errorf("convertJSONValueFieldNames: Expected an object key, got %v", keyToken)
+			}
+			if mapDepth > 0 {
+				elementMapDepth = mapDepth - 1
+			} else {
+				elementMapDepth = jsonMapFieldDepths[key]
+				if isGoFieldName(key) {
+					key = convertFieldName(key)
+				}
+			}
+			keyBytes, err := json.Marshal(key)
+			if err != nil {
+				return err
+			}
+			out.Write(keyBytes)
+			out.WriteByte(':')
+		}
+		if err = convertJSONValueFieldNames(decoder, out, convertFieldName, elementMapDepth); err != nil {
+			return err
+		}
+	}
+	// Consume the closing delimiter.
+	closingToken, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	out.WriteRune(rune(closingToken.(json.Delim)))
+	return nil
+}
+
+// jsonOpaqueMapFieldDepth marks a map of arbitrary JSON, none of whose keys are Go field names.
+const jsonOpaqueMapFieldDepth = math.MaxInt32
+
+// jsonMapFieldDepths holds the JSON names of the map-typed fields in this package, and how many levels of nested maps
+// each holds, ex: 2 for a map[string]map[string]bool. The keys of those maps are data, not field names, so they're
+// never converted, while the structs in their values still are. A name that's a map in any struct is treated as one
+// everywhere. TestJSONMapFieldDepths fails when a map-typed field is missing.
+var jsonMapFieldDepths = map[string]int{
+	"AcceptedBidHistoryMap":                      1,
+	"AdditionalCoinRoyaltiesMap":                 1,
+	"AdditionalDESORoyaltiesMap":                 1,
+	"AllCountryLevelSignUpBonuses":               1,
+	"Balances":                                   1,
+	"BlacklistedPKIDMap":                         1,
+	"BlacklistedResponseMap":                     1,
+	"BlacklistedUsernameMap":                     1,
+	"BlockedPubKeys":                             1,
+	"BlockedPublicKeys":                          1,
+	"Counts":                                     1,
+	"CreatorCoinOperationLimitMap":               2,
+	"custom_fields":                              1,
+	"DAOCoinLimitOrderLimitMap":                  2,
+	"DAOCoinOperationLimitMap":                   2,
+	"Default":                                    jsonOpaqueMapFieldDepth,
+	"DerivedKeys":                                1,
+	"DiamondLevelMap":                            1,
+	"Dimensions":                                 jsonOpaqueMapFieldDepth,
+	"event_properties":                           jsonOpaqueMapFieldDepth,
+	"EventProperties":                            jsonOpaqueMapFieldDepth,
+	"ExemptPublicKeyMap":                         jsonOpaqueMapFieldDepth,
+	"ExtraData":                                  1,
+	"Features":                                   1,
+	"FeeBasisPointsByPublicKey":                  1,
+	"FilteredOutNotificationCategories":          1,
+	"GraylistedPKIDMap":                          1,
+	"GraylistedResponseMap":                      1,
+	"GraylistedUsernameMap":                      1,
+	"Holders":                                    1,
+	"HotFeedApprovedPostsToMultipliers":          1,
+	"HotFeedBlockCache":                          1,
+	"HotFeedPKIDMultipliers":                     1,
+	"HotFeedPostHashToTagScoreMap":               1,
+	"HotFeedTxnTypeMultiplierMap":                1,
+	"MarketTradingFeeBasisPointsByUserPublicKey": 1,
+	"MessageReadStateByContact":                  1,
+	"MessageReadStateUpdatesByContact":           1,
+	"NFTOperationLimitMap":                       3,
+	"NFTsMap":                                    1,
+	"Nodes":                                      1,
+	"ParamUpdaters":                              1,
+	"PostExtraData":                              1,
+	"PostHashHexToPostEntryResponse":             1,
+	"PostHashToPostTagsMap":                      1,
+	"PostsByHash":                                1,
+	"PostTagToOrderedHotFeedEntries":             1,
+	"PostTagToOrderedNewestEntries":              1,
+	"PostTagToPostHashesMap":                     2,
+	"ProfilesByPublicKey":                        1,
+	"PubKeyToUserGlobalMetadata":                 1,
+	"PubKeyToUsername":                           1,
+	"PublicKeyBalancesToMonitor":                 1,
+	"PublicKeyBase58CheckToProfileEntryResponse": 1,
+	"PublicKeyToProfileEntry":                    1,
+	"PublicKeyToProfileEntryResponse":            1,
+	"result":                                     jsonOpaqueMapFieldDepth,
+	"SerialNumberToNFTEntryResponse":             1,
+	"SignUpBonusMetadata":                        1,
+	"ThreadIdToLastReadTimestampNanos":           1,
+	"ThreadIdToLastReadTimestampNanosString":     1,
+	"ThreadIdToTimestampNanos":                   1,
+	"ThreadIdToTimestampNanosString":             1,
+	"ThreadIdToUnreadCount":                      1,
+	"TransactionCountLimitMap":                   1,
+	"TransactionFeeMap":                          1,
+	"TransactionSummaryStats":                    1,
+	"TxnTypeMultiplierMap":                       1,
+	"UnreadStateByContact":                       1,
+	"VerifiedUsernameToPKID":                     1,
+	"VerifiedUsernameToPKIDMap":                  1,
+}
+
+// isGoFieldName returns true if key is an exported Go identifier that isn't a base58 public key.
+func isGoFieldName(key string) bool {
+	if key == "" || !unicode.IsUpper(rune(key[0])) {
+		return false
+	}
+	for _, char := range key {
+		if char > unicode.MaxASCII || !(unicode.IsLetter(char) || unicode.IsDigit(char) || char == '_') {
+			return false
+		}
+	}
+	if publicKeyBytes, _, err := lib.Base58CheckDecode(key); err == nil && len(publicKeyBytes) == btcec.PubKeyBytesLenCompressed {
+		return false
+	}
+	return true
+}
+
+// toSnakeCaseFieldName converts a Go field name to snake_case. Acronyms are kept together and digits stay with the
+// word before them, ex: DAOCoin1CreatorPublicKeyBase58Check becomes dao_coin1_creator_public_key_base58_check.
+func toSnakeCaseFieldName(fieldName string) string {
+	runes := []rune(fieldName)
+	var snakeCase strings.Builder
+	for ii, char := range runes {
+		if ii > 0 && unicode.IsUpper(char) && runes[ii-1] != '_' {
+			previousIsLowerOrDigit := unicode.IsLower(runes[ii-1]) || unicode.IsDigit(runes[ii-1])
+			// The last capital of an acronym starts a new word when a lowercase letter follows it, ex: the C in
+			// DAOCoin.
+			endsAcronym := unicode.IsUpper(runes[ii-1]) && ii+1 < len(runes) && unicode.IsLower(runes[ii+1])
+			if previousIsLowerOrDigit || endsAcronym {
+				snakeCase.WriteByte('_')
+			}
+		}
+		snakeCase.WriteRune(unicode.ToLower(char))
+	}
+	return snakeCase.String()
+}
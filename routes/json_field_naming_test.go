@@ -0,0 +1,174 @@
+package routes
+
+import (
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestToSnakeCaseFieldName(t *testing.T) {
+	for fieldName, expected := range map[string]string{
+		"PostHashHex":                         "post_hash_hex",
+		"OrderID":                             "order_id",
+		"DAOCoin1CreatorPublicKeyBase58Check": "dao_coin1_creator_public_key_base58_check",
+		"IsDAOCoin":                           "is_dao_coin",
+		"TxnHashHex":                          "txn_hash_hex",
+		"BookChecksum":                        "book_checksum",
+		"X":                                   "x",
+	} {
+		require.Equal(t, expected, toSnakeCaseFieldName(fieldName))
+	}
+}
+
+func TestConvertJSONFieldNames(t *testing.T) {
+	type thread struct {
+		ThreadIdHex    string
+		TimestampNanos uint64
+	}
+	type profile struct {
+		Username string
+	}
+	res := struct {
+		TxnHashHex                      string
+		BlockHeight                     uint64
+		Threads                         []thread
+		PublicKeyToProfileEntryResponse map[string]profile
+		ExtraData                       map[string]string
+		PubKeyToUsername                map[string]string
+		PostTagToPostHashesMap          map[string]map[string]bool
+		EventProperties                 map[string]interface{} `json:"event_properties"`
+		Error                           string                 `json:"error"`
+	}{
+		TxnHashHex:  "abc",
+		BlockHeight: 1700000000000000001,
+		Threads:     []thread{{ThreadIdHex: "01", TimestampNanos: 5}},
+		PublicKeyToProfileEntryResponse: map[string]profile{
+			senderPkString: {Username: "Sender"},
+		},
+		ExtraData:              map[string]string{"ContentType": "text", "EmbedVideoURL": "url", "app_name": "app"},
+		PubKeyToUsername:       map[string]string{"Sender": "Sender"},
+		PostTagToPostHashesMap: map[string]map[string]bool{"Tag": {"PostHash": true}},
+		EventProperties:        map[string]interface{}{"PageName": map[string]interface{}{"ButtonId": "Buy"}},
+	}
+	handler := http.HandlerFunc(func(ww http.ResponseWriter, req *http.Request) {
+		ww.WriteHeader(http.StatusAccepted)
+		require.NoError(t, json.NewEncoder(ww).Encode(res))
+	})
+	serve := func(handler http.Handler) *httptest.ResponseRecorder {
+		request, err := http.NewRequest("POST", "/api/v0/test", nil)
+		require.NoError(t, err)
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, request)
+		return response
+	}
+
+	// By default the Go field names are left alone.
+	pascalCaseResponse := serve(ConvertJSONFieldNames(handler, JSONFieldNamingConventionPascalCase))
+	expectedPascalCase, err := json.Marshal(res)
+	require.NoError(t, err)
+	require.JSONEq(t, string(expectedPascalCase), pascalCaseResponse.Body.String())
+
+	// With snake_case, field names are converted in place, including those of structs inside maps, while the exact
+	// numbers are kept. Map keys, such as ExtraData keys and usernames, are data and are left alone however deeply the
+	// maps are nested.
+	snakeCaseResponse := serve(ConvertJSONFieldNames(handler, JSONFieldNamingConventionSnakeCase))
+	require.Equal(t, http.StatusAccepted, snakeCaseResponse.Code)
+	require.Equal(t, `{"txn_hash_hex":"abc","block_height":1700000000000000001,`+
+		`"threads":[{"thread_id_hex":"01","timestamp_nanos":5}],`+
+		`"public_key_to_profile_entry_response":{"`+senderPkString+`":{"username":"Sender"}},`+
+		`"extra_data":{"ContentType":"text","EmbedVideoURL":"url","app_name":"app"},`+
+		`"pub_key_to_username":{"Sender":"Sender"},`+
+		`"post_tag_to_post_hashes_map":{"Tag":{"PostHash":true}},`+
+		`"event_properties":{"PageName":{"ButtonId":"Buy"}},"error":""}`+"\n", snakeCaseResponse.Body.String())
+
+	// Responses that aren't JSON pass through untouched.
+	notJSONResponse := serve(ConvertJSONFieldNames(http.HandlerFunc(func(ww http.ResponseWriter, req *http.Request) {
+		ww.Write([]byte("PlainText"))
+	}), JSONFieldNamingConventionSnakeCase))
+	require.Equal(t, http.StatusOK, notJSONResponse.Code)
+	require.Equal(t, "PlainText", notJSONResponse.Body.String())
+
+	require.NoError(t, ValidateJSONFieldNamingConvention(""))
+	require.NoError(t, ValidateJSONFieldNamingConvention(JSONFieldNamingConventionSnakeCase))
+	require.Error(t, ValidateJSONFieldNamingConvention("camelCase"))
+}
+
+func TestJSONMapFieldDepths(t *testing.T) {
+	// Every map-typed field in the package has to be in jsonMapFieldDepths, or its keys would be converted as though
+	// they were field names.
+	packages, err := parser.ParseDir(token.NewFileSet(), ".", func(fileInfo os.FileInfo) bool {
+		return !strings.HasSuffix(fileInfo.Name(), "_test.go")
+	}, 0)
+	require.NoError(t, err)
+	for _, pkg := range packages {
+		ast.Inspect(pkg, func(node ast.Node) bool {
+			structType, ok := node.(*ast.StructType)
+			if !ok {
+				return true
+			}
+			for _, field := range structType.Fields.List {
+				mapDepth := getMapFieldDepth(field.Type)
+				if mapDepth == 0 {
+					continue
+				}
+				for _, fieldName := range field.Names {
+					if !fieldName.IsExported() {
+						continue
+					}
+					jsonName := fieldName.Name
+					if field.Tag != nil {
+						tag, err := strconv.Unquote(field.Tag.Value)
+						require.NoError(t, err)
+						tagName := strings.Split(reflect.StructTag(tag).Get("json"), ",")[0]
+						if tagName == "-" {
+							continue
+						}
+						if tagName != "" {
+							jsonName = tagName
+						}
+					}
+					require.GreaterOrEqual(t, jsonMapFieldDepths[jsonName], mapDepth,
+						"%v is missing from jsonMapFieldDepths", jsonName)
+				}
+			}
+			return true
+		})
+	}
+}
+
+// getMapFieldDepth returns how many levels of nested maps a field of type fieldType holds, or
+// jsonOpaqueMapFieldDepth if they hold arbitrary JSON.
+func getMapFieldDepth(fieldType ast.Expr) int {
+	mapDepth := 0
+	for {
+		switch typedFieldType := fieldType.(type) {
+		case *ast.StarExpr:
+			fieldType = typedFieldType.X
+		case *ast.ArrayType:
+			fieldType = typedFieldType.Elt
+		case *ast.MapType:
+			mapDepth++
+			fieldType = typedFieldType.Value
+		case *ast.InterfaceType:
+			if mapDepth > 0 {
+				return jsonOpaqueMapFieldDepth
+			}
+			return 0
+		default:
+			if ident, ok := fieldType.(*ast.Ident); ok && ident.Name == "any" && mapDepth > 0 {
+				return jsonOpaqueMapFieldDepth
+			}
+			return mapDepth
+		}
+	}
+}
@@ -124,7 +124,7 @@ func (fes *APIServer) CoinLockup(ww http.ResponseWriter, req *http.Request) {
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CoinLockupRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CoinLockup: Problem parsing request body: %v", err))
 		return
 	}
@@ -252,7 +252,7 @@ func (fes *APIServer) UpdateCoinLockupParams(ww http.ResponseWriter, req *http.R
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UpdateCoinLockupParamsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("UpdateCoinLockupParams: Problem parsing request body: %v", err))
 		return
 	}
@@ -352,7 +352,7 @@ func (fes *APIServer) CoinLockupTransfer(ww http.ResponseWriter, req *http.Reque
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CoinLockupTransferRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CoinLockupTransfer: Problem parsing request body: %v", err))
 		return
 	}
@@ -463,7 +463,7 @@ func (fes *APIServer) CoinUnlock(ww http.ResponseWriter, req *http.Request) {
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CoinUnlockRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CoinUnlock: Problem parsing request body: %v", err))
 		return
 	}
@@ -558,7 +558,7 @@ func (fes *APIServer) LockedYieldCurvePoints(ww http.ResponseWriter, req *http.R
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("LockedYieldCurvePoints")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("LockedYieldCurvePoints: Problem getting utxoView: %v", err))
 		return
@@ -609,7 +609,7 @@ func (fes *APIServer) LockedBalanceEntries(ww http.ResponseWriter, req *http.Req
 	}
 
 	// Create an augmented UTXO view to include uncomitted transactions.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("LockedBalanceEntries")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("LockedBalanceEntriesHeldByPublicKey: Problem getting utxoView: %v", err))
 		return
@@ -268,7 +268,7 @@ type GetFullTikTokURLResponse struct {
 func (fes *APIServer) GetFullTikTokURL(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetFullTikTokURLRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetFullTikTokURL: Problem parsing request body: %v", err))
 		return
@@ -282,13 +282,14 @@ func (fes *APIServer) GetFullTikTokURL(ww http.ResponseWriter, req *http.Request
 	}
 	// Create a new HTTP Client, create the request, and perform the GET request.
 	client := &http.Client{}
-	req, err := http.NewRequest("GET", tiktokURL, nil)
+	tiktokReq, err := http.NewRequest("GET", tiktokURL, nil)
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetFullTikTokURL: creating GET request: %v", err))
 		return
 	}
-	resp, err := client.Do(req)
+	forwardCorrelationID(req.Context(), tiktokReq)
+	resp, err := client.Do(tiktokReq)
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetFullTikTokURL: error performing GET request: %v", err))
@@ -358,6 +359,7 @@ func (fes *APIServer) UploadVideo(ww http.ResponseWriter, req *http.Request) {
 	request.Header.Add("Upload-Length", req.Header.Get("Upload-Length"))
 	// Upload-Metadata options are described here: https://developers.cloudflare.com/stream/uploading-videos/upload-video-file#supported-options-in-upload-metadata
 	request.Header.Add("Upload-Metadata", req.Header.Get("Upload-Metadata"))
+	forwardCorrelationID(req.Context(), request)
 	// Perform the request
 	resp, err := client.Do(request)
 	if err != nil {
@@ -411,6 +413,7 @@ func (fes *APIServer) GetVideoStatus(ww http.ResponseWriter, req *http.Request)
 	request, err := http.NewRequest("GET", url, nil)
 	request.Header.Add("Authorization", fmt.Sprintf("Bearer %v", fes.Config.CloudflareStreamToken))
 	request.Header.Add("Content-Type", "application/json")
+	forwardCorrelationID(req.Context(), request)
 	resp, err := client.Do(request)
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
@@ -473,6 +476,7 @@ func (fes *APIServer) EnableVideoDownload(ww http.ResponseWriter, req *http.Requ
 	request, err := http.NewRequest("POST", url, nil)
 	request.Header.Add("Authorization", fmt.Sprintf("Bearer %v", fes.Config.CloudflareStreamToken))
 	request.Header.Add("Content-Type", "application/json")
+	forwardCorrelationID(req.Context(), request)
 	resp, err := client.Do(request)
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
@@ -524,6 +528,7 @@ func (fes *APIServer) GetVideoDimensions(ww http.ResponseWriter, req *http.Reque
 	client := &http.Client{}
 	request, err := http.NewRequest("GET", url, nil)
 	request.Header.Add("Content-Type", "application/json")
+	forwardCorrelationID(req.Context(), request)
 	resp, err := client.Do(request)
 	cfVideoOEmbedResponse := &CFVideoOEmbedResponse{}
 	if err = json.NewDecoder(resp.Body).Decode(&cfVideoOEmbedResponse); err != nil {
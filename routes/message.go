@@ -483,7 +483,7 @@ func (fes *APIServer) getOtherPartyInThread(messageEntry *lib.MessageEntry,
 func (fes *APIServer) GetMessagesStateless(ww http.ResponseWriter, rr *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	getMessagesRequest := GetMessagesStatelessRequest{}
-	if err := decoder.Decode(&getMessagesRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&getMessagesRequest)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetMessagesStateless: Error parsing request body: %v", err))
 		return
 	}
@@ -583,7 +583,7 @@ func (fes *APIServer) SendMessageStateless(ww http.ResponseWriter, req *http.Req
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SendMessageStatelessRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SendMessageStateless: Problem parsing request body: %v", err))
 		return
 	}
@@ -648,9 +648,9 @@ func (fes *APIServer) SendMessageStateless(ww http.ResponseWriter, req *http.Req
 	checkPartyMessagingKeysResponse, err := fes.CreateCheckPartyMessagingKeysResponse(senderPublicKey, lib.NewGroupKeyName(senderMessagingGroupKeyNameBytes),
 		recipientPublicKey, lib.NewGroupKeyName(recipientMessagingGroupKeyNameBytes))
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("SendMessageStateless: Problem checking party keys sender (public key: %v, key name: %v), recipient "+
+		_AddHandlerError(ww, fmt.Sprintf("SendMessageStateless: Problem checking party keys sender (public key: %v, key name: %v), recipient "+
 			"(public key: %v, key name: %v), error: %v", senderPublicKey, lib.NewGroupKeyName(senderMessagingGroupKeyNameBytes),
-			recipientPkBytes, lib.NewGroupKeyName(recipientMessagingGroupKeyNameBytes), err))
+			recipientPkBytes, lib.NewGroupKeyName(recipientMessagingGroupKeyNameBytes), err), err)
 		return
 	}
 
@@ -706,6 +706,10 @@ func (fes *APIServer) SendMessageStateless(ww http.ResponseWriter, req *http.Req
 		_AddBadRequestError(ww, fmt.Sprintf("SendMessageStateless: Problem encoding ExtraData: %v", err))
 		return
 	}
+	if err = fes.validateExtraDataLimits(extraData); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("SendMessageStateless: Invalid ExtraData: %v", err))
+		return
+	}
 
 	// Try and create the message for the user.
 	tstamp := uint64(time.Now().UnixNano())
@@ -755,7 +759,7 @@ type MarkContactMessagesReadRequest struct {
 func (fes *APIServer) MarkContactMessagesRead(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := MarkContactMessagesReadRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("MarkUserContactMessagesRead: Problem parsing request body: %v", err))
 		return
 	}
@@ -793,7 +797,7 @@ type MarkAllMessagesReadRequest struct {
 func (fes *APIServer) MarkAllMessagesRead(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := MarkAllMessagesReadRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("MarkUserContactMessagesRead: Problem parsing request body: %v", err))
 		return
 	}
@@ -921,7 +925,7 @@ func (fes *APIServer) RegisterMessagingGroupKey(ww http.ResponseWriter, req *htt
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := RegisterMessagingGroupKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("RegisterMessagingGroupKey: Problem parsing request body: %v", err))
 		return
 	}
@@ -1043,7 +1047,7 @@ type GetAllMessagingGroupKeysResponse struct {
 func (fes *APIServer) GetAllMessagingGroupKeys(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetAllMessagingGroupKeysRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetAllMessagingGroupKeys: Problem parsing request body: %v", err))
 		return
 	}
@@ -1142,7 +1146,7 @@ func (fes *APIServer) CreateCheckPartyMessagingKeysResponse(senderPublicKey *lib
 	}
 
 	// Get the augmented UtxoView.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("CreateCheckPartyMessagingKeysResponse")
 	if err != nil {
 		return nil, err
 	}
@@ -1176,7 +1180,7 @@ func (fes *APIServer) CheckPartyMessagingKeys(ww http.ResponseWriter, req *http.
 	// Decode the request.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CheckPartyMessagingKeysRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CheckPartyMessagingKeys: Problem parsing request body: %v", err))
 		return
 	}
@@ -1213,7 +1217,7 @@ func (fes *APIServer) CheckPartyMessagingKeys(ww http.ResponseWriter, req *http.
 	response, err := fes.CreateCheckPartyMessagingKeysResponse(lib.NewPublicKey(senderPublicKey), senderKeyName,
 		lib.NewPublicKey(recipientPublicKey), recipientKeyName)
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("CheckPartyMessagingKeys: Problem creating party messaging key response: %v", err))
+		_AddHandlerError(ww, fmt.Sprintf("CheckPartyMessagingKeys: Problem creating party messaging key response: %v", err), err)
 		return
 	}
 
@@ -1245,7 +1249,7 @@ type GetBulkMessagingPublicKeysResponse struct {
 func (fes *APIServer) GetBulkMessagingPublicKeys(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetBulkMessagingPublicKeysRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetBulkMessagingPublicKeys: Problem parsing request body: %v", err))
 		return
 	}
@@ -1275,9 +1279,9 @@ func (fes *APIServer) GetBulkMessagingPublicKeys(ww http.ResponseWriter, req *ht
 	}
 
 	// Check if the group owner public keys and messaging group key names are registered, if so fetch their messaging public keys.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetBulkMessagingPublicKeys")
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetBulkMessagingPublicKeys: Problem fetching utxoView: %v", err))
+		_AddInternalServerError(ww, fmt.Sprintf("GetBulkMessagingPublicKeys: Problem fetching utxoView: %v", err))
 		return
 	}
 
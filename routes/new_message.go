@@ -2,21 +2,29 @@ package routes
 
 import (
 	"bytes"
+	"encoding/base64"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"io"
+	"math"
 	"net/http"
 	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/deso-protocol/core/lib"
 	"github.com/pkg/errors"
+	"github.com/tyler-smith/go-bip39"
 )
 
 // Base58 decodes a public key string and verifies if it is in a valid public key format.
 func Base58DecodeAndValidatePublickey(publicKeyBase58Check string) ([]byte, error) {
+	if err := ValidateNotDESOIdentifier("Base58DecodeAndValidatePublickey: public key", publicKeyBase58Check); err != nil {
+		return nil, err
+	}
 	// Decode in Base58 Checksum format.
 	publicKeyBytes, _, err := lib.Base58CheckDecode(publicKeyBase58Check)
 	if err != nil {
@@ -38,6 +46,10 @@ func Base58DecodeAndValidatePublickey(publicKeyBase58Check string) ([]byte, erro
 
 // Basic validations on public key and access Group Keu name.
 func ValidateAccessGroupPublicKeyAndName(publicKeyBase58Check string, accessGroupKeyName string) ([]byte, []byte, error) {
+	if err := ValidateNotDESOIdentifier("ValidateAccessGroupPublicKeyAndName: access group owner public key",
+		publicKeyBase58Check); err != nil {
+		return nil, nil, err
+	}
 	publicKeyBytes, _, err := lib.Base58CheckDecode(publicKeyBase58Check)
 	if err != nil {
 		return nil, nil, errors.New(fmt.Sprintf("ValidateAccessGroupPublicKeyAndName: Problem decoding "+
@@ -83,6 +95,22 @@ func (fes *APIServer) makeAccessGroupInfo(
 	}
 }
 
+// buildAccessGroupInfoFromMessageEntrySender returns the AccessGroupInfo of the access group a message was sent from.
+func (fes *APIServer) buildAccessGroupInfoFromMessageEntrySender(newMessageEntry *lib.NewMessageEntry) AccessGroupInfo {
+	return fes.makeAccessGroupInfo(
+		newMessageEntry.SenderAccessGroupOwnerPublicKey,
+		newMessageEntry.SenderAccessGroupPublicKey,
+		newMessageEntry.SenderAccessGroupKeyName)
+}
+
+// buildAccessGroupInfoFromMessageEntryRecipient returns the AccessGroupInfo of the access group a message was sent to.
+func (fes *APIServer) buildAccessGroupInfoFromMessageEntryRecipient(newMessageEntry *lib.NewMessageEntry) AccessGroupInfo {
+	return fes.makeAccessGroupInfo(
+		newMessageEntry.RecipientAccessGroupOwnerPublicKey,
+		newMessageEntry.RecipientAccessGroupPublicKey,
+		newMessageEntry.RecipientAccessGroupKeyName)
+}
+
 func getFirstMessage(latestMessageEntries []*lib.NewMessageEntry) *lib.NewMessageEntry {
 	// If there are more than one entries fetch just the last message.
 	if len(latestMessageEntries) > 0 {
@@ -126,6 +154,67 @@ func (fes *APIServer) fetchMaxMessagesFromDmThread(
 	return latestMessageEntries, nil
 }
 
+// Fetch MaxMessagesToFetch from the Dm thread between the sender and recipient access groups, with message time
+// stamp starting from startTimestamp. If both parties are using their default key, messages sent with the base key
+// by either party are part of the same conversation, so we fetch those threads as well and merge them in.
+func (fes *APIServer) fetchMaxMessagesFromDmThreadIncludingBaseKeys(
+	senderPublicKey lib.PublicKey,
+	senderGroupKeyName lib.GroupKeyName,
+	recipientPublicKey lib.PublicKey,
+	recipientGroupKeyName lib.GroupKeyName,
+	startTimestamp uint64,
+	MaxMessagesToFetch int,
+	utxoView *lib.UtxoView,
+) ([]*lib.NewMessageEntry, error) {
+	// The information of the two parties involved in Dm has to encoded in lib.DmThreadKey.
+	dmThreadKey := lib.MakeDmThreadKey(senderPublicKey, senderGroupKeyName, recipientPublicKey, recipientGroupKeyName)
+	latestMessages, err := fes.fetchMaxMessagesFromDmThread(&dmThreadKey, startTimestamp, MaxMessagesToFetch, utxoView)
+	if err != nil {
+		return nil, err
+	}
+
+	if senderGroupKeyName != *lib.DefaultGroupKeyName() ||
+		recipientGroupKeyName != *lib.DefaultGroupKeyName() {
+		return latestMessages, nil
+	}
+
+	baseKey := *lib.BaseGroupKeyName()
+	baseKeyBaseKeyThreadKey := lib.MakeDmThreadKey(senderPublicKey, baseKey, recipientPublicKey, baseKey)
+	baseKeyBaseKeyLatestMessages, err := fes.fetchMaxMessagesFromDmThread(
+		&baseKeyBaseKeyThreadKey, startTimestamp, MaxMessagesToFetch, utxoView)
+	if err != nil {
+		return nil, errors.Wrap(err, "Problem getting paginated messages for base key - base key")
+	}
+	latestMessages = append(latestMessages, baseKeyBaseKeyLatestMessages...)
+
+	baseKeyDefaultKeyThreadKey := lib.MakeDmThreadKey(senderPublicKey, baseKey, recipientPublicKey, recipientGroupKeyName)
+	baseKeyDefaultKeyLatestMessages, err := fes.fetchMaxMessagesFromDmThread(
+		&baseKeyDefaultKeyThreadKey, startTimestamp, MaxMessagesToFetch, utxoView)
+	if err != nil {
+		return nil, errors.Wrap(err, "Problem getting paginated messages for base key - default key")
+	}
+	latestMessages = append(latestMessages, baseKeyDefaultKeyLatestMessages...)
+
+	defaultKeyBaseKeyThreadKey := lib.MakeDmThreadKey(senderPublicKey, senderGroupKeyName, recipientPublicKey, baseKey)
+	defaultKeyBaseKeyLatestMessages, err := fes.fetchMaxMessagesFromDmThread(
+		&defaultKeyBaseKeyThreadKey, startTimestamp, MaxMessagesToFetch, utxoView)
+	if err != nil {
+		return nil, errors.Wrap(err, "Problem getting paginated messages for default key - base key")
+	}
+	latestMessages = append(latestMessages, defaultKeyBaseKeyLatestMessages...)
+
+	// Now we sort them and take the first MaxMessagesToFetch
+	sort.Slice(latestMessages, func(ii, jj int) bool {
+		return latestMessages[ii].TimestampNanos > latestMessages[jj].TimestampNanos
+	})
+
+	lastIndex := MaxMessagesToFetch
+	if lastIndex > len(latestMessages) {
+		lastIndex = len(latestMessages)
+	}
+	return latestMessages[:lastIndex], nil
+}
+
 // Takes an array of DmThread Keys (Sender and Recipient public keys and access group key names),
 // returns the latest message with their timestamp for each dmthread key.
 func (fes *APIServer) fetchLatestMessageFromDmThreads(
@@ -226,7 +315,7 @@ type SendNewMessageRequest struct {
 	// although unencrypted message can be passed as well.
 	EncryptedMessageText string
 
-	// Only set if we are updating a message
+	// Only set if we are updating a message. Must be the TimestampNanos of a message the sender sent in the thread.
 	TimestampNanosString string `safeForLogging:"true"`
 
 	MinFeeRateNanosPerKB uint64 `safeForLogging:"true"`
@@ -234,10 +323,43 @@ type SendNewMessageRequest struct {
 	TransactionFees []TransactionFee `safeForLogging:"true"`
 	// ExtraData is an arbitrary key value map
 	ExtraData map[string]string
+
+	// Optional. The format of the message payload, so recipients know how to render it. Must be one of the
+	// MessageContentType constants. Stored in ExtraData under MessageContentTypeExtraDataKey.
+	ContentType string `safeForLogging:"true"`
+
+	// Optional. A unix timestamp in nanoseconds after which clients should hide the message. This is only a hint for
+	// clients to honor: the message stays on-chain and the node keeps serving it. Must be in the future. Stored in
+	// ExtraData under MessageExpiresAtNanosExtraDataKey.
+	ExpiresAtNanos uint64 `safeForLogging:"true"`
+
+	// Only applies to group chat messages. If set, we check that every current member of the recipient group has a
+	// valid access group public key registered before building the transaction. If any member can't be verified, no
+	// transaction is built and the members are returned in UnverifiableRecipientsBase58Check instead. This is a
+	// pre-send safety check, not a guarantee that every member can decrypt the message.
+	VerifyRecipientsCanDecrypt bool `safeForLogging:"true"`
+
+	// By default, the recipient access group must exist before a message can be sent to it, so that a mistyped key
+	// name doesn't produce a message nobody can read. Set this to skip the check, e.g. to message a group that will be
+	// registered later.
+	SkipRecipientAccessGroupCheck bool `safeForLogging:"true"`
+
+	// Optional. If set, the node signs the transaction with its own key for the sender and submits it to the mempool,
+	// instead of returning it unsigned. Only works for senders the node operator has configured a signer for via
+	// --message-signer-seeds; requests for any other sender are rejected. Requires JWT.
+	Broadcast bool `safeForLogging:"true"`
+
+	// A JWT for SenderAccessGroupOwnerPublicKeyBase58Check. Only required when Broadcast is set, so that only the
+	// sender can have the node sign messages on their behalf.
+	JWT string
 }
 
 // struct to serialize the response.
 type SendNewMessageResponse struct {
+	// Only set when VerifyRecipientsCanDecrypt is set and some group members couldn't be verified. In that case,
+	// none of the transaction fields below are set.
+	UnverifiableRecipientsBase58Check []string `json:",omitempty" safeForLogging:"true"`
+
 	TstampNanos uint64
 
 	TotalInputNanos   uint64
@@ -245,6 +367,9 @@ type SendNewMessageResponse struct {
 	FeeNanos          uint64
 	Transaction       *lib.MsgDeSoTxn
 	TransactionHex    string
+
+	// Only set when Broadcast is set. The hash of the signed transaction that was submitted to the mempool.
+	TxnHashHex string `json:",omitempty"`
 }
 
 // API to send Direct message.
@@ -257,14 +382,14 @@ type SendNewMessageResponse struct {
 // Only basic validations on the input data are performed here.
 func (fes *APIServer) SendDmMessage(ww http.ResponseWriter, req *http.Request) {
 	if err := fes.sendMessageHandler(ww, req, lib.NewMessageTypeDm, lib.NewMessageOperationCreate); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("SendDmMessage: %v", err))
+		_AddHandlerError(ww, fmt.Sprintf("SendDmMessage: %v", err), err)
 		return
 	}
 }
 
 func (fes *APIServer) UpdateDmMessage(ww http.ResponseWriter, req *http.Request) {
 	if err := fes.sendMessageHandler(ww, req, lib.NewMessageTypeDm, lib.NewMessageOperationUpdate); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("UpdateDmMessage: %v", err))
+		_AddHandlerError(ww, fmt.Sprintf("UpdateDmMessage: %v", err), err)
 		return
 	}
 }
@@ -278,14 +403,14 @@ func (fes *APIServer) UpdateDmMessage(ww http.ResponseWriter, req *http.Request)
 // Only basic validations on the input data are performed here.
 func (fes *APIServer) SendGroupChatMessage(ww http.ResponseWriter, req *http.Request) {
 	if err := fes.sendMessageHandler(ww, req, lib.NewMessageTypeGroupChat, lib.NewMessageOperationCreate); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("SendGroupChatMessage: %v", err))
+		_AddHandlerError(ww, fmt.Sprintf("SendGroupChatMessage: %v", err), err)
 		return
 	}
 }
 
 func (fes *APIServer) UpdateGroupChatMessage(ww http.ResponseWriter, req *http.Request) {
 	if err := fes.sendMessageHandler(ww, req, lib.NewMessageTypeGroupChat, lib.NewMessageOperationUpdate); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("UpdateGroupChatMessage: %v", err))
+		_AddHandlerError(ww, fmt.Sprintf("UpdateGroupChatMessage: %v", err), err)
 		return
 	}
 }
@@ -299,74 +424,194 @@ func (fes *APIServer) sendMessageHandler(
 	// Deserialize the request data.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SendNewMessageRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		return errors.Wrapf(err, "Problem parsing request body: ")
 	}
 
+	res, err := fes.buildNewMessageTxn(&requestData, newMessageType, newMessageOperationType,
+		func() (*lib.UtxoView, error) {
+			return fes.getAugmentedUniversalView("sendMessageHandler")
+		})
+	if err != nil {
+		return err
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		return errors.Wrapf(err, "Problem encoding response as JSON: ")
+	}
+	return nil
+}
+
+// buildNewMessageTxn validates a SendNewMessageRequest and constructs its transaction, broadcasting it if requested.
+// getUtxoView is only called if a check needs the view.
+func (fes *APIServer) buildNewMessageTxn(
+	requestData *SendNewMessageRequest,
+	newMessageType lib.NewMessageType,
+	newMessageOperationType lib.NewMessageOperation,
+	getUtxoView func() (*lib.UtxoView, error),
+) (*SendNewMessageResponse, error) {
+	if requestData.ContentType != "" {
+		if err := ValidateMessageContentType(requestData.ContentType); err != nil {
+			return nil, err
+		}
+	}
+	if requestData.ExpiresAtNanos != 0 {
+		if err := ValidateMessageExpiresAtNanos(requestData.ExpiresAtNanos, time.Now()); err != nil {
+			return nil, err
+		}
+	}
+
 	// Basic validation of the sender public key and access group name.
 	senderGroupOwnerPkBytes, senderGroupKeyNameBytes, err :=
 		ValidateAccessGroupPublicKeyAndName(requestData.SenderAccessGroupOwnerPublicKeyBase58Check, requestData.SenderAccessGroupKeyName)
 	// Abruptly end the request processing on error and return.
 	if err != nil {
-		return errors.Wrapf(err, fmt.Sprintf("Problem validating sender public key and access group name"+
+		return nil, errors.Wrapf(err, fmt.Sprintf("Problem validating sender public key and access group name"+
 			"base58 public key %s: %s ",
 			requestData.SenderAccessGroupOwnerPublicKeyBase58Check, requestData.SenderAccessGroupKeyName))
 	}
 
+	if reason, isDenied := fes.messagingDenylist[lib.PkToString(senderGroupOwnerPkBytes, fes.Params)]; isDenied {
+		if reason == "" {
+			return nil, errors.Errorf("Sender %v is not allowed to send messages through this node",
+				requestData.SenderAccessGroupOwnerPublicKeyBase58Check)
+		}
+		return nil, errors.Errorf("Sender %v is not allowed to send messages through this node: %v",
+			requestData.SenderAccessGroupOwnerPublicKeyBase58Check, reason)
+	}
+
+	// Check for a signer up front so that we don't build a transaction we can't broadcast.
+	var senderSigner *btcec.PrivateKey
+	if requestData.Broadcast {
+		senderSigner = fes.messageSigners[lib.PkToString(senderGroupOwnerPkBytes, fes.Params)]
+		if senderSigner == nil {
+			return nil, errors.Errorf("Broadcast is not enabled for sender %v on this node",
+				requestData.SenderAccessGroupOwnerPublicKeyBase58Check)
+		}
+		isValid, err := fes.ValidateJWT(requestData.SenderAccessGroupOwnerPublicKeyBase58Check, requestData.JWT)
+		if !isValid {
+			return nil, errors.Errorf("Broadcast requires a valid JWT for sender %v: %v",
+				requestData.SenderAccessGroupOwnerPublicKeyBase58Check, err)
+		}
+	}
+
 	// Basic validation of the recipient public key and access group name.
 	recipientGroupOwnerPkBytes, recipientGroupKeyNameBytes, err :=
 		ValidateAccessGroupPublicKeyAndName(requestData.RecipientAccessGroupOwnerPublicKeyBase58Check, requestData.RecipientAccessGroupKeyName)
 	// Abruptly end the request processing on error and return.
 	if err != nil {
-		return errors.Wrapf(err, fmt.Sprintf("Problem validating sender public key and access group name"+
+		return nil, errors.Wrapf(err, fmt.Sprintf("Problem validating recipient public key and access group name"+
 			"base58 public key %s: %s ",
-			requestData.SenderAccessGroupOwnerPublicKeyBase58Check, requestData.SenderAccessGroupKeyName))
+			requestData.RecipientAccessGroupOwnerPublicKeyBase58Check, requestData.RecipientAccessGroupKeyName))
 	}
 
 	hexDecodedEncryptedMessageBytes, err := hex.DecodeString(requestData.EncryptedMessageText)
 	if err != nil {
-		return errors.Wrapf(err, "Problem decoding encrypted message text hex")
+		return nil, errors.Wrapf(err, "Problem decoding encrypted message text hex")
+	}
+	if err = validateEncryptedMessageTextSize(
+		len(hexDecodedEncryptedMessageBytes), fes.getMaxDmMessageSizeBytes()); err != nil {
+		return nil, err
 	}
 
 	// Validate the sender access group public key.
 	senderAccessGroupPkbytes, err := Base58DecodeAndValidatePublickey(requestData.SenderAccessGroupPublicKeyBase58Check)
 	if err != nil {
-		return errors.Wrapf(err, fmt.Sprintf("Problem validating sender "+
+		return nil, errors.Wrapf(err, fmt.Sprintf("Problem validating sender "+
 			"base58 public key %s: ", requestData.SenderAccessGroupPublicKeyBase58Check))
 	}
 
 	// Validate the recipient access group public key.
 	recipientAccessGroupPkbytes, err := Base58DecodeAndValidatePublickey(requestData.RecipientAccessGroupPublicKeyBase58Check)
 	if err != nil {
-		return errors.Wrapf(err, fmt.Sprintf("Problem validating recipient "+
-			"base58 public key %s: ", requestData.SenderAccessGroupPublicKeyBase58Check))
+		return nil, errors.Wrapf(err, fmt.Sprintf("Problem validating recipient "+
+			"base58 public key %s: ", requestData.RecipientAccessGroupPublicKeyBase58Check))
+	}
+
+	verifyRecipientsCanDecrypt := newMessageType == lib.NewMessageTypeGroupChat && requestData.VerifyRecipientsCanDecrypt
+	var utxoView *lib.UtxoView
+	isUpdate := newMessageOperationType == lib.NewMessageOperationUpdate
+	if !requestData.SkipRecipientAccessGroupCheck || verifyRecipientsCanDecrypt || isUpdate {
+		utxoView, err = getUtxoView()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem generating utxo view: ")
+		}
+	}
+
+	if !requestData.SkipRecipientAccessGroupCheck {
+		if err = validateRecipientAccessGroupExists(
+			recipientGroupOwnerPkBytes, recipientGroupKeyNameBytes, utxoView.GetAccessGroupEntry); err != nil {
+			return nil, errors.Wrapf(err, "Recipient %v: ", requestData.RecipientAccessGroupOwnerPublicKeyBase58Check)
+		}
+	}
+
+	if verifyRecipientsCanDecrypt {
+		unverifiableRecipients, err := fes.getUnverifiableGroupChatMembers(
+			recipientGroupOwnerPkBytes, recipientGroupKeyNameBytes, utxoView)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem verifying recipients: ")
+		}
+		if len(unverifiableRecipients) > 0 {
+			return &SendNewMessageResponse{UnverifiableRecipientsBase58Check: unverifiableRecipients}, nil
+		}
 	}
 
 	// Compute the additional transaction fees as specified by the request body and the node-level fees.
 	additionalOutputs, err := fes.getTransactionFee(lib.TxnTypeNewMessage, senderGroupOwnerPkBytes, requestData.TransactionFees)
 	if err != nil {
-		return errors.Wrapf(err, "TransactionFees specified in Request body are invalid: ")
+		return nil, errors.Wrapf(err, "TransactionFees specified in Request body are invalid: ")
 	}
 
 	// extra data is relevant for certain type of requests. Refer to documentation for any requirement of adding extra data.
 	extraData, err := EncodeExtraDataMap(requestData.ExtraData)
 	if err != nil {
-		return errors.Wrapf(err, "Problem encoding ExtraData: ")
+		return nil, errors.Wrapf(err, "Problem encoding ExtraData: ")
+	}
+	extraData, err = setMessageContentType(extraData, requestData.ContentType)
+	if err != nil {
+		return nil, err
+	}
+	extraData, err = setMessageExpiresAtNanos(extraData, requestData.ExpiresAtNanos, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if err = fes.validateExtraDataLimits(extraData); err != nil {
+		return nil, errors.Wrapf(err, "Invalid ExtraData: ")
 	}
 
 	tstamp := uint64(time.Now().UnixNano())
 
-	if newMessageOperationType == lib.NewMessageOperationUpdate {
+	if isUpdate {
 		// convert timestampnanos string to uint64
 		tstamp, err = strconv.ParseUint(requestData.TimestampNanosString, 10, 64)
 		if err != nil {
-			return errors.Wrapf(err, "Problem converting TimestampNanosString to uint64: ")
+			return nil, errors.Wrapf(err, "Problem converting TimestampNanosString to uint64: ")
 		}
 		if tstamp == 0 {
-			return errors.Wrapf(err, "TimestampNanosString cannot be 0: ")
+			return nil, errors.New("TimestampNanosString cannot be 0")
+		}
+
+		// The original message is looked up in the same thread the update will be written to.
+		var fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error)
+		if newMessageType == lib.NewMessageTypeDm {
+			dmThreadKey := lib.MakeDmThreadKey(
+				*lib.NewPublicKey(senderGroupOwnerPkBytes), *lib.NewGroupKeyName(senderGroupKeyNameBytes),
+				*lib.NewPublicKey(recipientGroupOwnerPkBytes), *lib.NewGroupKeyName(recipientGroupKeyNameBytes))
+			fetchMessages = func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+				return fes.fetchMaxMessagesFromDmThread(&dmThreadKey, startTimestamp, maxMessagesToFetch, utxoView)
+			}
+		} else {
+			accessGroupId := &lib.AccessGroupId{
+				AccessGroupOwnerPublicKey: *lib.NewPublicKey(recipientGroupOwnerPkBytes),
+				AccessGroupKeyName:        *lib.NewGroupKeyName(recipientGroupKeyNameBytes),
+			}
+			fetchMessages = func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+				return fes.fetchMaxMessagesFromGroupChatThread(accessGroupId, startTimestamp, maxMessagesToFetch, utxoView)
+			}
+		}
+		if err = validateMessageToUpdate(senderGroupOwnerPkBytes, tstamp, fetchMessages); err != nil {
+			return nil, err
 		}
-		// Note that for now we do not validate that the message exists
-		// before updating or creating.
 	}
 
 	// Call CreateNewMessageTxn the core lib to construct the transaction to send a group chat message.
@@ -379,248 +624,930 @@ func (fes *APIServer) sendMessageHandler(
 		newMessageType, newMessageOperationType, extraData, requestData.MinFeeRateNanosPerKB,
 		fes.backendServer.GetMempool(), additionalOutputs)
 	if err != nil {
-		return errors.Wrapf(err, "Problem creating transaction: ")
+		return nil, errors.Wrapf(err, "Problem creating transaction: ")
 	}
 
 	// Add node source to txn metadata
 	fes.AddNodeSourceToTxnMetadata(txn)
 
-	txnBytes, err := txn.ToBytes(true)
+	var txnHashHex string
+	if senderSigner != nil {
+		txnSignature, err := txn.Sign(senderSigner)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem signing transaction: ")
+		}
+		txn.Signature.SetSignature(txnSignature)
+		if err = fes.backendServer.VerifyAndBroadcastTransaction(txn); err != nil {
+			return nil, errors.Wrapf(err, "Problem broadcasting transaction: ")
+		}
+		txnHashHex = hex.EncodeToString(txn.Hash()[:])
+	}
+
+	// The transaction is unsigned unless it was broadcast above.
+	txnBytes, err := txn.ToBytes(senderSigner == nil)
 	if err != nil {
-		return errors.Wrapf(err, "Problem serializing transaction: ")
+		return nil, errors.Wrapf(err, "Problem serializing transaction: ")
 	}
 
 	// Return all the data associated with the transaction in the response
-	res := SendNewMessageResponse{
+	return &SendNewMessageResponse{
 		TotalInputNanos:   totalInput,
 		ChangeAmountNanos: changeAmount,
 		FeeNanos:          fees,
 		Transaction:       txn,
 		TransactionHex:    hex.EncodeToString(txnBytes),
+		TxnHashHex:        txnHashHex,
+	}, nil
+}
+
+// MaxDmMessageSizeBytes is the largest EncryptedMessageText, after hex decoding, the send endpoints accept when the
+// node doesn't configure MaxDmMessageSizeBytes. Messages much larger than this make transactions too big to relay.
+const MaxDmMessageSizeBytes = 64 * 1024
+
+// getMaxDmMessageSizeBytes returns the node's configured MaxDmMessageSizeBytes, or the MaxDmMessageSizeBytes constant
+// if it isn't configured.
+func (fes *APIServer) getMaxDmMessageSizeBytes() int {
+	if fes.Config != nil && fes.Config.MaxDmMessageSizeBytes > 0 {
+		return fes.Config.MaxDmMessageSizeBytes
 	}
+	return MaxDmMessageSizeBytes
+}
 
-	if err = json.NewEncoder(ww).Encode(res); err != nil {
-		return errors.Wrapf(err, "Problem encoding response as JSON: ")
+// validateEncryptedMessageTextSize rejects messages whose decoded EncryptedMessageText is larger than
+// maxMessageSizeBytes, so oversized messages fail up front instead of producing a transaction that won't relay.
+func validateEncryptedMessageTextSize(messageSizeBytes int, maxMessageSizeBytes int) error {
+	if messageSizeBytes > maxMessageSizeBytes {
+		return errors.Errorf("EncryptedMessageText is %v bytes, which is larger than the maximum of %v bytes",
+			messageSizeBytes, maxMessageSizeBytes)
 	}
 	return nil
 }
 
-type ChatType string
+// The maximum number of recipients SendDmMessageBatch accepts in one request.
+const MaxRecipientsPerSendDmMessageBatch = 100
 
-const (
-	ChatTypeDM        = "DM"
-	ChatTypeGroupChat = "GroupChat"
-)
+// SendDmMessageBatchRecipient is one recipient of a SendDmMessageBatch along with the message encrypted for them.
+type SendDmMessageBatchRecipient struct {
+	RecipientAccessGroupOwnerPublicKeyBase58Check string `safeForLogging:"true"`
+	RecipientAccessGroupPublicKeyBase58Check      string `safeForLogging:"true"`
+	RecipientAccessGroupKeyName                   string `safeForLogging:"true"`
 
-type NewMessageEntryResponse struct {
-	ChatType      ChatType
-	SenderInfo    AccessGroupInfo
-	RecipientInfo AccessGroupInfo
-	MessageInfo   MessageInfo
+	EncryptedMessageText string
 }
 
-// Types to store the chat messages.
-type AccessGroupInfo struct {
-	OwnerPublicKeyBase58Check       string `safeForLogging:"true"`
-	AccessGroupPublicKeyBase58Check string `safeForLogging:"true"`
-	AccessGroupKeyName              string `safeForLogging:"true"`
-}
-type MessageInfo struct {
-	EncryptedText        string
-	TimestampNanos       uint64
-	TimestampNanosString string
-	ExtraData            map[string]string
-}
+type SendDmMessageBatchRequest struct {
+	// The sender fields are the same as in SendNewMessageRequest and are shared by every message in the batch.
+	SenderAccessGroupOwnerPublicKeyBase58Check string `safeForLogging:"true"`
+	SenderAccessGroupPublicKeyBase58Check      string `safeForLogging:"true"`
+	SenderAccessGroupKeyName                   string `safeForLogging:"true"`
 
-func (fes *APIServer) NewMessageEntryToResponse(newMessageEntry *lib.NewMessageEntry, chatType ChatType, utxoView *lib.UtxoView) NewMessageEntryResponse {
-	return NewMessageEntryResponse{
-		ChatType: chatType,
-		SenderInfo: fes.makeAccessGroupInfo(
-			newMessageEntry.SenderAccessGroupOwnerPublicKey,
-			newMessageEntry.SenderAccessGroupPublicKey,
-			newMessageEntry.SenderAccessGroupKeyName),
-		RecipientInfo: fes.makeAccessGroupInfo(
-			newMessageEntry.RecipientAccessGroupOwnerPublicKey,
-			newMessageEntry.RecipientAccessGroupPublicKey,
-			newMessageEntry.RecipientAccessGroupKeyName),
-		MessageInfo: MessageInfo{
-			EncryptedText:        hex.EncodeToString(newMessageEntry.EncryptedText),
-			TimestampNanos:       newMessageEntry.TimestampNanos,
-			TimestampNanosString: strconv.FormatUint(newMessageEntry.TimestampNanos, 10),
-			ExtraData:            DecodeExtraDataMap(fes.Params, utxoView, newMessageEntry.ExtraData),
-		},
-	}
-}
+	Recipients []SendDmMessageBatchRecipient `safeForLogging:"true"`
 
-// This endpoint should returns all dm threads for a user.
-// Calls the GetAllUserDmThreads function from the core library.
-// Should return the direct message threads of the user along with the latest message sent for each of them.
-// This API just doesn't write any data, hence it doesn't create a new transaction.
-// It's a public API, hence anyone with a valid public key can query the system to fetch their Direct message threads.
-func (fes *APIServer) GetUserDmThreadsOrderedByTimestamp(ww http.ResponseWriter, req *http.Request) {
-	if err := fes.getUserMessageThreadsHandler(ww, req, false, true); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetUserDmThreadsOrderedByTimestamp: %v", err))
-		return
-	}
+	// These are the same as in SendNewMessageRequest and apply to every message in the batch.
+	MinFeeRateNanosPerKB          uint64           `safeForLogging:"true"`
+	TransactionFees               []TransactionFee `safeForLogging:"true"`
+	ExtraData                     map[string]string
+	ContentType                   string `safeForLogging:"true"`
+	ExpiresAtNanos                uint64 `safeForLogging:"true"`
+	SkipRecipientAccessGroupCheck bool   `safeForLogging:"true"`
+	Broadcast                     bool   `safeForLogging:"true"`
+	JWT                           string
 }
 
-// type to deserialize the http request to fetch message from a specific direct message thread (dmThread).
-type GetPaginatedMessagesForDmThreadRequest struct {
-	//  A Direct message thread is a conversation between two parties.
-	// The first party is represented by the prefix "User".
-	UserGroupOwnerPublicKeyBase58Check string
-	UserGroupKeyName                   string
-	// The second party is represented by prefix "party"
-	PartyGroupOwnerPublicKeyBase58Check string
-	PartyGroupKeyName                   string
-	// Filter to fetch direct messages who time stamp is less than StartTimestamp.
-	// So you need to set this to current time and MaxMessagesToFetch to 10, to fetch
-	//  the latest 10 messages. We support passing start timestamp as string and uint64.
-	// uint64 can lose precision when being JSON decoded, so we prefer StartTimestampString.
-	StartTimestamp       uint64
-	StartTimestampString string
-	MaxMessagesToFetch   int
+type SendDmMessageBatchResult struct {
+	// The index of the recipient in the request.
+	Index int `safeForLogging:"true"`
+	// Why the message couldn't be built. Only set when Response is nil.
+	Error    string                  `json:",omitempty"`
+	Response *SendNewMessageResponse `json:",omitempty"`
 }
 
-// type to serialize the response containing the direct messages between two parties.
-type GetPaginatedMessagesForDmResponse struct {
-	ThreadMessages                  []NewMessageEntryResponse
-	PublicKeyToProfileEntryResponse map[string]*ProfileEntryResponse
+type SendDmMessageBatchResponse struct {
+	AllSucceeded bool `safeForLogging:"true"`
+	// One result per recipient, in the same order as the request.
+	Results []SendDmMessageBatchResult
 }
 
-// API is used to fetch the direct messages between two parties in a paginated way.
-// This is useful for applications to fetch only N number of direct messages between two parties at once.
-// Timestamp in the request data can be altered to fetch subsequent N messages in each call to fetch the direct messages.
-
-// This API just doesn't write any data, hence it doesn't create a new transaction.
-// It's a public API, hence anyone with a valid input data can query the system to fetch their Direct messages.
-func (fes *APIServer) GetPaginatedMessagesForDmThread(ww http.ResponseWriter, req *http.Request) {
-	// Deserialize the request data.
+// SendDmMessageBatch composes one DM transaction per recipient from the same sender, for senders fanning a message
+// out to many users. Each message is validated and built as though it had been sent to SendDmMessage on its own, and
+// a message that fails doesn't stop the others from being built. Like SendDmMessage, the transactions are returned
+// unsigned unless Broadcast is set.
+func (fes *APIServer) SendDmMessageBatch(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
-	requestData := GetPaginatedMessagesForDmThreadRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem parsing request body: %v", err))
+	requestData := SendDmMessageBatchRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("SendDmMessageBatch: Problem parsing request body: %v", err))
 		return
 	}
 
-	// Why fetch if there's less than one message to fetch!!!!!
-	if requestData.MaxMessagesToFetch < 1 {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: MaxMessagesToFetch cannot be less than 1: %v", requestData.MaxMessagesToFetch))
+	if len(requestData.Recipients) == 0 || len(requestData.Recipients) > MaxRecipientsPerSendDmMessageBatch {
+		_AddBadRequestError(ww, fmt.Sprintf("SendDmMessageBatch: Must provide between 1 and %v Recipients",
+			MaxRecipientsPerSendDmMessageBatch))
 		return
 	}
 
-	// Basic validation of the sender public key and access group name.
-	senderGroupOwnerPkBytes, senderGroupKeyNameBytes, err :=
-		ValidateAccessGroupPublicKeyAndName(requestData.UserGroupOwnerPublicKeyBase58Check, requestData.UserGroupKeyName)
-	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem validating "+
-			"user group owner public key and access group name %s: %s %v",
-			requestData.UserGroupOwnerPublicKeyBase58Check, requestData.PartyGroupOwnerPublicKeyBase58Check, err))
-		return
+	// The view is only read, so it's built once and shared by every message in the batch.
+	var utxoView *lib.UtxoView
+	getUtxoView := func() (*lib.UtxoView, error) {
+		if utxoView == nil {
+			var err error
+			if utxoView, err = fes.getAugmentedUniversalView("SendDmMessageBatch"); err != nil {
+				return nil, err
+			}
+		}
+		return utxoView, nil
 	}
 
-	// Basic validation of the public key and access group name of the other party in the dm.
-	recipientGroupOwnerPkBytes, recipientGroupKeyNameBytes, err :=
-		ValidateAccessGroupPublicKeyAndName(requestData.PartyGroupOwnerPublicKeyBase58Check, requestData.PartyGroupKeyName)
-	// Decode the access group owner public key.
-	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem validating "+
-			"party group owner public key and access group name %s: %s %v",
-			requestData.PartyGroupOwnerPublicKeyBase58Check, requestData.PartyGroupKeyName, err))
-		return
+	res := SendDmMessageBatchResponse{
+		AllSucceeded: true,
+		Results: sendDmMessageBatch(&requestData, func(messageRequest *SendNewMessageRequest) (
+			*SendNewMessageResponse, error) {
+			return fes.buildNewMessageTxn(
+				messageRequest, lib.NewMessageTypeDm, lib.NewMessageOperationCreate, getUtxoView)
+		}),
 	}
-
-	// sender and the recipient public keys cannot be the same.
-	if bytes.Equal(senderGroupOwnerPkBytes, recipientGroupOwnerPkBytes) {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Dm sender and recipient "+
-			"cannot be the same %s: %s",
-			requestData.UserGroupOwnerPublicKeyBase58Check, requestData.PartyGroupOwnerPublicKeyBase58Check))
+	for _, result := range res.Results {
+		res.AllSucceeded = res.AllSucceeded && result.Response != nil
+	}
+	if err := json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("SendDmMessageBatch: Problem encoding response as JSON: %v", err))
 		return
 	}
+}
 
-	startTimestamp := requestData.StartTimestamp
-	if requestData.StartTimestampString != "" {
-		startTimestamp, err = strconv.ParseUint(requestData.StartTimestampString, 10, 64)
+// sendDmMessageBatch expands a batch into one SendNewMessageRequest per recipient and runs buildTxn on each in turn,
+// recording its response or error.
+func sendDmMessageBatch(
+	requestData *SendDmMessageBatchRequest,
+	buildTxn func(messageRequest *SendNewMessageRequest) (*SendNewMessageResponse, error),
+) []SendDmMessageBatchResult {
+	results := []SendDmMessageBatchResult{}
+	for ii, recipient := range requestData.Recipients {
+		messageRequest := SendNewMessageRequest{
+			SenderAccessGroupOwnerPublicKeyBase58Check:    requestData.SenderAccessGroupOwnerPublicKeyBase58Check,
+			SenderAccessGroupPublicKeyBase58Check:         requestData.SenderAccessGroupPublicKeyBase58Check,
+			SenderAccessGroupKeyName:                      requestData.SenderAccessGroupKeyName,
+			RecipientAccessGroupOwnerPublicKeyBase58Check: recipient.RecipientAccessGroupOwnerPublicKeyBase58Check,
+			RecipientAccessGroupPublicKeyBase58Check:      recipient.RecipientAccessGroupPublicKeyBase58Check,
+			RecipientAccessGroupKeyName:                   recipient.RecipientAccessGroupKeyName,
+			EncryptedMessageText:                          recipient.EncryptedMessageText,
+			MinFeeRateNanosPerKB:                          requestData.MinFeeRateNanosPerKB,
+			TransactionFees:                               requestData.TransactionFees,
+			ExtraData:                                     requestData.ExtraData,
+			ContentType:                                   requestData.ContentType,
+			ExpiresAtNanos:                                requestData.ExpiresAtNanos,
+			SkipRecipientAccessGroupCheck:                 requestData.SkipRecipientAccessGroupCheck,
+			Broadcast:                                     requestData.Broadcast,
+			JWT:                                           requestData.JWT,
+		}
+		messageResponse, err := buildTxn(&messageRequest)
 		if err != nil {
-			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Error parsing "+
-				"StartTimestampString: %v", err))
-			return
+			results = append(results, SendDmMessageBatchResult{
+				Index: ii,
+				Error: fmt.Sprintf("Recipient %v: %v", recipient.RecipientAccessGroupOwnerPublicKeyBase58Check, err),
+			})
+			continue
 		}
+		results = append(results, SendDmMessageBatchResult{Index: ii, Response: messageResponse})
 	}
+	return results
+}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
-	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Error generating "+
-			"utxo view: %v", err))
-		return
-	}
-
-	senderPublicKey := *lib.NewPublicKey(senderGroupOwnerPkBytes)
-	senderGroupKeyName := *lib.NewGroupKeyName(senderGroupKeyNameBytes)
-	recipientPublicKey := *lib.NewPublicKey(recipientGroupOwnerPkBytes)
-	recipientGroupKeyName := *lib.NewGroupKeyName(recipientGroupKeyNameBytes)
-	// The information of the two parties involved in Dm has to encoded in lib.DmThreadKey.
-	dmThreadKey := lib.MakeDmThreadKey(senderPublicKey, senderGroupKeyName, recipientPublicKey, recipientGroupKeyName)
-
-	// Fetch the max messages between the sender and the party.
-	latestMessages, err := fes.fetchMaxMessagesFromDmThread(&dmThreadKey, startTimestamp, requestData.MaxMessagesToFetch, utxoView)
-	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem getting paginated messages for "+
-			"Request Data: %v: %v", requestData, err))
-		return
-	}
-
-	// Special case: If we're getting the DM thread for the default-key for
-	// both parties, then we also fetch base key DMs.
-	if senderGroupKeyName == *lib.DefaultGroupKeyName() &&
-		recipientGroupKeyName == *lib.DefaultGroupKeyName() {
-		baseKey := *lib.BaseGroupKeyName()
-		baseKeyBaseKeyThreadKey := lib.MakeDmThreadKey(senderPublicKey, baseKey, recipientPublicKey, baseKey)
-		baseKeyBaseKeyLatestMessages, err := fes.fetchMaxMessagesFromDmThread(
-			&baseKeyBaseKeyThreadKey, startTimestamp, requestData.MaxMessagesToFetch, utxoView)
-		if err != nil {
-			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem getting paginated "+
-				"messages for base key - base key - Request Data: %v: %v", requestData, err))
-			return
-		}
-		latestMessages = append(latestMessages, baseKeyBaseKeyLatestMessages...)
+// verifyGroupChatMembersBatchSize is the number of members fetched at a time when verifying group chat recipients.
+const verifyGroupChatMembersBatchSize = 1000
 
-		baseKeyDefaultKeyThreadKey := lib.MakeDmThreadKey(senderPublicKey, baseKey, recipientPublicKey, recipientGroupKeyName)
-		baseKeyDefaultKeyLatestMessages, err := fes.fetchMaxMessagesFromDmThread(
-			&baseKeyDefaultKeyThreadKey, startTimestamp, requestData.MaxMessagesToFetch, utxoView)
+// forEachAccessGroupMember pages through fetchMembers, which fetches up to maxMembersToFetch members starting at and
+// including startingMemberPkBytes, and calls visitMember once for each distinct member. It stops at the first error.
+func forEachAccessGroupMember(
+	fetchMembers func(startingMemberPkBytes []byte, maxMembersToFetch int) ([]*lib.PublicKey, error),
+	visitMember func(member *lib.PublicKey) error,
+) error {
+	seenMembers := make(map[string]bool)
+	var startingMemberPkBytes []byte
+	for {
+		members, err := fetchMembers(startingMemberPkBytes, verifyGroupChatMembersBatchSize)
 		if err != nil {
-			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem getting paginated "+
-				"messages for base key - default key - Request Data: %v: %v", requestData, err))
-			return
+			return err
 		}
-		latestMessages = append(latestMessages, baseKeyDefaultKeyLatestMessages...)
-
-		defaultKeyBaseKeyThreadKey := lib.MakeDmThreadKey(senderPublicKey, senderGroupKeyName, recipientPublicKey, baseKey)
-		defaultKeyBaseKeyLatestMessages, err := fes.fetchMaxMessagesFromDmThread(
-			&defaultKeyBaseKeyThreadKey, startTimestamp, requestData.MaxMessagesToFetch, utxoView)
-		if err != nil {
-			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem getting paginated "+
-				"messages for default key - base key - Request Data: %v: %v", requestData, err))
-			return
+		newMembers := 0
+		for _, member := range members {
+			if seenMembers[string(member.ToBytes())] {
+				continue
+			}
+			seenMembers[string(member.ToBytes())] = true
+			newMembers++
+			if err = visitMember(member); err != nil {
+				return err
+			}
 		}
-		latestMessages = append(latestMessages, defaultKeyBaseKeyLatestMessages...)
-
-		// Now we sort them and take the first MaxMessagesToFetch
-		sort.Slice(latestMessages, func(ii, jj int) bool {
-			return latestMessages[ii].TimestampNanos > latestMessages[jj].TimestampNanos
-		})
-
-		lastIndex := requestData.MaxMessagesToFetch
-		if lastIndex > len(latestMessages) {
-			lastIndex = len(latestMessages)
+		if newMembers == 0 || len(members) < verifyGroupChatMembersBatchSize {
+			return nil
 		}
-		latestMessages = latestMessages[:lastIndex]
+		startingMemberPkBytes = members[len(members)-1].ToBytes()
 	}
+}
 
-	// Since the two parties in the conversation in same in all the message if added this info upfront.
-	res := GetPaginatedMessagesForDmResponse{
-		ThreadMessages:                  []NewMessageEntryResponse{},
-		PublicKeyToProfileEntryResponse: make(map[string]*ProfileEntryResponse),
+// countAccessGroupMembers returns the number of distinct members returned by paging through fetchMembers.
+func countAccessGroupMembers(
+	fetchMembers func(startingMemberPkBytes []byte, maxMembersToFetch int) ([]*lib.PublicKey, error),
+) (int, error) {
+	memberCount := 0
+	err := forEachAccessGroupMember(fetchMembers, func(*lib.PublicKey) error {
+		memberCount++
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return memberCount, nil
+}
+
+// getUnverifiableGroupChatMembers returns the base58 public keys of the members of the given group that don't have a
+// valid access group public key registered for the key name they were added with.
+func (fes *APIServer) getUnverifiableGroupChatMembers(
+	groupOwnerPkBytes []byte,
+	groupKeyNameBytes []byte,
+	utxoView *lib.UtxoView,
+) ([]string, error) {
+	memberEntries, err := fes.fetchAllAccessGroupMemberEntries(groupOwnerPkBytes, groupKeyNameBytes, utxoView)
+	if err != nil {
+		return nil, err
+	}
+
+	unverifiableMemberPks, err := getUnverifiableAccessGroupMembers(memberEntries, utxoView.GetAccessGroupEntry)
+	if err != nil {
+		return nil, err
+	}
+	var unverifiableMembers []string
+	for _, memberPk := range unverifiableMemberPks {
+		unverifiableMembers = append(unverifiableMembers, lib.PkToString(memberPk.ToBytes(), fes.Params))
+	}
+	return unverifiableMembers, nil
+}
+
+// fetchAllAccessGroupMemberEntries pages through the members of the given group and returns the member entries of
+// those that haven't been removed.
+func (fes *APIServer) fetchAllAccessGroupMemberEntries(
+	groupOwnerPkBytes []byte,
+	groupKeyNameBytes []byte,
+	utxoView *lib.UtxoView,
+) ([]*lib.AccessGroupMemberEntry, error) {
+	var memberEntries []*lib.AccessGroupMemberEntry
+	err := forEachAccessGroupMember(
+		func(startingMemberPkBytes []byte, maxMembersToFetch int) ([]*lib.PublicKey, error) {
+			return fes.fetchMaxMembersFromAccessGroup(groupOwnerPkBytes, groupKeyNameBytes,
+				startingMemberPkBytes, maxMembersToFetch, utxoView)
+		},
+		func(member *lib.PublicKey) error {
+			memberEntry, err := utxoView.GetAccessGroupMemberEntry(
+				member, lib.NewPublicKey(groupOwnerPkBytes), lib.NewGroupKeyName(groupKeyNameBytes))
+			if err != nil {
+				return errors.Wrapf(err, "Problem getting access group member entry: ")
+			}
+			if memberEntry != nil && !memberEntry.IsDeleted() {
+				memberEntries = append(memberEntries, memberEntry)
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+	return memberEntries, nil
+}
+
+// validateMessageToUpdate returns an error unless the thread fetchMessages reads from has a message with
+// timestampNanos that was sent by the owner of senderPkBytes.
+func validateMessageToUpdate(
+	senderPkBytes []byte,
+	timestampNanos uint64,
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+) error {
+	// fetchMessages returns messages strictly older than its start timestamp, so the message itself is the first one
+	// before timestampNanos+1.
+	if timestampNanos == math.MaxUint64 {
+		return errors.Errorf("No message with TimestampNanos %v in the thread", timestampNanos)
+	}
+	messages, err := fetchMessages(timestampNanos+1, 1)
+	if err != nil {
+		return errors.Wrapf(err, "Problem fetching the message to update: ")
+	}
+	if len(messages) == 0 || messages[0].TimestampNanos != timestampNanos {
+		return errors.Errorf("No message with TimestampNanos %v in the thread", timestampNanos)
+	}
+	if !bytes.Equal(messages[0].SenderAccessGroupOwnerPublicKey.ToBytes(), senderPkBytes) {
+		return errors.Errorf("Message with TimestampNanos %v wasn't sent by the sender", timestampNanos)
+	}
+	return nil
+}
+
+// validateRecipientAccessGroupExists returns an error if the recipient's access group, identified by its owner public
+// key and key name, doesn't exist. The base key name always exists since its access group public key is the owner's
+// own public key.
+func validateRecipientAccessGroupExists(
+	recipientGroupOwnerPkBytes []byte,
+	recipientGroupKeyNameBytes []byte,
+	getAccessGroupEntry func(*lib.PublicKey, *lib.GroupKeyName) (*lib.AccessGroupEntry, error),
+) error {
+	recipientGroupKeyName := lib.NewGroupKeyName(recipientGroupKeyNameBytes)
+	if lib.EqualGroupKeyName(recipientGroupKeyName, lib.BaseGroupKeyName()) {
+		return nil
+	}
+	accessGroupEntry, err := getAccessGroupEntry(lib.NewPublicKey(recipientGroupOwnerPkBytes), recipientGroupKeyName)
+	if err != nil {
+		return errors.Wrapf(err, "Problem getting recipient access group entry: ")
+	}
+	if accessGroupEntry == nil || accessGroupEntry.IsDeleted() {
+		return errors.Errorf("Access group %v doesn't exist. Set SkipRecipientAccessGroupCheck to send to it anyway",
+			string(lib.MessagingKeyNameDecode(recipientGroupKeyName)))
+	}
+	return nil
+}
+
+// getUnverifiableAccessGroupMembers returns the public keys of the members whose access group, identified by the
+// member's public key and member key name, doesn't exist or doesn't have a valid access group public key. Members
+// added with the base key name are always verifiable since their access group public key is their own public key.
+func getUnverifiableAccessGroupMembers(
+	memberEntries []*lib.AccessGroupMemberEntry,
+	getAccessGroupEntry func(*lib.PublicKey, *lib.GroupKeyName) (*lib.AccessGroupEntry, error),
+) ([]*lib.PublicKey, error) {
+	var unverifiableMembers []*lib.PublicKey
+	for _, memberEntry := range memberEntries {
+		if lib.EqualGroupKeyName(memberEntry.AccessGroupMemberKeyName, lib.BaseGroupKeyName()) {
+			if lib.IsByteArrayValidPublicKey(memberEntry.AccessGroupMemberPublicKey.ToBytes()) != nil {
+				unverifiableMembers = append(unverifiableMembers, memberEntry.AccessGroupMemberPublicKey)
+			}
+			continue
+		}
+
+		accessGroupEntry, err := getAccessGroupEntry(
+			memberEntry.AccessGroupMemberPublicKey, memberEntry.AccessGroupMemberKeyName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem getting access group entry for member: ")
+		}
+		if accessGroupEntry == nil || accessGroupEntry.IsDeleted() || accessGroupEntry.AccessGroupPublicKey == nil ||
+			lib.IsByteArrayValidPublicKey(accessGroupEntry.AccessGroupPublicKey.ToBytes()) != nil {
+			unverifiableMembers = append(unverifiableMembers, memberEntry.AccessGroupMemberPublicKey)
+		}
+	}
+	return unverifiableMembers, nil
+}
+
+// MessageContentTypeExtraDataKey is the ExtraData key reserved for the format of a message's payload.
+const MessageContentTypeExtraDataKey = "MessageContentType"
+
+// The message content types clients can tag a message with.
+const (
+	MessageContentTypePlainText = "text/plain"
+	MessageContentTypeMarkdown  = "text/markdown"
+	// A serialized rich message object, e.g. a message with attachments or embeds.
+	MessageContentTypeJSON = "application/json"
+)
+
+var allowedMessageContentTypes = map[string]bool{
+	MessageContentTypePlainText: true,
+	MessageContentTypeMarkdown:  true,
+	MessageContentTypeJSON:      true,
+}
+
+// ValidateMessageContentType returns an error if contentType isn't one of the known message content types.
+func ValidateMessageContentType(contentType string) error {
+	if !allowedMessageContentTypes[contentType] {
+		return errors.Errorf("Unknown ContentType %v. Options are {%v, %v, %v}", contentType,
+			MessageContentTypePlainText, MessageContentTypeMarkdown, MessageContentTypeJSON)
+	}
+	return nil
+}
+
+// setMessageContentType stores contentType in extraData under MessageContentTypeExtraDataKey. A content type set
+// directly in ExtraData is held to the same allowlist, and must agree with contentType if both are set.
+func setMessageContentType(extraData map[string][]byte, contentType string) (map[string][]byte, error) {
+	if existingContentType, exists := extraData[MessageContentTypeExtraDataKey]; exists {
+		if err := ValidateMessageContentType(string(existingContentType)); err != nil {
+			return nil, errors.Wrapf(err, "Invalid %v in ExtraData: ", MessageContentTypeExtraDataKey)
+		}
+		if contentType != "" && contentType != string(existingContentType) {
+			return nil, errors.Errorf("ContentType %v doesn't match %v %v in ExtraData", contentType,
+				MessageContentTypeExtraDataKey, string(existingContentType))
+		}
+	}
+	if contentType == "" {
+		return extraData, nil
+	}
+	if err := ValidateMessageContentType(contentType); err != nil {
+		return nil, err
+	}
+	if extraData == nil {
+		extraData = make(map[string][]byte)
+	}
+	extraData[MessageContentTypeExtraDataKey] = []byte(contentType)
+	return extraData, nil
+}
+
+// MessageExpiresAtNanosExtraDataKey is the ExtraData key reserved for the time after which clients should hide a
+// message, encoded as a uvarint.
+const MessageExpiresAtNanosExtraDataKey = "MessageExpiresAtNanos"
+
+// ValidateMessageExpiresAtNanos returns an error unless expiresAtNanos is after now.
+func ValidateMessageExpiresAtNanos(expiresAtNanos uint64, now time.Time) error {
+	if expiresAtNanos <= uint64(now.UnixNano()) {
+		return errors.Errorf("ExpiresAtNanos %v must be in the future", expiresAtNanos)
+	}
+	return nil
+}
+
+// setMessageExpiresAtNanos stores expiresAtNanos in extraData under MessageExpiresAtNanosExtraDataKey. Like
+// setMessageContentType, an expiry set directly in ExtraData must also be in the future and agree with expiresAtNanos
+// if both are set.
+func setMessageExpiresAtNanos(
+	extraData map[string][]byte,
+	expiresAtNanos uint64,
+	now time.Time,
+) (map[string][]byte, error) {
+	if _, exists := extraData[MessageExpiresAtNanosExtraDataKey]; exists {
+		existingExpiresAtNanos := getMessageExpiresAtNanos(extraData)
+		if err := ValidateMessageExpiresAtNanos(existingExpiresAtNanos, now); err != nil {
+			return nil, errors.Wrapf(err, "Invalid %v in ExtraData: ", MessageExpiresAtNanosExtraDataKey)
+		}
+		if expiresAtNanos != 0 && expiresAtNanos != existingExpiresAtNanos {
+			return nil, errors.Errorf("ExpiresAtNanos %v doesn't match %v %v in ExtraData", expiresAtNanos,
+				MessageExpiresAtNanosExtraDataKey, existingExpiresAtNanos)
+		}
+	}
+	if expiresAtNanos == 0 {
+		return extraData, nil
+	}
+	if err := ValidateMessageExpiresAtNanos(expiresAtNanos, now); err != nil {
+		return nil, err
+	}
+	if extraData == nil {
+		extraData = make(map[string][]byte)
+	}
+	extraData[MessageExpiresAtNanosExtraDataKey] = lib.UintToBuf(expiresAtNanos)
+	return extraData, nil
+}
+
+// getMessageExpiresAtNanos returns the expiry hint stored in a message's ExtraData, or 0 if it has none.
+func getMessageExpiresAtNanos(extraData map[string][]byte) uint64 {
+	expiresAtNanosBytes, exists := extraData[MessageExpiresAtNanosExtraDataKey]
+	if !exists {
+		return 0
+	}
+	expiresAtNanos, _ := lib.Uvarint(expiresAtNanosBytes)
+	return expiresAtNanos
+}
+
+// NewMessageSignersFromSeeds derives the keys the node may sign messages with on behalf of their owners, keyed by
+// base58 public key. Each seed is a BIP39 mnemonic, like the starter DESO seed.
+func NewMessageSignersFromSeeds(seeds []string, params *lib.DeSoParams) (map[string]*btcec.PrivateKey, error) {
+	messageSigners := make(map[string]*btcec.PrivateKey)
+	for ii, seed := range seeds {
+		seedBytes, err := bip39.NewSeedWithErrorChecking(seed, "")
+		if err != nil {
+			return nil, errors.Errorf("NewMessageSignersFromSeeds: Problem converting mnemonic #%d: %v", ii, err)
+		}
+		publicKey, privateKey, _, err := lib.ComputeKeysFromSeed(seedBytes, 0, params)
+		if err != nil {
+			return nil, errors.Errorf("NewMessageSignersFromSeeds: Problem computing keys from seed #%d: %v", ii, err)
+		}
+		messageSigners[lib.PkToString(publicKey.SerializeCompressed(), params)] = privateKey
+	}
+	return messageSigners, nil
+}
+
+// NewMessageDecryptionKeys derives the keys the node may decrypt messages with, keyed by base58 public key. Returns
+// nil unless decryption is enabled. Every seed's public key must also be in allowedPublicKeys, so that a key can't be
+// decrypted for by adding its seed alone.
+func NewMessageDecryptionKeys(
+	enabled bool,
+	seeds []string,
+	allowedPublicKeys []string,
+	params *lib.DeSoParams,
+) (map[string]*btcec.PrivateKey, error) {
+	if !enabled {
+		return nil, nil
+	}
+	keys, err := NewMessageSignersFromSeeds(seeds, params)
+	if err != nil {
+		return nil, errors.Wrapf(err, "NewMessageDecryptionKeys: ")
+	}
+	allowed := lib.NewSet(allowedPublicKeys)
+	for publicKeyBase58Check := range keys {
+		if !allowed.Includes(publicKeyBase58Check) {
+			return nil, errors.Errorf("NewMessageDecryptionKeys: Public key %v of a message decryption seed isn't "+
+				"in the allowed public keys", publicKeyBase58Check)
+		}
+	}
+	return keys, nil
+}
+
+// getMessageDecryptionKeysForUser returns the decryption key the node holds for userPublicKeyBase58Check, keyed by
+// its base58 public key, but only if jwtToken is a valid JWT for that key. Holding a user's key doesn't entitle anyone
+// else to read their messages, so callers have to prove they are the recipient. Returns nil otherwise.
+func (fes *APIServer) getMessageDecryptionKeysForUser(
+	userPublicKeyBase58Check string,
+	jwtToken string,
+) map[string]*btcec.PrivateKey {
+	if len(fes.messageDecryptionKeys) == 0 || jwtToken == "" {
+		return nil
+	}
+	userPkBytes, _, err := lib.Base58CheckDecode(userPublicKeyBase58Check)
+	if err != nil {
+		return nil
+	}
+	userPkString := lib.PkToString(userPkBytes, fes.Params)
+	privateKey, exists := fes.messageDecryptionKeys[userPkString]
+	if !exists {
+		return nil
+	}
+	if isValid, _ := fes.ValidateJWT(userPublicKeyBase58Check, jwtToken); !isValid {
+		return nil
+	}
+	return map[string]*btcec.PrivateKey{userPkString: privateKey}
+}
+
+// decryptMessageText decrypts a message if it was sent to an access group whose public key is in
+// messageDecryptionKeys. Messages are encrypted to the recipient access group's public key, so the base access group,
+// whose public key is its owner's, can be decrypted with the owner's key. Returns "" if there's no key for the
+// recipient access group or the message can't be decrypted with it.
+func decryptMessageText(
+	messageDecryptionKeys map[string]*btcec.PrivateKey,
+	message *NewMessageEntryResponse,
+) string {
+	privateKey, exists := messageDecryptionKeys[message.RecipientInfo.AccessGroupPublicKeyBase58Check]
+	if !exists {
+		return ""
+	}
+	encryptedText, err := hex.DecodeString(message.MessageInfo.EncryptedText)
+	if err != nil {
+		return ""
+	}
+	decryptedText, err := lib.DecryptBytesWithPrivateKey(encryptedText, privateKey.ToECDSA())
+	if err != nil {
+		return ""
+	}
+	return string(decryptedText)
+}
+
+// decryptMessages fills in DecryptedText for each message that can be decrypted with messageDecryptionKeys.
+func decryptMessages(messageDecryptionKeys map[string]*btcec.PrivateKey, messages []NewMessageEntryResponse) {
+	if len(messageDecryptionKeys) == 0 {
+		return
+	}
+	for ii := range messages {
+		messages[ii].MessageInfo.DecryptedText = decryptMessageText(messageDecryptionKeys, &messages[ii])
+	}
+}
+
+// NewMessagingDenylist parses a list of PublicKey or PublicKey=Reason entries into a map from base58 public key to
+// reason. Returns nil if there are no entries.
+func NewMessagingDenylist(entries []string, params *lib.DeSoParams) (map[string]string, error) {
+	var messagingDenylist map[string]string
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		publicKeyBase58Check, reason, _ := strings.Cut(entry, "=")
+		publicKeyBytes, _, err := lib.Base58CheckDecode(strings.TrimSpace(publicKeyBase58Check))
+		if err != nil || len(publicKeyBytes) != btcec.PubKeyBytesLenCompressed {
+			return nil, errors.Errorf("NewMessagingDenylist: Invalid public key %v", publicKeyBase58Check)
+		}
+		if messagingDenylist == nil {
+			messagingDenylist = make(map[string]string)
+		}
+		messagingDenylist[lib.PkToString(publicKeyBytes, params)] = strings.TrimSpace(reason)
+	}
+	return messagingDenylist, nil
+}
+
+type GetDenylistStatusRequest struct {
+	PublicKeyBase58Check string `safeForLogging:"true"`
+}
+
+type GetDenylistStatusResponse struct {
+	// False if the node operator hasn't configured a messaging denylist, in which case IsDenied is always false.
+	DenylistConfigured bool
+	// Whether the node refuses to build message transactions sent by this public key.
+	IsDenied bool
+	// The operator's reason for denying the key, if they gave one.
+	Reason string `json:",omitempty"`
+}
+
+// GetDenylistStatus tells a user whether they're on the node's messaging denylist, and why. It only reports on the
+// queried public key; the full list isn't exposed.
+func (fes *APIServer) GetDenylistStatus(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDenylistStatusRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDenylistStatus: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("PublicKeyBase58Check", requestData.PublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDenylistStatus: %v", err))
+		return
+	}
+	publicKeyBytes, _, err := lib.Base58CheckDecode(requestData.PublicKeyBase58Check)
+	if err != nil || len(publicKeyBytes) != btcec.PubKeyBytesLenCompressed {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDenylistStatus: Problem decoding public key %v: %v",
+			requestData.PublicKeyBase58Check, err))
+		return
+	}
+
+	res := getDenylistStatus(fes.messagingDenylist, lib.PkToString(publicKeyBytes, fes.Params))
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDenylistStatus: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+func getDenylistStatus(messagingDenylist map[string]string, publicKeyBase58Check string) *GetDenylistStatusResponse {
+	reason, isDenied := messagingDenylist[publicKeyBase58Check]
+	return &GetDenylistStatusResponse{
+		DenylistConfigured: messagingDenylist != nil,
+		IsDenied:           isDenied,
+		Reason:             reason,
+	}
+}
+
+type ChatType string
+
+const (
+	ChatTypeDM        = "DM"
+	ChatTypeGroupChat = "GroupChat"
+)
+
+type NewMessageEntryResponse struct {
+	ChatType      ChatType
+	SenderInfo    AccessGroupInfo
+	RecipientInfo AccessGroupInfo
+	MessageInfo   MessageInfo
+
+	// Only set for group chat threads when IncludeMemberCounts is set. The number of members of the group, which
+	// doesn't include the owner unless they added themselves as a member.
+	MemberCount int `json:",omitempty"`
+}
+
+// Types to store the chat messages.
+type AccessGroupInfo struct {
+	OwnerPublicKeyBase58Check       string `safeForLogging:"true"`
+	AccessGroupPublicKeyBase58Check string `safeForLogging:"true"`
+	AccessGroupKeyName              string `safeForLogging:"true"`
+	// Only set by the thread list endpoints when IncludeProfiles is set. Nil if the owner has no profile.
+	ProfileEntryResponse *ProfileEntryResponse `json:",omitempty"`
+}
+type MessageInfo struct {
+	// Hex-encoded. Omitted by the thread list endpoints when IncludeFullEncryptedText is false.
+	EncryptedText string `json:",omitempty"`
+	// Only set when the node has message decryption enabled, holds the key of the recipient access group, and the
+	// request carries a valid JWT for the recipient. Omitted along with EncryptedText when IncludeFullEncryptedText is
+	// false.
+	DecryptedText        string `json:",omitempty"`
+	TimestampNanos       uint64
+	TimestampNanosString string
+	// TimestampNanos formatted as an RFC3339 string with nanosecond precision, for clients that can't represent
+	// 64-bit integers losslessly.
+	TimestampRFC3339 string
+	// The format of the message payload, if the sender tagged it. See the MessageContentType constants.
+	ContentType string
+	// The time after which the sender asked clients to hide the message, if they set one. The node still serves
+	// expired messages; hiding them is up to clients.
+	ExpiresAtNanos uint64 `json:",omitempty"`
+	// The message's position in its thread, counting from 0 for the oldest message. Only set by the paginated
+	// message endpoints when IncludeSequenceInThread is requested. Sync clients can use it to detect gaps without
+	// relying on timestamps. It's computed by counting the thread's messages, so it shifts if an older message is
+	// added later, and threads with more than MaxMessagesCountedForSequenceInThread messages before the fetched
+	// window are rejected.
+	SequenceInThread *uint64 `json:",omitempty"`
+	ExtraData        map[string]string
+}
+
+// FormatTimestampNanosAsRFC3339 formats a unix timestamp in nanoseconds as a UTC RFC3339 string with nanosecond
+// precision.
+func FormatTimestampNanosAsRFC3339(timestampNanos uint64) string {
+	return time.Unix(0, int64(timestampNanos)).UTC().Format(time.RFC3339Nano)
+}
+
+func (fes *APIServer) NewMessageEntryToResponse(newMessageEntry *lib.NewMessageEntry, chatType ChatType, utxoView *lib.UtxoView) NewMessageEntryResponse {
+	return NewMessageEntryResponse{
+		ChatType:      chatType,
+		SenderInfo:    fes.buildAccessGroupInfoFromMessageEntrySender(newMessageEntry),
+		RecipientInfo: fes.buildAccessGroupInfoFromMessageEntryRecipient(newMessageEntry),
+		MessageInfo: MessageInfo{
+			EncryptedText:        hex.EncodeToString(newMessageEntry.EncryptedText),
+			TimestampNanos:       newMessageEntry.TimestampNanos,
+			TimestampNanosString: strconv.FormatUint(newMessageEntry.TimestampNanos, 10),
+			TimestampRFC3339:     FormatTimestampNanosAsRFC3339(newMessageEntry.TimestampNanos),
+			ContentType:          string(newMessageEntry.ExtraData[MessageContentTypeExtraDataKey]),
+			ExpiresAtNanos:       getMessageExpiresAtNanos(newMessageEntry.ExtraData),
+			ExtraData:            DecodeExtraDataMap(fes.Params, utxoView, newMessageEntry.ExtraData),
+		},
+	}
+}
+
+// This endpoint should returns all dm threads for a user.
+// Calls the GetAllUserDmThreads function from the core library.
+// Should return the direct message threads of the user along with the latest message sent for each of them.
+// This API just doesn't write any data, hence it doesn't create a new transaction.
+// It's a public API, hence anyone with a valid public key can query the system to fetch their Direct message threads.
+func (fes *APIServer) GetUserDmThreadsOrderedByTimestamp(ww http.ResponseWriter, req *http.Request) {
+	if err := fes.getUserMessageThreadsHandler(ww, req, false, true); err != nil {
+		_AddHandlerError(ww, fmt.Sprintf("GetUserDmThreadsOrderedByTimestamp: %v", err), err)
+		return
+	}
+}
+
+// type to deserialize the http request to fetch message from a specific direct message thread (dmThread).
+type GetPaginatedMessagesForDmThreadRequest struct {
+	//  A Direct message thread is a conversation between two parties.
+	// The first party is represented by the prefix "User".
+	UserGroupOwnerPublicKeyBase58Check string
+	UserGroupKeyName                   string
+	// The second party is represented by prefix "party"
+	PartyGroupOwnerPublicKeyBase58Check string
+	PartyGroupKeyName                   string
+	// Filter to fetch direct messages who time stamp is less than StartTimestamp.
+	// So you need to set this to current time and MaxMessagesToFetch to 10, to fetch
+	//  the latest 10 messages. We support passing start timestamp as string and uint64.
+	// uint64 can lose precision when being JSON decoded, so we prefer StartTimestampString.
+	StartTimestamp       uint64
+	StartTimestampString string
+	// If omitted, the node's DefaultMaxMessagesToFetch is used.
+	MaxMessagesToFetch int
+	// If set, each message's SequenceInThread is populated. See MessageInfo.SequenceInThread.
+	IncludeSequenceInThread bool
+	// One of the MessageDirection values, from the point of view of UserGroupOwnerPublicKeyBase58Check. Defaults to
+	// MessageDirectionAll. Can't be combined with IncludeSequenceInThread.
+	Direction MessageDirection
+	// Optional. The NextCursor of the previous page, which takes precedence over StartTimestamp and
+	// StartTimestampString. Unlike a timestamp, it doesn't skip messages that share a timestamp across a page boundary.
+	// Can't be combined with Direction.
+	Cursor string
+	// Optional. A JWT for UserGroupOwnerPublicKeyBase58Check. Only needed to have messages sent to the user decrypted
+	// by a node with message decryption enabled; without it, messages are returned encrypted.
+	JWT string
+}
+
+// DefaultMaxMessagesToFetch is used when a paginated messages request omits MaxMessagesToFetch and the node doesn't
+// configure its own default.
+const DefaultMaxMessagesToFetch = 25
+
+// getMaxMessagesToFetch returns the number of messages a paginated messages request should fetch. A zero
+// maxMessagesToFetch means the client omitted it, so the node's default is used instead.
+func (fes *APIServer) getMaxMessagesToFetch(maxMessagesToFetch int) (int, error) {
+	if maxMessagesToFetch < 0 {
+		return 0, fmt.Errorf("MaxMessagesToFetch cannot be less than 1: %v", maxMessagesToFetch)
+	}
+	if maxMessagesToFetch > 0 {
+		return maxMessagesToFetch, nil
+	}
+	if fes.Config != nil && fes.Config.DefaultMaxMessagesToFetch > 0 {
+		return fes.Config.DefaultMaxMessagesToFetch, nil
+	}
+	return DefaultMaxMessagesToFetch, nil
+}
+
+// type to serialize the response containing the direct messages between two parties.
+type GetPaginatedMessagesForDmResponse struct {
+	ThreadMessages                  []NewMessageEntryResponse
+	PublicKeyToProfileEntryResponse map[string]*ProfileEntryResponse
+
+	// Only set when a Direction filter stopped scanning after MaxMessagesScannedForMessageDirection messages without
+	// filling the page. Pass it as StartTimestampString to keep scanning.
+	ScanResumeTimestampString string `json:",omitempty"`
+
+	// Pass as Cursor to fetch the next page. Empty if there are no more messages, or if a Direction filter was used.
+	NextCursor string `json:",omitempty"`
+}
+
+// API is used to fetch the direct messages between two parties in a paginated way.
+// This is useful for applications to fetch only N number of direct messages between two parties at once.
+// Timestamp in the request data can be altered to fetch subsequent N messages in each call to fetch the direct messages.
+
+// This API just doesn't write any data, hence it doesn't create a new transaction.
+// It's a public API, hence anyone with a valid input data can query the system to fetch their Direct messages.
+func (fes *APIServer) GetPaginatedMessagesForDmThread(ww http.ResponseWriter, req *http.Request) {
+	// Deserialize the request data.
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetPaginatedMessagesForDmThreadRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem parsing request body: %v", err))
+		return
+	}
+
+	maxMessagesToFetch, err := fes.getMaxMessagesToFetch(requestData.MaxMessagesToFetch)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: %v", err))
+		return
+	}
+
+	direction, err := validateMessageDirection(requestData.Direction, requestData.IncludeSequenceInThread)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: %v", err))
+		return
+	}
+
+	// Basic validation of the sender public key and access group name.
+	senderGroupOwnerPkBytes, senderGroupKeyNameBytes, err :=
+		ValidateAccessGroupPublicKeyAndName(requestData.UserGroupOwnerPublicKeyBase58Check, requestData.UserGroupKeyName)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem validating "+
+			"user group owner public key and access group name %s: %s %v",
+			requestData.UserGroupOwnerPublicKeyBase58Check, requestData.PartyGroupOwnerPublicKeyBase58Check, err))
+		return
+	}
+
+	// Basic validation of the public key and access group name of the other party in the dm.
+	recipientGroupOwnerPkBytes, recipientGroupKeyNameBytes, err :=
+		ValidateAccessGroupPublicKeyAndName(requestData.PartyGroupOwnerPublicKeyBase58Check, requestData.PartyGroupKeyName)
+	// Decode the access group owner public key.
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem validating "+
+			"party group owner public key and access group name %s: %s %v",
+			requestData.PartyGroupOwnerPublicKeyBase58Check, requestData.PartyGroupKeyName, err))
+		return
+	}
+
+	// sender and the recipient public keys cannot be the same.
+	if bytes.Equal(senderGroupOwnerPkBytes, recipientGroupOwnerPkBytes) {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Dm sender and recipient "+
+			"cannot be the same %s: %s",
+			requestData.UserGroupOwnerPublicKeyBase58Check, requestData.PartyGroupOwnerPublicKeyBase58Check))
+		return
+	}
+
+	startTimestamp := requestData.StartTimestamp
+	if requestData.StartTimestampString != "" {
+		startTimestamp, err = strconv.ParseUint(requestData.StartTimestampString, 10, 64)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Error parsing "+
+				"StartTimestampString: %v", err))
+			return
+		}
+	}
+
+	var cursor *DmThreadMessagesCursor
+	if requestData.Cursor != "" {
+		if direction != MessageDirectionAll {
+			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Cursor can't be combined with "+
+				"Direction %v", direction))
+			return
+		}
+		cursor, err = DecodeDmThreadMessagesCursor(requestData.Cursor)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Invalid Cursor: %v", err))
+			return
+		}
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetPaginatedMessagesForDmThread")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Error generating "+
+			"utxo view: %v", err))
+		return
+	}
+
+	senderPublicKey := *lib.NewPublicKey(senderGroupOwnerPkBytes)
+	senderGroupKeyName := *lib.NewGroupKeyName(senderGroupKeyNameBytes)
+	recipientPublicKey := *lib.NewPublicKey(recipientGroupOwnerPkBytes)
+	recipientGroupKeyName := *lib.NewGroupKeyName(recipientGroupKeyNameBytes)
+
+	fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		return fes.fetchMaxMessagesFromDmThreadIncludingBaseKeys(
+			senderPublicKey, senderGroupKeyName, recipientPublicKey, recipientGroupKeyName,
+			startTimestamp, maxMessagesToFetch, utxoView)
+	}
+
+	// Fetch the max messages between the sender and the party.
+	var latestMessages []*lib.NewMessageEntry
+	var scanResumeTimestamp *uint64
+	var nextCursor *DmThreadMessagesCursor
+	if direction == MessageDirectionAll {
+		latestMessages, nextCursor, err = fetchDmThreadMessagesPage(
+			fetchMessages, startTimestamp, cursor, maxMessagesToFetch)
+	} else {
+		latestMessages, scanResumeTimestamp, err = fetchMessagesInDirection(
+			senderGroupOwnerPkBytes, direction, fetchMessages, startTimestamp, maxMessagesToFetch)
+	}
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem getting paginated messages for "+
+			"Request Data: %v: %v", requestData, err))
+		return
+	}
+
+	// Since the two parties in the conversation in same in all the message if added this info upfront.
+	res := GetPaginatedMessagesForDmResponse{
+		ThreadMessages:                  []NewMessageEntryResponse{},
+		PublicKeyToProfileEntryResponse: make(map[string]*ProfileEntryResponse),
+	}
+	if scanResumeTimestamp != nil {
+		res.ScanResumeTimestampString = strconv.FormatUint(*scanResumeTimestamp, 10)
+	}
+	if nextCursor != nil {
+		if res.NextCursor, err = EncodeDmThreadMessagesCursor(nextCursor); err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem encoding cursor: %v", err))
+			return
+		}
 	}
 
 	// Now append each of their Direct message (Dm) conversations.
@@ -630,268 +1557,2674 @@ func (fes *APIServer) GetPaginatedMessagesForDmThread(ww http.ResponseWriter, re
 			fes.NewMessageEntryToResponse(threadMsg, ChatTypeDM, utxoView),
 		)
 	}
+	decryptMessages(fes.getMessageDecryptionKeysForUser(
+		requestData.UserGroupOwnerPublicKeyBase58Check, requestData.JWT), res.ThreadMessages)
+
+	if requestData.IncludeSequenceInThread {
+		if err = setSequenceInThread(res.ThreadMessages, fetchMessages); err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: %v", err))
+			return
+		}
+	}
+
+	// Add the sender's profile to the response.
+	res.PublicKeyToProfileEntryResponse[requestData.UserGroupOwnerPublicKeyBase58Check] = fes.GetProfileEntryResponseForPublicKeyBytes(
+		senderGroupOwnerPkBytes, utxoView)
+
+	// Add the recipient's profile to the response.
+	res.PublicKeyToProfileEntryResponse[requestData.PartyGroupOwnerPublicKeyBase58Check] = fes.GetProfileEntryResponseForPublicKeyBytes(
+		recipientGroupOwnerPkBytes, utxoView)
+
+	pendingMessageKeys, err := fes.getPendingMessageKeysForPage("GetPaginatedMessagesForDmThread", latestMessages,
+		func(committedUtxoView *lib.UtxoView, startTimestamp uint64, maxMessagesToFetch int) (
+			[]*lib.NewMessageEntry, error) {
+			return fes.fetchMaxMessagesFromDmThreadIncludingBaseKeys(
+				senderPublicKey, senderGroupKeyName, recipientPublicKey, recipientGroupKeyName,
+				startTimestamp, maxMessagesToFetch, committedUtxoView)
+		})
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: %v", err))
+		return
+	}
+	setMessagesCacheControlHeader(ww, res.ThreadMessages, pendingMessageKeys)
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem encoding response as JSON: %v", err))
+		return
+	}
+
+}
+
+const dmThreadMessagesCursorVersion = 1
+
+// DmThreadMessagesCursor is the position of the last message returned on a page of a DM thread. Clients treat the
+// encoded cursor as opaque.
+type DmThreadMessagesCursor struct {
+	Version int
+	// The timestamp of the last message, and its getDmMessageTiebreaker to order it among messages with the same
+	// timestamp.
+	TimestampNanos uint64
+	Tiebreaker     string
+}
+
+func EncodeDmThreadMessagesCursor(cursor *DmThreadMessagesCursor) (string, error) {
+	cursorBytes, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(cursorBytes), nil
+}
+
+func DecodeDmThreadMessagesCursor(encodedCursor string) (*DmThreadMessagesCursor, error) {
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(encodedCursor)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Problem decoding cursor")
+	}
+	cursor := &DmThreadMessagesCursor{}
+	if err = json.Unmarshal(cursorBytes, cursor); err != nil {
+		return nil, errors.Wrapf(err, "Problem decoding cursor")
+	}
+	if cursor.Version != dmThreadMessagesCursorVersion {
+		return nil, errors.Errorf("Unsupported cursor version %v", cursor.Version)
+	}
+	return cursor, nil
+}
+
+// getDmMessageTiebreaker orders DM messages that share a timestamp. A DM thread is stored under each pair of access
+// groups its messages were sent between, and a pair holds at most one message per timestamp, so the sender's and
+// recipient's access groups identify a message among those with its timestamp.
+func getDmMessageTiebreaker(message *lib.NewMessageEntry) string {
+	senderAccessGroupId := &lib.AccessGroupId{
+		AccessGroupOwnerPublicKey: *message.SenderAccessGroupOwnerPublicKey,
+		AccessGroupKeyName:        *message.SenderAccessGroupKeyName,
+	}
+	recipientAccessGroupId := &lib.AccessGroupId{
+		AccessGroupOwnerPublicKey: *message.RecipientAccessGroupOwnerPublicKey,
+		AccessGroupKeyName:        *message.RecipientAccessGroupKeyName,
+	}
+	return EncodeAccessGroupIdToHex(senderAccessGroupId) + EncodeAccessGroupIdToHex(recipientAccessGroupId)
+}
+
+// fetchDmThreadMessagesPage returns up to maxMessagesToFetch messages of a DM thread, sorted newest first and then by
+// getDmMessageTiebreaker, that come after cursor or, if it's nil, are older than startTimestamp. The cursor for the
+// next page is nil if there are no more messages.
+//
+// Messages with the same timestamp can only be put in order once all of them have been fetched, so if the page would
+// end partway through the oldest timestamp fetched, more messages are fetched until it doesn't.
+func fetchDmThreadMessagesPage(
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+	startTimestamp uint64,
+	cursor *DmThreadMessagesCursor,
+	maxMessagesToFetch int,
+) (_messages []*lib.NewMessageEntry, _nextCursor *DmThreadMessagesCursor, _err error) {
+	if cursor != nil {
+		// fetchMessages only returns messages strictly older than its startTimestamp, and the rest of the cursor's
+		// timestamp may not have been returned yet.
+		startTimestamp = cursor.TimestampNanos
+		if startTimestamp < math.MaxUint64 {
+			startTimestamp++
+		}
+	}
+	isAfterCursor := func(message *lib.NewMessageEntry) bool {
+		if cursor == nil || message.TimestampNanos < cursor.TimestampNanos {
+			return true
+		}
+		return message.TimestampNanos == cursor.TimestampNanos && getDmMessageTiebreaker(message) > cursor.Tiebreaker
+	}
+
+	numMessagesToFetch := maxMessagesToFetch + 1
+	for {
+		messages, err := fetchMessages(startTimestamp, numMessagesToFetch)
+		if err != nil {
+			return nil, nil, err
+		}
+		threadExhausted := len(messages) < numMessagesToFetch
+
+		candidates := []*lib.NewMessageEntry{}
+		oldestTimestampNanos := uint64(math.MaxUint64)
+		for _, message := range messages {
+			if message.TimestampNanos < oldestTimestampNanos {
+				oldestTimestampNanos = message.TimestampNanos
+			}
+			if isAfterCursor(message) {
+				candidates = append(candidates, message)
+			}
+		}
+		sort.Slice(candidates, func(ii, jj int) bool {
+			if candidates[ii].TimestampNanos != candidates[jj].TimestampNanos {
+				return candidates[ii].TimestampNanos > candidates[jj].TimestampNanos
+			}
+			return getDmMessageTiebreaker(candidates[ii]) < getDmMessageTiebreaker(candidates[jj])
+		})
+
+		hasMore := len(candidates) > maxMessagesToFetch
+		if !threadExhausted {
+			// Only the messages newer than the oldest timestamp fetched are known to be in their final order.
+			numOrdered := 0
+			for numOrdered < len(candidates) && candidates[numOrdered].TimestampNanos > oldestTimestampNanos {
+				numOrdered++
+			}
+			if numOrdered < maxMessagesToFetch {
+				numMessagesToFetch *= 2
+				continue
+			}
+			hasMore = true
+		}
+
+		page := candidates
+		if len(page) > maxMessagesToFetch {
+			page = page[:maxMessagesToFetch]
+		}
+		if !hasMore || len(page) == 0 {
+			return page, nil, nil
+		}
+		lastMessage := page[len(page)-1]
+		return page, &DmThreadMessagesCursor{
+			Version:        dmThreadMessagesCursorVersion,
+			TimestampNanos: lastMessage.TimestampNanos,
+			Tiebreaker:     getDmMessageTiebreaker(lastMessage),
+		}, nil
+	}
+}
+
+// Similar to GetUserDmThreadsOrderedByTimestamp, expect that it fetches the group chat threads instead of direct messages.
+// Need to call lib.GetAllUserGroupChatThreads from the core library.
+// Just need the public key of the user in the request data.
+// Returns the group chat threads along with their latest messages.
+// The group chats are sorted/ordered by the time stamp of their latest message.
+
+// This API just doesn't write any data, hence it doesn't create a new transaction.
+// It's a public API, hence anyone with a valid public key can query the system to fetch their Direct message threads.
+func (fes *APIServer) GetUserGroupChatThreadsOrderedByTimestamp(ww http.ResponseWriter, req *http.Request) {
+	if err := fes.getUserMessageThreadsHandler(ww, req, true, false); err != nil {
+		_AddHandlerError(ww, fmt.Sprintf("GetUserGroupChatThreadsOrderedByTimestamp: %v", err), err)
+		return
+	}
+}
+
+// types and functions to Fetch messages from the group chat thread of a user
+type GetPaginatedMessagesForGroupChatThreadRequest struct {
+	// Need the member/owner public key and the access group key name of the group they belong
+	// to fetch the group chat messages.
+	UserPublicKeyBase58Check string
+	AccessGroupKeyName       string
+	// Optional. A hex-encoded AccessGroupId (the owner public key followed by the padded access group key name).
+	// When set, it is used in place of UserPublicKeyBase58Check and AccessGroupKeyName.
+	AccessGroupIdHex string
+
+	// We support passing start timestamp as string and uint64.
+	// uint64 can lose precision when being JSON decoded, so we prefer StartTimestampString.
+	StartTimestamp       uint64
+	StartTimestampString string
+	// If omitted, the node's DefaultMaxMessagesToFetch is used.
+	MaxMessagesToFetch int
+	// If set, each message's SequenceInThread is populated. See MessageInfo.SequenceInThread.
+	IncludeSequenceInThread bool
+	// One of the MessageDirection values, from the point of view of ViewerPublicKeyBase58Check. Defaults to
+	// MessageDirectionAll. Can't be combined with IncludeSequenceInThread.
+	Direction MessageDirection
+	// The member whose messages count as outbound. Defaults to UserPublicKeyBase58Check, and must be set to filter by
+	// Direction when the group is identified by AccessGroupIdHex.
+	ViewerPublicKeyBase58Check string
+}
+
+type GetPaginatedMessagesForGroupChatThreadResponse struct {
+	GroupChatMessages               []NewMessageEntryResponse
+	PublicKeyToProfileEntryResponse map[string]*ProfileEntryResponse
+
+	// Only set when a Direction filter stopped scanning after MaxMessagesScannedForMessageDirection messages without
+	// filling the page. Pass it as StartTimestampString to keep scanning.
+	ScanResumeTimestampString string `json:",omitempty"`
+}
+
+// Similar to GetPaginatedMessagesForDmThread API, but fetches messages from a group chat instead.
+
+// This API just doesn't write any data, hence it doesn't create a new transaction.
+// It's a public API, hence anyone with a valid public key can query the system to fetch their Direct message threads.
+func (fes *APIServer) GetPaginatedMessagesForGroupChatThread(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetPaginatedMessagesForGroupChatThreadRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Problem parsing request body: %v", err))
+		return
+	}
+
+	maxMessagesToFetch, err := fes.getMaxMessagesToFetch(requestData.MaxMessagesToFetch)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: %v", err))
+		return
+	}
+
+	direction, err := validateMessageDirection(requestData.Direction, requestData.IncludeSequenceInThread)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: %v", err))
+		return
+	}
+	var viewerPkBytes []byte
+	if direction != MessageDirectionAll {
+		viewerPublicKeyBase58Check := requestData.ViewerPublicKeyBase58Check
+		if viewerPublicKeyBase58Check == "" && requestData.AccessGroupIdHex == "" {
+			viewerPublicKeyBase58Check = requestData.UserPublicKeyBase58Check
+		}
+		if viewerPublicKeyBase58Check == "" {
+			_AddBadRequestError(ww, "GetPaginatedMessagesForGroupChatThread: ViewerPublicKeyBase58Check is required "+
+				"to filter by Direction when AccessGroupIdHex is set")
+			return
+		}
+		if err = ValidateNotDESOIdentifier("ViewerPublicKeyBase58Check", viewerPublicKeyBase58Check); err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: %v", err))
+			return
+		}
+		viewerPkBytes, _, err = lib.Base58CheckDecode(viewerPublicKeyBase58Check)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Problem decoding viewer "+
+				"public key %s: %v", viewerPublicKeyBase58Check, err))
+			return
+		}
+	}
+
+	// The public of the member of the group and their access key
+	// have to represented using the lib.AccessGroupId type.
+	accessGroupId, err := getAccessGroupIdForGroupChatThreadRequest(&requestData)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: %v", err))
+		return
+	}
+
+	startTimestamp := requestData.StartTimestamp
+	if requestData.StartTimestampString != "" {
+		startTimestamp, err = strconv.ParseUint(requestData.StartTimestampString, 10, 64)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Error parsing "+
+				"StartTimestampString: %v", err))
+			return
+		}
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetPaginatedMessagesForGroupChatThread")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Error generating "+
+			"utxo view: %v", err))
+		return
+	}
+
+	// Fetch the max group chat messages from the access group.
+	groupChatMessages, scanResumeTimestamp, err := fetchMessagesInDirection(
+		viewerPkBytes, direction,
+		func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			return fes.fetchMaxMessagesFromGroupChatThread(accessGroupId, startTimestamp, maxMessagesToFetch, utxoView)
+		},
+		startTimestamp, maxMessagesToFetch)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Problem getting paginated messages for "+
+			"Request Data: %v: %v", requestData, err))
+		return
+	}
+
+	// group chat threads with each group chat represented by GroupChatThread.
+	// Each entry consists of the sender account, recipient account info and the latest message.
+	messages := []NewMessageEntryResponse{}
+	publicKeyToProfileEntryResponseMap := make(map[string]*ProfileEntryResponse)
+
+	for _, threadMsg := range groupChatMessages {
+		message := fes.NewMessageEntryToResponse(threadMsg, ChatTypeGroupChat, utxoView)
+		messages = append(messages, message)
+		// Add the sender's profile to the response.
+		senderPublicKeyBase58Check := message.SenderInfo.OwnerPublicKeyBase58Check
+		if _, ok := publicKeyToProfileEntryResponseMap[senderPublicKeyBase58Check]; !ok {
+			publicKeyToProfileEntryResponseMap[senderPublicKeyBase58Check] = fes.GetProfileEntryResponseForPublicKeyBytes(
+				threadMsg.SenderAccessGroupOwnerPublicKey.ToBytes(), utxoView)
+		}
+
+		// Add the recipient's profile to the response.
+		if _, ok := publicKeyToProfileEntryResponseMap[message.RecipientInfo.OwnerPublicKeyBase58Check]; !ok {
+			publicKeyToProfileEntryResponseMap[message.RecipientInfo.OwnerPublicKeyBase58Check] = fes.GetProfileEntryResponseForPublicKeyBytes(
+				threadMsg.RecipientAccessGroupOwnerPublicKey.ToBytes(), utxoView)
+		}
+	}
+
+	if requestData.IncludeSequenceInThread {
+		fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			return fes.fetchMaxMessagesFromGroupChatThread(accessGroupId, startTimestamp, maxMessagesToFetch, utxoView)
+		}
+		if err = setSequenceInThread(messages, fetchMessages); err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: %v", err))
+			return
+		}
+	}
+
+	// response containing group chat messages from the given access group ID of a public key.
+	res := GetPaginatedMessagesForGroupChatThreadResponse{
+		GroupChatMessages:               messages,
+		PublicKeyToProfileEntryResponse: publicKeyToProfileEntryResponseMap,
+	}
+	if scanResumeTimestamp != nil {
+		res.ScanResumeTimestampString = strconv.FormatUint(*scanResumeTimestamp, 10)
+	}
+
+	pendingMessageKeys, err := fes.getPendingMessageKeysForPage("GetPaginatedMessagesForGroupChatThread",
+		groupChatMessages,
+		func(committedUtxoView *lib.UtxoView, startTimestamp uint64, maxMessagesToFetch int) (
+			[]*lib.NewMessageEntry, error) {
+			return fes.fetchMaxMessagesFromGroupChatThread(
+				accessGroupId, startTimestamp, maxMessagesToFetch, committedUtxoView)
+		})
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: %v", err))
+		return
+	}
+	setMessagesCacheControlHeader(ww, messages, pendingMessageKeys)
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// MessageDirection filters the paginated message endpoints to the messages a viewer sent or received.
+type MessageDirection string
+
+const (
+	MessageDirectionAll MessageDirection = "ALL"
+	// Messages sent by anyone other than the viewer.
+	MessageDirectionInbound MessageDirection = "INBOUND"
+	// Messages sent by the viewer.
+	MessageDirectionOutbound MessageDirection = "OUTBOUND"
+)
+
+// MaxMessagesScannedForMessageDirection caps how many of a thread's messages a Direction-filtered page scans. A
+// thread where one side rarely posts may return a short page along with a timestamp to resume the scan from.
+const MaxMessagesScannedForMessageDirection = 10000
+
+// validateMessageDirection defaults an empty direction to MessageDirectionAll. SequenceInThread counts every message
+// in the thread, so it can't be computed for a filtered page.
+func validateMessageDirection(direction MessageDirection, includeSequenceInThread bool) (MessageDirection, error) {
+	switch direction {
+	case "", MessageDirectionAll:
+		return MessageDirectionAll, nil
+	case MessageDirectionInbound, MessageDirectionOutbound:
+		if includeSequenceInThread {
+			return "", errors.Errorf("IncludeSequenceInThread can't be combined with Direction %v", direction)
+		}
+		return direction, nil
+	}
+	return "", errors.Errorf("Invalid Direction %v. Options are {%v, %v, %v}", direction,
+		MessageDirectionAll, MessageDirectionInbound, MessageDirectionOutbound)
+}
+
+// fetchMessagesInDirection returns up to maxMessagesToFetch of a thread's messages older than startTimestamp, newest
+// first, that the viewer sent (MessageDirectionOutbound) or didn't send (MessageDirectionInbound). Since filtering
+// can underfill a page, it keeps paging back through the thread with fetchMessages, scanning at most
+// MaxMessagesScannedForMessageDirection messages. If it stops at the cap before filling the page, it also returns
+// the timestamp of the last message scanned so the client can resume from there. MessageDirectionAll is a single
+// fetch.
+func fetchMessagesInDirection(
+	viewerPkBytes []byte,
+	direction MessageDirection,
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+	startTimestamp uint64,
+	maxMessagesToFetch int,
+) (_messages []*lib.NewMessageEntry, _scanResumeTimestamp *uint64, _err error) {
+	if direction == MessageDirectionAll {
+		messages, err := fetchMessages(startTimestamp, maxMessagesToFetch)
+		return messages, nil, err
+	}
+
+	matchingMessages := []*lib.NewMessageEntry{}
+	messagesScanned := 0
+	pageStartTimestamp := startTimestamp
+	for {
+		messages, err := fetchMessages(pageStartTimestamp, messageTimestampsPageSize)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, message := range messages {
+			if messagesScanned == MaxMessagesScannedForMessageDirection {
+				return matchingMessages, &pageStartTimestamp, nil
+			}
+			messagesScanned++
+			pageStartTimestamp = message.TimestampNanos
+			isOutbound := bytes.Equal(message.SenderAccessGroupOwnerPublicKey.ToBytes(), viewerPkBytes)
+			if isOutbound == (direction == MessageDirectionOutbound) {
+				matchingMessages = append(matchingMessages, message)
+				if len(matchingMessages) == maxMessagesToFetch {
+					return matchingMessages, nil, nil
+				}
+			}
+		}
+		if len(messages) < messageTimestampsPageSize {
+			return matchingMessages, nil, nil
+		}
+	}
+}
+
+// ConfirmedMessagesCacheMaxAgeSeconds is the max-age the paginated message endpoints advertise for pages whose
+// messages have all been mined. A sender can still edit a mined message, so this bounds how long an HTTP cache can
+// keep serving the old text.
+const ConfirmedMessagesCacheMaxAgeSeconds = 24 * 60 * 60
+
+// pendingMessageKey identifies a message by its sender and timestamp.
+type pendingMessageKey struct {
+	SenderAccessGroupOwnerPublicKeyBase58Check string
+	TimestampNanos                             uint64
+}
+
+// MaxCommittedMessagesScannedForPendingCheck caps how many committed messages getPendingMessageKeys reads to find the
+// mined versions of a page of messages. Messages it doesn't get to are treated as pending.
+const MaxCommittedMessagesScannedForPendingCheck = 1000
+
+// getPendingMessageKeys returns the keys of the messages that were sent or edited by txns still in the mempool, out of
+// messages read from a thread with the mempool applied. fetchCommittedMessages reads the same thread from the
+// committed state, and only the span of timestamps covered by messages is read from it, so the cost doesn't grow with
+// the mempool. A message is pending if the committed thread doesn't have it with the same text.
+func getPendingMessageKeys(
+	messages []*lib.NewMessageEntry,
+	fetchCommittedMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+	params *lib.DeSoParams,
+) (map[pendingMessageKey]struct{}, error) {
+	getKey := func(message *lib.NewMessageEntry) pendingMessageKey {
+		return pendingMessageKey{
+			SenderAccessGroupOwnerPublicKeyBase58Check: lib.PkToString(
+				message.SenderAccessGroupOwnerPublicKey.ToBytes(), params),
+			TimestampNanos: message.TimestampNanos,
+		}
+	}
+
+	var oldestTimestamp, newestTimestamp uint64 = math.MaxUint64, 0
+	for _, message := range messages {
+		if message == nil || message.SenderAccessGroupOwnerPublicKey == nil {
+			continue
+		}
+		if message.TimestampNanos < oldestTimestamp {
+			oldestTimestamp = message.TimestampNanos
+		}
+		if message.TimestampNanos > newestTimestamp {
+			newestTimestamp = message.TimestampNanos
+		}
+	}
+
+	committedMessageTexts := make(map[pendingMessageKey][]byte)
+	if oldestTimestamp <= newestTimestamp {
+		// fetchCommittedMessages returns messages strictly older than its start timestamp.
+		startTimestamp := newestTimestamp
+		if startTimestamp < math.MaxUint64 {
+			startTimestamp++
+		}
+		pageSize := len(messages)
+		for scanned := 0; scanned < MaxCommittedMessagesScannedForPendingCheck; {
+			committedMessages, err := fetchCommittedMessages(startTimestamp, pageSize)
+			if err != nil {
+				return nil, errors.Wrapf(err, "Problem fetching committed messages: ")
+			}
+			for _, committedMessage := range committedMessages {
+				if committedMessage.SenderAccessGroupOwnerPublicKey != nil {
+					committedMessageTexts[getKey(committedMessage)] = committedMessage.EncryptedText
+				}
+			}
+			scanned += len(committedMessages)
+			if len(committedMessages) < pageSize ||
+				committedMessages[len(committedMessages)-1].TimestampNanos <= oldestTimestamp {
+				break
+			}
+			startTimestamp = committedMessages[len(committedMessages)-1].TimestampNanos
+		}
+	}
+
+	pendingMessageKeys := make(map[pendingMessageKey]struct{})
+	for _, message := range messages {
+		if message == nil || message.SenderAccessGroupOwnerPublicKey == nil {
+			continue
+		}
+		key := getKey(message)
+		if committedText, isCommitted := committedMessageTexts[key]; !isCommitted ||
+			!bytes.Equal(committedText, message.EncryptedText) {
+			pendingMessageKeys[key] = struct{}{}
+		}
+	}
+	return pendingMessageKeys, nil
+}
+
+// getPendingMessageKeysForPage is getPendingMessageKeys with the committed thread read by fetchMessagesFromView from a
+// view of the committed state. The view isn't built for an empty page.
+func (fes *APIServer) getPendingMessageKeysForPage(
+	endpoint string,
+	messages []*lib.NewMessageEntry,
+	fetchMessagesFromView func(
+		utxoView *lib.UtxoView, startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+) (map[pendingMessageKey]struct{}, error) {
+	if len(messages) == 0 {
+		return map[pendingMessageKey]struct{}{}, nil
+	}
+	committedUtxoView, err := fes.GetUtxoViewGivenTxnStatus(TxnStatusCommitted, endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Problem getting committed utxo view: ")
+	}
+	return getPendingMessageKeys(messages,
+		func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			return fetchMessagesFromView(committedUtxoView, startTimestamp, maxMessagesToFetch)
+		}, fes.Params)
+}
+
+// setMessagesCacheControlHeader lets HTTP caches keep a page of messages if all of them have been mined, and tells
+// them not to if any is still in the mempool, since it may yet change or be dropped. An empty page isn't cached
+// either, since the messages it's waiting on may not have been sent yet. A page with any DecryptedText was only
+// decrypted for the authenticated recipient, so it must never be stored, and in particular not by a shared cache.
+func setMessagesCacheControlHeader(
+	ww http.ResponseWriter,
+	messages []NewMessageEntryResponse,
+	pendingMessageKeys map[pendingMessageKey]struct{},
+) {
+	for _, message := range messages {
+		if message.MessageInfo.DecryptedText != "" {
+			ww.Header().Set("Cache-Control", "private, no-store")
+			return
+		}
+	}
+	isCacheable := len(messages) > 0
+	for _, message := range messages {
+		if _, isPending := pendingMessageKeys[pendingMessageKey{
+			SenderAccessGroupOwnerPublicKeyBase58Check: message.SenderInfo.OwnerPublicKeyBase58Check,
+			TimestampNanos: message.MessageInfo.TimestampNanos,
+		}]; isPending {
+			isCacheable = false
+			break
+		}
+	}
+	if isCacheable {
+		ww.Header().Set("Cache-Control", fmt.Sprintf("public, immutable, max-age=%d",
+			ConfirmedMessagesCacheMaxAgeSeconds))
+	} else {
+		ww.Header().Set("Cache-Control", "no-cache")
+	}
+}
+
+const (
+	// MaxMessageTimestampsWindowNanos caps how far apart the start and end of the window passed to
+	// GetMessageTimestampsForThread can be.
+	MaxMessageTimestampsWindowNanos = uint64(30 * 24 * time.Hour)
+	// MaxMessageTimestampsToFetch caps how many timestamps GetMessageTimestampsForThread returns. If a window has
+	// more messages than this, the oldest ones are left out and Truncated is set in the response.
+	MaxMessageTimestampsToFetch = 10000
+	// messageTimestampsPageSize is how many messages we fetch from the view at a time while scanning a window.
+	messageTimestampsPageSize = 1000
+)
+
+// MessageThreadSpec identifies a DM or group chat thread.
+type MessageThreadSpec struct {
+	// Either ChatTypeDM or ChatTypeGroupChat.
+	ChatType ChatType
+
+	// The two parties of a DM thread. Only used when ChatType is ChatTypeDM.
+	UserGroupOwnerPublicKeyBase58Check  string
+	UserGroupKeyName                    string
+	PartyGroupOwnerPublicKeyBase58Check string
+	PartyGroupKeyName                   string
+
+	// The group chat. Only used when ChatType is ChatTypeGroupChat. AccessGroupIdHex takes precedence over the
+	// owner public key and key name, as in GetPaginatedMessagesForGroupChatThread.
+	AccessGroupOwnerPublicKeyBase58Check string
+	AccessGroupKeyName                   string
+	AccessGroupIdHex                     string
+}
+
+type GetMessageTimestampsForThreadRequest struct {
+	MessageThreadSpec
+
+	// The window is [EndTimestamp, StartTimestamp), matching the paginated message endpoints which return messages
+	// older than StartTimestamp. We support passing timestamps as string and uint64. uint64 can lose precision when
+	// being JSON decoded, so we prefer the string versions.
+	StartTimestamp       uint64
+	StartTimestampString string
+	EndTimestamp         uint64
+	EndTimestampString   string
+}
+
+type GetMessageTimestampsForThreadResponse struct {
+	// The TimestampNanos of every message in the window, sorted from oldest to newest.
+	TimestampsNanos []uint64
+	// Set when the window has more than MaxMessageTimestampsToFetch messages. Only the newest ones are returned, so
+	// clients should request the rest of the window with StartTimestamp set to the oldest timestamp returned.
+	Truncated bool
+}
+
+// GetMessageTimestampsForThread returns just the timestamps of the messages in a DM or group chat thread over a window
+// of time. Sync clients can diff these against the messages they have stored to find gaps to backfill, which is much
+// cheaper than fetching the messages themselves.
+func (fes *APIServer) GetMessageTimestampsForThread(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetMessageTimestampsForThreadRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessageTimestampsForThread: Problem parsing request body: %v", err))
+		return
+	}
+
+	var err error
+	startTimestamp := requestData.StartTimestamp
+	if requestData.StartTimestampString != "" {
+		startTimestamp, err = strconv.ParseUint(requestData.StartTimestampString, 10, 64)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetMessageTimestampsForThread: Error parsing "+
+				"StartTimestampString: %v", err))
+			return
+		}
+	}
+	endTimestamp := requestData.EndTimestamp
+	if requestData.EndTimestampString != "" {
+		endTimestamp, err = strconv.ParseUint(requestData.EndTimestampString, 10, 64)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetMessageTimestampsForThread: Error parsing "+
+				"EndTimestampString: %v", err))
+			return
+		}
+	}
+	if endTimestamp >= startTimestamp {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessageTimestampsForThread: EndTimestamp %v must be less than "+
+			"StartTimestamp %v", endTimestamp, startTimestamp))
+		return
+	}
+	if startTimestamp-endTimestamp > MaxMessageTimestampsWindowNanos {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessageTimestampsForThread: Window of %v nanos is larger than "+
+			"the maximum of %v nanos", startTimestamp-endTimestamp, MaxMessageTimestampsWindowNanos))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetMessageTimestampsForThread")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessageTimestampsForThread: Error generating utxo view: %v", err))
+		return
+	}
+
+	fetchMessages, err := fes.getMessageFetcherForThread(&requestData.MessageThreadSpec, utxoView)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessageTimestampsForThread: %v", err))
+		return
+	}
+
+	timestampsNanos, truncated, err := getMessageTimestampsInWindow(
+		fetchMessages, startTimestamp, endTimestamp, MaxMessageTimestampsToFetch)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessageTimestampsForThread: %v", err))
+		return
+	}
+
+	res := GetMessageTimestampsForThreadResponse{
+		TimestampsNanos: timestampsNanos,
+		Truncated:       truncated,
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessageTimestampsForThread: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getMessageTimestampsInWindow pages backwards through a thread from startTimestamp using fetchMessages, which should
+// return messages older than the timestamp passed in, newest first. It returns the timestamps of the messages in
+// [endTimestamp, startTimestamp) sorted from oldest to newest. At most maxTimestamps are returned; if the window has
+// more messages than that, the newest ones are kept and truncated is set.
+func getMessageTimestampsInWindow(
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+	startTimestamp uint64,
+	endTimestamp uint64,
+	maxTimestamps int,
+) (_timestampsNanos []uint64, _truncated bool, _err error) {
+	timestampsNanos := []uint64{}
+	pageStartTimestamp := startTimestamp
+	for {
+		messages, err := fetchMessages(pageStartTimestamp, messageTimestampsPageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, message := range messages {
+			if message.TimestampNanos < endTimestamp {
+				return reverseUint64s(timestampsNanos), false, nil
+			}
+			if len(timestampsNanos) == maxTimestamps {
+				return reverseUint64s(timestampsNanos), true, nil
+			}
+			timestampsNanos = append(timestampsNanos, message.TimestampNanos)
+		}
+		if len(messages) < messageTimestampsPageSize {
+			return reverseUint64s(timestampsNanos), false, nil
+		}
+		pageStartTimestamp = messages[len(messages)-1].TimestampNanos
+	}
+}
+
+// MaxMessagesCountedForSequenceInThread bounds how many messages older than a fetched window we count to compute
+// SequenceInThread.
+const MaxMessagesCountedForSequenceInThread = 10000
+
+// setSequenceInThread sets SequenceInThread on messages, a window of a thread's messages sorted newest first as the
+// paginated endpoints return them. fetchMessages is used to count the thread's messages older than the window, as in
+// getMessageTimestampsInWindow.
+func setSequenceInThread(
+	messages []NewMessageEntryResponse,
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	olderTimestampsNanos, truncated, err := getMessageTimestampsInWindow(
+		fetchMessages, messages[len(messages)-1].MessageInfo.TimestampNanos, 0, MaxMessagesCountedForSequenceInThread)
+	if err != nil {
+		return errors.Wrapf(err, "Problem counting messages for SequenceInThread: ")
+	}
+	if truncated {
+		return errors.Errorf("Thread has more than %v messages before the requested window, so SequenceInThread "+
+			"can't be computed", MaxMessagesCountedForSequenceInThread)
+	}
+	for ii := range messages {
+		sequenceInThread := uint64(len(olderTimestampsNanos) + len(messages) - 1 - ii)
+		messages[ii].MessageInfo.SequenceInThread = &sequenceInThread
+	}
+	return nil
+}
+
+// getMessageFetcherForThread validates threadSpec and returns a function that fetches up to maxMessagesToFetch of the
+// thread's messages older than startTimestamp, newest first.
+func (fes *APIServer) getMessageFetcherForThread(
+	threadSpec *MessageThreadSpec,
+	utxoView *lib.UtxoView,
+) (func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error), error) {
+	switch threadSpec.ChatType {
+	case ChatTypeDM:
+		senderGroupOwnerPkBytes, senderGroupKeyNameBytes, err :=
+			ValidateAccessGroupPublicKeyAndName(threadSpec.UserGroupOwnerPublicKeyBase58Check, threadSpec.UserGroupKeyName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem validating user group owner public key and access group name "+
+				"%s: %s", threadSpec.UserGroupOwnerPublicKeyBase58Check, threadSpec.UserGroupKeyName)
+		}
+		recipientGroupOwnerPkBytes, recipientGroupKeyNameBytes, err :=
+			ValidateAccessGroupPublicKeyAndName(threadSpec.PartyGroupOwnerPublicKeyBase58Check, threadSpec.PartyGroupKeyName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem validating party group owner public key and access group name "+
+				"%s: %s", threadSpec.PartyGroupOwnerPublicKeyBase58Check, threadSpec.PartyGroupKeyName)
+		}
+		if bytes.Equal(senderGroupOwnerPkBytes, recipientGroupOwnerPkBytes) {
+			return nil, errors.Errorf("Dm sender and recipient cannot be the same %s: %s",
+				threadSpec.UserGroupOwnerPublicKeyBase58Check, threadSpec.PartyGroupOwnerPublicKeyBase58Check)
+		}
+		return func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			return fes.fetchMaxMessagesFromDmThreadIncludingBaseKeys(
+				*lib.NewPublicKey(senderGroupOwnerPkBytes), *lib.NewGroupKeyName(senderGroupKeyNameBytes),
+				*lib.NewPublicKey(recipientGroupOwnerPkBytes), *lib.NewGroupKeyName(recipientGroupKeyNameBytes),
+				startTimestamp, maxMessagesToFetch, utxoView)
+		}, nil
+	case ChatTypeGroupChat:
+		accessGroupId, err := getAccessGroupIdForGroupChatThreadRequest(&GetPaginatedMessagesForGroupChatThreadRequest{
+			UserPublicKeyBase58Check: threadSpec.AccessGroupOwnerPublicKeyBase58Check,
+			AccessGroupKeyName:       threadSpec.AccessGroupKeyName,
+			AccessGroupIdHex:         threadSpec.AccessGroupIdHex,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			return fes.fetchMaxMessagesFromGroupChatThread(accessGroupId, startTimestamp, maxMessagesToFetch, utxoView)
+		}, nil
+	default:
+		return nil, errors.Errorf("Invalid ChatType %v. Options are {%v, %v}",
+			threadSpec.ChatType, ChatTypeDM, ChatTypeGroupChat)
+	}
+}
+
+func reverseUint64s(values []uint64) []uint64 {
+	for ii, jj := 0, len(values)-1; ii < jj; ii, jj = ii+1, jj-1 {
+		values[ii], values[jj] = values[jj], values[ii]
+	}
+	return values
+}
+
+// MaxMessageReferencesPerRequest caps how many messages can be requested at once from GetMessagesByReferences.
+const MaxMessageReferencesPerRequest = 100
+
+// MessageReference identifies a single message by its thread and timestamp.
+type MessageReference struct {
+	ThreadSpec MessageThreadSpec
+
+	// We support passing the timestamp as string and uint64. uint64 can lose precision when being JSON decoded, so we
+	// prefer the string version.
+	TimestampNanos       uint64
+	TimestampNanosString string
+}
+
+type GetMessagesByReferencesRequest struct {
+	References []MessageReference
+}
+
+type MessageReferenceResult struct {
+	// False if the reference didn't resolve to a message, either because there's no message in the thread at that
+	// timestamp or because the reference itself is invalid, in which case Error explains why.
+	Found   bool
+	Message *NewMessageEntryResponse `json:",omitempty"`
+	Error   string                   `json:",omitempty"`
+}
+
+type GetMessagesByReferencesResponse struct {
+	// One result per reference, in the same order as the request.
+	Results []MessageReferenceResult
+}
+
+// GetMessagesByReferences returns specific messages identified by thread and timestamp. Clients that have found gaps
+// with GetMessageTimestampsForThread can use this to backfill exactly the messages they're missing rather than paging
+// through the thread.
+func (fes *APIServer) GetMessagesByReferences(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetMessagesByReferencesRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessagesByReferences: Problem parsing request body: %v", err))
+		return
+	}
+
+	if len(requestData.References) > MaxMessageReferencesPerRequest {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessagesByReferences: Requested %v references, which is more than "+
+			"the maximum of %v", len(requestData.References), MaxMessageReferencesPerRequest))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetMessagesByReferences")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessagesByReferences: Error generating utxo view: %v", err))
+		return
+	}
+
+	results := make([]MessageReferenceResult, len(requestData.References))
+	for ii := range requestData.References {
+		reference := &requestData.References[ii]
+		timestampNanos := reference.TimestampNanos
+		if reference.TimestampNanosString != "" {
+			timestampNanos, err = strconv.ParseUint(reference.TimestampNanosString, 10, 64)
+			if err != nil {
+				results[ii].Error = fmt.Sprintf("Error parsing TimestampNanosString: %v", err)
+				continue
+			}
+		}
+		fetchMessages, err := fes.getMessageFetcherForThread(&reference.ThreadSpec, utxoView)
+		if err != nil {
+			results[ii].Error = err.Error()
+			continue
+		}
+		message, err := getMessageAtTimestamp(fetchMessages, timestampNanos)
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetMessagesByReferences: Problem fetching message: %v", err))
+			return
+		}
+		if message == nil {
+			continue
+		}
+		messageResponse := fes.NewMessageEntryToResponse(message, reference.ThreadSpec.ChatType, utxoView)
+		results[ii] = MessageReferenceResult{Found: true, Message: &messageResponse}
+	}
+
+	if err = json.NewEncoder(ww).Encode(GetMessagesByReferencesResponse{Results: results}); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessagesByReferences: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getMessageAtTimestamp returns the message at exactly timestampNanos in the thread fetchMessages reads from, or nil
+// if there isn't one. fetchMessages should return messages older than the timestamp passed in, newest first.
+func getMessageAtTimestamp(
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+	timestampNanos uint64,
+) (*lib.NewMessageEntry, error) {
+	if timestampNanos == math.MaxUint64 {
+		return nil, nil
+	}
+	messages, err := fetchMessages(timestampNanos+1, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 || messages[0].TimestampNanos != timestampNanos {
+		return nil, nil
+	}
+	return messages[0], nil
+}
+
+// getAccessGroupIdForGroupChatThreadRequest returns the AccessGroupId of the group chat being requested, either by
+// decoding AccessGroupIdHex or by combining the owner public key with the access group key name.
+func getAccessGroupIdForGroupChatThreadRequest(requestData *GetPaginatedMessagesForGroupChatThreadRequest) (*lib.AccessGroupId, error) {
+	if requestData.AccessGroupIdHex != "" {
+		accessGroupId, err := DecodeAccessGroupIdFromHex(requestData.AccessGroupIdHex)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Problem decoding AccessGroupIdHex")
+		}
+		return accessGroupId, nil
+	}
+
+	// Basic validation of the sender public key and access group name.
+	accessGroupOwnerPkBytes, accessGroupKeyNameBytes, err :=
+		ValidateAccessGroupPublicKeyAndName(requestData.UserPublicKeyBase58Check, requestData.AccessGroupKeyName)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Problem validating user group owner public key and access group name %s: %s",
+			requestData.UserPublicKeyBase58Check, requestData.AccessGroupKeyName)
+	}
+	return &lib.AccessGroupId{
+		AccessGroupOwnerPublicKey: *lib.NewPublicKey(accessGroupOwnerPkBytes),
+		AccessGroupKeyName:        *lib.NewGroupKeyName(accessGroupKeyNameBytes),
+	}, nil
+}
+
+// EncodeAccessGroupIdToHex hex-encodes the owner public key followed by the padded access group key name.
+func EncodeAccessGroupIdToHex(accessGroupId *lib.AccessGroupId) string {
+	return hex.EncodeToString(append(accessGroupId.AccessGroupOwnerPublicKey.ToBytes(), accessGroupId.AccessGroupKeyName.ToBytes()...))
+}
+
+// DecodeAccessGroupIdFromHex is the inverse of EncodeAccessGroupIdToHex. It validates that the result is a
+// well-formed AccessGroupId.
+func DecodeAccessGroupIdFromHex(accessGroupIdHex string) (*lib.AccessGroupId, error) {
+	accessGroupIdBytes, err := hex.DecodeString(accessGroupIdHex)
+	if err != nil {
+		return nil, errors.Wrapf(err, "DecodeAccessGroupIdFromHex: Problem decoding hex")
+	}
+	publicKeyLength := len(lib.PublicKey{})
+	groupKeyNameLength := len(lib.GroupKeyName{})
+	if len(accessGroupIdBytes) != publicKeyLength+groupKeyNameLength {
+		return nil, errors.Errorf("DecodeAccessGroupIdFromHex: AccessGroupId has length (%d) but should be (%d)",
+			len(accessGroupIdBytes), publicKeyLength+groupKeyNameLength)
+	}
+	accessGroupOwnerPkBytes := accessGroupIdBytes[:publicKeyLength]
+	accessGroupKeyName := lib.NewGroupKeyName(accessGroupIdBytes[publicKeyLength:])
+
+	// Run the same validations as we would on a base58 public key and a key name.
+	if err = lib.IsByteArrayValidPublicKey(accessGroupOwnerPkBytes); err != nil {
+		return nil, errors.Wrapf(err, "DecodeAccessGroupIdFromHex: Invalid access group owner public key")
+	}
+	accessGroupKeyNameBytes := lib.MessagingKeyNameDecode(accessGroupKeyName)
+	if len(accessGroupKeyNameBytes) > 0 {
+		if err = lib.ValidateAccessGroupPublicKeyAndName(accessGroupOwnerPkBytes, accessGroupKeyNameBytes); err != nil {
+			return nil, errors.Wrapf(err, "DecodeAccessGroupIdFromHex: Invalid access group key name")
+		}
+	}
+	return &lib.AccessGroupId{
+		AccessGroupOwnerPublicKey: *lib.NewPublicKey(accessGroupOwnerPkBytes),
+		AccessGroupKeyName:        *accessGroupKeyName,
+	}, nil
+}
+
+// aggregate threads from both direct messages and group chat messages.
+type GetUserMessageThreadsRequest struct {
+	// PublicKeyBase58Check is the public key whose group IDs needs to be queried.
+	UserPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// If set, MemberCount is filled in for each group chat thread. Off by default since it requires enumerating the
+	// members of every group.
+	IncludeMemberCounts bool `safeForLogging:"true"`
+
+	// If set, DM threads are returned in DmConversations, grouped by counterparty, instead of in MessageThreads. A
+	// user can have several DM threads with the same person under different access groups.
+	GroupDmThreadsByCounterparty bool `safeForLogging:"true"`
+
+	// Defaults to true. If false, each thread's latest message is returned as a preview without its EncryptedText,
+	// keeping only the timestamps and unencrypted metadata such as ContentType and ExtraData. The server can't
+	// decrypt the message to truncate it, so this is the only way to shrink the inbox payload.
+	IncludeFullEncryptedText *bool `safeForLogging:"true"`
+
+	// If set, the profile of each thread's sender and recipient owner is attached to its SenderInfo and
+	// RecipientInfo, so clients don't have to look up every participant separately.
+	IncludeProfiles bool `safeForLogging:"true"`
+
+	// Optional. The maximum number of threads to return. If unset, every thread is returned. Can't be combined with
+	// GroupDmThreadsByCounterparty.
+	Limit int `safeForLogging:"true"`
+	// Optional. The NextCursor of the previous page. If unset, the first page is returned.
+	Cursor string `safeForLogging:"true"`
+	// Optional. A JWT for UserPublicKeyBase58Check. Only needed to have messages sent to the user decrypted by a node with
+	// message decryption enabled; without it, messages are returned encrypted.
+	JWT string
+}
+
+type GetUserMessageThreadsResponse struct {
+	MessageThreads []NewMessageEntryResponse
+
+	// Only set when Limit is set and there are more threads to fetch. Pass it as the Cursor of the next request. A
+	// thread with a new message moves to the front of the inbox, so it's picked up by refetching the first page
+	// rather than by following the cursor.
+	NextCursor string `json:",omitempty" safeForLogging:"true"`
+
+	// Only set when GroupDmThreadsByCounterparty is set. Sorted by the timestamp of each conversation's latest message,
+	// newest first.
+	DmConversations []DmConversationResponse `json:",omitempty"`
+
+	PublicKeyToProfileEntryResponse map[string]*ProfileEntryResponse
+}
+
+// This API just doesn't write any data, hence it doesn't create a new transaction.
+// It's a public API, hence anyone with a valid public key can query the system to fetch their Direct message threads.
+func (fes *APIServer) GetAllUserMessageThreads(ww http.ResponseWriter, req *http.Request) {
+	if err := fes.getUserMessageThreadsHandler(ww, req, true, true); err != nil {
+		_AddHandlerError(ww, fmt.Sprintf("GetAllUserMessageThreads: %v", err), err)
+		return
+	}
+}
+
+func (fes *APIServer) getUserMessageThreadsHandler(ww http.ResponseWriter, req *http.Request, getGroupChats bool, getDMs bool) error {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetUserMessageThreadsRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		return errors.Wrapf(err, "Problem parsing request body: ")
+	}
+
+	if err := ValidateNotDESOIdentifier("UserPublicKeyBase58Check", requestData.UserPublicKeyBase58Check); err != nil {
+		return err
+	}
+
+	if requestData.Limit < 0 {
+		return errors.Errorf("Limit must not be negative: %v", requestData.Limit)
+	}
+	if requestData.Limit > 0 && requestData.GroupDmThreadsByCounterparty {
+		return errors.New("Limit can't be combined with GroupDmThreadsByCounterparty")
+	}
+	var cursor *UserMessageThreadsCursor
+	if requestData.Cursor != "" {
+		var err error
+		cursor, err = DecodeUserMessageThreadsCursor(requestData.Cursor)
+		if err != nil {
+			return errors.Wrapf(err, "Invalid Cursor: ")
+		}
+	}
+
+	// Decode the access group owner public key.
+	accessGroupOwnerPkBytes, _, err := lib.Base58CheckDecode(requestData.UserPublicKeyBase58Check)
+	if err != nil {
+		return errors.Wrapf(err, fmt.Sprintf("Problem decoding owner"+
+			"base58 public key %s: ", requestData.UserPublicKeyBase58Check))
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("getUserMessageThreadsHandler")
+	if err != nil {
+		return errors.Wrapf(err, "Error generating "+
+			"utxo view: ")
+	}
+
+	var userMessageThreads []UserMessageThread
+	if getDMs {
+		// get all the direct message threads associated with the public key.
+		dmThreads, err := utxoView.GetAllUserDmThreads(*lib.NewPublicKey(accessGroupOwnerPkBytes))
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("Problem getting access group IDs of"+
+				"public key %s: ", requestData.UserPublicKeyBase58Check))
+		}
+
+		// fetch the latest message for each of the dmThread.
+		latestMessagesForThreadKeys, err := fes.fetchLatestMessageFromDmThreads(dmThreads, utxoView)
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("Problem getting access group IDs of"+
+				"public key %s: ", requestData.UserPublicKeyBase58Check))
+		}
+
+		for _, threadMsg := range latestMessagesForThreadKeys {
+			userMessageThreads = append(userMessageThreads, UserMessageThread{
+				ThreadId:      GetMessageThreadId(accessGroupOwnerPkBytes, threadMsg, ChatTypeDM),
+				LatestMessage: fes.NewMessageEntryToResponse(threadMsg, ChatTypeDM, utxoView),
+			})
+		}
+	}
+
+	if getGroupChats {
+		// get all the group chat threads for the public key.
+		groupChatThreads, err := utxoView.GetAllUserGroupChatThreads(*lib.NewPublicKey(accessGroupOwnerPkBytes))
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("Problem getting access group IDs of"+
+				"public key %s: ", requestData.UserPublicKeyBase58Check))
+		}
+		// get the latest message for each group chat thread.
+		latestMessagesForGroupChats, err := fes.fetchLatestMessageFromGroupChatThreads(groupChatThreads, utxoView)
+		if err != nil {
+			return errors.Wrapf(err, fmt.Sprintf("Problem getting access group IDs of"+
+				"public key %s: ", requestData.UserPublicKeyBase58Check))
+		}
+
+		// Add direct messages into MessageThread type.
+		for _, threadMsg := range latestMessagesForGroupChats {
+			messageThread := fes.NewMessageEntryToResponse(threadMsg, ChatTypeGroupChat, utxoView)
+			if requestData.IncludeMemberCounts {
+				// The recipient of a group chat message is the group itself.
+				groupOwnerPkBytes := threadMsg.RecipientAccessGroupOwnerPublicKey.ToBytes()
+				groupKeyNameBytes := lib.MessagingKeyNameDecode(threadMsg.RecipientAccessGroupKeyName)
+				messageThread.MemberCount, err = countAccessGroupMembers(
+					func(startingMemberPkBytes []byte, maxMembersToFetch int) ([]*lib.PublicKey, error) {
+						return fes.fetchMaxMembersFromAccessGroup(groupOwnerPkBytes, groupKeyNameBytes,
+							startingMemberPkBytes, maxMembersToFetch, utxoView)
+					})
+				if err != nil {
+					return errors.Wrapf(err, "Problem counting members of group chat %v: ",
+						messageThread.RecipientInfo.AccessGroupKeyName)
+				}
+			}
+			userMessageThreads = append(userMessageThreads, UserMessageThread{
+				ThreadId:      GetMessageThreadId(accessGroupOwnerPkBytes, threadMsg, ChatTypeGroupChat),
+				LatestMessage: messageThread,
+			})
+		}
+	}
+
+	// Sort group chats and DMs by the timestamp of their latest messages, and cut out the requested page.
+	messageThreads, nextCursor, err := PaginateUserMessageThreads(userMessageThreads, requestData.Limit, cursor)
+	if err != nil {
+		return errors.Wrapf(err, "Problem paginating threads: ")
+	}
+
+	if requestData.IncludeFullEncryptedText != nil && !*requestData.IncludeFullEncryptedText {
+		omitEncryptedTextFromMessageThreads(messageThreads)
+	} else {
+		decryptMessages(fes.getMessageDecryptionKeysForUser(requestData.UserPublicKeyBase58Check, requestData.JWT),
+			messageThreads)
+	}
+
+	// The user is a participant in every thread, so each distinct owner is only looked up once rather than twice per
+	// thread.
+	publicKeyToProfileEntryResponseMap := make(map[string]*ProfileEntryResponse)
+	for _, publicKeyBase58Check := range getMessageThreadParticipantPublicKeys(messageThreads) {
+		profileEntryResponse, err := fes.GetProfileEntryResponseForPublicKeyBase58Check(publicKeyBase58Check, utxoView)
+		if err != nil {
+			return errors.Wrapf(err, "GetUserMessageThreads: ")
+		}
+		publicKeyToProfileEntryResponseMap[publicKeyBase58Check] = profileEntryResponse
+	}
+	if requestData.IncludeProfiles {
+		attachProfilesToMessageThreads(messageThreads, publicKeyToProfileEntryResponseMap)
+	}
+
+	// response containing all user chats.
+	res := GetUserMessageThreadsResponse{
+		MessageThreads:                  messageThreads,
+		NextCursor:                      nextCursor,
+		PublicKeyToProfileEntryResponse: publicKeyToProfileEntryResponseMap,
+	}
+	if requestData.GroupDmThreadsByCounterparty {
+		res.MessageThreads, res.DmConversations = GroupDmThreadsByCounterparty(
+			lib.PkToString(accessGroupOwnerPkBytes, fes.Params), messageThreads)
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		return errors.Wrapf(err, "Problem encoding response as JSON: ")
+	}
+	return nil
+}
+
+// UserMessageThread is one of a user's threads along with its GetMessageThreadId.
+type UserMessageThread struct {
+	ThreadId      string
+	LatestMessage NewMessageEntryResponse
+}
+
+// userMessageThreadsCursorVersion is bumped whenever the cursor's contents or the inbox order change, so cursors
+// issued by older nodes are rejected rather than misread.
+const userMessageThreadsCursorVersion = 1
+
+// UserMessageThreadsCursor is the position of the last thread returned on a page of a user's threads. Clients treat
+// it as an opaque string.
+type UserMessageThreadsCursor struct {
+	Version int
+	// The timestamp of the last thread's latest message, and its thread ID to break ties between threads whose
+	// latest messages have the same timestamp.
+	TimestampNanos uint64
+	ThreadId       string
+}
+
+func EncodeUserMessageThreadsCursor(cursor *UserMessageThreadsCursor) (string, error) {
+	cursorBytes, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(cursorBytes), nil
+}
+
+func DecodeUserMessageThreadsCursor(encodedCursor string) (*UserMessageThreadsCursor, error) {
+	cursorBytes, err := base64.RawURLEncoding.DecodeString(encodedCursor)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Problem decoding cursor")
+	}
+	cursor := &UserMessageThreadsCursor{}
+	if err = json.Unmarshal(cursorBytes, cursor); err != nil {
+		return nil, errors.Wrapf(err, "Problem decoding cursor")
+	}
+	if cursor.Version != userMessageThreadsCursorVersion {
+		return nil, errors.Errorf("Unsupported cursor version %v", cursor.Version)
+	}
+	return cursor, nil
+}
+
+// PaginateUserMessageThreads sorts threads by the timestamp of their latest message, newest first, then by thread
+// ID, and returns the page of up to limit threads that follows cursor, or the first page if it's nil. If limit is 0,
+// every thread after cursor is returned. The cursor for the next page is empty if there are no more threads.
+func PaginateUserMessageThreads(
+	threads []UserMessageThread,
+	limit int,
+	cursor *UserMessageThreadsCursor,
+) (_page []NewMessageEntryResponse, _nextCursor string, _err error) {
+	isBefore := func(timestampNanos1 uint64, threadId1 string, timestampNanos2 uint64, threadId2 string) bool {
+		if timestampNanos1 != timestampNanos2 {
+			return timestampNanos1 > timestampNanos2
+		}
+		return threadId1 < threadId2
+	}
+	sortedThreads := append([]UserMessageThread{}, threads...)
+	sort.Slice(sortedThreads, func(ii, jj int) bool {
+		return isBefore(sortedThreads[ii].LatestMessage.MessageInfo.TimestampNanos, sortedThreads[ii].ThreadId,
+			sortedThreads[jj].LatestMessage.MessageInfo.TimestampNanos, sortedThreads[jj].ThreadId)
+	})
+
+	startIndex := 0
+	if cursor != nil {
+		startIndex = sort.Search(len(sortedThreads), func(ii int) bool {
+			return isBefore(cursor.TimestampNanos, cursor.ThreadId,
+				sortedThreads[ii].LatestMessage.MessageInfo.TimestampNanos, sortedThreads[ii].ThreadId)
+		})
+	}
+	endIndex := len(sortedThreads)
+	if limit > 0 && startIndex+limit < endIndex {
+		endIndex = startIndex + limit
+	}
+
+	page := []NewMessageEntryResponse{}
+	for _, thread := range sortedThreads[startIndex:endIndex] {
+		page = append(page, thread.LatestMessage)
+	}
+	if endIndex == len(sortedThreads) {
+		return page, "", nil
+	}
+	lastThread := sortedThreads[endIndex-1]
+	nextCursor, err := EncodeUserMessageThreadsCursor(&UserMessageThreadsCursor{
+		Version:        userMessageThreadsCursorVersion,
+		TimestampNanos: lastThread.LatestMessage.MessageInfo.TimestampNanos,
+		ThreadId:       lastThread.ThreadId,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return page, nextCursor, nil
+}
+
+// omitEncryptedTextFromMessageThreads strips the encrypted body from each thread's latest message in place, leaving
+// a preview of its unencrypted fields.
+func omitEncryptedTextFromMessageThreads(messageThreads []NewMessageEntryResponse) {
+	for ii := range messageThreads {
+		messageThreads[ii].MessageInfo.EncryptedText = ""
+		messageThreads[ii].MessageInfo.DecryptedText = ""
+	}
+}
+
+// getMessageThreadParticipantPublicKeys returns the distinct sender and recipient owner public keys of the threads'
+// latest messages, in the order they first appear.
+func getMessageThreadParticipantPublicKeys(messageThreads []NewMessageEntryResponse) []string {
+	seen := make(map[string]struct{})
+	var publicKeys []string
+	for _, messageThread := range messageThreads {
+		for _, publicKeyBase58Check := range []string{
+			messageThread.SenderInfo.OwnerPublicKeyBase58Check,
+			messageThread.RecipientInfo.OwnerPublicKeyBase58Check,
+		} {
+			if _, exists := seen[publicKeyBase58Check]; exists {
+				continue
+			}
+			seen[publicKeyBase58Check] = struct{}{}
+			publicKeys = append(publicKeys, publicKeyBase58Check)
+		}
+	}
+	return publicKeys
+}
+
+// attachProfilesToMessageThreads sets the ProfileEntryResponse of each thread's SenderInfo and RecipientInfo in place
+// from publicKeyToProfileEntryResponse.
+func attachProfilesToMessageThreads(
+	messageThreads []NewMessageEntryResponse,
+	publicKeyToProfileEntryResponse map[string]*ProfileEntryResponse,
+) {
+	for ii := range messageThreads {
+		messageThreads[ii].SenderInfo.ProfileEntryResponse =
+			publicKeyToProfileEntryResponse[messageThreads[ii].SenderInfo.OwnerPublicKeyBase58Check]
+		messageThreads[ii].RecipientInfo.ProfileEntryResponse =
+			publicKeyToProfileEntryResponse[messageThreads[ii].RecipientInfo.OwnerPublicKeyBase58Check]
+	}
+}
+
+// DmConversationResponse is every DM thread between a user and one counterparty.
+type DmConversationResponse struct {
+	CounterpartyPublicKeyBase58Check string `safeForLogging:"true"`
+	// The newest message across all of the Threads.
+	LatestMessage NewMessageEntryResponse
+	// One entry per pair of access groups the two have messaged through, with its latest message, newest first.
+	Threads []NewMessageEntryResponse
+}
+
+// GroupDmThreadsByCounterparty splits messageThreads into the threads that aren't DMs and the DM threads grouped by
+// the owner public key of the other party, from the point of view of userPublicKeyBase58Check. messageThreads must
+// be sorted newest first, and both results keep that order.
+func GroupDmThreadsByCounterparty(
+	userPublicKeyBase58Check string,
+	messageThreads []NewMessageEntryResponse,
+) (_otherThreads []NewMessageEntryResponse, _dmConversations []DmConversationResponse) {
+	otherThreads := []NewMessageEntryResponse{}
+	dmConversations := []DmConversationResponse{}
+	conversationIndexByCounterparty := make(map[string]int)
+	for _, messageThread := range messageThreads {
+		if messageThread.ChatType != ChatTypeDM {
+			otherThreads = append(otherThreads, messageThread)
+			continue
+		}
+		counterparty := messageThread.RecipientInfo.OwnerPublicKeyBase58Check
+		if counterparty == userPublicKeyBase58Check {
+			counterparty = messageThread.SenderInfo.OwnerPublicKeyBase58Check
+		}
+		conversationIndex, exists := conversationIndexByCounterparty[counterparty]
+		if !exists {
+			// Threads are sorted newest first, so the first thread we see for a counterparty has the latest message.
+			conversationIndex = len(dmConversations)
+			conversationIndexByCounterparty[counterparty] = conversationIndex
+			dmConversations = append(dmConversations, DmConversationResponse{
+				CounterpartyPublicKeyBase58Check: counterparty,
+				LatestMessage:                    messageThread,
+			})
+		}
+		dmConversations[conversationIndex].Threads = append(dmConversations[conversationIndex].Threads, messageThread)
+	}
+	return otherThreads, dmConversations
+}
+
+type GetMostRecentMessageTimestampRequest struct {
+	// The public key whose DM and group chat threads are checked.
+	UserPublicKeyBase58Check string `safeForLogging:"true"`
+}
+
+type GetMostRecentMessageTimestampResponse struct {
+	// The timestamp of the newest message in any of the user's threads. Zero if the user has no messages.
+	TimestampNanos uint64
+	// TimestampNanos as a string, since uint64 can lose precision when being JSON decoded.
+	TimestampNanosString string
+}
+
+// GetMostRecentMessageTimestamp returns the timestamp of the newest message across all of a user's DM and group chat
+// threads, without any message payloads. Clients can compare it to the last timestamp they've seen to decide whether
+// to refresh the inbox, which is much cheaper than fetching it.
+func (fes *APIServer) GetMostRecentMessageTimestamp(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetMostRecentMessageTimestampRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMostRecentMessageTimestamp: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("UserPublicKeyBase58Check", requestData.UserPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMostRecentMessageTimestamp: %v", err))
+		return
+	}
+
+	ownerPkBytes, _, err := lib.Base58CheckDecode(requestData.UserPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMostRecentMessageTimestamp: Problem decoding owner "+
+			"base58 public key %s: %v", requestData.UserPublicKeyBase58Check, err))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetMostRecentMessageTimestamp")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMostRecentMessageTimestamp: Error generating utxo view: %v", err))
+		return
+	}
+
+	latestDmMessages, latestGroupChatMessages, err := fes.fetchLatestMessageFromAllUserThreads(ownerPkBytes, utxoView)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMostRecentMessageTimestamp: %v", err))
+		return
+	}
+
+	mostRecentTimestampNanos := getMostRecentMessageTimestampNanos(latestDmMessages, latestGroupChatMessages)
+	res := GetMostRecentMessageTimestampResponse{
+		TimestampNanos:       mostRecentTimestampNanos,
+		TimestampNanosString: strconv.FormatUint(mostRecentTimestampNanos, 10),
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMostRecentMessageTimestamp: Problem encoding response as "+
+			"JSON: %v", err))
+		return
+	}
+}
+
+// fetchLatestMessageFromAllUserThreads returns the latest message of each of the user's DM threads and of each of
+// their group chat threads. Threads without any messages are skipped.
+func (fes *APIServer) fetchLatestMessageFromAllUserThreads(
+	ownerPkBytes []byte,
+	utxoView *lib.UtxoView,
+) (_latestDmMessages []*lib.NewMessageEntry, _latestGroupChatMessages []*lib.NewMessageEntry, _err error) {
+	ownerPublicKey := *lib.NewPublicKey(ownerPkBytes)
+	dmThreads, err := utxoView.GetAllUserDmThreads(ownerPublicKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Problem getting dm threads")
+	}
+	latestDmMessages, err := fes.fetchLatestMessageFromDmThreads(dmThreads, utxoView)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Problem getting latest dm messages")
+	}
+
+	groupChatThreads, err := utxoView.GetAllUserGroupChatThreads(ownerPublicKey)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Problem getting group chat threads")
+	}
+	latestGroupChatMessages, err := fes.fetchLatestMessageFromGroupChatThreads(groupChatThreads, utxoView)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "Problem getting latest group chat messages")
+	}
+	return latestDmMessages, latestGroupChatMessages, nil
+}
+
+// getMostRecentMessageTimestampNanos returns the largest TimestampNanos among the messages, or zero if there are none.
+func getMostRecentMessageTimestampNanos(messageLists ...[]*lib.NewMessageEntry) uint64 {
+	mostRecentTimestampNanos := uint64(0)
+	for _, messages := range messageLists {
+		for _, message := range messages {
+			if message != nil && message.TimestampNanos > mostRecentTimestampNanos {
+				mostRecentTimestampNanos = message.TimestampNanos
+			}
+		}
+	}
+	return mostRecentTimestampNanos
+}
+
+type GetThreadLastActivityRequest struct {
+	// The public key whose DM and group chat threads are checked.
+	UserPublicKeyBase58Check string `safeForLogging:"true"`
+}
+
+type GetThreadLastActivityResponse struct {
+	// Maps each of the user's threads to the TimestampNanos of its latest message. Threads are identified by
+	// GetMessageThreadId. Threads without any messages are left out.
+	ThreadIdToTimestampNanos map[string]uint64
+	// ThreadIdToTimestampNanos with the timestamps as strings, since uint64 can lose precision when being JSON decoded.
+	ThreadIdToTimestampNanosString map[string]string
+}
+
+// GetThreadLastActivity returns when each of a user's DM and group chat threads last had a message, without any
+// message payloads. Sync clients can compare these against the timestamps they've stored to decide which threads to
+// refresh.
+func (fes *APIServer) GetThreadLastActivity(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetThreadLastActivityRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadLastActivity: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("UserPublicKeyBase58Check", requestData.UserPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadLastActivity: %v", err))
+		return
+	}
+
+	ownerPkBytes, _, err := lib.Base58CheckDecode(requestData.UserPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadLastActivity: Problem decoding owner "+
+			"base58 public key %s: %v", requestData.UserPublicKeyBase58Check, err))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetThreadLastActivity")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadLastActivity: Error generating utxo view: %v", err))
+		return
+	}
+
+	latestDmMessages, latestGroupChatMessages, err := fes.fetchLatestMessageFromAllUserThreads(ownerPkBytes, utxoView)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadLastActivity: %v", err))
+		return
+	}
 
-	// Add the sender's profile to the response.
-	res.PublicKeyToProfileEntryResponse[requestData.UserGroupOwnerPublicKeyBase58Check] = fes.GetProfileEntryResponseForPublicKeyBytes(
-		senderGroupOwnerPkBytes, utxoView)
+	threadIdToTimestampNanos := getThreadLastActivity(ownerPkBytes, latestDmMessages, latestGroupChatMessages)
+	res := GetThreadLastActivityResponse{
+		ThreadIdToTimestampNanos:       threadIdToTimestampNanos,
+		ThreadIdToTimestampNanosString: make(map[string]string, len(threadIdToTimestampNanos)),
+	}
+	for threadId, timestampNanos := range threadIdToTimestampNanos {
+		res.ThreadIdToTimestampNanosString[threadId] = strconv.FormatUint(timestampNanos, 10)
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadLastActivity: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
 
-	// Add the recipient's profile to the response.
-	res.PublicKeyToProfileEntryResponse[requestData.PartyGroupOwnerPublicKeyBase58Check] = fes.GetProfileEntryResponseForPublicKeyBytes(
-		recipientGroupOwnerPkBytes, utxoView)
+// getThreadLastActivity maps the thread of each of the user's latest messages to the message's TimestampNanos.
+func getThreadLastActivity(
+	ownerPkBytes []byte,
+	latestDmMessages []*lib.NewMessageEntry,
+	latestGroupChatMessages []*lib.NewMessageEntry,
+) map[string]uint64 {
+	threadIdToTimestampNanos := make(map[string]uint64)
+	for _, message := range latestDmMessages {
+		threadIdToTimestampNanos[GetMessageThreadId(ownerPkBytes, message, ChatTypeDM)] = message.TimestampNanos
+	}
+	for _, message := range latestGroupChatMessages {
+		threadIdToTimestampNanos[GetMessageThreadId(ownerPkBytes, message, ChatTypeGroupChat)] = message.TimestampNanos
+	}
+	return threadIdToTimestampNanos
+}
+
+// DefaultMaxMessagesScannedForUnreadCount is used when the node doesn't configure MaxMessagesScannedForUnreadCount.
+const DefaultMaxMessagesScannedForUnreadCount = 1000
+
+type GetUnreadMessageCountRequest struct {
+	// The public key whose DM and group chat threads are counted.
+	OwnerPublicKeyBase58Check string `safeForLogging:"true"`
+	// Maps threads, identified by GetMessageThreadId, to the TimestampNanos of the latest message the user has read in
+	// them. Reads aren't recorded on-chain, so the client keeps track of these. Threads that aren't in the map are
+	// treated as fully unread.
+	ThreadIdToLastReadTimestampNanos map[string]uint64 `safeForLogging:"true"`
+	// ThreadIdToLastReadTimestampNanos with the timestamps as strings, since uint64 can lose precision when being
+	// JSON decoded. Takes precedence over ThreadIdToLastReadTimestampNanos for threads that appear in both.
+	ThreadIdToLastReadTimestampNanosString map[string]string `safeForLogging:"true"`
+}
+
+type GetUnreadMessageCountResponse struct {
+	// The number of messages newer than the last read timestamp in each of the user's threads. Threads without any
+	// messages are left out.
+	ThreadIdToUnreadCount map[string]uint64
+	// The sum of ThreadIdToUnreadCount.
+	TotalUnreadCount uint64
+	// Threads with more unread messages than the node scans per thread. Their count is only a lower bound.
+	TruncatedThreadIds []string
+}
+
+// GetUnreadMessageCount returns how many messages in each of a user's DM and group chat threads are newer than the
+// last read timestamps the client passes in, for showing badge counts.
+func (fes *APIServer) GetUnreadMessageCount(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetUnreadMessageCountRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetUnreadMessageCount: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("OwnerPublicKeyBase58Check", requestData.OwnerPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetUnreadMessageCount: %v", err))
+		return
+	}
+
+	ownerPkBytes, _, err := lib.Base58CheckDecode(requestData.OwnerPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetUnreadMessageCount: Problem decoding owner "+
+			"base58 public key %s: %v", requestData.OwnerPublicKeyBase58Check, err))
+		return
+	}
+
+	threadIdToLastReadTimestampNanos := make(map[string]uint64)
+	for threadId, timestampNanos := range requestData.ThreadIdToLastReadTimestampNanos {
+		threadIdToLastReadTimestampNanos[threadId] = timestampNanos
+	}
+	for threadId, timestampNanosString := range requestData.ThreadIdToLastReadTimestampNanosString {
+		timestampNanos, err := strconv.ParseUint(timestampNanosString, 10, 64)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetUnreadMessageCount: Error parsing last read timestamp %v "+
+				"of thread %v: %v", timestampNanosString, threadId, err))
+			return
+		}
+		threadIdToLastReadTimestampNanos[threadId] = timestampNanos
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetUnreadMessageCount")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetUnreadMessageCount: Error generating utxo view: %v", err))
+		return
+	}
+
+	messageThreads, err := fes.getUserMessageThreadFetchers(ownerPkBytes, utxoView)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetUnreadMessageCount: %v", err))
+		return
+	}
+
+	maxMessagesScanned := fes.getMaxMessagesScannedForUnreadCount()
+	res := GetUnreadMessageCountResponse{
+		ThreadIdToUnreadCount: make(map[string]uint64),
+		TruncatedThreadIds:    []string{},
+	}
+	for _, thread := range messageThreads {
+		latestMessages, err := thread.fetchMessages(math.MaxUint64, 1)
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetUnreadMessageCount: %v", err))
+			return
+		}
+		if len(latestMessages) == 0 {
+			continue
+		}
+		threadId := GetMessageThreadId(ownerPkBytes, latestMessages[0], thread.chatType)
+		unreadCount, truncated, err := countMessagesAfterTimestamp(
+			thread.fetchMessages, threadIdToLastReadTimestampNanos[threadId], maxMessagesScanned)
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetUnreadMessageCount: %v", err))
+			return
+		}
+		res.ThreadIdToUnreadCount[threadId] = unreadCount
+		res.TotalUnreadCount += unreadCount
+		if truncated {
+			res.TruncatedThreadIds = append(res.TruncatedThreadIds, threadId)
+		}
+	}
+	sort.Strings(res.TruncatedThreadIds)
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetUnreadMessageCount: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// messageThreadFetcher pages backwards through the messages of one of a user's threads.
+type messageThreadFetcher struct {
+	chatType      ChatType
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error)
+}
+
+// getUserMessageThreadFetchers returns a fetcher for each of the DM and group chat threads the owner is in.
+func (fes *APIServer) getUserMessageThreadFetchers(
+	ownerPkBytes []byte,
+	utxoView *lib.UtxoView,
+) ([]messageThreadFetcher, error) {
+	ownerPublicKey := *lib.NewPublicKey(ownerPkBytes)
+	dmThreads, err := utxoView.GetAllUserDmThreads(ownerPublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Problem getting dm threads: ")
+	}
+	groupChatThreads, err := utxoView.GetAllUserGroupChatThreads(ownerPublicKey)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Problem getting group chat threads: ")
+	}
+
+	var messageThreads []messageThreadFetcher
+	for _, dmThread := range dmThreads {
+		dmThread := dmThread
+		messageThreads = append(messageThreads, messageThreadFetcher{
+			chatType: ChatTypeDM,
+			fetchMessages: func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+				return fes.fetchMaxMessagesFromDmThread(dmThread, startTimestamp, maxMessagesToFetch, utxoView)
+			},
+		})
+	}
+	for _, groupChatThread := range groupChatThreads {
+		groupChatThread := groupChatThread
+		messageThreads = append(messageThreads, messageThreadFetcher{
+			chatType: ChatTypeGroupChat,
+			fetchMessages: func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+				return fes.fetchMaxMessagesFromGroupChatThread(groupChatThread, startTimestamp, maxMessagesToFetch, utxoView)
+			},
+		})
+	}
+	return messageThreads, nil
+}
+
+// getMaxMessagesScannedForUnreadCount returns the node's MaxMessagesScannedForUnreadCount, or
+// DefaultMaxMessagesScannedForUnreadCount if it isn't configured.
+func (fes *APIServer) getMaxMessagesScannedForUnreadCount() int {
+	if fes.Config != nil && fes.Config.MaxMessagesScannedForUnreadCount > 0 {
+		return fes.Config.MaxMessagesScannedForUnreadCount
+	}
+	return DefaultMaxMessagesScannedForUnreadCount
+}
+
+// countMessagesAfterTimestamp counts the messages in a thread with a timestamp strictly greater than
+// afterTimestampNanos, reading at most maxMessagesScanned of them. If there are more, it returns maxMessagesScanned
+// and sets _truncated.
+func countMessagesAfterTimestamp(
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+	afterTimestampNanos uint64,
+	maxMessagesScanned int,
+) (_count uint64, _truncated bool, _err error) {
+	count := uint64(0)
+	pageStartTimestamp := uint64(math.MaxUint64)
+	for {
+		// Fetch one more than we're allowed to count so that we can tell whether the count was truncated.
+		pageSize := maxMessagesScanned + 1 - int(count)
+		if pageSize > messageTimestampsPageSize {
+			pageSize = messageTimestampsPageSize
+		}
+		messages, err := fetchMessages(pageStartTimestamp, pageSize)
+		if err != nil {
+			return 0, false, err
+		}
+		for _, message := range messages {
+			if message.TimestampNanos <= afterTimestampNanos {
+				return count, false, nil
+			}
+			if count == uint64(maxMessagesScanned) {
+				return count, true, nil
+			}
+			count++
+		}
+		if len(messages) < pageSize {
+			return count, false, nil
+		}
+		pageStartTimestamp = messages[len(messages)-1].TimestampNanos
+	}
+}
+
+// MaxMessagesScannedForThreadParticipants bounds how many of a group chat's most recent messages we scan for senders
+// who are no longer members of the group.
+const MaxMessagesScannedForThreadParticipants = 10000
+
+type GetThreadParticipantKeysRequest struct {
+	// The hex-encoded AccessGroupId of the group chat. See EncodeAccessGroupIdToHex.
+	AccessGroupIdHex string `safeForLogging:"true"`
+}
+
+type ThreadParticipantKey struct {
+	OwnerPublicKeyBase58Check string
+	// The key name of the participant's own access group used for the thread: the one they were added to the group
+	// with, or for participants who have left, the one they last sent a message with.
+	AccessGroupKeyName string
+	// The participant's current access group public key for AccessGroupKeyName. This can differ from the key on
+	// older messages if the participant has rotated it since. Empty if the participant's access group no longer
+	// exists.
+	AccessGroupPublicKeyBase58Check string
+	// False for participants who have sent messages to the group but have since left or been removed.
+	IsCurrentMember bool
+}
+
+type GetThreadParticipantKeysResponse struct {
+	// The group's current members, followed by past senders who have left, each sorted by owner public key.
+	Participants []ThreadParticipantKey
+	// True if the group chat has more than MaxMessagesScannedForThreadParticipants messages, in which case
+	// participants who left without sending any of the most recent ones are left out.
+	Truncated bool
+}
+
+// GetThreadParticipantKeys returns the current access group public key of each participant of a group chat, so
+// clients can tell which key to use for each member after key rotations.
+func (fes *APIServer) GetThreadParticipantKeys(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetThreadParticipantKeysRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadParticipantKeys: Problem parsing request body: %v", err))
+		return
+	}
+
+	accessGroupId, err := DecodeAccessGroupIdFromHex(requestData.AccessGroupIdHex)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadParticipantKeys: %v", err))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetThreadParticipantKeys")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadParticipantKeys: Error generating utxo view: %v", err))
+		return
+	}
+
+	accessGroupEntry, err := utxoView.GetAccessGroupEntryWithAccessGroupId(accessGroupId)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadParticipantKeys: Problem getting access group entry: %v", err))
+		return
+	}
+	if accessGroupEntry == nil || accessGroupEntry.IsDeleted() {
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadParticipantKeys: Access group %v does not exist",
+			requestData.AccessGroupIdHex))
+		return
+	}
+
+	memberEntries, err := fes.fetchAllAccessGroupMemberEntries(
+		accessGroupId.AccessGroupOwnerPublicKey.ToBytes(), accessGroupId.AccessGroupKeyName.ToBytes(), utxoView)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadParticipantKeys: Problem getting access group members: %v", err))
+		return
+	}
+	fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		return fes.fetchMaxMessagesFromGroupChatThread(accessGroupId, startTimestamp, maxMessagesToFetch, utxoView)
+	}
+	participants, truncated, err := getThreadParticipantKeys(
+		memberEntries, fetchMessages, utxoView.GetAccessGroupEntry, fes.Params)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadParticipantKeys: %v", err))
+		return
+	}
+
+	res := GetThreadParticipantKeysResponse{
+		Participants: participants,
+		Truncated:    truncated,
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadParticipantKeys: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getThreadParticipantKeys returns the participants of a group chat with their current access group public keys: the
+// members in memberEntries, followed by the senders of the thread's messages who are no longer members. Messages are
+// paged through with fetchMessages as in getMessageTimestampsInWindow, up to MaxMessagesScannedForThreadParticipants
+// of them.
+func getThreadParticipantKeys(
+	memberEntries []*lib.AccessGroupMemberEntry,
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+	getAccessGroupEntry func(*lib.PublicKey, *lib.GroupKeyName) (*lib.AccessGroupEntry, error),
+	params *lib.DeSoParams,
+) (_participants []ThreadParticipantKey, _truncated bool, _err error) {
+	newParticipant := func(
+		ownerPk *lib.PublicKey, keyName *lib.GroupKeyName, isCurrentMember bool,
+	) (ThreadParticipantKey, error) {
+		participant := ThreadParticipantKey{
+			OwnerPublicKeyBase58Check: lib.PkToString(ownerPk.ToBytes(), params),
+			AccessGroupKeyName:        string(lib.MessagingKeyNameDecode(keyName)),
+			IsCurrentMember:           isCurrentMember,
+		}
+		// The base access group's public key is always the owner's own public key.
+		if lib.EqualGroupKeyName(keyName, lib.BaseGroupKeyName()) {
+			participant.AccessGroupPublicKeyBase58Check = participant.OwnerPublicKeyBase58Check
+			return participant, nil
+		}
+		accessGroupEntry, err := getAccessGroupEntry(ownerPk, keyName)
+		if err != nil {
+			return ThreadParticipantKey{}, errors.Wrapf(err, "Problem getting access group entry for participant: ")
+		}
+		if accessGroupEntry != nil && !accessGroupEntry.IsDeleted() && accessGroupEntry.AccessGroupPublicKey != nil {
+			participant.AccessGroupPublicKeyBase58Check = lib.PkToString(
+				accessGroupEntry.AccessGroupPublicKey.ToBytes(), params)
+		}
+		return participant, nil
+	}
+
+	seenParticipants := make(map[string]bool)
+	var members []ThreadParticipantKey
+	for _, memberEntry := range memberEntries {
+		seenParticipants[string(memberEntry.AccessGroupMemberPublicKey.ToBytes())] = true
+		member, err := newParticipant(memberEntry.AccessGroupMemberPublicKey, memberEntry.AccessGroupMemberKeyName, true)
+		if err != nil {
+			return nil, false, err
+		}
+		members = append(members, member)
+	}
+
+	// Messages are fetched newest first, so each past member is reported with the key name they last used.
+	var pastMembers []ThreadParticipantKey
+	messagesScanned := 0
+	pageStartTimestamp := uint64(math.MaxUint64)
+	for {
+		messages, err := fetchMessages(pageStartTimestamp, messageTimestampsPageSize)
+		if err != nil {
+			return nil, false, err
+		}
+		for _, message := range messages {
+			if messagesScanned == MaxMessagesScannedForThreadParticipants {
+				_truncated = true
+				break
+			}
+			messagesScanned++
+			senderPkBytes := message.SenderAccessGroupOwnerPublicKey.ToBytes()
+			if seenParticipants[string(senderPkBytes)] {
+				continue
+			}
+			seenParticipants[string(senderPkBytes)] = true
+			pastMember, err := newParticipant(
+				message.SenderAccessGroupOwnerPublicKey, message.SenderAccessGroupKeyName, false)
+			if err != nil {
+				return nil, false, err
+			}
+			pastMembers = append(pastMembers, pastMember)
+		}
+		if _truncated || len(messages) < messageTimestampsPageSize {
+			break
+		}
+		pageStartTimestamp = messages[len(messages)-1].TimestampNanos
+	}
+
+	for _, participants := range [][]ThreadParticipantKey{members, pastMembers} {
+		sort.Slice(participants, func(ii, jj int) bool {
+			return participants[ii].OwnerPublicKeyBase58Check < participants[jj].OwnerPublicKeyBase58Check
+		})
+	}
+	return append(append([]ThreadParticipantKey{}, members...), pastMembers...), _truncated, nil
+}
+
+// GetMessageThreadId identifies the thread a message belongs to, from the point of view of the user with ownerPkBytes.
+// A group chat is identified by EncodeAccessGroupIdToHex of the group, and a DM by EncodeAccessGroupIdToHex of the
+// user's access group followed by that of the other party's, so the two never collide.
+func GetMessageThreadId(ownerPkBytes []byte, message *lib.NewMessageEntry, chatType ChatType) string {
+	recipientAccessGroupId := &lib.AccessGroupId{
+		AccessGroupOwnerPublicKey: *message.RecipientAccessGroupOwnerPublicKey,
+		AccessGroupKeyName:        *message.RecipientAccessGroupKeyName,
+	}
+	if chatType == ChatTypeGroupChat {
+		return EncodeAccessGroupIdToHex(recipientAccessGroupId)
+	}
+	senderAccessGroupId := &lib.AccessGroupId{
+		AccessGroupOwnerPublicKey: *message.SenderAccessGroupOwnerPublicKey,
+		AccessGroupKeyName:        *message.SenderAccessGroupKeyName,
+	}
+	if bytes.Equal(message.SenderAccessGroupOwnerPublicKey.ToBytes(), ownerPkBytes) {
+		return EncodeAccessGroupIdToHex(senderAccessGroupId) + EncodeAccessGroupIdToHex(recipientAccessGroupId)
+	}
+	return EncodeAccessGroupIdToHex(recipientAccessGroupId) + EncodeAccessGroupIdToHex(senderAccessGroupId)
+}
+
+type GetDmContactsRequest struct {
+	UserPublicKeyBase58Check string `safeForLogging:"true"`
+	// Optional. A JWT for UserPublicKeyBase58Check. Only needed to have messages sent to the user decrypted by a node with
+	// message decryption enabled; without it, messages are returned encrypted.
+	JWT string
+}
+
+// DmContact is someone the user has a DM thread with.
+type DmContact struct {
+	PublicKeyBase58Check string `safeForLogging:"true"`
+	// Empty if the contact doesn't have a profile.
+	Username string `safeForLogging:"true"`
+
+	// The timestamp of the latest message between the user and the contact, across all of their DM threads.
+	LastInteractionTimestampNanos       uint64
+	LastInteractionTimestampNanosString string
+}
+
+type GetDmContactsResponse struct {
+	// Sorted by LastInteractionTimestampNanos, most recent first.
+	Contacts []DmContact
+}
+
+// GetDmContacts returns the distinct owner public keys the user has DM threads with, most recent first. This is
+// lighter than GetUserDmThreadsOrderedByTimestamp for clients that only need a contacts list.
+func (fes *APIServer) GetDmContacts(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetDmContactsRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDmContacts: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("UserPublicKeyBase58Check", requestData.UserPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDmContacts: %v", err))
+		return
+	}
+
+	ownerPkBytes, _, err := lib.Base58CheckDecode(requestData.UserPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetDmContacts: Problem decoding owner "+
+			"base58 public key %s: %v", requestData.UserPublicKeyBase58Check, err))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetDmContacts")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDmContacts: Error generating utxo view: %v", err))
+		return
+	}
+
+	dmThreads, err := utxoView.GetAllUserDmThreads(*lib.NewPublicKey(ownerPkBytes))
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDmContacts: Problem getting dm threads: %v", err))
+		return
+	}
+	latestDmMessages, err := fes.fetchLatestMessageFromDmThreads(dmThreads, utxoView)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDmContacts: Problem getting latest dm messages: %v", err))
+		return
+	}
+	latestDmMessageResponses := []NewMessageEntryResponse{}
+	for _, latestDmMessage := range latestDmMessages {
+		latestDmMessageResponses = append(latestDmMessageResponses,
+			fes.NewMessageEntryToResponse(latestDmMessage, ChatTypeDM, utxoView))
+	}
+	decryptMessages(fes.getMessageDecryptionKeysForUser(requestData.UserPublicKeyBase58Check, requestData.JWT),
+		latestDmMessageResponses)
+
+	contacts := getDmContacts(lib.PkToString(ownerPkBytes, fes.Params), latestDmMessageResponses,
+		func(publicKeyBase58Check string) string {
+			publicKeyBytes, _, err := lib.Base58CheckDecode(publicKeyBase58Check)
+			if err != nil {
+				return ""
+			}
+			profileEntry := utxoView.GetProfileEntryForPublicKey(publicKeyBytes)
+			if profileEntry == nil || profileEntry.IsDeleted() {
+				return ""
+			}
+			return string(profileEntry.Username)
+		})
+	if err = json.NewEncoder(ww).Encode(GetDmContactsResponse{Contacts: contacts}); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetDmContacts: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// getDmContacts collapses the latest message of each of the user's DM threads into one contact per counterparty,
+// most recent first. getUsername returns the username for a public key, or an empty string if it has no profile.
+func getDmContacts(
+	userPublicKeyBase58Check string,
+	latestDmMessages []NewMessageEntryResponse,
+	getUsername func(publicKeyBase58Check string) string,
+) []DmContact {
+	sortedMessages := append([]NewMessageEntryResponse{}, latestDmMessages...)
+	sort.Slice(sortedMessages, func(ii, jj int) bool {
+		return sortedMessages[ii].MessageInfo.TimestampNanos > sortedMessages[jj].MessageInfo.TimestampNanos
+	})
+	_, dmConversations := GroupDmThreadsByCounterparty(userPublicKeyBase58Check, sortedMessages)
+
+	contacts := []DmContact{}
+	for _, dmConversation := range dmConversations {
+		timestampNanos := dmConversation.LatestMessage.MessageInfo.TimestampNanos
+		contacts = append(contacts, DmContact{
+			PublicKeyBase58Check:                dmConversation.CounterpartyPublicKeyBase58Check,
+			Username:                            getUsername(dmConversation.CounterpartyPublicKeyBase58Check),
+			LastInteractionTimestampNanos:       timestampNanos,
+			LastInteractionTimestampNanosString: strconv.FormatUint(timestampNanos, 10),
+		})
+	}
+	return contacts
+}
+
+const (
+	// MaxRecentMessageSendersWindowNanos caps how far back GetRecentMessageSenders looks. It's also the window used
+	// when the request doesn't set one.
+	MaxRecentMessageSendersWindowNanos = uint64(7 * 24 * time.Hour)
+	// MaxRecentMessageSenders caps how many senders GetRecentMessageSenders returns. It's also the default.
+	MaxRecentMessageSenders = 100
+	// MaxMessagesScannedPerThreadForRecentSenders bounds how many messages in the window we scan in each thread.
+	MaxMessagesScannedPerThreadForRecentSenders = 1000
+)
+
+type GetRecentMessageSendersRequest struct {
+	// The recipient whose DM and group chat threads are checked.
+	UserPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// Only messages sent at or after this time are counted. If unset, the last MaxRecentMessageSendersWindowNanos
+	// are used. We support passing the timestamp as string and uint64. uint64 can lose precision when being JSON
+	// decoded, so we prefer SinceTimestampNanosString.
+	SinceTimestampNanos       uint64 `safeForLogging:"true"`
+	SinceTimestampNanosString string `safeForLogging:"true"`
+
+	// If unset, defaults to MaxRecentMessageSenders.
+	MaxSenders int `safeForLogging:"true"`
+}
+
+// RecentMessageSender is someone who messaged the user, in a DM or a group chat they're in.
+type RecentMessageSender struct {
+	PublicKeyBase58Check string `safeForLogging:"true"`
+	// Empty if the sender doesn't have a profile.
+	Username string `safeForLogging:"true"`
+
+	// The timestamp of the sender's latest message to the user in the window.
+	LastMessageTimestampNanos       uint64
+	LastMessageTimestampNanosString string
+}
+
+type GetRecentMessageSendersResponse struct {
+	// The distinct senders, most recent first, up to MaxSenders of them.
+	Senders []RecentMessageSender
+	// The number of distinct senders in the window, including those left out of Senders.
+	NumSenders int
+}
+
+// GetRecentMessageSenders returns the distinct people who messaged the user within a recent window, most recent
+// first. This is meant for notifications like "you have messages from Alice, Bob, and 3 others".
+func (fes *APIServer) GetRecentMessageSenders(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetRecentMessageSendersRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetRecentMessageSenders: Problem parsing request body: %v", err))
+		return
+	}
+
+	if err := ValidateNotDESOIdentifier("UserPublicKeyBase58Check", requestData.UserPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetRecentMessageSenders: %v", err))
+		return
+	}
+	ownerPkBytes, _, err := lib.Base58CheckDecode(requestData.UserPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetRecentMessageSenders: Problem decoding owner "+
+			"base58 public key %s: %v", requestData.UserPublicKeyBase58Check, err))
+		return
+	}
+
+	currentTimestampNanos := uint64(time.Now().UnixNano())
+	sinceTimestampNanos := requestData.SinceTimestampNanos
+	if requestData.SinceTimestampNanosString != "" {
+		sinceTimestampNanos, err = strconv.ParseUint(requestData.SinceTimestampNanosString, 10, 64)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("GetRecentMessageSenders: Error parsing "+
+				"SinceTimestampNanosString: %v", err))
+			return
+		}
+	}
+	if sinceTimestampNanos == 0 {
+		sinceTimestampNanos = currentTimestampNanos - MaxRecentMessageSendersWindowNanos
+	}
+	if sinceTimestampNanos < currentTimestampNanos-MaxRecentMessageSendersWindowNanos {
+		_AddBadRequestError(ww, fmt.Sprintf("GetRecentMessageSenders: SinceTimestampNanos %v is further back than "+
+			"the maximum window of %v nanos", sinceTimestampNanos, MaxRecentMessageSendersWindowNanos))
+		return
+	}
+
+	maxSenders := requestData.MaxSenders
+	if maxSenders == 0 {
+		maxSenders = MaxRecentMessageSenders
+	}
+	if maxSenders < 0 || maxSenders > MaxRecentMessageSenders {
+		_AddBadRequestError(ww, fmt.Sprintf("GetRecentMessageSenders: MaxSenders must be between 1 and %v, got %v",
+			MaxRecentMessageSenders, maxSenders))
+		return
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetRecentMessageSenders")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetRecentMessageSenders: Error generating utxo view: %v", err))
+		return
+	}
+
+	// Collect every thread's inbound messages in the window.
+	var threadFetchers []func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error)
+	dmThreads, err := utxoView.GetAllUserDmThreads(*lib.NewPublicKey(ownerPkBytes))
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetRecentMessageSenders: Problem getting dm threads: %v", err))
+		return
+	}
+	for _, dmThread := range dmThreads {
+		dmThread := dmThread
+		threadFetchers = append(threadFetchers, func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			return fes.fetchMaxMessagesFromDmThread(dmThread, startTimestamp, maxMessagesToFetch, utxoView)
+		})
+	}
+	groupChatThreads, err := utxoView.GetAllUserGroupChatThreads(*lib.NewPublicKey(ownerPkBytes))
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetRecentMessageSenders: Problem getting group chat threads: %v", err))
+		return
+	}
+	for _, groupChatThread := range groupChatThreads {
+		groupChatThread := groupChatThread
+		threadFetchers = append(threadFetchers, func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			return fes.fetchMaxMessagesFromGroupChatThread(groupChatThread, startTimestamp, maxMessagesToFetch, utxoView)
+		})
+	}
+	var inboundMessages []*lib.NewMessageEntry
+	for _, fetchMessages := range threadFetchers {
+		threadMessages, err := getInboundMessagesInWindow(
+			ownerPkBytes, fetchMessages, currentTimestampNanos, sinceTimestampNanos)
+		if err != nil {
+			_AddInternalServerError(ww, fmt.Sprintf("GetRecentMessageSenders: Problem getting messages: %v", err))
+			return
+		}
+		inboundMessages = append(inboundMessages, threadMessages...)
+	}
 
+	senders, numSenders := getRecentMessageSenders(inboundMessages, maxSenders, fes.Params,
+		func(publicKeyBytes []byte) string {
+			profileEntry := utxoView.GetProfileEntryForPublicKey(publicKeyBytes)
+			if profileEntry == nil || profileEntry.IsDeleted() {
+				return ""
+			}
+			return string(profileEntry.Username)
+		})
+	res := GetRecentMessageSendersResponse{
+		Senders:    senders,
+		NumSenders: numSenders,
+	}
 	if err = json.NewEncoder(ww).Encode(res); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Problem encoding response as JSON: %v", err))
+		_AddInternalServerError(ww, fmt.Sprintf("GetRecentMessageSenders: Problem encoding response as JSON: %v", err))
 		return
 	}
+}
 
+// getInboundMessagesInWindow pages backwards through a thread from startTimestamp using fetchMessages, as in
+// getMessageTimestampsInWindow, and returns the messages sent at or after endTimestamp by anyone other than the owner.
+// At most MaxMessagesScannedPerThreadForRecentSenders messages are scanned.
+func getInboundMessagesInWindow(
+	ownerPkBytes []byte,
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+	startTimestamp uint64,
+	endTimestamp uint64,
+) ([]*lib.NewMessageEntry, error) {
+	var inboundMessages []*lib.NewMessageEntry
+	messagesScanned := 0
+	pageStartTimestamp := startTimestamp
+	for {
+		messages, err := fetchMessages(pageStartTimestamp, messageTimestampsPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			if message.TimestampNanos < endTimestamp || messagesScanned == MaxMessagesScannedPerThreadForRecentSenders {
+				return inboundMessages, nil
+			}
+			messagesScanned++
+			if !bytes.Equal(message.SenderAccessGroupOwnerPublicKey.ToBytes(), ownerPkBytes) {
+				inboundMessages = append(inboundMessages, message)
+			}
+		}
+		if len(messages) < messageTimestampsPageSize {
+			return inboundMessages, nil
+		}
+		pageStartTimestamp = messages[len(messages)-1].TimestampNanos
+	}
 }
 
-// Similar to GetUserDmThreadsOrderedByTimestamp, expect that it fetches the group chat threads instead of direct messages.
-// Need to call lib.GetAllUserGroupChatThreads from the core library.
-// Just need the public key of the user in the request data.
-// Returns the group chat threads along with their latest messages.
-// The group chats are sorted/ordered by the time stamp of their latest message.
+// getRecentMessageSenders returns the distinct senders of inboundMessages ordered by their latest message, most recent
+// first, up to maxSenders of them, along with the total number of distinct senders. getUsername returns the username
+// for a public key, or an empty string if it has no profile. It's only called for the senders that are returned.
+func getRecentMessageSenders(
+	inboundMessages []*lib.NewMessageEntry,
+	maxSenders int,
+	params *lib.DeSoParams,
+	getUsername func(publicKeyBytes []byte) string,
+) (_senders []RecentMessageSender, _numSenders int) {
+	senderToLatestMessage := make(map[string]*lib.NewMessageEntry)
+	for _, message := range inboundMessages {
+		senderPkBytes := message.SenderAccessGroupOwnerPublicKey.ToBytes()
+		latestMessage, exists := senderToLatestMessage[string(senderPkBytes)]
+		if !exists || message.TimestampNanos > latestMessage.TimestampNanos {
+			senderToLatestMessage[string(senderPkBytes)] = message
+		}
+	}
+	latestMessages := make([]*lib.NewMessageEntry, 0, len(senderToLatestMessage))
+	for _, message := range senderToLatestMessage {
+		latestMessages = append(latestMessages, message)
+	}
+	// Ties are broken by public key so the order is deterministic.
+	sort.Slice(latestMessages, func(ii, jj int) bool {
+		if latestMessages[ii].TimestampNanos != latestMessages[jj].TimestampNanos {
+			return latestMessages[ii].TimestampNanos > latestMessages[jj].TimestampNanos
+		}
+		return bytes.Compare(latestMessages[ii].SenderAccessGroupOwnerPublicKey.ToBytes(),
+			latestMessages[jj].SenderAccessGroupOwnerPublicKey.ToBytes()) < 0
+	})
 
-// This API just doesn't write any data, hence it doesn't create a new transaction.
-// It's a public API, hence anyone with a valid public key can query the system to fetch their Direct message threads.
-func (fes *APIServer) GetUserGroupChatThreadsOrderedByTimestamp(ww http.ResponseWriter, req *http.Request) {
-	if err := fes.getUserMessageThreadsHandler(ww, req, true, false); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetUserGroupChatThreadsOrderedByTimestamp: %v", err))
-		return
+	senders := []RecentMessageSender{}
+	for _, message := range latestMessages {
+		if len(senders) == maxSenders {
+			break
+		}
+		senderPkBytes := message.SenderAccessGroupOwnerPublicKey.ToBytes()
+		senders = append(senders, RecentMessageSender{
+			PublicKeyBase58Check:            lib.PkToString(senderPkBytes, params),
+			Username:                        getUsername(senderPkBytes),
+			LastMessageTimestampNanos:       message.TimestampNanos,
+			LastMessageTimestampNanosString: strconv.FormatUint(message.TimestampNanos, 10),
+		})
 	}
+	return senders, len(latestMessages)
 }
 
-// types and functions to Fetch messages from the group chat thread of a user
-type GetPaginatedMessagesForGroupChatThreadRequest struct {
-	// Need the member/owner public key and the access group key name of the group they belong
-	// to fetch the group chat messages.
-	UserPublicKeyBase58Check string
-	AccessGroupKeyName       string
+// MaxMessagesScannedForNewMessages caps how many messages GetNewMessagesForThread reads back from the newest one
+// before giving up on reaching AfterTimestampNanos. Clients that fall further behind than this should resync with
+// the paginated message endpoints.
+const MaxMessagesScannedForNewMessages = 10000
 
-	// We support passing start timestamp as string and uint64.
-	// uint64 can lose precision when being JSON decoded, so we prefer StartTimestampString.
-	StartTimestamp       uint64
-	StartTimestampString string
-	MaxMessagesToFetch   int
-}
+type GetNewMessagesForThreadRequest struct {
+	MessageThreadSpec
 
-type GetPaginatedMessagesForGroupChatThreadResponse struct {
-	GroupChatMessages               []NewMessageEntryResponse
-	PublicKeyToProfileEntryResponse map[string]*ProfileEntryResponse
+	// Only messages with a timestamp strictly greater than this are returned. uint64 can lose precision when being
+	// JSON decoded, so we prefer AfterTimestampNanosString.
+	AfterTimestampNanos       uint64 `safeForLogging:"true"`
+	AfterTimestampNanosString string `safeForLogging:"true"`
+	// If omitted, the node's DefaultMaxMessagesToFetch is used.
+	MaxMessagesToFetch int `safeForLogging:"true"`
+	// Optional. A JWT for UserGroupOwnerPublicKeyBase58Check. Only needed to have messages sent to the user decrypted
+	// by a node with message decryption enabled; without it, messages are returned encrypted.
+	JWT string
 }
 
-// Similar to GetPaginatedMessagesForDmThread API, but fetches messages from a group chat instead.
+type GetNewMessagesForThreadResponse struct {
+	// Oldest first. The timestamp of the last message is the AfterTimestampNanos of the next poll.
+	Messages []NewMessageEntryResponse
+	// True if there are more new messages than MaxMessagesToFetch. The ones left out are all newer than the ones
+	// returned.
+	HasMore bool `safeForLogging:"true"`
+}
 
-// This API just doesn't write any data, hence it doesn't create a new transaction.
-// It's a public API, hence anyone with a valid public key can query the system to fetch their Direct message threads.
-func (fes *APIServer) GetPaginatedMessagesForGroupChatThread(ww http.ResponseWriter, req *http.Request) {
+// GetNewMessagesForThread returns the messages in a thread that are newer than a cursor, oldest first. Unlike the
+// paginated message endpoints, which page backwards from a timestamp, it's meant for clients polling a thread for
+// what's arrived since they last looked.
+func (fes *APIServer) GetNewMessagesForThread(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
-	requestData := GetPaginatedMessagesForGroupChatThreadRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Problem parsing request body: %v", err))
-		return
-	}
-
-	// Why fetch if there's less than one message to fetch!!!!!
-	if requestData.MaxMessagesToFetch < 1 {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: MaxMessagesToFetch cannot be less than 1: %v", requestData.MaxMessagesToFetch))
+	requestData := GetNewMessagesForThreadRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetNewMessagesForThread: Problem parsing request body: %v", err))
 		return
 	}
 
-	// Basic validation of the sender public key and access group name.
-	accessGroupOwnerPkBytes, AccessGroupKeyNameBytes, err :=
-		ValidateAccessGroupPublicKeyAndName(requestData.UserPublicKeyBase58Check, requestData.AccessGroupKeyName)
-	// Decode the access group owner public key.
+	maxMessagesToFetch, err := fes.getMaxMessagesToFetch(requestData.MaxMessagesToFetch)
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Problem validating "+
-			"user group owner public key and access group name %s: %s %v",
-			requestData.UserPublicKeyBase58Check, requestData.AccessGroupKeyName, err))
+		_AddBadRequestError(ww, fmt.Sprintf("GetNewMessagesForThread: %v", err))
 		return
 	}
 
-	startTimestamp := requestData.StartTimestamp
-	if requestData.StartTimestampString != "" {
-		startTimestamp, err = strconv.ParseUint(requestData.StartTimestampString, 10, 64)
+	afterTimestampNanos := requestData.AfterTimestampNanos
+	if requestData.AfterTimestampNanosString != "" {
+		afterTimestampNanos, err = strconv.ParseUint(requestData.AfterTimestampNanosString, 10, 64)
 		if err != nil {
-			_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForDmThread: Error parsing "+
-				"StartTimestampString: %v", err))
+			_AddBadRequestError(ww, fmt.Sprintf("GetNewMessagesForThread: Error parsing "+
+				"AfterTimestampNanosString: %v", err))
 			return
 		}
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetNewMessagesForThread")
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Error generating "+
-			"utxo view: %v", err))
+		_AddInternalServerError(ww, fmt.Sprintf("GetNewMessagesForThread: Error generating utxo view: %v", err))
 		return
 	}
 
-	// The public of the member of the group and their access key
-	// have to represented using the lib.AccessGroupId type.
-	accessGroupId := lib.AccessGroupId{
-		AccessGroupOwnerPublicKey: *lib.NewPublicKey(accessGroupOwnerPkBytes),
-		AccessGroupKeyName:        *lib.NewGroupKeyName(AccessGroupKeyNameBytes),
+	fetchMessages, err := fes.getMessageFetcherForThread(&requestData.MessageThreadSpec, utxoView)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetNewMessagesForThread: %v", err))
+		return
 	}
 
-	// Fetch the max group chat messages from the access group.
-	groupChatMessages, err := fes.fetchMaxMessagesFromGroupChatThread(&accessGroupId, startTimestamp, requestData.MaxMessagesToFetch, utxoView)
+	newMessages, hasMore, err := fetchMessagesAfterTimestamp(fetchMessages, afterTimestampNanos, maxMessagesToFetch)
 	if err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Problem getting paginated messages for "+
-			"Request Data: %v: %v", requestData, err))
+		_AddBadRequestError(ww, fmt.Sprintf("GetNewMessagesForThread: %v", err))
 		return
 	}
 
-	// group chat threads with each group chat represented by GroupChatThread.
-	// Each entry consists of the sender account, recipient account info and the latest message.
-	messages := []NewMessageEntryResponse{}
-	publicKeyToProfileEntryResponseMap := make(map[string]*ProfileEntryResponse)
+	res := GetNewMessagesForThreadResponse{
+		Messages: []NewMessageEntryResponse{},
+		HasMore:  hasMore,
+	}
+	for _, message := range newMessages {
+		res.Messages = append(res.Messages, fes.NewMessageEntryToResponse(message, requestData.ChatType, utxoView))
+	}
+	decryptMessages(fes.getMessageDecryptionKeysForUser(
+		requestData.UserGroupOwnerPublicKeyBase58Check, requestData.JWT), res.Messages)
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetNewMessagesForThread: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
 
-	for _, threadMsg := range groupChatMessages {
-		message := fes.NewMessageEntryToResponse(threadMsg, ChatTypeGroupChat, utxoView)
-		messages = append(messages, message)
-		// Add the sender's profile to the response.
-		senderPublicKeyBase58Check := message.SenderInfo.OwnerPublicKeyBase58Check
-		if _, ok := publicKeyToProfileEntryResponseMap[senderPublicKeyBase58Check]; !ok {
-			publicKeyToProfileEntryResponseMap[senderPublicKeyBase58Check] = fes.GetProfileEntryResponseForPublicKeyBytes(
-				threadMsg.SenderAccessGroupOwnerPublicKey.ToBytes(), utxoView)
+// fetchMessagesAfterTimestamp returns the up to maxMessagesToFetch oldest messages in a thread with a timestamp
+// strictly greater than afterTimestampNanos, oldest first, and whether there are newer ones left out. fetchMessages
+// only pages backwards, so the thread is read from its newest message down to afterTimestampNanos, scanning at most
+// MaxMessagesScannedForNewMessages messages.
+func fetchMessagesAfterTimestamp(
+	fetchMessages func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error),
+	afterTimestampNanos uint64,
+	maxMessagesToFetch int,
+) (_messages []*lib.NewMessageEntry, _hasMore bool, _err error) {
+	// Newest first until reversed below.
+	var newMessages []*lib.NewMessageEntry
+	pageStartTimestamp := uint64(math.MaxUint64)
+	reachedCursor := false
+	for !reachedCursor {
+		messages, err := fetchMessages(pageStartTimestamp, messageTimestampsPageSize)
+		if err != nil {
+			return nil, false, err
 		}
-
-		// Add the recipient's profile to the response.
-		if _, ok := publicKeyToProfileEntryResponseMap[message.RecipientInfo.OwnerPublicKeyBase58Check]; !ok {
-			publicKeyToProfileEntryResponseMap[message.RecipientInfo.OwnerPublicKeyBase58Check] = fes.GetProfileEntryResponseForPublicKeyBytes(
-				threadMsg.RecipientAccessGroupOwnerPublicKey.ToBytes(), utxoView)
+		for _, message := range messages {
+			if message.TimestampNanos <= afterTimestampNanos {
+				reachedCursor = true
+				break
+			}
+			if len(newMessages) == MaxMessagesScannedForNewMessages {
+				return nil, false, errors.Errorf("More than %v messages are newer than AfterTimestampNanos %v. "+
+					"Use the paginated message endpoints to catch up", MaxMessagesScannedForNewMessages, afterTimestampNanos)
+			}
+			newMessages = append(newMessages, message)
+		}
+		if len(messages) < messageTimestampsPageSize {
+			break
 		}
+		pageStartTimestamp = messages[len(messages)-1].TimestampNanos
 	}
 
-	// response containing group chat messages from the given access group ID of a public key.
-	res := GetPaginatedMessagesForGroupChatThreadResponse{
-		GroupChatMessages:               messages,
-		PublicKeyToProfileEntryResponse: publicKeyToProfileEntryResponseMap,
+	// Keep the oldest maxMessagesToFetch, which are at the end.
+	hasMore := len(newMessages) > maxMessagesToFetch
+	if hasMore {
+		newMessages = newMessages[len(newMessages)-maxMessagesToFetch:]
 	}
-
-	if err = json.NewEncoder(ww).Encode(res); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetPaginatedMessagesForGroupChatThread: Problem encoding response as JSON: %v", err))
-		return
+	for ii, jj := 0, len(newMessages)-1; ii < jj; ii, jj = ii+1, jj-1 {
+		newMessages[ii], newMessages[jj] = newMessages[jj], newMessages[ii]
 	}
+	return newMessages, hasMore, nil
 }
 
-// aggregate threads from both direct messages and group chat messages.
-type GetUserMessageThreadsRequest struct {
-	// PublicKeyBase58Check is the public key whose group IDs needs to be queried.
-	UserPublicKeyBase58Check string `safeForLogging:"true"`
-}
+// MaxMessagesCheckedForStatusCounts caps how many of a thread's most recent messages GetThreadMessageStatusCounts
+// checks against the mempool.
+const MaxMessagesCheckedForStatusCounts = 100
 
-type GetUserMessageThreadsResponse struct {
-	MessageThreads []NewMessageEntryResponse
+type GetThreadMessageStatusCountsRequest struct {
+	MessageThreadSpec
 
-	PublicKeyToProfileEntryResponse map[string]*ProfileEntryResponse
+	// How many of the thread's most recent messages to check. If omitted or greater than
+	// MaxMessagesCheckedForStatusCounts, MaxMessagesCheckedForStatusCounts is used.
+	MaxMessagesToCheck int `safeForLogging:"true"`
 }
 
-// This API just doesn't write any data, hence it doesn't create a new transaction.
-// It's a public API, hence anyone with a valid public key can query the system to fetch their Direct message threads.
-func (fes *APIServer) GetAllUserMessageThreads(ww http.ResponseWriter, req *http.Request) {
-	if err := fes.getUserMessageThreadsHandler(ww, req, true, true); err != nil {
-		_AddBadRequestError(ww, fmt.Sprintf("GetAllUserMessageThreads: %v", err))
-		return
-	}
+type GetThreadMessageStatusCountsResponse struct {
+	// Of the messages checked, how many have been mined and how many are still in the mempool.
+	ConfirmedCount uint64 `safeForLogging:"true"`
+	PendingCount   uint64 `safeForLogging:"true"`
 }
 
-func (fes *APIServer) getUserMessageThreadsHandler(ww http.ResponseWriter, req *http.Request, getGroupChats bool, getDMs bool) error {
+// GetThreadMessageStatusCounts counts how many of a thread's most recent messages have been mined and how many are
+// still waiting in the mempool, so clients can show the delivery status of messages they've just sent.
+func (fes *APIServer) GetThreadMessageStatusCounts(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
-	requestData := GetUserMessageThreadsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
-		return errors.Wrapf(err, "Problem parsing request body: ")
+	requestData := GetThreadMessageStatusCountsRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadMessageStatusCounts: Problem parsing request body: %v", err))
+		return
 	}
 
-	// Decode the access group owner public key.
-	accessGroupOwnerPkBytes, _, err := lib.Base58CheckDecode(requestData.UserPublicKeyBase58Check)
+	if requestData.MaxMessagesToCheck < 0 {
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadMessageStatusCounts: MaxMessagesToCheck must be non-negative, "+
+			"got %v", requestData.MaxMessagesToCheck))
+		return
+	}
+	maxMessagesToCheck := requestData.MaxMessagesToCheck
+	if maxMessagesToCheck == 0 || maxMessagesToCheck > MaxMessagesCheckedForStatusCounts {
+		maxMessagesToCheck = MaxMessagesCheckedForStatusCounts
+	}
+
+	utxoView, err := fes.getAugmentedUniversalView("GetThreadMessageStatusCounts")
 	if err != nil {
-		return errors.Wrapf(err, fmt.Sprintf("Problem decoding owner"+
-			"base58 public key %s: ", requestData.UserPublicKeyBase58Check))
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadMessageStatusCounts: Error generating utxo view: %v", err))
+		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	fetchMessages, err := fes.getMessageFetcherForThread(&requestData.MessageThreadSpec, utxoView)
 	if err != nil {
-		return errors.Wrapf(err, "Error generating "+
-			"utxo view: ")
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadMessageStatusCounts: %v", err))
+		return
 	}
 
-	var messageThreads []NewMessageEntryResponse
-	if getDMs {
-		// get all the direct message threads associated with the public key.
-		dmThreads, err := utxoView.GetAllUserDmThreads(*lib.NewPublicKey(accessGroupOwnerPkBytes))
-		if err != nil {
-			return errors.Wrapf(err, fmt.Sprintf("Problem getting access group IDs of"+
-				"public key %s: ", requestData.UserPublicKeyBase58Check))
-		}
+	messages, err := fetchMessages(math.MaxUint64, maxMessagesToCheck)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetThreadMessageStatusCounts: Problem fetching messages: %v", err))
+		return
+	}
 
-		// fetch the latest message for each of the dmThread.
-		latestMessagesForThreadKeys, err := fes.fetchLatestMessageFromDmThreads(dmThreads, utxoView)
-		if err != nil {
-			return errors.Wrapf(err, fmt.Sprintf("Problem getting access group IDs of"+
-				"public key %s: ", requestData.UserPublicKeyBase58Check))
-		}
+	pendingMessageKeys, err := fes.getPendingMessageKeysForPage("GetThreadMessageStatusCounts", messages,
+		func(committedUtxoView *lib.UtxoView, startTimestamp uint64, maxMessagesToFetch int) (
+			[]*lib.NewMessageEntry, error) {
+			fetchCommittedMessages, err := fes.getMessageFetcherForThread(&requestData.MessageThreadSpec, committedUtxoView)
+			if err != nil {
+				return nil, err
+			}
+			return fetchCommittedMessages(startTimestamp, maxMessagesToFetch)
+		})
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadMessageStatusCounts: %v", err))
+		return
+	}
 
-		for _, threadMsg := range latestMessagesForThreadKeys {
-			messageThreads = append(messageThreads,
-				fes.NewMessageEntryToResponse(threadMsg, ChatTypeDM, utxoView))
-		}
+	res := GetThreadMessageStatusCountsResponse{}
+	res.ConfirmedCount, res.PendingCount = countMessagesByStatus(messages, pendingMessageKeys, fes.Params)
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetThreadMessageStatusCounts: Problem encoding response as JSON: %v",
+			err))
+		return
 	}
+}
 
-	if getGroupChats {
-		// get all the group chat threads for the public key.
-		groupChatThreads, err := utxoView.GetAllUserGroupChatThreads(*lib.NewPublicKey(accessGroupOwnerPkBytes))
-		if err != nil {
-			return errors.Wrapf(err, fmt.Sprintf("Problem getting access group IDs of"+
-				"public key %s: ", requestData.UserPublicKeyBase58Check))
+// countMessagesByStatus splits messages into those that have been mined and those still in the mempool, as found by
+// getPendingMessageKeys.
+func countMessagesByStatus(
+	messages []*lib.NewMessageEntry,
+	pendingMessageKeys map[pendingMessageKey]struct{},
+	params *lib.DeSoParams,
+) (_confirmedCount uint64, _pendingCount uint64) {
+	var confirmedCount, pendingCount uint64
+	for _, message := range messages {
+		if message == nil || message.SenderAccessGroupOwnerPublicKey == nil {
+			continue
 		}
-		// get the latest message for each group chat thread.
-		latestMessagesForGroupChats, err := fes.fetchLatestMessageFromGroupChatThreads(groupChatThreads, utxoView)
-		if err != nil {
-			return errors.Wrapf(err, fmt.Sprintf("Problem getting access group IDs of"+
-				"public key %s: ", requestData.UserPublicKeyBase58Check))
+		if _, isPending := pendingMessageKeys[pendingMessageKey{
+			SenderAccessGroupOwnerPublicKeyBase58Check: lib.PkToString(
+				message.SenderAccessGroupOwnerPublicKey.ToBytes(), params),
+			TimestampNanos: message.TimestampNanos,
+		}]; isPending {
+			pendingCount++
+		} else {
+			confirmedCount++
 		}
+	}
+	return confirmedCount, pendingCount
+}
 
-		// Add direct messages into MessageThread type.
-		for _, threadMsg := range latestMessagesForGroupChats {
-			messageThreads = append(messageThreads, fes.NewMessageEntryToResponse(threadMsg, ChatTypeGroupChat, utxoView))
-		}
+type GetMessagesAcrossAllThreadsRequest struct {
+	UserPublicKeyBase58Check string `safeForLogging:"true"`
+	// If omitted, the node's DefaultMaxMessagesToFetch is used.
+	MaxMessagesToFetch int `safeForLogging:"true"`
+	// Optional. A JWT for UserPublicKeyBase58Check. Only needed to have messages sent to the user decrypted by a node with
+	// message decryption enabled; without it, messages are returned encrypted.
+	JWT string
+}
+
+// ThreadMessageResponse is a message along with the GetMessageThreadId of the thread it was sent in.
+type ThreadMessageResponse struct {
+	ThreadId string
+	Message  NewMessageEntryResponse
+}
+
+type GetMessagesAcrossAllThreadsResponse struct {
+	// The most recent messages across all of the user's threads, newest first.
+	Messages []ThreadMessageResponse
+
+	PublicKeyToProfileEntryResponse map[string]*ProfileEntryResponse
+}
+
+// GetMessagesAcrossAllThreads returns the most recent messages across every DM and group chat thread a user is in,
+// merged into a single list, for an "all messages" inbox. Unlike GetAllUserMessageThreads, a thread can contribute
+// more than its latest message.
+func (fes *APIServer) GetMessagesAcrossAllThreads(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetMessagesAcrossAllThreadsRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessagesAcrossAllThreads: Problem parsing request body: %v", err))
+		return
 	}
 
-	// Sorting Group chats and Dms by timestamp of their latest messages.
-	sort.Slice(messageThreads, func(i, j int) bool {
-		return messageThreads[i].MessageInfo.TimestampNanos > messageThreads[j].MessageInfo.TimestampNanos
-	})
+	if err := ValidateNotDESOIdentifier("UserPublicKeyBase58Check", requestData.UserPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessagesAcrossAllThreads: %v", err))
+		return
+	}
+	userPkBytes, _, err := lib.Base58CheckDecode(requestData.UserPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessagesAcrossAllThreads: Problem decoding user "+
+			"base58 public key %s: %v", requestData.UserPublicKeyBase58Check, err))
+		return
+	}
 
-	publicKeyToProfileEntryResponseMap := make(map[string]*ProfileEntryResponse)
+	maxMessagesToFetch, err := fes.getMaxMessagesToFetch(requestData.MaxMessagesToFetch)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetMessagesAcrossAllThreads: %v", err))
+		return
+	}
 
-	for _, message := range messageThreads {
-		// Get Sender Profile.
-		if _, ok := publicKeyToProfileEntryResponseMap[message.SenderInfo.OwnerPublicKeyBase58Check]; !ok {
-			profileEntryResponse, err := fes.GetProfileEntryResponseForPublicKeyBase58Check(message.SenderInfo.OwnerPublicKeyBase58Check, utxoView)
-			if err != nil {
-				return errors.Wrapf(err, "GetUserMessageThreads: ")
-			}
-			publicKeyToProfileEntryResponseMap[message.SenderInfo.OwnerPublicKeyBase58Check] = profileEntryResponse
-		}
+	utxoView, err := fes.getAugmentedUniversalView("GetMessagesAcrossAllThreads")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessagesAcrossAllThreads: Error generating utxo view: %v", err))
+		return
+	}
 
-		if _, ok := publicKeyToProfileEntryResponseMap[message.RecipientInfo.OwnerPublicKeyBase58Check]; !ok {
-			profileEntryResponse, err := fes.GetProfileEntryResponseForPublicKeyBase58Check(message.RecipientInfo.OwnerPublicKeyBase58Check, utxoView)
+	messageThreads, err := fes.getUserMessageThreadFetchers(userPkBytes, utxoView)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessagesAcrossAllThreads: %v", err))
+		return
+	}
+
+	mergedMessages, err := mergeRecentMessagesAcrossThreads(messageThreads, maxMessagesToFetch)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessagesAcrossAllThreads: %v", err))
+		return
+	}
+
+	res := GetMessagesAcrossAllThreadsResponse{
+		Messages:                        []ThreadMessageResponse{},
+		PublicKeyToProfileEntryResponse: make(map[string]*ProfileEntryResponse),
+	}
+	messageDecryptionKeys := fes.getMessageDecryptionKeysForUser(requestData.UserPublicKeyBase58Check, requestData.JWT)
+	for _, mergedMessage := range mergedMessages {
+		message := fes.NewMessageEntryToResponse(mergedMessage.message, mergedMessage.chatType, utxoView)
+		message.MessageInfo.DecryptedText = decryptMessageText(messageDecryptionKeys, &message)
+		res.Messages = append(res.Messages, ThreadMessageResponse{
+			ThreadId: GetMessageThreadId(userPkBytes, mergedMessage.message, mergedMessage.chatType),
+			Message:  message,
+		})
+		for _, publicKeyBase58Check := range []string{
+			message.SenderInfo.OwnerPublicKeyBase58Check, message.RecipientInfo.OwnerPublicKeyBase58Check,
+		} {
+			if _, exists := res.PublicKeyToProfileEntryResponse[publicKeyBase58Check]; exists {
+				continue
+			}
+			res.PublicKeyToProfileEntryResponse[publicKeyBase58Check], err =
+				fes.GetProfileEntryResponseForPublicKeyBase58Check(publicKeyBase58Check, utxoView)
 			if err != nil {
-				return errors.Wrapf(err, "GetUserMessageThreads: ")
+				_AddInternalServerError(ww, fmt.Sprintf("GetMessagesAcrossAllThreads: %v", err))
+				return
 			}
-			publicKeyToProfileEntryResponseMap[message.RecipientInfo.OwnerPublicKeyBase58Check] = profileEntryResponse
 		}
 	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetMessagesAcrossAllThreads: Problem encoding response as JSON: %v",
+			err))
+		return
+	}
+}
 
-	// response containing all user chats.
-	res := GetUserMessageThreadsResponse{
-		MessageThreads:                  messageThreads,
-		PublicKeyToProfileEntryResponse: publicKeyToProfileEntryResponseMap,
+// threadMessageEntry is a message along with the type of the thread it was fetched from.
+type threadMessageEntry struct {
+	chatType ChatType
+	message  *lib.NewMessageEntry
+}
+
+// mergeRecentMessagesAcrossThreads returns the maxMessagesToFetch most recent messages across messageThreads, newest
+// first. Each thread's latest message is read first, and the threads are then visited newest first. Once the result is
+// full, the remaining threads are skipped as soon as one's latest message is no newer than the oldest message kept,
+// since none of their messages could make the cut. Messages with the same timestamp keep the order of their threads.
+func mergeRecentMessagesAcrossThreads(
+	messageThreads []messageThreadFetcher,
+	maxMessagesToFetch int,
+) ([]threadMessageEntry, error) {
+	type threadWithLatestMessage struct {
+		thread               messageThreadFetcher
+		latestTimestampNanos uint64
+	}
+	var threads []threadWithLatestMessage
+	for _, thread := range messageThreads {
+		latestMessages, err := thread.fetchMessages(math.MaxUint64, 1)
+		if err != nil {
+			return nil, err
+		}
+		if len(latestMessages) == 0 {
+			continue
+		}
+		threads = append(threads, threadWithLatestMessage{
+			thread:               thread,
+			latestTimestampNanos: latestMessages[0].TimestampNanos,
+		})
 	}
+	sort.SliceStable(threads, func(ii, jj int) bool {
+		return threads[ii].latestTimestampNanos > threads[jj].latestTimestampNanos
+	})
 
-	if err = json.NewEncoder(ww).Encode(res); err != nil {
-		return errors.Wrapf(err, "Problem encoding response as JSON: ")
+	mergedMessages := []threadMessageEntry{}
+	for _, thread := range threads {
+		if len(mergedMessages) == maxMessagesToFetch &&
+			thread.latestTimestampNanos <= mergedMessages[len(mergedMessages)-1].message.TimestampNanos {
+			break
+		}
+		messages, err := thread.thread.fetchMessages(math.MaxUint64, maxMessagesToFetch)
+		if err != nil {
+			return nil, err
+		}
+		for _, message := range messages {
+			mergedMessages = append(mergedMessages, threadMessageEntry{chatType: thread.thread.chatType, message: message})
+		}
+		sort.SliceStable(mergedMessages, func(ii, jj int) bool {
+			return mergedMessages[ii].message.TimestampNanos > mergedMessages[jj].message.TimestampNanos
+		})
+		if len(mergedMessages) > maxMessagesToFetch {
+			mergedMessages = mergedMessages[:maxMessagesToFetch]
+		}
 	}
-	return nil
+	return mergedMessages, nil
 }
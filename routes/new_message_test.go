@@ -0,0 +1,1986 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/deso-protocol/backend/config"
+	"github.com/deso-protocol/core/lib"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestJWT signs a JWT with privKey, as a client would to authenticate as its public key.
+func newTestJWT(t *testing.T, privKey *btcec.PrivateKey) string {
+	jwtToken, err := jwt.New(jwt.SigningMethodES256).SignedString(privKey.ToECDSA())
+	require.NoError(t, err)
+	return jwtToken
+}
+
+func TestGetAccessGroupIdForGroupChatThreadRequest(t *testing.T) {
+	// The owner/name path.
+	ownerAndNameAccessGroupId, err := getAccessGroupIdForGroupChatThreadRequest(&GetPaginatedMessagesForGroupChatThreadRequest{
+		UserPublicKeyBase58Check: senderPkString,
+		AccessGroupKeyName:       "groupchat",
+	})
+	require.NoError(t, err)
+	require.Equal(t, lib.MustBase58CheckDecode(senderPkString), ownerAndNameAccessGroupId.AccessGroupOwnerPublicKey.ToBytes())
+
+	// The hex path resolves to the identical AccessGroupId, so both paths fetch the same messages.
+	hexAccessGroupId, err := getAccessGroupIdForGroupChatThreadRequest(&GetPaginatedMessagesForGroupChatThreadRequest{
+		AccessGroupIdHex: EncodeAccessGroupIdToHex(ownerAndNameAccessGroupId),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ownerAndNameAccessGroupId, hexAccessGroupId)
+
+	// AccessGroupIdHex takes precedence over the owner and key name.
+	hexAccessGroupId, err = getAccessGroupIdForGroupChatThreadRequest(&GetPaginatedMessagesForGroupChatThreadRequest{
+		UserPublicKeyBase58Check: recipientPkString,
+		AccessGroupKeyName:       "othergroup",
+		AccessGroupIdHex:         EncodeAccessGroupIdToHex(ownerAndNameAccessGroupId),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ownerAndNameAccessGroupId, hexAccessGroupId)
+
+	// Malformed IDs are rejected.
+	_, err = DecodeAccessGroupIdFromHex("not hex")
+	require.Error(t, err)
+	_, err = DecodeAccessGroupIdFromHex(EncodeAccessGroupIdToHex(ownerAndNameAccessGroupId)[2:])
+	require.Error(t, err)
+	_, err = DecodeAccessGroupIdFromHex(EncodeAccessGroupIdToHex(&lib.AccessGroupId{}))
+	require.Error(t, err)
+}
+
+func TestGetUnverifiableAccessGroupMembers(t *testing.T) {
+	baseKeyMember := lib.NewPublicKey(generateRandomPublicKey(t))
+	registeredMember := lib.NewPublicKey(generateRandomPublicKey(t))
+	unregisteredMember := lib.NewPublicKey(generateRandomPublicKey(t))
+	memberKeyName := lib.NewGroupKeyName([]byte("memberkey"))
+
+	// Only the registered member has an access group for memberKeyName.
+	getAccessGroupEntry := func(owner *lib.PublicKey, keyName *lib.GroupKeyName) (*lib.AccessGroupEntry, error) {
+		if *owner != *registeredMember || !lib.EqualGroupKeyName(keyName, memberKeyName) {
+			return nil, nil
+		}
+		return &lib.AccessGroupEntry{
+			AccessGroupOwnerPublicKey: registeredMember,
+			AccessGroupKeyName:        memberKeyName,
+			AccessGroupPublicKey:      lib.NewPublicKey(generateRandomPublicKey(t)),
+		}, nil
+	}
+
+	// All members are verifiable.
+	memberEntries := []*lib.AccessGroupMemberEntry{
+		{AccessGroupMemberPublicKey: baseKeyMember, AccessGroupMemberKeyName: lib.BaseGroupKeyName()},
+		{AccessGroupMemberPublicKey: registeredMember, AccessGroupMemberKeyName: memberKeyName},
+	}
+	unverifiableMembers, err := getUnverifiableAccessGroupMembers(memberEntries, getAccessGroupEntry)
+	require.NoError(t, err)
+	require.Empty(t, unverifiableMembers)
+
+	// A member without a registered access group is reported.
+	memberEntries = append(memberEntries, &lib.AccessGroupMemberEntry{
+		AccessGroupMemberPublicKey: unregisteredMember, AccessGroupMemberKeyName: memberKeyName,
+	})
+	unverifiableMembers, err = getUnverifiableAccessGroupMembers(memberEntries, getAccessGroupEntry)
+	require.NoError(t, err)
+	require.Equal(t, []*lib.PublicKey{unregisteredMember}, unverifiableMembers)
+}
+
+func TestFormatTimestampNanosAsRFC3339(t *testing.T) {
+	for _, timestampNanos := range []uint64{0, 1, 1672531200000000000, 1672531200123456789} {
+		parsedTimestamp, err := time.Parse(time.RFC3339Nano, FormatTimestampNanosAsRFC3339(timestampNanos))
+		require.NoError(t, err)
+		require.Equal(t, timestampNanos, uint64(parsedTimestamp.UnixNano()))
+	}
+	require.Equal(t, "2023-01-01T00:00:00.123456789Z", FormatTimestampNanosAsRFC3339(1672531200123456789))
+}
+
+func TestGetMessageTimestampsInWindow(t *testing.T) {
+	// A thread with a message every 10 nanos, spanning more than one page.
+	var threadMessages []*lib.NewMessageEntry
+	for ii := 2500; ii > 0; ii-- {
+		threadMessages = append(threadMessages, &lib.NewMessageEntry{TimestampNanos: uint64(ii * 10)})
+	}
+	fetchCount := 0
+	fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		fetchCount++
+		var messages []*lib.NewMessageEntry
+		for _, message := range threadMessages {
+			if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+				messages = append(messages, message)
+			}
+		}
+		return messages, nil
+	}
+
+	// The returned timestamps are exactly the messages in [endTimestamp, startTimestamp), oldest first.
+	timestampsNanos, truncated, err := getMessageTimestampsInWindow(fetchMessages, 20005, 5000, MaxMessageTimestampsToFetch)
+	require.NoError(t, err)
+	require.False(t, truncated)
+	var expectedTimestampsNanos []uint64
+	for _, message := range threadMessages {
+		if message.TimestampNanos >= 5000 && message.TimestampNanos < 20005 {
+			expectedTimestampsNanos = append([]uint64{message.TimestampNanos}, expectedTimestampsNanos...)
+		}
+	}
+	require.Len(t, timestampsNanos, 1501)
+	require.Equal(t, expectedTimestampsNanos, timestampsNanos)
+	require.Equal(t, 2, fetchCount)
+
+	// A window reaching back past the first message returns the rest of the thread.
+	timestampsNanos, truncated, err = getMessageTimestampsInWindow(fetchMessages, 55, 0, MaxMessageTimestampsToFetch)
+	require.NoError(t, err)
+	require.False(t, truncated)
+	require.Equal(t, []uint64{10, 20, 30, 40, 50}, timestampsNanos)
+
+	// When the window has too many messages, the newest ones are returned.
+	timestampsNanos, truncated, err = getMessageTimestampsInWindow(fetchMessages, 55, 0, 3)
+	require.NoError(t, err)
+	require.True(t, truncated)
+	require.Equal(t, []uint64{30, 40, 50}, timestampsNanos)
+
+	// An empty window returns no timestamps.
+	timestampsNanos, truncated, err = getMessageTimestampsInWindow(fetchMessages, 5, 0, MaxMessageTimestampsToFetch)
+	require.NoError(t, err)
+	require.False(t, truncated)
+	require.Empty(t, timestampsNanos)
+}
+
+func TestMessageContentType(t *testing.T) {
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams}
+
+	// A content type set on the request is stored in ExtraData and surfaced on the fetched message.
+	extraData, err := EncodeExtraDataMap(map[string]string{"app": "chat"})
+	require.NoError(t, err)
+	extraData, err = setMessageContentType(extraData, MessageContentTypeMarkdown)
+	require.NoError(t, err)
+	messageEntry := &lib.NewMessageEntry{
+		SenderAccessGroupOwnerPublicKey: lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString)),
+		EncryptedText:                   []byte("**hello**"),
+		TimestampNanos:                  1,
+		ExtraData:                       extraData,
+	}
+	messageResponse := apiServer.NewMessageEntryToResponse(messageEntry, ChatTypeDM, nil)
+	require.Equal(t, MessageContentTypeMarkdown, messageResponse.MessageInfo.ContentType)
+	require.Equal(t, "chat", messageResponse.MessageInfo.ExtraData["app"])
+
+	// Messages without a content type have none.
+	messageEntry.ExtraData = nil
+	messageResponse = apiServer.NewMessageEntryToResponse(messageEntry, ChatTypeDM, nil)
+	require.Empty(t, messageResponse.MessageInfo.ContentType)
+
+	// Content types set directly in ExtraData are validated too, and must agree with the request's.
+	_, err = setMessageContentType(map[string][]byte{MessageContentTypeExtraDataKey: []byte("text/html")}, "")
+	require.Error(t, err)
+	_, err = setMessageContentType(
+		map[string][]byte{MessageContentTypeExtraDataKey: []byte(MessageContentTypeJSON)}, MessageContentTypePlainText)
+	require.Error(t, err)
+
+	// Unknown content types are rejected before the transaction is built.
+	requestBody, err := json.Marshal(SendNewMessageRequest{
+		SenderAccessGroupOwnerPublicKeyBase58Check: senderPkString,
+		EncryptedMessageText:                       "00",
+		ContentType:                                "text/html",
+	})
+	require.NoError(t, err)
+	request, err := http.NewRequest("POST", RoutePathSendDmMessage, bytes.NewBuffer(requestBody))
+	require.NoError(t, err)
+	response := httptest.NewRecorder()
+	apiServer.SendDmMessage(response, request)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "Unknown ContentType text/html")
+}
+
+func TestGetMostRecentMessageTimestampNanos(t *testing.T) {
+	dmMessages := []*lib.NewMessageEntry{
+		{TimestampNanos: 1700000000000000005},
+		{TimestampNanos: 1700000000000000001},
+	}
+	groupChatMessages := []*lib.NewMessageEntry{
+		{TimestampNanos: 1700000000000000003},
+		{TimestampNanos: 1700000000000000009},
+	}
+
+	// The newest message can be in either kind of thread.
+	require.Equal(t, uint64(1700000000000000009), getMostRecentMessageTimestampNanos(dmMessages, groupChatMessages))
+	require.Equal(t, uint64(1700000000000000005), getMostRecentMessageTimestampNanos(dmMessages, nil))
+
+	// A user without any messages gets zero.
+	require.Equal(t, uint64(0), getMostRecentMessageTimestampNanos(nil, nil))
+}
+
+func TestGetThreadLastActivity(t *testing.T) {
+	decodePublicKey := func(publicKeyBase58Check string) *lib.PublicKey {
+		publicKeyBytes, _, err := lib.Base58CheckDecode(publicKeyBase58Check)
+		require.NoError(t, err)
+		return lib.NewPublicKey(publicKeyBytes)
+	}
+	userPublicKey := decodePublicKey(senderPkString)
+	partyPublicKey := decodePublicKey(recipientPkString)
+	groupOwnerPublicKey := decodePublicKey(moneyPkString)
+	baseKey := lib.BaseGroupKeyName()
+	groupKeyName := lib.NewGroupKeyName([]byte("group1"))
+	newMessage := func(
+		sender *lib.PublicKey, senderKeyName *lib.GroupKeyName,
+		recipient *lib.PublicKey, recipientKeyName *lib.GroupKeyName,
+		timestampNanos uint64,
+	) *lib.NewMessageEntry {
+		return &lib.NewMessageEntry{
+			SenderAccessGroupOwnerPublicKey:    sender,
+			SenderAccessGroupKeyName:           senderKeyName,
+			RecipientAccessGroupOwnerPublicKey: recipient,
+			RecipientAccessGroupKeyName:        recipientKeyName,
+			TimestampNanos:                     timestampNanos,
+		}
+	}
+
+	// The user's latest DM with the party was received, and the latest in their DM with the group owner was sent.
+	dmFromParty := newMessage(partyPublicKey, baseKey, userPublicKey, baseKey, 1700000000000000005)
+	dmToGroupOwner := newMessage(userPublicKey, baseKey, groupOwnerPublicKey, baseKey, 1700000000000000007)
+	// The latest message in the group chat was sent by the party.
+	groupChatMessage := newMessage(partyPublicKey, baseKey, groupOwnerPublicKey, groupKeyName, 1700000000000000009)
+
+	userPkBytes := userPublicKey.ToBytes()
+	threadIdToTimestampNanos := getThreadLastActivity(
+		userPkBytes, []*lib.NewMessageEntry{dmFromParty, dmToGroupOwner}, []*lib.NewMessageEntry{groupChatMessage})
+	encodeAccessGroupId := func(owner *lib.PublicKey, keyName *lib.GroupKeyName) string {
+		return EncodeAccessGroupIdToHex(&lib.AccessGroupId{AccessGroupOwnerPublicKey: *owner, AccessGroupKeyName: *keyName})
+	}
+	require.Equal(t, map[string]uint64{
+		encodeAccessGroupId(userPublicKey, baseKey) + encodeAccessGroupId(partyPublicKey, baseKey):      1700000000000000005,
+		encodeAccessGroupId(userPublicKey, baseKey) + encodeAccessGroupId(groupOwnerPublicKey, baseKey): 1700000000000000007,
+		encodeAccessGroupId(groupOwnerPublicKey, groupKeyName):                                          1700000000000000009,
+	}, threadIdToTimestampNanos)
+
+	// A DM thread has the same ID whichever party sent its latest message.
+	dmToParty := newMessage(userPublicKey, baseKey, partyPublicKey, baseKey, 1700000000000000011)
+	require.Equal(t,
+		GetMessageThreadId(userPkBytes, dmFromParty, ChatTypeDM), GetMessageThreadId(userPkBytes, dmToParty, ChatTypeDM))
+
+	// A user without any messages gets an empty map.
+	require.Empty(t, getThreadLastActivity(userPkBytes, nil, nil))
+}
+
+func TestDESOIdentifierAsMessagingPublicKey(t *testing.T) {
+	_, _, err := ValidateAccessGroupPublicKeyAndName(DESOCoinIdentifierString, "")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "DESO identifier")
+	_, err = Base58DecodeAndValidatePublickey(DESOCoinIdentifierString)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "DESO identifier")
+
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams}
+	sendRequests := []SendNewMessageRequest{
+		{
+			SenderAccessGroupOwnerPublicKeyBase58Check:    DESOCoinIdentifierString,
+			RecipientAccessGroupOwnerPublicKeyBase58Check: recipientPkString,
+		},
+		{
+			SenderAccessGroupOwnerPublicKeyBase58Check:    senderPkString,
+			RecipientAccessGroupOwnerPublicKeyBase58Check: DESOCoinIdentifierString,
+		},
+		{
+			SenderAccessGroupOwnerPublicKeyBase58Check:    senderPkString,
+			RecipientAccessGroupOwnerPublicKeyBase58Check: recipientPkString,
+			SenderAccessGroupPublicKeyBase58Check:         DESOCoinIdentifierString,
+			EncryptedMessageText:                          "00",
+		},
+	}
+	for _, sendRequest := range sendRequests {
+		requestBody, err := json.Marshal(sendRequest)
+		require.NoError(t, err)
+		request, err := http.NewRequest("POST", RoutePathSendDmMessage, bytes.NewBuffer(requestBody))
+		require.NoError(t, err)
+		response := httptest.NewRecorder()
+		apiServer.SendDmMessage(response, request)
+		require.Equal(t, http.StatusBadRequest, response.Code)
+		require.Contains(t, response.Body.String(), "DESO identifier")
+	}
+
+	// The thread endpoints reject DESO as the user whose threads are being fetched.
+	handlers := map[string]http.HandlerFunc{
+		RoutePathGetAllUserMessageThreads:      apiServer.GetAllUserMessageThreads,
+		RoutePathGetMostRecentMessageTimestamp: apiServer.GetMostRecentMessageTimestamp,
+	}
+	requestBody := []byte(fmt.Sprintf(`{"UserPublicKeyBase58Check": "%v"}`, DESOCoinIdentifierString))
+	for routePath, handler := range handlers {
+		request, err := http.NewRequest("POST", routePath, bytes.NewBuffer(requestBody))
+		require.NoError(t, err)
+		response := httptest.NewRecorder()
+		handler(response, request)
+		require.Equal(t, http.StatusBadRequest, response.Code, routePath)
+		require.Contains(t, response.Body.String(), "UserPublicKeyBase58Check must be a user public key", routePath)
+	}
+}
+
+func TestNewMessageSignersFromSeeds(t *testing.T) {
+	messageSigners, err := NewMessageSignersFromSeeds(nil, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Empty(t, messageSigners)
+
+	testSeed := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	messageSigners, err = NewMessageSignersFromSeeds([]string{testSeed}, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Len(t, messageSigners, 1)
+	for publicKeyBase58Check, privateKey := range messageSigners {
+		require.Equal(t, publicKeyBase58Check,
+			lib.PkToString(privateKey.PubKey().SerializeCompressed(), &lib.DeSoTestnetParams))
+	}
+
+	_, err = NewMessageSignersFromSeeds([]string{"not a seed phrase"}, &lib.DeSoTestnetParams)
+	require.Error(t, err)
+}
+
+func TestMessageDecryption(t *testing.T) {
+	testSeed := "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	messageSigners, err := NewMessageSignersFromSeeds([]string{testSeed}, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Len(t, messageSigners, 1)
+	var testPublicKeyBase58Check string
+	var testPrivateKey *btcec.PrivateKey
+	for publicKeyBase58Check, privateKey := range messageSigners {
+		testPublicKeyBase58Check, testPrivateKey = publicKeyBase58Check, privateKey
+	}
+
+	// Decryption is off by default, and every key has to be allowlisted when it's on.
+	messageDecryptionKeys, err := NewMessageDecryptionKeys(false, []string{testSeed}, nil, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Nil(t, messageDecryptionKeys)
+	_, err = NewMessageDecryptionKeys(true, []string{testSeed}, []string{senderPkString}, &lib.DeSoTestnetParams)
+	require.Error(t, err)
+	messageDecryptionKeys, err = NewMessageDecryptionKeys(
+		true, []string{testSeed}, []string{testPublicKeyBase58Check}, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Len(t, messageDecryptionKeys, 1)
+
+	// A message to the test key's base access group, and one to someone else.
+	encryptedText, err := lib.EncryptBytesWithPublicKey([]byte("hello"), testPrivateKey.PubKey().ToECDSA())
+	require.NoError(t, err)
+	testPublicKey := lib.NewPublicKey(testPrivateKey.PubKey().SerializeCompressed())
+	message := &lib.NewMessageEntry{
+		RecipientAccessGroupOwnerPublicKey: testPublicKey,
+		RecipientAccessGroupPublicKey:      testPublicKey,
+		RecipientAccessGroupKeyName:        lib.BaseGroupKeyName(),
+		EncryptedText:                      encryptedText,
+	}
+	recipientPkBytes, _, err := lib.Base58CheckDecode(recipientPkString)
+	require.NoError(t, err)
+	otherMessage := &lib.NewMessageEntry{
+		RecipientAccessGroupOwnerPublicKey: lib.NewPublicKey(recipientPkBytes),
+		RecipientAccessGroupPublicKey:      lib.NewPublicKey(recipientPkBytes),
+		RecipientAccessGroupKeyName:        lib.BaseGroupKeyName(),
+		EncryptedText:                      encryptedText,
+	}
+
+	// Responses are never decrypted when they're built, only for a caller authenticated as the recipient.
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams, messageDecryptionKeys: messageDecryptionKeys}
+	responses := []NewMessageEntryResponse{
+		apiServer.NewMessageEntryToResponse(message, ChatTypeDM, nil),
+		apiServer.NewMessageEntryToResponse(otherMessage, ChatTypeDM, nil),
+	}
+	require.Empty(t, responses[0].MessageInfo.DecryptedText)
+	require.Equal(t, hex.EncodeToString(encryptedText), responses[0].MessageInfo.EncryptedText)
+
+	// Without a JWT, or with one for someone else, the node doesn't decrypt for the caller.
+	require.Nil(t, apiServer.getMessageDecryptionKeysForUser(testPublicKeyBase58Check, ""))
+	recipientPrivKeyBytes, _, err := lib.Base58CheckDecode(recipientPrivString)
+	require.NoError(t, err)
+	recipientPrivKey, _ := btcec.PrivKeyFromBytes(recipientPrivKeyBytes)
+	require.Nil(t, apiServer.getMessageDecryptionKeysForUser(testPublicKeyBase58Check, newTestJWT(t, recipientPrivKey)))
+	// A valid JWT doesn't help a user whose key the node doesn't hold.
+	require.Nil(t, apiServer.getMessageDecryptionKeysForUser(recipientPkString, newTestJWT(t, recipientPrivKey)))
+	decryptMessages(apiServer.getMessageDecryptionKeysForUser(testPublicKeyBase58Check, ""), responses)
+	require.Empty(t, responses[0].MessageInfo.DecryptedText)
+
+	// With a JWT for the recipient, only messages to their key are decrypted.
+	decryptMessages(apiServer.getMessageDecryptionKeysForUser(
+		testPublicKeyBase58Check, newTestJWT(t, testPrivateKey)), responses)
+	require.Equal(t, "hello", responses[0].MessageInfo.DecryptedText)
+	require.Empty(t, responses[1].MessageInfo.DecryptedText)
+
+	// By default nothing is decrypted, and the field is left out of the JSON.
+	apiServer = &APIServer{Params: &lib.DeSoTestnetParams}
+	require.Nil(t, apiServer.getMessageDecryptionKeysForUser(testPublicKeyBase58Check, newTestJWT(t, testPrivateKey)))
+	response := apiServer.NewMessageEntryToResponse(message, ChatTypeDM, nil)
+	require.Empty(t, response.MessageInfo.DecryptedText)
+	responseJSON, err := json.Marshal(response)
+	require.NoError(t, err)
+	require.NotContains(t, string(responseJSON), "DecryptedText")
+}
+
+func TestMessagingDenylist(t *testing.T) {
+	// No denylist configured.
+	messagingDenylist, err := NewMessagingDenylist(nil, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Nil(t, messagingDenylist)
+	require.Equal(t, &GetDenylistStatusResponse{}, getDenylistStatus(messagingDenylist, senderPkString))
+
+	messagingDenylist, err = NewMessagingDenylist(
+		[]string{senderPkString + "=Spam", " " + recipientPkString + " "}, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+
+	// A denied key, with and without a reason.
+	require.Equal(t, &GetDenylistStatusResponse{DenylistConfigured: true, IsDenied: true, Reason: "Spam"},
+		getDenylistStatus(messagingDenylist, senderPkString))
+	require.Equal(t, &GetDenylistStatusResponse{DenylistConfigured: true, IsDenied: true},
+		getDenylistStatus(messagingDenylist, recipientPkString))
+
+	// An allowed key.
+	allowedPkString := lib.PkToString(bytes.Repeat([]byte{2}, 33), &lib.DeSoTestnetParams)
+	require.Equal(t, &GetDenylistStatusResponse{DenylistConfigured: true},
+		getDenylistStatus(messagingDenylist, allowedPkString))
+
+	_, err = NewMessagingDenylist([]string{"not a public key=Spam"}, &lib.DeSoTestnetParams)
+	require.Error(t, err)
+}
+
+func TestSendMessageBroadcast(t *testing.T) {
+	apiServer := newTestApiServer(t)
+
+	// Configure a test signer for the sender only.
+	senderPrivKeyBytes, _, err := lib.Base58CheckDecode(senderPrivString)
+	require.NoError(t, err)
+	senderPrivKey, _ := btcec.PrivKeyFromBytes(senderPrivKeyBytes)
+	apiServer.messageSigners = map[string]*btcec.PrivateKey{senderPkString: senderPrivKey}
+
+	senderJWT := newTestJWT(t, senderPrivKey)
+
+	sendDmMessage := func(
+		senderPk string, recipientPk string, broadcast bool, jwtToken string) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(SendNewMessageRequest{
+			SenderAccessGroupOwnerPublicKeyBase58Check:    senderPk,
+			SenderAccessGroupPublicKeyBase58Check:         senderPk,
+			RecipientAccessGroupOwnerPublicKeyBase58Check: recipientPk,
+			RecipientAccessGroupPublicKeyBase58Check:      recipientPk,
+			EncryptedMessageText:                          hex.EncodeToString([]byte("hello")),
+			MinFeeRateNanosPerKB:                          apiServer.MinFeeRateNanosPerKB,
+			Broadcast:                                     broadcast,
+			JWT:                                           jwtToken,
+		})
+		require.NoError(t, err)
+		request, err := http.NewRequest("POST", RoutePathSendDmMessage, bytes.NewBuffer(requestBody))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		apiServer.router.ServeHTTP(response, request)
+		return response
+	}
+	decodeTxn := func(res *SendNewMessageResponse) *lib.MsgDeSoTxn {
+		txnBytes, err := hex.DecodeString(res.TransactionHex)
+		require.NoError(t, err)
+		txn := &lib.MsgDeSoTxn{}
+		require.NoError(t, txn.FromBytes(txnBytes))
+		return txn
+	}
+
+	// By default the transaction is returned unsigned and isn't submitted.
+	response := sendDmMessage(senderPkString, recipientPkString, false, "")
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	unsignedRes := &SendNewMessageResponse{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), unsignedRes))
+	require.Empty(t, unsignedRes.TxnHashHex)
+	unsignedTxn := decodeTxn(unsignedRes)
+	require.Nil(t, unsignedTxn.Signature.Sign)
+	require.False(t, apiServer.backendServer.GetMempool().IsTransactionInPool(unsignedTxn.Hash()))
+
+	// Broadcasting is rejected for a sender the node has no signer for.
+	response = sendDmMessage(recipientPkString, senderPkString, true, senderJWT)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "Broadcast is not enabled")
+
+	// Broadcasting is rejected without a JWT for the sender, even when the node has a signer for them.
+	response = sendDmMessage(senderPkString, recipientPkString, true, "")
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "Broadcast requires a valid JWT")
+	recipientPrivKeyBytes, _, err := lib.Base58CheckDecode(recipientPrivString)
+	require.NoError(t, err)
+	recipientPrivKey, _ := btcec.PrivKeyFromBytes(recipientPrivKeyBytes)
+	response = sendDmMessage(senderPkString, recipientPkString, true, newTestJWT(t, recipientPrivKey))
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "Broadcast requires a valid JWT")
+
+	// With a signer configured, the node signs and submits the transaction and returns its hash.
+	response = sendDmMessage(senderPkString, recipientPkString, true, senderJWT)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+	broadcastRes := &SendNewMessageResponse{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), broadcastRes))
+	broadcastTxn := decodeTxn(broadcastRes)
+	require.NotNil(t, broadcastTxn.Signature.Sign)
+	txnHash := broadcastTxn.Hash()
+	require.Equal(t, hex.EncodeToString(txnHash[:]), broadcastRes.TxnHashHex)
+	require.True(t, apiServer.backendServer.GetMempool().IsTransactionInPool(txnHash))
+}
+
+func TestCountAccessGroupMembers(t *testing.T) {
+	makeMembers := func(numMembers int) []*lib.PublicKey {
+		var members []*lib.PublicKey
+		for ii := 0; ii < numMembers; ii++ {
+			memberPkBytes := make([]byte, 33)
+			memberPkBytes[0] = 2
+			memberPkBytes[1] = byte(ii >> 8)
+			memberPkBytes[2] = byte(ii)
+			members = append(members, lib.NewPublicKey(memberPkBytes))
+		}
+		return members
+	}
+	// Mimics the member enumeration, which starts at and includes startingMemberPkBytes.
+	fetchMembersFrom := func(members []*lib.PublicKey) func([]byte, int) ([]*lib.PublicKey, error) {
+		return func(startingMemberPkBytes []byte, maxMembersToFetch int) ([]*lib.PublicKey, error) {
+			startIndex := 0
+			if startingMemberPkBytes != nil {
+				for startIndex < len(members) && !bytes.Equal(members[startIndex].ToBytes(), startingMemberPkBytes) {
+					startIndex++
+				}
+			}
+			endIndex := startIndex + maxMembersToFetch
+			if endIndex > len(members) {
+				endIndex = len(members)
+			}
+			return members[startIndex:endIndex], nil
+		}
+	}
+
+	// Groups of known sizes, including ones that span several pages.
+	for _, groupSize := range []int{0, 1, 12, verifyGroupChatMembersBatchSize, 2*verifyGroupChatMembersBatchSize + 500} {
+		memberCount, err := countAccessGroupMembers(fetchMembersFrom(makeMembers(groupSize)))
+		require.NoError(t, err)
+		require.Equal(t, groupSize, memberCount)
+	}
+
+	_, err := countAccessGroupMembers(func([]byte, int) ([]*lib.PublicKey, error) {
+		return nil, fmt.Errorf("view error")
+	})
+	require.Error(t, err)
+}
+
+func TestGetMessageAtTimestamp(t *testing.T) {
+	// A thread with a message every 10 nanos.
+	var threadMessages []*lib.NewMessageEntry
+	for ii := 10; ii > 0; ii-- {
+		threadMessages = append(threadMessages, &lib.NewMessageEntry{
+			TimestampNanos: uint64(ii * 10),
+			EncryptedText:  []byte(fmt.Sprintf("message %d", ii)),
+		})
+	}
+	fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		var messages []*lib.NewMessageEntry
+		for _, message := range threadMessages {
+			if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+				messages = append(messages, message)
+			}
+		}
+		return messages, nil
+	}
+
+	// A client that found gaps at these timestamps backfills exactly those messages.
+	for _, missingTimestampNanos := range []uint64{20, 50, 90} {
+		message, err := getMessageAtTimestamp(fetchMessages, missingTimestampNanos)
+		require.NoError(t, err)
+		require.NotNil(t, message)
+		require.Equal(t, missingTimestampNanos, message.TimestampNanos)
+		require.Equal(t, fmt.Sprintf("message %d", missingTimestampNanos/10), string(message.EncryptedText))
+	}
+
+	// Timestamps without a message aren't matched to a neighboring message.
+	for _, timestampNanos := range []uint64{0, 5, 55, 101, math.MaxUint64} {
+		message, err := getMessageAtTimestamp(fetchMessages, timestampNanos)
+		require.NoError(t, err)
+		require.Nil(t, message, timestampNanos)
+	}
+
+	// Invalid thread specs are reported rather than fetched.
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams}
+	_, err := apiServer.getMessageFetcherForThread(&MessageThreadSpec{ChatType: "Forum"}, nil)
+	require.Error(t, err)
+	_, err = apiServer.getMessageFetcherForThread(&MessageThreadSpec{
+		ChatType:                            ChatTypeDM,
+		UserGroupOwnerPublicKeyBase58Check:  senderPkString,
+		PartyGroupOwnerPublicKeyBase58Check: senderPkString,
+	}, nil)
+	require.Error(t, err)
+}
+
+func TestGroupDmThreadsByCounterparty(t *testing.T) {
+	dmThread := func(sender string, senderKeyName string, recipient string, recipientKeyName string, ts uint64) NewMessageEntryResponse {
+		return NewMessageEntryResponse{
+			ChatType:      ChatTypeDM,
+			SenderInfo:    AccessGroupInfo{OwnerPublicKeyBase58Check: sender, AccessGroupKeyName: senderKeyName},
+			RecipientInfo: AccessGroupInfo{OwnerPublicKeyBase58Check: recipient, AccessGroupKeyName: recipientKeyName},
+			MessageInfo:   MessageInfo{TimestampNanos: ts},
+		}
+	}
+	groupChat := NewMessageEntryResponse{
+		ChatType:      ChatTypeGroupChat,
+		RecipientInfo: AccessGroupInfo{OwnerPublicKeyBase58Check: "carol", AccessGroupKeyName: "friends"},
+		MessageInfo:   MessageInfo{TimestampNanos: 35},
+	}
+
+	// Threads sorted newest first, as getUserMessageThreadsHandler returns them. Bob is reachable through both the
+	// base group and a "work" group.
+	threads := []NewMessageEntryResponse{
+		dmThread("bob", "work", "alice", "work", 40),
+		groupChat,
+		dmThread("alice", "", "carol", "", 30),
+		dmThread("alice", "", "bob", "", 20),
+	}
+
+	otherThreads, dmConversations := GroupDmThreadsByCounterparty("alice", threads)
+	require.Equal(t, []NewMessageEntryResponse{groupChat}, otherThreads)
+	require.Len(t, dmConversations, 2)
+
+	require.Equal(t, "bob", dmConversations[0].CounterpartyPublicKeyBase58Check)
+	require.Equal(t, uint64(40), dmConversations[0].LatestMessage.MessageInfo.TimestampNanos)
+	require.Len(t, dmConversations[0].Threads, 2)
+	require.Equal(t, "work", dmConversations[0].Threads[0].SenderInfo.AccessGroupKeyName)
+	require.Equal(t, "", dmConversations[0].Threads[1].RecipientInfo.AccessGroupKeyName)
+
+	require.Equal(t, "carol", dmConversations[1].CounterpartyPublicKeyBase58Check)
+	require.Equal(t, uint64(30), dmConversations[1].LatestMessage.MessageInfo.TimestampNanos)
+	require.Len(t, dmConversations[1].Threads, 1)
+
+	otherThreads, dmConversations = GroupDmThreadsByCounterparty("alice", nil)
+	require.Empty(t, otherThreads)
+	require.Empty(t, dmConversations)
+}
+
+func TestOmitEncryptedTextFromMessageThreads(t *testing.T) {
+	threads := []NewMessageEntryResponse{{
+		ChatType: ChatTypeDM,
+		MessageInfo: MessageInfo{
+			EncryptedText:  "deadbeef",
+			TimestampNanos: 10,
+			ContentType:    "text/plain",
+			ExtraData:      map[string]string{"Subject": "hi"},
+		},
+	}}
+
+	omitEncryptedTextFromMessageThreads(threads)
+	require.Empty(t, threads[0].MessageInfo.EncryptedText)
+	// The preview keeps the timestamp and the unencrypted metadata.
+	require.Equal(t, uint64(10), threads[0].MessageInfo.TimestampNanos)
+	require.Equal(t, "text/plain", threads[0].MessageInfo.ContentType)
+	require.Equal(t, map[string]string{"Subject": "hi"}, threads[0].MessageInfo.ExtraData)
+
+	// The field is dropped from the JSON entirely rather than sent as an empty string.
+	threadJSON, err := json.Marshal(threads[0])
+	require.NoError(t, err)
+	require.NotContains(t, string(threadJSON), "EncryptedText")
+}
+
+func TestAttachProfilesToMessageThreads(t *testing.T) {
+	thread := func(senderPublicKeyBase58Check string, recipientPublicKeyBase58Check string) NewMessageEntryResponse {
+		return NewMessageEntryResponse{
+			SenderInfo:    AccessGroupInfo{OwnerPublicKeyBase58Check: senderPublicKeyBase58Check},
+			RecipientInfo: AccessGroupInfo{OwnerPublicKeyBase58Check: recipientPublicKeyBase58Check},
+		}
+	}
+	threads := []NewMessageEntryResponse{
+		thread(senderPkString, recipientPkString),
+		thread(moneyPkString, senderPkString),
+		thread(senderPkString, moneyPkString),
+	}
+
+	// Each participant is looked up once, however many threads they're in.
+	require.Equal(t, []string{senderPkString, recipientPkString, moneyPkString},
+		getMessageThreadParticipantPublicKeys(threads))
+
+	// The money account has no profile.
+	senderProfile := &ProfileEntryResponse{PublicKeyBase58Check: senderPkString, Username: "sender"}
+	recipientProfile := &ProfileEntryResponse{PublicKeyBase58Check: recipientPkString, Username: "recipient"}
+	attachProfilesToMessageThreads(threads, map[string]*ProfileEntryResponse{
+		senderPkString:    senderProfile,
+		recipientPkString: recipientProfile,
+		moneyPkString:     nil,
+	})
+	require.Equal(t, senderProfile, threads[0].SenderInfo.ProfileEntryResponse)
+	require.Equal(t, recipientProfile, threads[0].RecipientInfo.ProfileEntryResponse)
+	require.Nil(t, threads[1].SenderInfo.ProfileEntryResponse)
+	require.Equal(t, senderProfile, threads[1].RecipientInfo.ProfileEntryResponse)
+
+	// Threads fetched without IncludeProfiles don't carry the field at all.
+	threadJSON, err := json.Marshal(thread(senderPkString, recipientPkString))
+	require.NoError(t, err)
+	require.NotContains(t, string(threadJSON), "ProfileEntryResponse")
+}
+
+func TestValidateEncryptedMessageTextSize(t *testing.T) {
+	// An unconfigured node uses the default limit.
+	apiServer := &APIServer{Config: &config.Config{}}
+	require.Equal(t, MaxDmMessageSizeBytes, apiServer.getMaxDmMessageSizeBytes())
+	apiServer.Config.MaxDmMessageSizeBytes = 100
+	require.Equal(t, 100, apiServer.getMaxDmMessageSizeBytes())
+
+	// Messages up to the limit are accepted.
+	require.NoError(t, validateEncryptedMessageTextSize(0, 100))
+	require.NoError(t, validateEncryptedMessageTextSize(100, 100))
+
+	// The error names both the actual and the allowed size.
+	err := validateEncryptedMessageTextSize(101, 100)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "101 bytes")
+	require.Contains(t, err.Error(), "maximum of 100 bytes")
+}
+
+func TestGetMaxMessagesToFetch(t *testing.T) {
+	apiServer := &APIServer{Config: &config.Config{}}
+
+	// An omitted MaxMessagesToFetch falls back to the default.
+	maxMessagesToFetch, err := apiServer.getMaxMessagesToFetch(0)
+	require.NoError(t, err)
+	require.Equal(t, DefaultMaxMessagesToFetch, maxMessagesToFetch)
+
+	// The node's configured default takes precedence over the built-in one.
+	apiServer.Config.DefaultMaxMessagesToFetch = 50
+	maxMessagesToFetch, err = apiServer.getMaxMessagesToFetch(0)
+	require.NoError(t, err)
+	require.Equal(t, 50, maxMessagesToFetch)
+
+	// An explicit value is used as is.
+	maxMessagesToFetch, err = apiServer.getMaxMessagesToFetch(10)
+	require.NoError(t, err)
+	require.Equal(t, 10, maxMessagesToFetch)
+
+	// An explicit negative value is rejected.
+	_, err = apiServer.getMaxMessagesToFetch(-1)
+	require.Error(t, err)
+
+	for _, handler := range []http.HandlerFunc{
+		apiServer.GetPaginatedMessagesForDmThread,
+		apiServer.GetPaginatedMessagesForGroupChatThread,
+	} {
+		request := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(`{"MaxMessagesToFetch": -1}`))
+		recorder := httptest.NewRecorder()
+		handler(recorder, request)
+		require.Equal(t, http.StatusBadRequest, recorder.Code)
+		require.Contains(t, recorder.Body.String(), "MaxMessagesToFetch cannot be less than 1")
+	}
+}
+
+func TestGetDmContacts(t *testing.T) {
+	dmMessage := func(sender string, senderKeyName string, recipient string, ts uint64) NewMessageEntryResponse {
+		return NewMessageEntryResponse{
+			ChatType:      ChatTypeDM,
+			SenderInfo:    AccessGroupInfo{OwnerPublicKeyBase58Check: sender, AccessGroupKeyName: senderKeyName},
+			RecipientInfo: AccessGroupInfo{OwnerPublicKeyBase58Check: recipient},
+			MessageInfo:   MessageInfo{TimestampNanos: ts},
+		}
+	}
+	usernames := map[string]string{"bob": "Bob", "carol": "Carol"}
+	getUsername := func(publicKeyBase58Check string) string {
+		return usernames[publicKeyBase58Check]
+	}
+
+	// The latest message of each thread, in no particular order. Bob has two threads with alice, and dave has no
+	// profile.
+	latestDmMessages := []NewMessageEntryResponse{
+		dmMessage("alice", "", "bob", 10),
+		dmMessage("carol", "", "alice", 30),
+		dmMessage("bob", "work", "alice", 40),
+		dmMessage("alice", "", "dave", 20),
+	}
+	contacts := getDmContacts("alice", latestDmMessages, getUsername)
+	require.Equal(t, []DmContact{
+		{PublicKeyBase58Check: "bob", Username: "Bob",
+			LastInteractionTimestampNanos: 40, LastInteractionTimestampNanosString: "40"},
+		{PublicKeyBase58Check: "carol", Username: "Carol",
+			LastInteractionTimestampNanos: 30, LastInteractionTimestampNanosString: "30"},
+		{PublicKeyBase58Check: "dave",
+			LastInteractionTimestampNanos: 20, LastInteractionTimestampNanosString: "20"},
+	}, contacts)
+	// The input is left untouched.
+	require.Equal(t, uint64(10), latestDmMessages[0].MessageInfo.TimestampNanos)
+
+	require.Empty(t, getDmContacts("alice", nil, getUsername))
+}
+
+func TestBuildAccessGroupInfoFromMessageEntry(t *testing.T) {
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams}
+	senderAccessGroupPk := lib.NewPublicKey(generateRandomPublicKey(t))
+	recipientAccessGroupPk := lib.NewPublicKey(generateRandomPublicKey(t))
+	newMessageEntry := &lib.NewMessageEntry{
+		SenderAccessGroupOwnerPublicKey:    lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString)),
+		SenderAccessGroupKeyName:           lib.NewGroupKeyName([]byte("sendergroup")),
+		SenderAccessGroupPublicKey:         senderAccessGroupPk,
+		RecipientAccessGroupOwnerPublicKey: lib.NewPublicKey(lib.MustBase58CheckDecode(recipientPkString)),
+		RecipientAccessGroupKeyName:        lib.NewGroupKeyName([]byte("recipientgroup")),
+		RecipientAccessGroupPublicKey:      recipientAccessGroupPk,
+	}
+
+	require.Equal(t, AccessGroupInfo{
+		OwnerPublicKeyBase58Check:       senderPkString,
+		AccessGroupPublicKeyBase58Check: lib.PkToString(senderAccessGroupPk.ToBytes(), apiServer.Params),
+		AccessGroupKeyName:              "sendergroup",
+	}, apiServer.buildAccessGroupInfoFromMessageEntrySender(newMessageEntry))
+	require.Equal(t, AccessGroupInfo{
+		OwnerPublicKeyBase58Check:       recipientPkString,
+		AccessGroupPublicKeyBase58Check: lib.PkToString(recipientAccessGroupPk.ToBytes(), apiServer.Params),
+		AccessGroupKeyName:              "recipientgroup",
+	}, apiServer.buildAccessGroupInfoFromMessageEntryRecipient(newMessageEntry))
+
+	// The base group's key name decodes to an empty string.
+	newMessageEntry.SenderAccessGroupKeyName = lib.BaseGroupKeyName()
+	require.Equal(t, "", apiServer.buildAccessGroupInfoFromMessageEntrySender(newMessageEntry).AccessGroupKeyName)
+}
+
+func TestMessageExpiresAtNanos(t *testing.T) {
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams}
+	now := time.Now()
+	expiresAtNanos := uint64(now.Add(time.Hour).UnixNano())
+
+	// An expiry set on the request is stored in ExtraData and surfaced on the fetched message.
+	extraData, err := setMessageExpiresAtNanos(nil, expiresAtNanos, now)
+	require.NoError(t, err)
+	messageEntry := &lib.NewMessageEntry{
+		SenderAccessGroupOwnerPublicKey: lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString)),
+		EncryptedText:                   []byte("hello"),
+		TimestampNanos:                  1,
+		ExtraData:                       extraData,
+	}
+	messageResponse := apiServer.NewMessageEntryToResponse(messageEntry, ChatTypeDM, nil)
+	require.Equal(t, expiresAtNanos, messageResponse.MessageInfo.ExpiresAtNanos)
+	require.Equal(t, strconv.FormatUint(expiresAtNanos, 10),
+		messageResponse.MessageInfo.ExtraData[MessageExpiresAtNanosExtraDataKey])
+
+	// An expiry set directly in ExtraData round-trips the same way.
+	extraData, err = EncodeExtraDataMap(
+		map[string]string{MessageExpiresAtNanosExtraDataKey: strconv.FormatUint(expiresAtNanos, 10)})
+	require.NoError(t, err)
+	extraData, err = setMessageExpiresAtNanos(extraData, 0, now)
+	require.NoError(t, err)
+	require.Equal(t, expiresAtNanos, getMessageExpiresAtNanos(extraData))
+
+	// Messages without an expiry have none.
+	messageEntry.ExtraData = nil
+	messageResponse = apiServer.NewMessageEntryToResponse(messageEntry, ChatTypeDM, nil)
+	require.Zero(t, messageResponse.MessageInfo.ExpiresAtNanos)
+
+	// Expiries in the past are rejected, as are ones that disagree with ExtraData.
+	_, err = setMessageExpiresAtNanos(nil, uint64(now.Add(-time.Second).UnixNano()), now)
+	require.Error(t, err)
+	_, err = setMessageExpiresAtNanos(extraData, expiresAtNanos+1, now)
+	require.Error(t, err)
+	_, err = setMessageExpiresAtNanos(extraData, 0, now.Add(2*time.Hour))
+	require.Error(t, err)
+
+	requestBody, err := json.Marshal(SendNewMessageRequest{
+		SenderAccessGroupOwnerPublicKeyBase58Check: senderPkString,
+		EncryptedMessageText:                       "00",
+		ExpiresAtNanos:                             uint64(now.Add(-time.Second).UnixNano()),
+	})
+	require.NoError(t, err)
+	request, err := http.NewRequest("POST", RoutePathSendDmMessage, bytes.NewBuffer(requestBody))
+	require.NoError(t, err)
+	response := httptest.NewRecorder()
+	apiServer.SendDmMessage(response, request)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "must be in the future")
+}
+
+func TestGetPaginatedMessagesForDmThreadRoundTrip(t *testing.T) {
+	apiServer := newTestApiServer(t)
+	senderPrivKeyBytes, _, err := lib.Base58CheckDecode(senderPrivString)
+	require.NoError(t, err)
+	senderPrivKey, _ := btcec.PrivKeyFromBytes(senderPrivKeyBytes)
+	apiServer.messageSigners = map[string]*btcec.PrivateKey{senderPkString: senderPrivKey}
+	postJSON := func(routePath string, requestData interface{}) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(requestData)
+		require.NoError(t, err)
+		request, err := http.NewRequest("POST", routePath, bytes.NewBuffer(requestBody))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		apiServer.router.ServeHTTP(response, request)
+		return response
+	}
+
+	// Send a DM between the two base keys and submit it to the mempool.
+	encryptedText := hex.EncodeToString([]byte("round trip"))
+	response := postJSON(RoutePathSendDmMessage, SendNewMessageRequest{
+		SenderAccessGroupOwnerPublicKeyBase58Check:    senderPkString,
+		SenderAccessGroupPublicKeyBase58Check:         senderPkString,
+		RecipientAccessGroupOwnerPublicKeyBase58Check: recipientPkString,
+		RecipientAccessGroupPublicKeyBase58Check:      recipientPkString,
+		EncryptedMessageText:                          encryptedText,
+		MinFeeRateNanosPerKB:                          apiServer.MinFeeRateNanosPerKB,
+		Broadcast:                                     true,
+		JWT:                                           newTestJWT(t, senderPrivKey),
+	})
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	// Both parties see the message in their thread, attributed to the sender's owner public key.
+	for _, parties := range [][2]string{{senderPkString, recipientPkString}, {recipientPkString, senderPkString}} {
+		var res GetPaginatedMessagesForDmResponse
+		require.Eventually(t, func() bool {
+			response := postJSON(RoutePathGetPaginatedMessagesForDmThread, GetPaginatedMessagesForDmThreadRequest{
+				UserGroupOwnerPublicKeyBase58Check:  parties[0],
+				PartyGroupOwnerPublicKeyBase58Check: parties[1],
+				StartTimestamp:                      uint64(time.Now().UnixNano()),
+				MaxMessagesToFetch:                  10,
+			})
+			require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+			res = GetPaginatedMessagesForDmResponse{}
+			require.NoError(t, json.Unmarshal(response.Body.Bytes(), &res))
+			return len(res.ThreadMessages) > 0
+		}, 5*time.Second, 10*time.Millisecond)
+		require.Len(t, res.ThreadMessages, 1)
+		message := res.ThreadMessages[0]
+		require.Equal(t, senderPkString, message.SenderInfo.OwnerPublicKeyBase58Check)
+		require.Equal(t, recipientPkString, message.RecipientInfo.OwnerPublicKeyBase58Check)
+		require.Equal(t, encryptedText, message.MessageInfo.EncryptedText)
+	}
+}
+
+func TestGetPaginatedMessagesForDmThreadDecryptedIsNotCached(t *testing.T) {
+	apiServer := newTestApiServer(t)
+	senderPrivKeyBytes, _, err := lib.Base58CheckDecode(senderPrivString)
+	require.NoError(t, err)
+	senderPrivKey, _ := btcec.PrivKeyFromBytes(senderPrivKeyBytes)
+	recipientPrivKeyBytes, _, err := lib.Base58CheckDecode(recipientPrivString)
+	require.NoError(t, err)
+	recipientPrivKey, _ := btcec.PrivKeyFromBytes(recipientPrivKeyBytes)
+	apiServer.messageSigners = map[string]*btcec.PrivateKey{senderPkString: senderPrivKey}
+	apiServer.messageDecryptionKeys = map[string]*btcec.PrivateKey{recipientPkString: recipientPrivKey}
+	postJSON := func(routePath string, requestData interface{}) *httptest.ResponseRecorder {
+		requestBody, err := json.Marshal(requestData)
+		require.NoError(t, err)
+		request, err := http.NewRequest("POST", routePath, bytes.NewBuffer(requestBody))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		apiServer.router.ServeHTTP(response, request)
+		return response
+	}
+
+	encryptedText, err := lib.EncryptBytesWithPublicKey([]byte("for your eyes only"), recipientPrivKey.PubKey().ToECDSA())
+	require.NoError(t, err)
+	response := postJSON(RoutePathSendDmMessage, SendNewMessageRequest{
+		SenderAccessGroupOwnerPublicKeyBase58Check:    senderPkString,
+		SenderAccessGroupPublicKeyBase58Check:         senderPkString,
+		RecipientAccessGroupOwnerPublicKeyBase58Check: recipientPkString,
+		RecipientAccessGroupPublicKeyBase58Check:      recipientPkString,
+		EncryptedMessageText:                          hex.EncodeToString(encryptedText),
+		MinFeeRateNanosPerKB:                          apiServer.MinFeeRateNanosPerKB,
+		Broadcast:                                     true,
+		JWT:                                           newTestJWT(t, senderPrivKey),
+	})
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	getThread := func(jwt string) (*httptest.ResponseRecorder, GetPaginatedMessagesForDmResponse) {
+		var res GetPaginatedMessagesForDmResponse
+		var response *httptest.ResponseRecorder
+		require.Eventually(t, func() bool {
+			response = postJSON(RoutePathGetPaginatedMessagesForDmThread, GetPaginatedMessagesForDmThreadRequest{
+				UserGroupOwnerPublicKeyBase58Check:  recipientPkString,
+				PartyGroupOwnerPublicKeyBase58Check: senderPkString,
+				StartTimestamp:                      uint64(time.Now().UnixNano()),
+				MaxMessagesToFetch:                  10,
+				JWT:                                 jwt,
+			})
+			require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+			res = GetPaginatedMessagesForDmResponse{}
+			require.NoError(t, json.Unmarshal(response.Body.Bytes(), &res))
+			return len(res.ThreadMessages) > 0
+		}, 5*time.Second, 10*time.Millisecond)
+		return response, res
+	}
+
+	// The recipient's decrypted page must never be kept by a shared cache.
+	response, res := getThread(newTestJWT(t, recipientPrivKey))
+	require.Len(t, res.ThreadMessages, 1)
+	require.Equal(t, "for your eyes only", res.ThreadMessages[0].MessageInfo.DecryptedText)
+	require.Equal(t, "private, no-store", response.Header().Get("Cache-Control"))
+
+	// Without a JWT the page is only ciphertext.
+	response, res = getThread("")
+	require.Len(t, res.ThreadMessages, 1)
+	require.Empty(t, res.ThreadMessages[0].MessageInfo.DecryptedText)
+	require.NotContains(t, response.Header().Get("Cache-Control"), "private")
+}
+
+func TestSendMessageRecipientAccessGroupPublicKey(t *testing.T) {
+	apiServer := newTestApiServer(t)
+
+	// The sender and recipient use distinct access group keys so that swapping them would be caught.
+	senderAccessGroupPkString := lib.PkToString(generateRandomPublicKey(t), apiServer.Params)
+	recipientAccessGroupPkBytes := generateRandomPublicKey(t)
+	recipientAccessGroupPkString := lib.PkToString(recipientAccessGroupPkBytes, apiServer.Params)
+	sendMessage := func(routePath string, recipientKeyName string) *lib.NewMessageMetadata {
+		requestBody, err := json.Marshal(SendNewMessageRequest{
+			SenderAccessGroupOwnerPublicKeyBase58Check:    senderPkString,
+			SenderAccessGroupPublicKeyBase58Check:         senderAccessGroupPkString,
+			RecipientAccessGroupOwnerPublicKeyBase58Check: recipientPkString,
+			RecipientAccessGroupPublicKeyBase58Check:      recipientAccessGroupPkString,
+			RecipientAccessGroupKeyName:                   recipientKeyName,
+			EncryptedMessageText:                          hex.EncodeToString([]byte("hello")),
+			MinFeeRateNanosPerKB:                          apiServer.MinFeeRateNanosPerKB,
+			SkipRecipientAccessGroupCheck:                 true,
+		})
+		require.NoError(t, err)
+		request, err := http.NewRequest("POST", routePath, bytes.NewBuffer(requestBody))
+		require.NoError(t, err)
+		request.Header.Set("Content-Type", "application/json")
+		response := httptest.NewRecorder()
+		apiServer.router.ServeHTTP(response, request)
+		require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+		res := &SendNewMessageResponse{}
+		require.NoError(t, json.Unmarshal(response.Body.Bytes(), res))
+		txnBytes, err := hex.DecodeString(res.TransactionHex)
+		require.NoError(t, err)
+		txn := &lib.MsgDeSoTxn{}
+		require.NoError(t, txn.FromBytes(txnBytes))
+		metadata, ok := txn.TxnMeta.(*lib.NewMessageMetadata)
+		require.True(t, ok)
+		return metadata
+	}
+
+	for _, metadata := range []*lib.NewMessageMetadata{
+		sendMessage(RoutePathSendDmMessage, ""),
+		sendMessage(RoutePathSendGroupChatMessage, "group1"),
+	} {
+		require.Equal(t, recipientAccessGroupPkBytes, metadata.RecipientAccessGroupPublicKey.ToBytes())
+		require.Equal(t, lib.MustBase58CheckDecode(senderAccessGroupPkString), metadata.SenderAccessGroupPublicKey.ToBytes())
+	}
+}
+
+func TestSendGroupChatMessageToSelfOwnedGroup(t *testing.T) {
+	apiServer := newTestApiServer(t)
+
+	// The sender owns the recipient group, so both owner public keys are the same.
+	requestBody, err := json.Marshal(SendNewMessageRequest{
+		SenderAccessGroupOwnerPublicKeyBase58Check:    senderPkString,
+		SenderAccessGroupPublicKeyBase58Check:         senderPkString,
+		RecipientAccessGroupOwnerPublicKeyBase58Check: senderPkString,
+		RecipientAccessGroupPublicKeyBase58Check:      lib.PkToString(generateRandomPublicKey(t), apiServer.Params),
+		RecipientAccessGroupKeyName:                   "group1",
+		EncryptedMessageText:                          hex.EncodeToString([]byte("hello group")),
+		MinFeeRateNanosPerKB:                          apiServer.MinFeeRateNanosPerKB,
+		// The group isn't registered on the test chain.
+		SkipRecipientAccessGroupCheck: true,
+	})
+	require.NoError(t, err)
+	request, err := http.NewRequest("POST", RoutePathSendGroupChatMessage, bytes.NewBuffer(requestBody))
+	require.NoError(t, err)
+	request.Header.Set("Content-Type", "application/json")
+	response := httptest.NewRecorder()
+	apiServer.router.ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code, response.Body.String())
+
+	res := &SendNewMessageResponse{}
+	require.NoError(t, json.Unmarshal(response.Body.Bytes(), res))
+	txnBytes, err := hex.DecodeString(res.TransactionHex)
+	require.NoError(t, err)
+	txn := &lib.MsgDeSoTxn{}
+	require.NoError(t, txn.FromBytes(txnBytes))
+	metadata, ok := txn.TxnMeta.(*lib.NewMessageMetadata)
+	require.True(t, ok)
+	require.Equal(t, lib.NewMessageTypeGroupChat, metadata.NewMessageType)
+}
+
+func TestSetSequenceInThread(t *testing.T) {
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams}
+	newThread := func(numMessages int) []*lib.NewMessageEntry {
+		var threadMessages []*lib.NewMessageEntry
+		for ii := numMessages; ii > 0; ii-- {
+			threadMessages = append(threadMessages, &lib.NewMessageEntry{
+				SenderAccessGroupOwnerPublicKey: lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString)),
+				TimestampNanos:                  uint64(ii * 10),
+			})
+		}
+		return threadMessages
+	}
+	threadMessages := newThread(30)
+	fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		var messages []*lib.NewMessageEntry
+		for _, message := range threadMessages {
+			if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+				messages = append(messages, message)
+			}
+		}
+		return messages, nil
+	}
+	fetchWindow := func(startTimestamp uint64, maxMessagesToFetch int) []NewMessageEntryResponse {
+		entries, err := fetchMessages(startTimestamp, maxMessagesToFetch)
+		require.NoError(t, err)
+		var messages []NewMessageEntryResponse
+		for _, entry := range entries {
+			messages = append(messages, apiServer.NewMessageEntryToResponse(entry, ChatTypeDM, nil))
+		}
+		require.NoError(t, setSequenceInThread(messages, fetchMessages))
+		return messages
+	}
+
+	// Paging back through the thread, sequence numbers count down by one from the newest message to the oldest,
+	// which is 0, and carry on across pages.
+	expectedSequenceInThread := uint64(29)
+	startTimestamp := uint64(math.MaxUint64)
+	for page := 0; page < 3; page++ {
+		messages := fetchWindow(startTimestamp, 10)
+		require.Len(t, messages, 10)
+		for _, message := range messages {
+			require.NotNil(t, message.MessageInfo.SequenceInThread)
+			require.Equal(t, expectedSequenceInThread, *message.MessageInfo.SequenceInThread)
+			expectedSequenceInThread--
+		}
+		startTimestamp = messages[len(messages)-1].MessageInfo.TimestampNanos
+	}
+	require.Equal(t, uint64(10), startTimestamp)
+
+	// A window in the middle of the thread is numbered the same way.
+	messages := fetchWindow(155, 3)
+	require.Equal(t, uint64(14), *messages[0].MessageInfo.SequenceInThread)
+	require.Equal(t, uint64(12), *messages[2].MessageInfo.SequenceInThread)
+
+	// Messages fetched without IncludeSequenceInThread don't have one.
+	require.Nil(t, apiServer.NewMessageEntryToResponse(threadMessages[0], ChatTypeDM, nil).MessageInfo.SequenceInThread)
+	require.NoError(t, setSequenceInThread(nil, fetchMessages))
+
+	// Threads with too many messages before the window are rejected.
+	threadMessages = newThread(MaxMessagesCountedForSequenceInThread + 2)
+	entries, err := fetchMessages(math.MaxUint64, 1)
+	require.NoError(t, err)
+	messages = []NewMessageEntryResponse{apiServer.NewMessageEntryToResponse(entries[0], ChatTypeDM, nil)}
+	require.Error(t, setSequenceInThread(messages, fetchMessages))
+}
+
+func TestGetThreadParticipantKeys(t *testing.T) {
+	groupKeyName := lib.NewGroupKeyName([]byte("groupchat"))
+	rotatedMember := lib.NewPublicKey(generateRandomPublicKey(t))
+	baseKeyMember := lib.NewPublicKey(generateRandomPublicKey(t))
+	pastMember := lib.NewPublicKey(generateRandomPublicKey(t))
+	oldRotatedMemberKey := lib.NewPublicKey(generateRandomPublicKey(t))
+	newRotatedMemberKey := lib.NewPublicKey(generateRandomPublicKey(t))
+	pastMemberKey := lib.NewPublicKey(generateRandomPublicKey(t))
+
+	// rotatedMember has replaced the access group key they sent the earlier message with.
+	accessGroupPublicKeys := map[lib.PublicKey]*lib.PublicKey{
+		*rotatedMember: newRotatedMemberKey,
+		*pastMember:    pastMemberKey,
+	}
+	getAccessGroupEntry := func(owner *lib.PublicKey, keyName *lib.GroupKeyName) (*lib.AccessGroupEntry, error) {
+		accessGroupPublicKey, exists := accessGroupPublicKeys[*owner]
+		if !exists || !lib.EqualGroupKeyName(keyName, groupKeyName) {
+			return nil, nil
+		}
+		return &lib.AccessGroupEntry{
+			AccessGroupOwnerPublicKey: owner,
+			AccessGroupKeyName:        keyName,
+			AccessGroupPublicKey:      accessGroupPublicKey,
+		}, nil
+	}
+	memberEntries := []*lib.AccessGroupMemberEntry{
+		{AccessGroupMemberPublicKey: rotatedMember, AccessGroupMemberKeyName: groupKeyName},
+		{AccessGroupMemberPublicKey: baseKeyMember, AccessGroupMemberKeyName: lib.BaseGroupKeyName()},
+	}
+	// Sorted newest first, as the view returns them.
+	threadMessages := []*lib.NewMessageEntry{
+		{
+			SenderAccessGroupOwnerPublicKey: pastMember,
+			SenderAccessGroupKeyName:        groupKeyName,
+			SenderAccessGroupPublicKey:      pastMemberKey,
+			TimestampNanos:                  30,
+		},
+		{
+			SenderAccessGroupOwnerPublicKey: rotatedMember,
+			SenderAccessGroupKeyName:        groupKeyName,
+			SenderAccessGroupPublicKey:      oldRotatedMemberKey,
+			TimestampNanos:                  20,
+		},
+		{
+			SenderAccessGroupOwnerPublicKey: pastMember,
+			SenderAccessGroupKeyName:        lib.BaseGroupKeyName(),
+			SenderAccessGroupPublicKey:      pastMember,
+			TimestampNanos:                  10,
+		},
+	}
+	fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		var messages []*lib.NewMessageEntry
+		for _, message := range threadMessages {
+			if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+				messages = append(messages, message)
+			}
+		}
+		return messages, nil
+	}
+
+	participants, truncated, err := getThreadParticipantKeys(
+		memberEntries, fetchMessages, getAccessGroupEntry, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.False(t, truncated)
+	pkString := func(publicKey *lib.PublicKey) string {
+		return lib.PkToString(publicKey.ToBytes(), &lib.DeSoTestnetParams)
+	}
+	currentMembers := []ThreadParticipantKey{
+		{
+			OwnerPublicKeyBase58Check:       pkString(rotatedMember),
+			AccessGroupKeyName:              "groupchat",
+			AccessGroupPublicKeyBase58Check: pkString(newRotatedMemberKey),
+			IsCurrentMember:                 true,
+		},
+		{
+			OwnerPublicKeyBase58Check:       pkString(baseKeyMember),
+			AccessGroupKeyName:              "",
+			AccessGroupPublicKeyBase58Check: pkString(baseKeyMember),
+			IsCurrentMember:                 true,
+		},
+	}
+	sort.Slice(currentMembers, func(ii, jj int) bool {
+		return currentMembers[ii].OwnerPublicKeyBase58Check < currentMembers[jj].OwnerPublicKeyBase58Check
+	})
+	// The rotated member's current key is returned rather than the one on their message, and the member who left is
+	// listed with the key name of their latest message.
+	require.Equal(t, append(currentMembers, ThreadParticipantKey{
+		OwnerPublicKeyBase58Check:       pkString(pastMember),
+		AccessGroupKeyName:              "groupchat",
+		AccessGroupPublicKeyBase58Check: pkString(pastMemberKey),
+		IsCurrentMember:                 false,
+	}), participants)
+
+	// A member whose access group was deleted has no current key.
+	delete(accessGroupPublicKeys, *rotatedMember)
+	participants, _, err = getThreadParticipantKeys(memberEntries, fetchMessages, getAccessGroupEntry, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	for _, participant := range participants {
+		if participant.OwnerPublicKeyBase58Check == pkString(rotatedMember) {
+			require.Empty(t, participant.AccessGroupPublicKeyBase58Check)
+		}
+	}
+}
+
+func TestGetRecentMessageSenders(t *testing.T) {
+	owner := lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString))
+	alice := lib.NewPublicKey(lib.MustBase58CheckDecode(recipientPkString))
+	bob := lib.NewPublicKey(lib.MustBase58CheckDecode(moneyPkString))
+	carol := lib.NewPublicKey(generateRandomPublicKey(t))
+	newMessage := func(sender *lib.PublicKey, timestampNanos uint64) *lib.NewMessageEntry {
+		return &lib.NewMessageEntry{SenderAccessGroupOwnerPublicKey: sender, TimestampNanos: timestampNanos}
+	}
+	// Sorted newest first, as the view returns them.
+	dmWithAlice := []*lib.NewMessageEntry{newMessage(owner, 90), newMessage(alice, 40), newMessage(alice, 10)}
+	groupChat := []*lib.NewMessageEntry{
+		newMessage(bob, 80), newMessage(owner, 70), newMessage(alice, 60), newMessage(carol, 50), newMessage(carol, 5),
+	}
+	fetchFrom := func(threadMessages []*lib.NewMessageEntry) func(uint64, int) ([]*lib.NewMessageEntry, error) {
+		return func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			var messages []*lib.NewMessageEntry
+			for _, message := range threadMessages {
+				if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+					messages = append(messages, message)
+				}
+			}
+			return messages, nil
+		}
+	}
+
+	// Messages the owner sent and messages before the window are left out.
+	var inboundMessages []*lib.NewMessageEntry
+	for _, threadMessages := range [][]*lib.NewMessageEntry{dmWithAlice, groupChat} {
+		messages, err := getInboundMessagesInWindow(owner.ToBytes(), fetchFrom(threadMessages), 100, 10)
+		require.NoError(t, err)
+		inboundMessages = append(inboundMessages, messages...)
+	}
+	require.Equal(t, []*lib.NewMessageEntry{dmWithAlice[1], dmWithAlice[2], groupChat[0], groupChat[2], groupChat[3]},
+		inboundMessages)
+
+	usernames := map[string]string{string(alice.ToBytes()): "alice", string(bob.ToBytes()): "bob"}
+	getUsername := func(publicKeyBytes []byte) string {
+		return usernames[string(publicKeyBytes)]
+	}
+	pkString := func(publicKey *lib.PublicKey) string {
+		return lib.PkToString(publicKey.ToBytes(), &lib.DeSoTestnetParams)
+	}
+
+	// Each sender appears once, ordered by their latest message across threads.
+	senders, numSenders := getRecentMessageSenders(inboundMessages, MaxRecentMessageSenders, &lib.DeSoTestnetParams,
+		getUsername)
+	require.Equal(t, 3, numSenders)
+	require.Equal(t, []RecentMessageSender{
+		{PublicKeyBase58Check: pkString(bob), Username: "bob", LastMessageTimestampNanos: 80,
+			LastMessageTimestampNanosString: "80"},
+		{PublicKeyBase58Check: pkString(alice), Username: "alice", LastMessageTimestampNanos: 60,
+			LastMessageTimestampNanosString: "60"},
+		{PublicKeyBase58Check: pkString(carol), Username: "", LastMessageTimestampNanos: 50,
+			LastMessageTimestampNanosString: "50"},
+	}, senders)
+
+	// Capping the senders still reports how many there were in total.
+	senders, numSenders = getRecentMessageSenders(inboundMessages, 1, &lib.DeSoTestnetParams, getUsername)
+	require.Equal(t, 3, numSenders)
+	require.Len(t, senders, 1)
+	require.Equal(t, pkString(bob), senders[0].PublicKeyBase58Check)
+
+	senders, numSenders = getRecentMessageSenders(nil, MaxRecentMessageSenders, &lib.DeSoTestnetParams, getUsername)
+	require.Zero(t, numSenders)
+	require.Empty(t, senders)
+}
+
+func TestGetPendingMessageKeys(t *testing.T) {
+	sender := lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString))
+	recipient := lib.NewPublicKey(lib.MustBase58CheckDecode(recipientPkString))
+	message := func(senderPublicKey *lib.PublicKey, timestampNanos uint64, text string) *lib.NewMessageEntry {
+		return &lib.NewMessageEntry{
+			SenderAccessGroupOwnerPublicKey: senderPublicKey,
+			TimestampNanos:                  timestampNanos,
+			EncryptedText:                   []byte(text),
+		}
+	}
+	// The thread with the mempool applied. The sender's message at 500 hasn't been mined, and their message at 400
+	// has been edited in the mempool.
+	thread := []*lib.NewMessageEntry{
+		message(sender, 500, "new"),
+		message(sender, 400, "edited"),
+		message(recipient, 400, "hi"),
+		message(sender, 300, "hello"),
+		message(recipient, 200, "hey"),
+		message(sender, 100, "first"),
+	}
+	committedThread := []*lib.NewMessageEntry{
+		message(sender, 400, "original"),
+		message(recipient, 400, "hi"),
+		message(sender, 300, "hello"),
+		message(recipient, 200, "hey"),
+		message(sender, 100, "first"),
+	}
+	var committedMessagesFetched int
+	fetchCommittedMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		var messages []*lib.NewMessageEntry
+		for _, message := range committedThread {
+			if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+				messages = append(messages, message)
+			}
+		}
+		committedMessagesFetched += len(messages)
+		return messages, nil
+	}
+	key := func(senderPublicKeyBase58Check string, timestampNanos uint64) pendingMessageKey {
+		return pendingMessageKey{
+			SenderAccessGroupOwnerPublicKeyBase58Check: senderPublicKeyBase58Check,
+			TimestampNanos: timestampNanos,
+		}
+	}
+
+	pendingMessageKeys, err := getPendingMessageKeys(thread[:4], fetchCommittedMessages, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Equal(t, map[pendingMessageKey]struct{}{key(senderPkString, 500): {}, key(senderPkString, 400): {}},
+		pendingMessageKeys)
+	// Only the span of the page is read from the committed thread.
+	require.Equal(t, 3, committedMessagesFetched)
+
+	// A page of mined messages has nothing pending, even if it takes several reads to cover it.
+	pendingMessageKeys, err = getPendingMessageKeys(
+		[]*lib.NewMessageEntry{thread[3], thread[5]}, fetchCommittedMessages, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Empty(t, pendingMessageKeys)
+
+	pendingMessageKeys, err = getPendingMessageKeys(nil, fetchCommittedMessages, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Empty(t, pendingMessageKeys)
+
+	_, err = getPendingMessageKeys(thread, func(uint64, int) ([]*lib.NewMessageEntry, error) {
+		return nil, fmt.Errorf("view error")
+	}, &lib.DeSoTestnetParams)
+	require.Error(t, err)
+}
+
+func TestSetMessagesCacheControlHeader(t *testing.T) {
+	message := func(senderPublicKeyBase58Check string, timestampNanos uint64) NewMessageEntryResponse {
+		return NewMessageEntryResponse{
+			SenderInfo:  AccessGroupInfo{OwnerPublicKeyBase58Check: senderPublicKeyBase58Check},
+			MessageInfo: MessageInfo{TimestampNanos: timestampNanos},
+		}
+	}
+	// The sender has one message still in the mempool.
+	pendingMessageKeys := map[pendingMessageKey]struct{}{
+		{SenderAccessGroupOwnerPublicKeyBase58Check: senderPkString, TimestampNanos: 300}: {},
+	}
+
+	cacheControl := func(messages []NewMessageEntryResponse) string {
+		response := httptest.NewRecorder()
+		setMessagesCacheControlHeader(response, messages, pendingMessageKeys)
+		return response.Header().Get("Cache-Control")
+	}
+
+	// A page of mined messages can be cached.
+	require.Equal(t, fmt.Sprintf("public, immutable, max-age=%d", ConfirmedMessagesCacheMaxAgeSeconds), cacheControl(
+		[]NewMessageEntryResponse{message(senderPkString, 200), message(recipientPkString, 300)}))
+
+	// Unless any of it was decrypted for the caller.
+	decryptedMessage := message(recipientPkString, 100)
+	decryptedMessage.MessageInfo.DecryptedText = "hello"
+	require.Equal(t, "private, no-store", cacheControl(
+		[]NewMessageEntryResponse{message(senderPkString, 200), decryptedMessage}))
+
+	// A page with a pending message can't.
+	require.Equal(t, "no-cache", cacheControl(
+		[]NewMessageEntryResponse{message(senderPkString, 300), message(recipientPkString, 200)}))
+
+	// Neither can an empty page.
+	require.Equal(t, "no-cache", cacheControl([]NewMessageEntryResponse{}))
+}
+
+func TestCountMessagesByStatus(t *testing.T) {
+	sender := lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString))
+	recipient := lib.NewPublicKey(lib.MustBase58CheckDecode(recipientPkString))
+	message := func(senderPublicKey *lib.PublicKey, timestampNanos uint64) *lib.NewMessageEntry {
+		return &lib.NewMessageEntry{
+			SenderAccessGroupOwnerPublicKey: senderPublicKey,
+			TimestampNanos:                  timestampNanos,
+		}
+	}
+	// The sender has just sent two messages that are still in the mempool. The recipient's message at the same
+	// timestamp as one of them has been mined.
+	pendingMessageKeys := map[pendingMessageKey]struct{}{
+		{SenderAccessGroupOwnerPublicKeyBase58Check: senderPkString, TimestampNanos: 500}: {},
+		{SenderAccessGroupOwnerPublicKeyBase58Check: senderPkString, TimestampNanos: 400}: {},
+	}
+	thread := []*lib.NewMessageEntry{
+		message(sender, 500),
+		message(sender, 400),
+		message(recipient, 400),
+		message(sender, 300),
+		message(recipient, 200),
+	}
+
+	confirmedCount, pendingCount := countMessagesByStatus(thread, pendingMessageKeys, &lib.DeSoTestnetParams)
+	require.Equal(t, uint64(3), confirmedCount)
+	require.Equal(t, uint64(2), pendingCount)
+
+	// With an empty mempool, everything is confirmed.
+	confirmedCount, pendingCount = countMessagesByStatus(
+		thread, map[pendingMessageKey]struct{}{}, &lib.DeSoTestnetParams)
+	require.Equal(t, uint64(5), confirmedCount)
+	require.Equal(t, uint64(0), pendingCount)
+
+	// An empty thread has nothing to count.
+	confirmedCount, pendingCount = countMessagesByStatus(nil, pendingMessageKeys, &lib.DeSoTestnetParams)
+	require.Equal(t, uint64(0), confirmedCount)
+	require.Equal(t, uint64(0), pendingCount)
+}
+
+func TestFetchMessagesInDirection(t *testing.T) {
+	viewer := lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString))
+	alice := lib.NewPublicKey(lib.MustBase58CheckDecode(recipientPkString))
+	bob := lib.NewPublicKey(lib.MustBase58CheckDecode(moneyPkString))
+	newMessage := func(sender *lib.PublicKey, timestampNanos uint64) *lib.NewMessageEntry {
+		return &lib.NewMessageEntry{SenderAccessGroupOwnerPublicKey: sender, TimestampNanos: timestampNanos}
+	}
+	fetchFrom := func(threadMessages []*lib.NewMessageEntry) func(uint64, int) ([]*lib.NewMessageEntry, error) {
+		return func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			var messages []*lib.NewMessageEntry
+			for _, message := range threadMessages {
+				if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+					messages = append(messages, message)
+				}
+			}
+			return messages, nil
+		}
+	}
+	// Sorted newest first, as the view returns them.
+	thread := []*lib.NewMessageEntry{
+		newMessage(alice, 90), newMessage(viewer, 80), newMessage(bob, 70), newMessage(viewer, 60),
+		newMessage(alice, 50), newMessage(viewer, 40),
+	}
+
+	messages, resumeTimestamp, err := fetchMessagesInDirection(
+		viewer.ToBytes(), MessageDirectionInbound, fetchFrom(thread), math.MaxUint64, 10)
+	require.NoError(t, err)
+	require.Nil(t, resumeTimestamp)
+	require.Equal(t, []*lib.NewMessageEntry{thread[0], thread[2], thread[4]}, messages)
+
+	messages, resumeTimestamp, err = fetchMessagesInDirection(
+		viewer.ToBytes(), MessageDirectionOutbound, fetchFrom(thread), math.MaxUint64, 10)
+	require.NoError(t, err)
+	require.Nil(t, resumeTimestamp)
+	require.Equal(t, []*lib.NewMessageEntry{thread[1], thread[3], thread[5]}, messages)
+
+	// The page stops once it's full, and starts strictly before startTimestamp.
+	messages, _, err = fetchMessagesInDirection(viewer.ToBytes(), MessageDirectionOutbound, fetchFrom(thread), 80, 1)
+	require.NoError(t, err)
+	require.Equal(t, []*lib.NewMessageEntry{thread[3]}, messages)
+
+	messages, resumeTimestamp, err = fetchMessagesInDirection(
+		viewer.ToBytes(), MessageDirectionAll, fetchFrom(thread), math.MaxUint64, 4)
+	require.NoError(t, err)
+	require.Nil(t, resumeTimestamp)
+	require.Equal(t, thread[:4], messages)
+
+	// A thread where the viewer rarely posts is scanned across pages until the cap, which returns where to resume.
+	var longThread []*lib.NewMessageEntry
+	for ii := 0; ii < MaxMessagesScannedForMessageDirection+10; ii++ {
+		sender := alice
+		if ii == 1500 {
+			sender = viewer
+		}
+		longThread = append(longThread, newMessage(sender, uint64(MaxMessagesScannedForMessageDirection+10-ii)))
+	}
+	messages, resumeTimestamp, err = fetchMessagesInDirection(
+		viewer.ToBytes(), MessageDirectionOutbound, fetchFrom(longThread), math.MaxUint64, 10)
+	require.NoError(t, err)
+	require.Equal(t, []*lib.NewMessageEntry{longThread[1500]}, messages)
+	require.NotNil(t, resumeTimestamp)
+	require.Equal(t, longThread[MaxMessagesScannedForMessageDirection-1].TimestampNanos, *resumeTimestamp)
+
+	_, err = validateMessageDirection(MessageDirectionInbound, true)
+	require.Error(t, err)
+	_, err = validateMessageDirection("SIDEWAYS", false)
+	require.Error(t, err)
+	direction, err := validateMessageDirection("", true)
+	require.NoError(t, err)
+	require.Equal(t, MessageDirectionAll, direction)
+}
+
+func TestValidateMessageToUpdate(t *testing.T) {
+	sender := lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString))
+	recipient := lib.NewPublicKey(lib.MustBase58CheckDecode(recipientPkString))
+	// Sorted newest first, as the view returns them.
+	thread := []*lib.NewMessageEntry{
+		{SenderAccessGroupOwnerPublicKey: recipient, TimestampNanos: 300},
+		{SenderAccessGroupOwnerPublicKey: sender, TimestampNanos: 200},
+		{SenderAccessGroupOwnerPublicKey: sender, TimestampNanos: 100},
+	}
+	fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		var messages []*lib.NewMessageEntry
+		for _, message := range thread {
+			if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+				messages = append(messages, message)
+			}
+		}
+		return messages, nil
+	}
+
+	// The sender can update their own messages.
+	require.NoError(t, validateMessageToUpdate(sender.ToBytes(), 200, fetchMessages))
+	require.NoError(t, validateMessageToUpdate(sender.ToBytes(), 100, fetchMessages))
+
+	// Timestamps that don't match a message are rejected, even if there's an older message.
+	err := validateMessageToUpdate(sender.ToBytes(), 150, fetchMessages)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "No message with TimestampNanos 150")
+	require.Error(t, validateMessageToUpdate(sender.ToBytes(), 50, fetchMessages))
+	require.Error(t, validateMessageToUpdate(sender.ToBytes(), math.MaxUint64, fetchMessages))
+
+	// So are messages the other party sent.
+	err = validateMessageToUpdate(sender.ToBytes(), 300, fetchMessages)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "wasn't sent by the sender")
+}
+
+func TestFetchMessagesAfterTimestamp(t *testing.T) {
+	// Sorted newest first, as the view returns them.
+	var thread []*lib.NewMessageEntry
+	for timestamp := uint64(2500); timestamp >= 100; timestamp -= 100 {
+		thread = append(thread, &lib.NewMessageEntry{TimestampNanos: timestamp})
+	}
+	fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		var messages []*lib.NewMessageEntry
+		for _, message := range thread {
+			if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+				messages = append(messages, message)
+			}
+		}
+		return messages, nil
+	}
+	timestamps := func(messages []*lib.NewMessageEntry) []uint64 {
+		var res []uint64
+		for _, message := range messages {
+			res = append(res, message.TimestampNanos)
+		}
+		return res
+	}
+
+	// Only messages strictly newer than the cursor are returned, oldest first.
+	messages, hasMore, err := fetchMessagesAfterTimestamp(fetchMessages, 2200, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2300, 2400, 2500}, timestamps(messages))
+	require.False(t, hasMore)
+
+	// A cursor between two messages behaves the same as the older one.
+	messages, hasMore, err = fetchMessagesAfterTimestamp(fetchMessages, 2250, 10)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2300, 2400, 2500}, timestamps(messages))
+	require.False(t, hasMore)
+
+	// When there are more new messages than requested, the oldest ones are returned so the client can keep polling
+	// from the last one without gaps.
+	messages, hasMore, err = fetchMessagesAfterTimestamp(fetchMessages, 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{100, 200, 300}, timestamps(messages))
+	require.True(t, hasMore)
+	messages, hasMore, err = fetchMessagesAfterTimestamp(fetchMessages, 2100, 4)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2200, 2300, 2400, 2500}, timestamps(messages))
+	require.False(t, hasMore)
+
+	// Nothing is newer than the latest message.
+	messages, hasMore, err = fetchMessagesAfterTimestamp(fetchMessages, 2500, 10)
+	require.NoError(t, err)
+	require.Empty(t, messages)
+	require.False(t, hasMore)
+}
+
+func TestMergeRecentMessagesAcrossThreads(t *testing.T) {
+	// Counts the fetches of each thread beyond the initial read of its latest message.
+	fullFetches := make(map[string]int)
+	newThread := func(name string, chatType ChatType, timestamps ...uint64) messageThreadFetcher {
+		// Sorted newest first, as the view returns them.
+		var thread []*lib.NewMessageEntry
+		for _, timestamp := range timestamps {
+			thread = append(thread, &lib.NewMessageEntry{TimestampNanos: timestamp})
+		}
+		return messageThreadFetcher{
+			chatType: chatType,
+			fetchMessages: func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+				if maxMessagesToFetch > 1 {
+					fullFetches[name]++
+				}
+				var messages []*lib.NewMessageEntry
+				for _, message := range thread {
+					if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+						messages = append(messages, message)
+					}
+				}
+				return messages, nil
+			},
+		}
+	}
+	threads := []messageThreadFetcher{
+		newThread("stale", ChatTypeDM, 300, 200),
+		newThread("dm", ChatTypeDM, 1000, 700, 400),
+		newThread("empty", ChatTypeGroupChat),
+		newThread("group", ChatTypeGroupChat, 900, 800, 100),
+	}
+	timestampsAndChatTypes := func(messages []threadMessageEntry) ([]uint64, []ChatType) {
+		var timestamps []uint64
+		var chatTypes []ChatType
+		for _, message := range messages {
+			timestamps = append(timestamps, message.message.TimestampNanos)
+			chatTypes = append(chatTypes, message.chatType)
+		}
+		return timestamps, chatTypes
+	}
+
+	// The three newest messages come from two threads. The stale thread's latest message is older than all of them,
+	// so it's never fetched in full.
+	messages, err := mergeRecentMessagesAcrossThreads(threads, 3)
+	require.NoError(t, err)
+	timestamps, chatTypes := timestampsAndChatTypes(messages)
+	require.Equal(t, []uint64{1000, 900, 800}, timestamps)
+	require.Equal(t, []ChatType{ChatTypeDM, ChatTypeGroupChat, ChatTypeGroupChat}, chatTypes)
+	require.Equal(t, map[string]int{"dm": 1, "group": 1}, fullFetches)
+
+	// With room for every message, every thread is merged in.
+	fullFetches = make(map[string]int)
+	messages, err = mergeRecentMessagesAcrossThreads(threads, 10)
+	require.NoError(t, err)
+	timestamps, _ = timestampsAndChatTypes(messages)
+	require.Equal(t, []uint64{1000, 900, 800, 700, 400, 300, 200, 100}, timestamps)
+	require.Equal(t, map[string]int{"dm": 1, "group": 1, "stale": 1}, fullFetches)
+
+	// A user without messages gets an empty list.
+	messages, err = mergeRecentMessagesAcrossThreads(nil, 10)
+	require.NoError(t, err)
+	require.Empty(t, messages)
+}
+
+func TestCountMessagesAfterTimestamp(t *testing.T) {
+	// Sorted newest first, as the view returns them.
+	var thread []*lib.NewMessageEntry
+	for timestamp := uint64(2500); timestamp >= 100; timestamp -= 100 {
+		thread = append(thread, &lib.NewMessageEntry{TimestampNanos: timestamp})
+	}
+	fetchMessages := func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+		var messages []*lib.NewMessageEntry
+		for _, message := range thread {
+			if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+				messages = append(messages, message)
+			}
+		}
+		return messages, nil
+	}
+
+	// Only messages strictly newer than the last read timestamp are unread.
+	count, truncated, err := countMessagesAfterTimestamp(fetchMessages, 2200, 100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), count)
+	require.False(t, truncated)
+	count, truncated, err = countMessagesAfterTimestamp(fetchMessages, 2500, 100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), count)
+	require.False(t, truncated)
+
+	// A thread that has never been read is fully unread.
+	count, truncated, err = countMessagesAfterTimestamp(fetchMessages, 0, 100)
+	require.NoError(t, err)
+	require.Equal(t, uint64(25), count)
+	require.False(t, truncated)
+
+	// The count stops at the scan limit, but only reports truncation if there were more unread messages.
+	count, truncated, err = countMessagesAfterTimestamp(fetchMessages, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), count)
+	require.True(t, truncated)
+	count, truncated, err = countMessagesAfterTimestamp(fetchMessages, 0, 25)
+	require.NoError(t, err)
+	require.Equal(t, uint64(25), count)
+	require.False(t, truncated)
+	count, truncated, err = countMessagesAfterTimestamp(fetchMessages, 1500, 10)
+	require.NoError(t, err)
+	require.Equal(t, uint64(10), count)
+	require.False(t, truncated)
+}
+
+func TestFetchDmThreadMessagesPage(t *testing.T) {
+	sender := lib.NewPublicKey(lib.MustBase58CheckDecode(senderPkString))
+	recipient := lib.NewPublicKey(lib.MustBase58CheckDecode(recipientPkString))
+	baseKeyName := lib.NewGroupKeyName([]byte{})
+	defaultKeyName := lib.NewGroupKeyName([]byte("default-key"))
+	newMessage := func(
+		timestampNanos uint64, senderKeyName *lib.GroupKeyName, recipientKeyName *lib.GroupKeyName,
+		fromRecipient bool,
+	) *lib.NewMessageEntry {
+		message := &lib.NewMessageEntry{
+			SenderAccessGroupOwnerPublicKey:    sender,
+			SenderAccessGroupKeyName:           senderKeyName,
+			RecipientAccessGroupOwnerPublicKey: recipient,
+			RecipientAccessGroupKeyName:        recipientKeyName,
+			TimestampNanos:                     timestampNanos,
+		}
+		if fromRecipient {
+			message.SenderAccessGroupOwnerPublicKey, message.RecipientAccessGroupOwnerPublicKey = recipient, sender
+		}
+		return message
+	}
+	// Messages sent through different pairs of access groups can share a timestamp.
+	thread := []*lib.NewMessageEntry{
+		newMessage(500, defaultKeyName, defaultKeyName, false),
+		newMessage(400, defaultKeyName, defaultKeyName, false),
+		newMessage(400, baseKeyName, baseKeyName, false),
+		newMessage(400, defaultKeyName, baseKeyName, true),
+		newMessage(300, baseKeyName, defaultKeyName, false),
+		newMessage(200, defaultKeyName, defaultKeyName, true),
+		newMessage(200, baseKeyName, baseKeyName, true),
+		newMessage(100, defaultKeyName, defaultKeyName, false),
+	}
+	// fetchMessages returns messages that share a timestamp in the order of thread, or reversed.
+	newFetchMessages := func(reverseTies bool) func(uint64, int) ([]*lib.NewMessageEntry, error) {
+		return func(startTimestamp uint64, maxMessagesToFetch int) ([]*lib.NewMessageEntry, error) {
+			sortedThread := append([]*lib.NewMessageEntry{}, thread...)
+			if reverseTies {
+				for ii, jj := 0, len(sortedThread)-1; ii < jj; ii, jj = ii+1, jj-1 {
+					sortedThread[ii], sortedThread[jj] = sortedThread[jj], sortedThread[ii]
+				}
+			}
+			sort.SliceStable(sortedThread, func(ii, jj int) bool {
+				return sortedThread[ii].TimestampNanos > sortedThread[jj].TimestampNanos
+			})
+			var messages []*lib.NewMessageEntry
+			for _, message := range sortedThread {
+				if message.TimestampNanos < startTimestamp && len(messages) < maxMessagesToFetch {
+					messages = append(messages, message)
+				}
+			}
+			return messages, nil
+		}
+	}
+	paginate := func(reverseTies bool, maxMessagesToFetch int) [][]*lib.NewMessageEntry {
+		fetchMessages := newFetchMessages(reverseTies)
+		var pages [][]*lib.NewMessageEntry
+		var cursor *DmThreadMessagesCursor
+		for {
+			page, nextCursor, err := fetchDmThreadMessagesPage(fetchMessages, math.MaxUint64, cursor, maxMessagesToFetch)
+			require.NoError(t, err)
+			pages = append(pages, page)
+			if nextCursor == nil {
+				return pages
+			}
+			// The cursor survives the round trip through its opaque encoding.
+			encodedCursor, err := EncodeDmThreadMessagesCursor(nextCursor)
+			require.NoError(t, err)
+			cursor, err = DecodeDmThreadMessagesCursor(encodedCursor)
+			require.NoError(t, err)
+			require.Equal(t, nextCursor, cursor)
+		}
+	}
+
+	for _, maxMessagesToFetch := range []int{1, 2, 3, len(thread), len(thread) + 1} {
+		pages := paginate(false, maxMessagesToFetch)
+
+		// Every message is returned exactly once, even when a page boundary falls between messages that share a
+		// timestamp, newest first and then by tiebreaker.
+		var allMessages []*lib.NewMessageEntry
+		for ii, page := range pages {
+			if ii < len(pages)-1 {
+				require.Len(t, page, maxMessagesToFetch)
+			}
+			allMessages = append(allMessages, page...)
+		}
+		require.ElementsMatch(t, thread, allMessages)
+		require.True(t, sort.SliceIsSorted(allMessages, func(ii, jj int) bool {
+			if allMessages[ii].TimestampNanos != allMessages[jj].TimestampNanos {
+				return allMessages[ii].TimestampNanos > allMessages[jj].TimestampNanos
+			}
+			return getDmMessageTiebreaker(allMessages[ii]) < getDmMessageTiebreaker(allMessages[jj])
+		}))
+
+		// The pages don't depend on the order the thread returns messages that share a timestamp in.
+		require.Equal(t, pages, paginate(true, maxMessagesToFetch))
+	}
+
+	// Without a cursor, the page starts before StartTimestamp.
+	page, nextCursor, err := fetchDmThreadMessagesPage(newFetchMessages(false), 300, nil, 10)
+	require.NoError(t, err)
+	require.Nil(t, nextCursor)
+	require.Len(t, page, 3)
+	require.Equal(t, uint64(200), page[0].TimestampNanos)
+
+	// Cursors from other versions are rejected.
+	encodedCursor, err := EncodeDmThreadMessagesCursor(&DmThreadMessagesCursor{Version: 2, TimestampNanos: 400})
+	require.NoError(t, err)
+	_, err = DecodeDmThreadMessagesCursor(encodedCursor)
+	require.Error(t, err)
+	_, err = DecodeDmThreadMessagesCursor("not a cursor")
+	require.Error(t, err)
+}
+
+func TestSendDmMessageBatch(t *testing.T) {
+	requestData := &SendDmMessageBatchRequest{
+		SenderAccessGroupOwnerPublicKeyBase58Check: senderPkString,
+		SenderAccessGroupPublicKeyBase58Check:      senderPkString,
+		SenderAccessGroupKeyName:                   "default-key",
+		Recipients: []SendDmMessageBatchRecipient{
+			{RecipientAccessGroupOwnerPublicKeyBase58Check: recipientPkString, EncryptedMessageText: "aa"},
+			{RecipientAccessGroupOwnerPublicKeyBase58Check: "bad", EncryptedMessageText: "bb"},
+			{RecipientAccessGroupOwnerPublicKeyBase58Check: moneyPkString, EncryptedMessageText: "cc"},
+		},
+		MinFeeRateNanosPerKB: 1000,
+		ContentType:          MessageContentTypeMarkdown,
+	}
+
+	var builtRequests []SendNewMessageRequest
+	results := sendDmMessageBatch(requestData, func(messageRequest *SendNewMessageRequest) (
+		*SendNewMessageResponse, error) {
+		builtRequests = append(builtRequests, *messageRequest)
+		if messageRequest.RecipientAccessGroupOwnerPublicKeyBase58Check == "bad" {
+			return nil, fmt.Errorf("invalid public key")
+		}
+		return &SendNewMessageResponse{TransactionHex: messageRequest.EncryptedMessageText}, nil
+	})
+
+	// Every recipient is attempted, with the shared sender fields and their own message.
+	require.Len(t, builtRequests, 3)
+	for ii, builtRequest := range builtRequests {
+		require.Equal(t, senderPkString, builtRequest.SenderAccessGroupOwnerPublicKeyBase58Check)
+		require.Equal(t, "default-key", builtRequest.SenderAccessGroupKeyName)
+		require.Equal(t, uint64(1000), builtRequest.MinFeeRateNanosPerKB)
+		require.Equal(t, MessageContentTypeMarkdown, builtRequest.ContentType)
+		require.Equal(t, requestData.Recipients[ii].EncryptedMessageText, builtRequest.EncryptedMessageText)
+	}
+
+	// The failed recipient doesn't stop the ones after it.
+	require.Len(t, results, 3)
+	require.Equal(t, SendDmMessageBatchResult{Index: 0, Response: &SendNewMessageResponse{TransactionHex: "aa"}},
+		results[0])
+	require.Equal(t, 1, results[1].Index)
+	require.Nil(t, results[1].Response)
+	require.Contains(t, results[1].Error, "invalid public key")
+	require.Equal(t, SendDmMessageBatchResult{Index: 2, Response: &SendNewMessageResponse{TransactionHex: "cc"}},
+		results[2])
+}
+
+func TestValidateRecipientAccessGroupExists(t *testing.T) {
+	recipientPkBytes := lib.MustBase58CheckDecode(recipientPkString)
+	registeredKeyName := []byte("registered")
+	getAccessGroupEntry := func(owner *lib.PublicKey, keyName *lib.GroupKeyName) (*lib.AccessGroupEntry, error) {
+		if !bytes.Equal(owner.ToBytes(), recipientPkBytes) {
+			return nil, nil
+		}
+		if !lib.EqualGroupKeyName(keyName, lib.NewGroupKeyName(registeredKeyName)) {
+			return nil, nil
+		}
+		return &lib.AccessGroupEntry{
+			AccessGroupOwnerPublicKey: owner,
+			AccessGroupKeyName:        keyName,
+			AccessGroupPublicKey:      lib.NewPublicKey(generateRandomPublicKey(t)),
+		}, nil
+	}
+
+	// An existing recipient group and the base key are accepted.
+	require.NoError(t, validateRecipientAccessGroupExists(recipientPkBytes, registeredKeyName, getAccessGroupEntry))
+	require.NoError(t, validateRecipientAccessGroupExists(
+		recipientPkBytes, lib.BaseGroupKeyName().ToBytes(), getAccessGroupEntry))
+
+	// A mistyped group or someone else's group is rejected.
+	err := validateRecipientAccessGroupExists(recipientPkBytes, []byte("registerd"), getAccessGroupEntry)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "registerd")
+	require.Error(t, validateRecipientAccessGroupExists(
+		lib.MustBase58CheckDecode(senderPkString), registeredKeyName, getAccessGroupEntry))
+}
+
+func TestPaginateUserMessageThreads(t *testing.T) {
+	// Each thread's latest message is tagged with its thread ID so pages can be checked by ID.
+	newThread := func(threadId string, timestampNanos uint64) UserMessageThread {
+		return UserMessageThread{
+			ThreadId: threadId,
+			LatestMessage: NewMessageEntryResponse{
+				MessageInfo: MessageInfo{EncryptedText: threadId, TimestampNanos: timestampNanos},
+			},
+		}
+	}
+	// Threads b, c, and d tie on the timestamp of their latest message, as do e and f.
+	threads := []UserMessageThread{
+		newThread("e", 10), newThread("d", 50), newThread("a", 90), newThread("f", 10),
+		newThread("b", 50), newThread("c", 50), newThread("g", 5),
+	}
+	threadIds := func(page []NewMessageEntryResponse) []string {
+		var ids []string
+		for _, thread := range page {
+			ids = append(ids, thread.MessageInfo.EncryptedText)
+		}
+		return ids
+	}
+
+	// Without a limit every thread is returned, newest first.
+	page, nextCursor, err := PaginateUserMessageThreads(threads, 0, nil)
+	require.NoError(t, err)
+	require.Empty(t, nextCursor)
+	require.Equal(t, []string{"a", "b", "c", "d", "e", "f", "g"}, threadIds(page))
+
+	// Paging two at a time splits the ties across pages without skipping or repeating a thread.
+	var pages [][]string
+	var cursor *UserMessageThreadsCursor
+	for {
+		page, nextCursor, err = PaginateUserMessageThreads(threads, 2, cursor)
+		require.NoError(t, err)
+		pages = append(pages, threadIds(page))
+		if nextCursor == "" {
+			break
+		}
+		cursor, err = DecodeUserMessageThreadsCursor(nextCursor)
+		require.NoError(t, err)
+	}
+	require.Equal(t, [][]string{{"a", "b"}, {"c", "d"}, {"e", "f"}, {"g"}}, pages)
+
+	// A page that ends exactly at the last thread has no next cursor.
+	_, nextCursor, err = PaginateUserMessageThreads(threads, len(threads), nil)
+	require.NoError(t, err)
+	require.Empty(t, nextCursor)
+
+	_, err = DecodeUserMessageThreadsCursor("not a cursor")
+	require.Error(t, err)
+	staleCursor, err := EncodeUserMessageThreadsCursor(&UserMessageThreadsCursor{Version: 0, TimestampNanos: 50})
+	require.NoError(t, err)
+	_, err = DecodeUserMessageThreadsCursor(staleCursor)
+	require.Error(t, err)
+}
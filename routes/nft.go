@@ -106,7 +106,7 @@ type CreateNFTResponse struct {
 func (fes *APIServer) CreateNFT(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CreateNFTRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateNFT: Error parsing request body: %v", err))
 		return
 	}
@@ -329,7 +329,7 @@ type UpdateNFTResponse struct {
 func (fes *APIServer) UpdateNFT(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UpdateNFTRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("UpdateNFT: Error parsing request body: %v", err))
 		return
 	}
@@ -484,7 +484,7 @@ type CreateNFTBidResponse struct {
 func (fes *APIServer) CreateNFTBid(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CreateNFTBidRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateNFTBid: Error parsing request body: %v", err))
 		return
 	}
@@ -650,7 +650,7 @@ type AcceptNFTBidResponse struct {
 func (fes *APIServer) AcceptNFTBid(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AcceptNFTBidRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AcceptNFTBid: Error parsing request body: %v", err))
 		return
 	}
@@ -797,7 +797,7 @@ type GetNFTShowcaseResponse struct {
 func (fes *APIServer) GetNFTShowcase(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNFTShowcaseRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTShowcase: Error parsing request body: %v", err))
 		return
 	}
@@ -839,7 +839,7 @@ func (fes *APIServer) GetNFTShowcase(ww http.ResponseWriter, req *http.Request)
 	}
 
 	// Now that we have the drop entry, fetch the NFTs.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetNFTShowcase")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTShowcase: Error getting utxoView: %v", err))
 		return
@@ -895,7 +895,7 @@ type GetNextNFTShowcaseResponse struct {
 func (fes *APIServer) GetNextNFTShowcase(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNextNFTShowcaseRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNextNFTShowcase: Error parsing request body: %v", err))
 		return
 	}
@@ -945,7 +945,7 @@ type GetNFTsForUserResponse struct {
 func (fes *APIServer) GetNFTsForUser(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNFTsForUserRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTsForUser: Error parsing request body: %v", err))
 		return
 	}
@@ -970,7 +970,7 @@ func (fes *APIServer) GetNFTsForUser(ww http.ResponseWriter, req *http.Request)
 	}
 
 	// Get the NFT bid so we can do a more hardcore validation of the request data.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetNFTsForUser")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTsForUser: Error getting utxoView: %v", err))
 		return
@@ -1061,7 +1061,7 @@ type GetNFTBidsForUserResponse struct {
 func (fes *APIServer) GetNFTBidsForUser(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNFTBidsForUserRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTBidsForUser: Error parsing request body: %v", err))
 		return
 	}
@@ -1089,7 +1089,7 @@ func (fes *APIServer) GetNFTBidsForUser(ww http.ResponseWriter, req *http.Reques
 	}
 
 	// Get the NFT bid so we can do a more hardcore validation of the request data.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetNFTBidsForUser")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTBidsForUser: Error getting utxoView: %v", err))
 		return
@@ -1150,7 +1150,7 @@ type GetNFTBidsForNFTPostResponse struct {
 func (fes *APIServer) GetNFTBidsForNFTPost(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNFTBidsForNFTPostRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTBidsForNFTPost: Error parsing request body: %v", err))
 		return
 	}
@@ -1181,7 +1181,7 @@ func (fes *APIServer) GetNFTBidsForNFTPost(ww http.ResponseWriter, req *http.Req
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetNFTBidsForNFTPost")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTBidsForNFTPost: Error getting utxoView: %v", err))
 		return
@@ -1232,7 +1232,7 @@ type GetNFTCollectionSummaryResponse struct {
 func (fes *APIServer) GetNFTCollectionSummary(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNFTCollectionSummaryRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTCollectionSummary: Error parsing request body: %v", err))
 		return
 	}
@@ -1253,7 +1253,7 @@ func (fes *APIServer) GetNFTCollectionSummary(ww http.ResponseWriter, req *http.
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetNFTCollectionSummary")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTCollectionSummary: Error getting utxoView: %v", err))
 		return
@@ -1324,7 +1324,7 @@ type GetNFTEntriesForPostHashResponse struct {
 func (fes *APIServer) GetNFTEntriesForPostHash(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNFTEntriesForPostHashRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTEntriesForPostHash: Error parsing request body: %v", err))
 		return
 	}
@@ -1344,7 +1344,7 @@ func (fes *APIServer) GetNFTEntriesForPostHash(ww http.ResponseWriter, req *http
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetNFTEntriesForPostHash")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTEntriesForPostHash: Error getting utxoView: %v", err))
 		return
@@ -1571,7 +1571,7 @@ type TransferNFTResponse struct {
 func (fes *APIServer) TransferNFT(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := TransferNFTRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("TransferNFT: Error parsing request body: %v", err))
 		return
 	}
@@ -1737,7 +1737,7 @@ type AcceptNFTTransferResponse struct {
 func (fes *APIServer) AcceptNFTTransfer(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AcceptNFTTransferRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AcceptNFTTransfer: Error parsing request body: %v", err))
 		return
 	}
@@ -1881,7 +1881,7 @@ type BurnNFTResponse struct {
 func (fes *APIServer) BurnNFT(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := BurnNFTRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("BurnNFT: Error parsing request body: %v", err))
 		return
 	}
@@ -2027,13 +2027,13 @@ type GetNFTsCreatedByPublicKeyResponse struct {
 func (fes *APIServer) GetNFTsCreatedByPublicKey(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNFTsCreatedByPublicKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTsCreatedByPublicKey: Error parsing request body: %v", err))
 		return
 	}
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetNFTsCreatedByPublicKey")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetNFTsCreatedByPublicKey: Error getting utxoView: %v", err))
 		return
@@ -2174,7 +2174,7 @@ func (fes *APIServer) GetAcceptedBidHistory(ww http.ResponseWriter, req *http.Re
 	copy(nftPostHash[:], nftPostHashBytes)
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetAcceptedBidHistory")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetAcceptedBidHistory: Error getting utxoView: %v", err))
 		return
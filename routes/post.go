@@ -295,7 +295,7 @@ func (fes *APIServer) GetAllPostEntries(readerPK []byte) (
 	_postEntryReaderStates map[lib.BlockHash]*lib.PostEntryReaderState, err error) {
 
 	// Get a view with all the mempool transactions (used to get all posts / reader state).
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetAllPostEntries")
 	if err != nil {
 		return nil, nil, nil, nil, fmt.Errorf("GetPostsStateless: Error fetching mempool view: %v", err)
 	}
@@ -397,7 +397,7 @@ func (fes *APIServer) GetPostEntriesByDESOAfterTimePaginated(readerPK []byte,
 	startTstampNanos := uint64(currentTime) - (uint64(time.Minute.Nanoseconds()) * minutesLookback)
 
 	// Get a view with all the mempool transactions (used to get all posts / reader state).
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetPostEntriesByDESOAfterTimePaginated")
 	if err != nil {
 		return nil, nil, fmt.Errorf("GetPostEntriesByDESO: Error fetching mempool view: %v", err)
 	}
@@ -841,7 +841,7 @@ func (fes *APIServer) GetGlobalFeedPostHashesForLastWeek() (_postHashes []*lib.B
 func (fes *APIServer) GetPostsStateless(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetPostsStatelessRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetPostsStateless: Problem parsing request body: %v", err))
 		return
 	}
@@ -864,7 +864,7 @@ func (fes *APIServer) GetPostsStateless(ww http.ResponseWriter, req *http.Reques
 	}
 
 	// Get a view with all the mempool transactions (used to get all posts / reader state).
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetPostsStateless")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetPostsStateless: Error fetching mempool view"))
 		return
@@ -1044,7 +1044,7 @@ type GetPostsHashHexListResponse struct {
 func (fes *APIServer) GetPostsHashHexList(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetPostsHashHexListRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetPostsHashHexList: Problem parsing request body: %v", err))
 		return
 	}
@@ -1082,7 +1082,7 @@ func (fes *APIServer) GetPostsHashHexList(ww http.ResponseWriter, req *http.Requ
 	}
 
 	// Get a view with all the mempool transactions.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetPostsHashHexList")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetPostsHashHexList: Error constructing utxoView: %v", err))
 		return
@@ -1226,7 +1226,7 @@ type GetSinglePostResponse struct {
 func (fes *APIServer) GetSinglePost(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetSinglePostRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetSinglePost: Problem parsing request body: %v", err))
 		return
 	}
@@ -1259,7 +1259,7 @@ func (fes *APIServer) GetSinglePost(ww http.ResponseWriter, req *http.Request) {
 	}
 
 	// Get a view with all the mempool transactions.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetSinglePost")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetSinglePost: Error constructing utxoView: %v", err))
 		return
@@ -1694,7 +1694,7 @@ type GetPostsForPublicKeyResponse struct {
 func (fes *APIServer) GetPostsForPublicKey(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetPostsForPublicKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetPostsForPublicKey: Error parsing request body: %v", err))
 		return
 	}
@@ -1705,7 +1705,7 @@ func (fes *APIServer) GetPostsForPublicKey(ww http.ResponseWriter, req *http.Req
 	}
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetPostsForPublicKey")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetPostsForPublicKey: Error getting utxoView: %v", err))
 		return
@@ -1844,14 +1844,14 @@ type GetPostsDiamondedBySenderForReceiverResponse struct {
 func (fes *APIServer) GetDiamondedPosts(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetPostsDiamondedBySenderForReceiverRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetDiamondedPosts: Problem parsing request body: %v", err))
 		return
 	}
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetDiamondedPosts")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetDiamondedPosts: Error getting utxoView: %v", err))
 		return
@@ -2010,7 +2010,7 @@ type GetLikesForPostResponse struct {
 func (fes *APIServer) GetLikesForPost(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetLikesForPostRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww,
 			fmt.Sprintf("GetLikesForPost: Problem parsing request body: %v", err))
 		return
@@ -2034,7 +2034,7 @@ func (fes *APIServer) GetLikesForPost(ww http.ResponseWriter, req *http.Request)
 	}
 
 	// Get a view with all the mempool transactions.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetLikesForPost")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetLikesForPost: Error constructing utxoView: %v", err))
 		return
@@ -2131,7 +2131,7 @@ type DiamondSenderResponse struct {
 func (fes *APIServer) GetDiamondsForPost(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetDiamondsForPostRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetDiamondsForPost: Problem parsing request body: %v", err))
 		return
 	}
@@ -2154,7 +2154,7 @@ func (fes *APIServer) GetDiamondsForPost(ww http.ResponseWriter, req *http.Reque
 	}
 
 	// Get a view with all the mempool transactions.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetDiamondsForPost")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetDiamondsForPost: Error constructing utxoView: %v", err))
 		return
@@ -2266,7 +2266,7 @@ type GetRepostsForPostResponse struct {
 func (fes *APIServer) GetRepostsForPost(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetRepostsForPostRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetRepostsForPost: Problem parsing request body: %v", err))
 		return
 	}
@@ -2288,7 +2288,7 @@ func (fes *APIServer) GetRepostsForPost(ww http.ResponseWriter, req *http.Reques
 	}
 
 	// Get a view with all the mempool transactions.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetRepostsForPost")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetRepostsForPost: Error constructing utxoView: %v", err))
 		return
@@ -2384,7 +2384,7 @@ type GetQuoteRepostsForPostResponse struct {
 func (fes *APIServer) GetQuoteRepostsForPost(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetQuoteRepostsForPostRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetQuoteRepostsForPost: Problem parsing request body: %v", err))
 		return
 	}
@@ -2407,7 +2407,7 @@ func (fes *APIServer) GetQuoteRepostsForPost(ww http.ResponseWriter, req *http.R
 	}
 
 	// Get a view with all the mempool transactions.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetQuoteRepostsForPost")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetQuoteRepostsForPost: Error constructing utxoView: %v", err))
 		return
@@ -0,0 +1,301 @@
+package routes
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// KeyedRateLimiter is a fixed-window rate limiter that allows up to limit events per key in each window. All keys
+// share the same window so that memory is bounded by the number of distinct keys seen within a single window.
+type KeyedRateLimiter struct {
+	mtx sync.Mutex
+
+	limit  uint64
+	window time.Duration
+
+	windowStart time.Time
+	counts      map[string]uint64
+}
+
+// NewKeyedRateLimiter creates a rate limiter. A limit of zero disables rate limiting.
+func NewKeyedRateLimiter(limit uint64, window time.Duration) *KeyedRateLimiter {
+	return &KeyedRateLimiter{
+		limit:       limit,
+		window:      window,
+		windowStart: time.Now(),
+		counts:      make(map[string]uint64),
+	}
+}
+
+// Allow records an event for key and returns false if key has exceeded its limit for the current window.
+func (limiter *KeyedRateLimiter) Allow(key string) bool {
+	if limiter == nil || limiter.limit == 0 {
+		return true
+	}
+	limiter.mtx.Lock()
+	defer limiter.mtx.Unlock()
+
+	if time.Since(limiter.windowStart) >= limiter.window {
+		limiter.windowStart = time.Now()
+		limiter.counts = make(map[string]uint64)
+	}
+	if limiter.counts[key] >= limiter.limit {
+		return false
+	}
+	limiter.counts[key]++
+	return true
+}
+
+// ipRateLimiterSweepInterval is how often idle buckets are dropped from an IPRateLimiter.
+const ipRateLimiterSweepInterval = time.Minute
+
+// IPRateLimiter is a token bucket rate limiter keyed by client IP. Each IP may make burst requests at once, and its
+// bucket refills at requestsPerSecond.
+type IPRateLimiter struct {
+	mtx sync.Mutex
+
+	requestsPerSecond float64
+	burst             float64
+	trustedProxies    []*net.IPNet
+
+	buckets   map[string]*ipTokenBucket
+	lastSweep time.Time
+
+	// now is swapped out in tests.
+	now func() time.Time
+}
+
+type ipTokenBucket struct {
+	tokens     float64
+	lastRefill time.Time
+}
+
+// NewIPRateLimiter creates a rate limiter. A requestsPerSecond of zero disables rate limiting. A burst of zero allows
+// one second's worth of requests at once. trustedProxies is a list of IPs or CIDR ranges whose X-Forwarded-For
+// headers are honored.
+func NewIPRateLimiter(requestsPerSecond float64, burst uint64, trustedProxies []string) (*IPRateLimiter, error) {
+	if burst == 0 {
+		burst = uint64(math.Max(1, math.Ceil(requestsPerSecond)))
+	}
+	limiter := &IPRateLimiter{
+		requestsPerSecond: requestsPerSecond,
+		burst:             float64(burst),
+		buckets:           make(map[string]*ipTokenBucket),
+		now:               time.Now,
+	}
+	for _, trustedProxy := range trustedProxies {
+		trustedProxy = strings.TrimSpace(trustedProxy)
+		if trustedProxy == "" {
+			continue
+		}
+		if !strings.Contains(trustedProxy, "/") {
+			if ip := net.ParseIP(trustedProxy); ip != nil && ip.To4() != nil {
+				trustedProxy += "/32"
+			} else {
+				trustedProxy += "/128"
+			}
+		}
+		_, ipNet, err := net.ParseCIDR(trustedProxy)
+		if err != nil {
+			return nil, fmt.Errorf("NewIPRateLimiter: Invalid trusted proxy %v: %v", trustedProxy, err)
+		}
+		limiter.trustedProxies = append(limiter.trustedProxies, ipNet)
+	}
+	limiter.lastSweep = limiter.now()
+	return limiter, nil
+}
+
+// Allow takes a token from ip's bucket. If the bucket is empty, it returns false along with how long the client
+// should wait before retrying.
+func (limiter *IPRateLimiter) Allow(ip string) (bool, time.Duration) {
+	if limiter == nil || limiter.requestsPerSecond <= 0 {
+		return true, 0
+	}
+	limiter.mtx.Lock()
+	defer limiter.mtx.Unlock()
+
+	now := limiter.now()
+	if now.Sub(limiter.lastSweep) >= ipRateLimiterSweepInterval {
+		limiter.sweep(now)
+	}
+
+	bucket, exists := limiter.buckets[ip]
+	if !exists {
+		bucket = &ipTokenBucket{tokens: limiter.burst, lastRefill: now}
+		limiter.buckets[ip] = bucket
+	}
+	limiter.refill(bucket, now)
+
+	if bucket.tokens < 1 {
+		retryAfter := time.Duration((1 - bucket.tokens) / limiter.requestsPerSecond * float64(time.Second))
+		return false, retryAfter
+	}
+	bucket.tokens--
+	return true, 0
+}
+
+func (limiter *IPRateLimiter) refill(bucket *ipTokenBucket, now time.Time) {
+	elapsed := now.Sub(bucket.lastRefill).Seconds()
+	bucket.tokens = math.Min(limiter.burst, bucket.tokens+elapsed*limiter.requestsPerSecond)
+	bucket.lastRefill = now
+}
+
+// sweep drops buckets that have refilled completely, since they are indistinguishable from new buckets.
+func (limiter *IPRateLimiter) sweep(now time.Time) {
+	for ip, bucket := range limiter.buckets {
+		limiter.refill(bucket, now)
+		if bucket.tokens >= limiter.burst {
+			delete(limiter.buckets, ip)
+		}
+	}
+	limiter.lastSweep = now
+}
+
+// ClientIP returns the IP address of the client that made the request. X-Forwarded-For is only honored when the
+// request comes from a trusted proxy, in which case the rightmost address that isn't a trusted proxy is used.
+func (limiter *IPRateLimiter) ClientIP(req *http.Request) string {
+	remoteIP, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		remoteIP = req.RemoteAddr
+	}
+	if !limiter.isTrustedProxy(remoteIP) {
+		return remoteIP
+	}
+
+	forwardedFor := req.Header.Values("X-Forwarded-For")
+	var forwardedIPs []string
+	for _, header := range forwardedFor {
+		for _, forwardedIP := range strings.Split(header, ",") {
+			if forwardedIP = strings.TrimSpace(forwardedIP); forwardedIP != "" {
+				forwardedIPs = append(forwardedIPs, forwardedIP)
+			}
+		}
+	}
+	clientIP := remoteIP
+	for ii := len(forwardedIPs) - 1; ii >= 0; ii-- {
+		clientIP = forwardedIPs[ii]
+		if !limiter.isTrustedProxy(clientIP) {
+			break
+		}
+	}
+	return clientIP
+}
+
+func (limiter *IPRateLimiter) isTrustedProxy(ipString string) bool {
+	ip := net.ParseIP(ipString)
+	if limiter == nil || ip == nil {
+		return false
+	}
+	for _, trustedProxy := range limiter.trustedProxies {
+		if trustedProxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// RateLimitByIP is middleware that rejects requests with a 429 once the client's IP has exceeded its rate limit.
+func RateLimitByIP(inner http.Handler, limiter *IPRateLimiter) http.Handler {
+	return http.HandlerFunc(func(ww http.ResponseWriter, rr *http.Request) {
+		if limiter == nil || limiter.requestsPerSecond <= 0 {
+			inner.ServeHTTP(ww, rr)
+			return
+		}
+
+		allowed, retryAfter := limiter.Allow(limiter.ClientIP(rr))
+		if !allowed {
+			ww.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			_AddTooManyRequestsError(ww, "RateLimitByIP: Too many requests, please try again later")
+			return
+		}
+		inner.ServeHTTP(ww, rr)
+	})
+}
+
+// RouteConcurrencyLimiter caps how many requests to each route can be in flight at once. Unlike the rate limiters
+// above, it bounds concurrency rather than request frequency, so expensive endpoints can't pile up goroutines.
+type RouteConcurrencyLimiter struct {
+	// Each route with a limit has a semaphore whose capacity is the limit. The map is never modified after
+	// construction, so it's safe to read without a lock.
+	semaphores map[string]chan struct{}
+}
+
+// NewRouteConcurrencyLimiter creates a concurrency limiter from a list of RouteName=MaxInFlightRequests entries, e.g.
+// "GetDAOCoinLimitOrders=16". Routes without an entry are not limited.
+func NewRouteConcurrencyLimiter(routeLimits []string) (*RouteConcurrencyLimiter, error) {
+	limiter := &RouteConcurrencyLimiter{
+		semaphores: make(map[string]chan struct{}),
+	}
+	for _, routeLimit := range routeLimits {
+		routeLimit = strings.TrimSpace(routeLimit)
+		if routeLimit == "" {
+			continue
+		}
+		routeName, limitString, found := strings.Cut(routeLimit, "=")
+		if !found {
+			return nil, fmt.Errorf("NewRouteConcurrencyLimiter: Expected RouteName=MaxInFlightRequests but got %v",
+				routeLimit)
+		}
+		limit, err := strconv.ParseUint(strings.TrimSpace(limitString), 10, 32)
+		if err != nil || limit == 0 {
+			return nil, fmt.Errorf("NewRouteConcurrencyLimiter: Invalid limit for route %v: %v", routeName, limitString)
+		}
+		limiter.semaphores[strings.TrimSpace(routeName)] = make(chan struct{}, limit)
+	}
+	return limiter, nil
+}
+
+// TryAcquire reserves an in-flight slot for routeName. If it returns true, the caller must call Release once the
+// request is done.
+func (limiter *RouteConcurrencyLimiter) TryAcquire(routeName string) bool {
+	semaphore, exists := limiter.semaphores[routeName]
+	if !exists {
+		return true
+	}
+	select {
+	case semaphore <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+// Release frees a slot reserved by TryAcquire.
+func (limiter *RouteConcurrencyLimiter) Release(routeName string) {
+	if semaphore, exists := limiter.semaphores[routeName]; exists {
+		<-semaphore
+	}
+}
+
+// InFlight returns the number of requests to routeName currently being served. It's always zero for routes without
+// a limit, since those aren't tracked.
+func (limiter *RouteConcurrencyLimiter) InFlight(routeName string) int {
+	return len(limiter.semaphores[routeName])
+}
+
+// LimitConcurrency is middleware that rejects requests to routeName with a 503 while the route is at its limit of
+// in-flight requests.
+func LimitConcurrency(inner http.Handler, limiter *RouteConcurrencyLimiter, routeName string) http.Handler {
+	if limiter == nil {
+		return inner
+	}
+	if _, exists := limiter.semaphores[routeName]; !exists {
+		return inner
+	}
+	return http.HandlerFunc(func(ww http.ResponseWriter, rr *http.Request) {
+		if !limiter.TryAcquire(routeName) {
+			ww.Header().Set("Retry-After", "1")
+			_AddServiceUnavailableError(ww, fmt.Sprintf("LimitConcurrency: Too many requests to %v in flight, "+
+				"please try again later", routeName))
+			return
+		}
+		defer limiter.Release(routeName)
+		inner.ServeHTTP(ww, rr)
+	})
+}
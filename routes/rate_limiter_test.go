@@ -0,0 +1,144 @@
+package routes
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPRateLimiter(t *testing.T) {
+	limiter, err := NewIPRateLimiter(2, 3, nil)
+	require.NoError(t, err)
+	now := time.Unix(0, 0)
+	limiter.now = func() time.Time { return now }
+
+	// A burst of 3 is allowed, and the next request must wait for a token.
+	for ii := 0; ii < 3; ii++ {
+		allowed, _ := limiter.Allow("1.1.1.1")
+		require.True(t, allowed)
+	}
+	allowed, retryAfter := limiter.Allow("1.1.1.1")
+	require.False(t, allowed)
+	require.Equal(t, 500*time.Millisecond, retryAfter)
+
+	// Other IPs have their own bucket.
+	allowed, _ = limiter.Allow("2.2.2.2")
+	require.True(t, allowed)
+
+	// Tokens refill at the configured rate.
+	now = now.Add(500 * time.Millisecond)
+	allowed, _ = limiter.Allow("1.1.1.1")
+	require.True(t, allowed)
+	allowed, _ = limiter.Allow("1.1.1.1")
+	require.False(t, allowed)
+
+	// A zero rate disables rate limiting.
+	limiter, err = NewIPRateLimiter(0, 0, nil)
+	require.NoError(t, err)
+	for ii := 0; ii < 100; ii++ {
+		allowed, _ = limiter.Allow("1.1.1.1")
+		require.True(t, allowed)
+	}
+
+	_, err = NewIPRateLimiter(1, 1, []string{"not an ip"})
+	require.Error(t, err)
+}
+
+func TestIPRateLimiterClientIP(t *testing.T) {
+	limiter, err := NewIPRateLimiter(1, 1, []string{"10.0.0.1", "192.168.0.0/16"})
+	require.NoError(t, err)
+
+	request := httptest.NewRequest("GET", RoutePathHealthCheck, nil)
+
+	// X-Forwarded-For is ignored unless the request comes from a trusted proxy.
+	request.RemoteAddr = "5.5.5.5:1234"
+	request.Header.Set("X-Forwarded-For", "6.6.6.6")
+	require.Equal(t, "5.5.5.5", limiter.ClientIP(request))
+
+	// Behind a trusted proxy, the rightmost untrusted address is the client. Addresses to its left can be spoofed.
+	request.RemoteAddr = "10.0.0.1:1234"
+	request.Header.Set("X-Forwarded-For", "7.7.7.7, 6.6.6.6, 192.168.1.1")
+	require.Equal(t, "6.6.6.6", limiter.ClientIP(request))
+
+	// A trusted proxy without the header is treated as the client.
+	request.Header.Del("X-Forwarded-For")
+	require.Equal(t, "10.0.0.1", limiter.ClientIP(request))
+}
+
+func TestRateLimitByIP(t *testing.T) {
+	limiter, err := NewIPRateLimiter(1, 2, nil)
+	require.NoError(t, err)
+	handler := RateLimitByIP(http.HandlerFunc(func(ww http.ResponseWriter, rr *http.Request) {}), limiter)
+
+	serve := func(remoteAddr string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest("GET", RoutePathHealthCheck, nil)
+		request.RemoteAddr = remoteAddr
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, request)
+		return response
+	}
+
+	require.Equal(t, http.StatusOK, serve("1.1.1.1:1").Code)
+	require.Equal(t, http.StatusOK, serve("1.1.1.1:2").Code)
+	response := serve("1.1.1.1:3")
+	require.Equal(t, http.StatusTooManyRequests, response.Code)
+	require.Equal(t, "1", response.Header().Get("Retry-After"))
+	require.Equal(t, http.StatusOK, serve("2.2.2.2:1").Code)
+}
+
+func TestLimitConcurrency(t *testing.T) {
+	limiter, err := NewRouteConcurrencyLimiter([]string{"GetDAOCoinLimitOrders=2"})
+	require.NoError(t, err)
+
+	// Requests block until released so that they stay in flight.
+	release := make(chan struct{})
+	started := make(chan struct{})
+	inner := http.HandlerFunc(func(ww http.ResponseWriter, rr *http.Request) {
+		started <- struct{}{}
+		<-release
+	})
+	handler := LimitConcurrency(inner, limiter, "GetDAOCoinLimitOrders")
+
+	serve := func() *httptest.ResponseRecorder {
+		response := httptest.NewRecorder()
+		handler.ServeHTTP(response, httptest.NewRequest("POST", RoutePathGetDaoCoinLimitOrders, nil))
+		return response
+	}
+
+	// Saturate the route.
+	responses := make(chan *httptest.ResponseRecorder, 2)
+	for ii := 0; ii < 2; ii++ {
+		go func() { responses <- serve() }()
+		<-started
+	}
+	require.Equal(t, 2, limiter.InFlight("GetDAOCoinLimitOrders"))
+
+	// Excess requests are rejected without reaching the handler.
+	for ii := 0; ii < 3; ii++ {
+		response := serve()
+		require.Equal(t, http.StatusServiceUnavailable, response.Code)
+		require.Equal(t, "1", response.Header().Get("Retry-After"))
+	}
+
+	// Once the in-flight requests finish, the route accepts requests again.
+	close(release)
+	for ii := 0; ii < 2; ii++ {
+		require.Equal(t, http.StatusOK, (<-responses).Code)
+	}
+	require.Equal(t, 0, limiter.InFlight("GetDAOCoinLimitOrders"))
+	go func() { <-started }()
+	require.Equal(t, http.StatusOK, serve().Code)
+
+	// Routes without a limit are always allowed.
+	for ii := 0; ii < 10; ii++ {
+		require.True(t, limiter.TryAcquire("GetAppState"))
+	}
+
+	_, err = NewRouteConcurrencyLimiter([]string{"GetDAOCoinLimitOrders"})
+	require.Error(t, err)
+	_, err = NewRouteConcurrencyLimiter([]string{"GetDAOCoinLimitOrders=0"})
+	require.Error(t, err)
+}
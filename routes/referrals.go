@@ -23,7 +23,7 @@ type GetReferralInfoForUserResponse struct {
 func (fes *APIServer) GetReferralInfoForUser(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetReferralInfoForUserRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetReferralInfoForUser: Problem parsing request body: %v", err))
 		return
@@ -78,7 +78,7 @@ type GetReferralInfoForReferralHashResponse struct {
 func (fes *APIServer) GetReferralInfoForReferralHash(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetReferralInfoForReferralHashRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetReferralInfoForReferralHash: Problem parsing request body: %v", err))
 		return
@@ -2,6 +2,8 @@ package routes
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"crypto/subtle"
 	"encoding/json"
 	fmt "fmt"
 	"io"
@@ -47,30 +49,37 @@ const (
 	RoutePathGetExchangeRate  = "/api/v0/get-exchange-rate"
 	RoutePathGetAppState      = "/api/v0/get-app-state"
 	RoutePathGetIngressCookie = "/api/v0/get-ingress-cookie"
+	RoutePathGetNodeInfo      = "/api/v0/get-node-info"
+
+	// client_events.go
+	RoutePathLogClientEvent = "/api/v0/log-client-event"
 
 	// transaction.go
-	RoutePathGetTxn                   = "/api/v0/get-txn"
-	RoutePathSubmitTransaction        = "/api/v0/submit-transaction"
-	RoutePathSubmitAtomicTransaction  = "/api/v0/submit-atomic-transaction"
-	RoutePathUpdateProfile            = "/api/v0/update-profile"
-	RoutePathExchangeBitcoin          = "/api/v0/exchange-bitcoin"
-	RoutePathSendDeSo                 = "/api/v0/send-deso"
-	RoutePathSubmitPost               = "/api/v0/submit-post"
-	RoutePathCreateFollowTxnStateless = "/api/v0/create-follow-txn-stateless"
-	RoutePathCreateLikeStateless      = "/api/v0/create-like-stateless"
-	RoutePathBuyOrSellCreatorCoin     = "/api/v0/buy-or-sell-creator-coin"
-	RoutePathTransferCreatorCoin      = "/api/v0/transfer-creator-coin"
-	RoutePathSendDiamonds             = "/api/v0/send-diamonds"
-	RoutePathAuthorizeDerivedKey      = "/api/v0/authorize-derived-key"
-	RoutePathDAOCoin                  = "/api/v0/dao-coin"
-	RoutePathTransferDAOCoin          = "/api/v0/transfer-dao-coin"
-	RoutePathCreateDAOCoinLimitOrder  = "/api/v0/create-dao-coin-limit-order"
-	RoutePathCreateDAOCoinMarketOrder = "/api/v0/create-dao-coin-market-order"
-	RoutePathCancelDAOCoinLimitOrder  = "/api/v0/cancel-dao-coin-limit-order"
-	RoutePathAppendExtraData          = "/api/v0/append-extra-data"
-	RoutePathGetTransactionSpending   = "/api/v0/get-transaction-spending"
-	RoutePathGetSignatureIndex        = "/api/v0/signature-index"
-	RoutePathGetTxnConstructionParams = "/api/v0/txn-construction-params"
+	RoutePathGetTxn                       = "/api/v0/get-txn"
+	RoutePathSubmitTransaction            = "/api/v0/submit-transaction"
+	RoutePathSubmitAtomicTransaction      = "/api/v0/submit-atomic-transaction"
+	RoutePathUpdateProfile                = "/api/v0/update-profile"
+	RoutePathExchangeBitcoin              = "/api/v0/exchange-bitcoin"
+	RoutePathSendDeSo                     = "/api/v0/send-deso"
+	RoutePathSubmitPost                   = "/api/v0/submit-post"
+	RoutePathCreateFollowTxnStateless     = "/api/v0/create-follow-txn-stateless"
+	RoutePathCreateLikeStateless          = "/api/v0/create-like-stateless"
+	RoutePathBuyOrSellCreatorCoin         = "/api/v0/buy-or-sell-creator-coin"
+	RoutePathTransferCreatorCoin          = "/api/v0/transfer-creator-coin"
+	RoutePathSendDiamonds                 = "/api/v0/send-diamonds"
+	RoutePathAuthorizeDerivedKey          = "/api/v0/authorize-derived-key"
+	RoutePathDAOCoin                      = "/api/v0/dao-coin"
+	RoutePathTransferDAOCoin              = "/api/v0/transfer-dao-coin"
+	RoutePathCreateDAOCoinLimitOrder      = "/api/v0/create-dao-coin-limit-order"
+	RoutePathCreateDAOCoinMarketOrder     = "/api/v0/create-dao-coin-market-order"
+	RoutePathCancelDAOCoinLimitOrder      = "/api/v0/cancel-dao-coin-limit-order"
+	RoutePathBuildMatchingOrderForOrderID = "/api/v0/build-matching-order-for-order-id"
+	RoutePathAppendExtraData              = "/api/v0/append-extra-data"
+	RoutePathGetTransactionSpending       = "/api/v0/get-transaction-spending"
+	RoutePathGetSignatureIndex            = "/api/v0/signature-index"
+	RoutePathGetTxnConstructionParams     = "/api/v0/txn-construction-params"
+	RoutePathGetFeeRateEstimates          = "/api/v0/get-fee-rate-estimates"
+	RoutePathGetTransactionStatus         = "/api/v0/get-transaction-status"
 
 	RoutePathGetUsersStateless                           = "/api/v0/get-users-stateless"
 	RoutePathDeleteIdentities                            = "/api/v0/delete-identities"
@@ -105,6 +114,23 @@ const (
 	RoutePathGetDaoCoinLimitOrders           = "/api/v0/get-dao-coin-limit-orders"
 	RoutePathGetDaoCoinLimitOrdersById       = "/api/v0/get-dao-coin-limit-orders-by-id"
 	RoutePathGetTransactorDaoCoinLimitOrders = "/api/v0/get-transactor-dao-coin-limit-orders"
+	RoutePathGetDaoCoinLimitOrderLadder      = "/api/v0/get-dao-coin-limit-order-ladder"
+	RoutePathGetTransactorOpenOrderCount     = "/api/v0/get-transactor-open-order-count"
+	RoutePathGetUserCoinInvolvement          = "/api/v0/get-user-coin-involvement"
+	RoutePathGetAvailableBalanceForOrder     = "/api/v0/get-available-balance-for-order"
+	RoutePathGetBestAffordableOrder          = "/api/v0/get-best-affordable-order"
+	RoutePathGetTransactorCrossingOrders     = "/api/v0/get-transactor-crossing-orders"
+	RoutePathValidateDAOCoinLimitOrdersBatch = "/api/v0/validate-dao-coin-limit-orders-batch"
+	RoutePathGetOrderBookPlacement           = "/api/v0/get-order-book-placement"
+	RoutePathGetDAOCoinSpreadHistory         = "/api/v0/get-dao-coin-spread-history"
+	RoutePathGetTransactorFillHistory        = "/api/v0/get-transactor-fill-history"
+	RoutePathGetDESOCostToBuyDAOCoin         = "/api/v0/get-deso-cost-to-buy-dao-coin"
+	RoutePathGetDAOCoinPriceImpact           = "/api/v0/get-dao-coin-price-impact"
+	RoutePathGetDAOCoinRoundTripBreakEven    = "/api/v0/get-dao-coin-round-trip-break-even"
+	RoutePathGetDAOCoinLiquidityWithinBand   = "/api/v0/get-dao-coin-liquidity-within-band"
+	RoutePathGetDAOCoinOrderBookImbalance    = "/api/v0/get-dao-coin-order-book-imbalance"
+	RoutePathGetDAOCoinCrossRate             = "/api/v0/get-dao-coin-cross-rate"
+	RoutePathGetDAOCoin24hStats              = "/api/v0/get-dao-coin-24h-stats"
 
 	// dao_coin_exchange_with_fees.go
 	RoutePathUpdateDaoCoinMarketFees        = "/api/v0/update-dao-coin-market-fees"
@@ -203,12 +229,26 @@ const (
 	RoutePathNodeControl           = "/api/v0/admin/node-control"
 	RoutePathAdminGetMempoolStats  = "/api/v0/admin/get-mempool-stats"
 	RoutePathAdminUpdateViewNumber = "/api/v0/admin/update-view-number"
+	RoutePathFlushViewCaches       = "/api/v0/admin/flush-view-caches"
+
+	// admin_messaging_stats.go
+	RoutePathGetMessagingThroughputStats = "/api/v0/admin/get-messaging-throughput-stats"
+	RoutePathGetPublicKeyMessageRate     = "/api/v0/admin/get-public-key-message-rate"
+
+	// admin_state_size_stats.go
+	RoutePathGetStateSizeStats = "/api/v0/admin/get-state-size-stats"
+
+	// admin_database_comparison.go
+	RoutePathStartDatabaseComparison     = "/api/v0/admin/start-database-comparison"
+	RoutePathGetDatabaseComparisonStatus = "/api/v0/admin/get-database-comparison-status"
+	RoutePathGetDatabaseComparisonResult = "/api/v0/admin/get-database-comparison-result"
 
 	// admin_buy_deso.go
 	RoutePathSetUSDCentsToDeSoReserveExchangeRate = "/api/v0/admin/set-usd-cents-to-deso-reserve-exchange-rate"
 	RoutePathGetUSDCentsToDeSoReserveExchangeRate = "/api/v0/admin/get-usd-cents-to-deso-reserve-exchange-rate"
 	RoutePathSetBuyDeSoFeeBasisPoints             = "/api/v0/admin/set-buy-deso-fee-basis-points"
 	RoutePathGetBuyDeSoFeeBasisPoints             = "/api/v0/admin/get-buy-deso-fee-basis-points"
+	RoutePathGetExchangeRateParameterHistory      = "/api/v0/admin/get-exchange-rate-parameter-history"
 
 	// admin_transaction.go
 	RoutePathGetGlobalParams                   = "/api/v0/get-global-params"
@@ -296,22 +336,26 @@ const (
 	RoutePathGetCountKeysWithDESO = "/api/v0/count-keys-with-deso"
 
 	// access_group.go
-	RoutePathCreateAccessGroup                = "/api/v0/create-access-group"
-	RoutePathUpdateAccessGroup                = "/api/v0/update-access-group"
-	RoutePathAddAccessGroupMembers            = "/api/v0/add-access-group-members"
-	RoutePathRemoveAccessGroupMembers         = "/api/v0/remove-access-group-members"
-	RoutePathUpdateAccessGroupMembers         = "/api/v0/update-access-group-members"
-	RoutePathGetAllUserAccessGroups           = "/api/v0/get-all-user-access-groups"
-	RoutePathGetAllUserAccessGroupsOwned      = "/api/v0/get-all-user-access-groups-owned"
-	RoutePathGetAllUserAccessGroupsMemberOnly = "/api/v0/get-all-user-access-groups-member-only"
-	RoutePathCheckPartyAccessGroups           = "/api/v0/check-party-access-groups"
-	RoutePathGetAccessGroupInfo               = "/api/v0/get-access-group-info"
-	RoutePathGetAccessGroupMemberInfo         = "/api/v0/get-access-group-member-info"
-	RoutePathGetPaginatedAccessGroupMembers   = "/api/v0/get-paginated-access-group-members"
-	RoutePathGetBulkAccessGroupEntries        = "/api/v0/get-bulk-access-group-entries"
+	RoutePathCreateAccessGroup                 = "/api/v0/create-access-group"
+	RoutePathUpdateAccessGroup                 = "/api/v0/update-access-group"
+	RoutePathAddAccessGroupMembers             = "/api/v0/add-access-group-members"
+	RoutePathRemoveAccessGroupMembers          = "/api/v0/remove-access-group-members"
+	RoutePathUpdateAccessGroupMembers          = "/api/v0/update-access-group-members"
+	RoutePathGetAllUserAccessGroups            = "/api/v0/get-all-user-access-groups"
+	RoutePathGetAllUserAccessGroupsOwned       = "/api/v0/get-all-user-access-groups-owned"
+	RoutePathGetAllUserAccessGroupsMemberOnly  = "/api/v0/get-all-user-access-groups-member-only"
+	RoutePathCheckPartyAccessGroups            = "/api/v0/check-party-access-groups"
+	RoutePathGetAccessGroupInfo                = "/api/v0/get-access-group-info"
+	RoutePathGetAccessGroupMemberInfo          = "/api/v0/get-access-group-member-info"
+	RoutePathGetPaginatedAccessGroupMembers    = "/api/v0/get-paginated-access-group-members"
+	RoutePathGetBulkAccessGroupEntries         = "/api/v0/get-bulk-access-group-entries"
+	RoutePathIsAccessGroupKeyNameAvailable     = "/api/v0/is-access-group-key-name-available"
+	RoutePathSearchAccessGroupsByKeyNamePrefix = "/api/v0/search-access-groups-by-key-name-prefix"
+	RoutePathGetGroupKeyMaterialForMember      = "/api/v0/get-group-key-material-for-member"
 
 	// new_message.go
 	RoutePathSendDmMessage                             = "/api/v0/send-dm-message"
+	RoutePathSendDmMessageBatch                        = "/api/v0/send-dm-message-batch"
 	RoutePathUpdateDmMessage                           = "/api/v0/update-dm-message"
 	RoutePathSendGroupChatMessage                      = "/api/v0/send-group-chat-message"
 	RoutePathUpdateGroupChatMessage                    = "/api/v0/update-group-chat-message"
@@ -319,7 +363,19 @@ const (
 	RoutePathGetPaginatedMessagesForDmThread           = "/api/v0/get-paginated-messages-for-dm-thread"
 	RoutePathGetUserGroupChatThreadsOrderedByTimestamp = "/api/v0/get-user-group-chat-threads-ordered-by-timestamp"
 	RoutePathGetPaginatedMessagesForGroupChatThread    = "/api/v0/get-paginated-messages-for-group-chat-thread"
+	RoutePathGetNewMessagesForThread                   = "/api/v0/get-new-messages-for-thread"
 	RoutePathGetAllUserMessageThreads                  = "/api/v0/get-all-user-message-threads"
+	RoutePathGetMessageTimestampsForThread             = "/api/v0/get-message-timestamps-for-thread"
+	RoutePathGetMostRecentMessageTimestamp             = "/api/v0/get-most-recent-message-timestamp"
+	RoutePathGetMessagesByReferences                   = "/api/v0/get-messages-by-references"
+	RoutePathGetThreadLastActivity                     = "/api/v0/get-thread-last-activity"
+	RoutePathGetUnreadMessageCount                     = "/api/v0/get-unread-message-count"
+	RoutePathGetThreadMessageStatusCounts              = "/api/v0/get-thread-message-status-counts"
+	RoutePathGetMessagesAcrossAllThreads               = "/api/v0/get-messages-across-all-threads"
+	RoutePathGetThreadParticipantKeys                  = "/api/v0/get-thread-participant-keys"
+	RoutePathGetDmContacts                             = "/api/v0/get-dm-contacts"
+	RoutePathGetRecentMessageSenders                   = "/api/v0/get-recent-message-senders"
+	RoutePathGetDenylistStatus                         = "/api/v0/get-denylist-status"
 
 	// associations.go
 	RoutePathUserAssociations = "/api/v0/user-associations"
@@ -504,6 +560,51 @@ type APIServer struct {
 	// Public keys that need their balances monitored. Map of Label to Public key
 	PublicKeyBalancesToMonitor map[string]string
 
+	// Rate limits LogClientEvent by client IP, and by public key for events that name one.
+	clientEventRateLimiter *KeyedRateLimiter
+
+	// Warm cache of GetDAOCoinLimitOrders responses. Only set when the order book warmer is enabled.
+	DAOCoinOrderBookCache *DAOCoinOrderBookCache
+	// Top-of-book samples the order book warmer takes of the pairs it warms. Only set when the warmer is enabled.
+	DAOCoinSpreadHistory *DAOCoinSpreadHistory
+
+	// Database comparisons started through the admin API.
+	databaseComparisonJobs *DatabaseComparisonJobs
+
+	// The last result of GetMessagingThroughputStats, which scans a day of blocks.
+	messagingStatsCache *messagingThroughputStatsCache
+
+	// The NewMessage transactions in the last day of blocks and the mempool, by sender. Shared by
+	// GetMessagingThroughputStats and GetPublicKeyMessageRate so that neither rescans the blocks per request.
+	recentMessageIndexCache *recentMessageIndexCache
+
+	// The last result of GetStateSizeStats, which scans the whole db.
+	stateSizeStatsCache *stateSizeStatsCache
+
+	// Warns about slow UtxoView constructions in getAugmentedUniversalView.
+	utxoViewTimer *utxoViewTimer
+
+	// Rate limits every endpoint by client IP.
+	ipRateLimiter *IPRateLimiter
+
+	// Caps the number of in-flight requests to expensive endpoints.
+	routeConcurrencyLimiter *RouteConcurrencyLimiter
+
+	// Keys the node signs and broadcasts messages with when a send request sets Broadcast, keyed by the sender's
+	// base58 public key. Empty unless the operator configures message signer seeds.
+	messageSigners map[string]*btcec.PrivateKey
+
+	// Senders the node won't build message transactions for, keyed by base58 public key, with the operator's reason
+	// for each. Nil unless the operator configures a messaging denylist.
+	messagingDenylist map[string]string
+
+	// Keys the node decrypts messages sent to them with, keyed by base58 public key. Nil unless the operator enables
+	// message decryption and allowlists the keys.
+	messageDecryptionKeys map[string]*btcec.PrivateKey
+
+	// When the APIServer was created. Used to report uptime.
+	startTime time.Time
+
 	// Signals that the frontend server is in a stopped state
 	quit chan struct{}
 }
@@ -541,6 +642,41 @@ func NewAPIServer(
 
 	publicKeyBase58Prefix := lib.Base58CheckEncode(make([]byte, btcec.PubKeyBytesLenCompressed), false, params)[0:3]
 
+	ipRateLimiter, err := NewIPRateLimiter(
+		config.IPRateLimitRequestsPerSecond, config.IPRateLimitBurst, config.TrustedProxies)
+	if err != nil {
+		return nil, err
+	}
+
+	routeConcurrencyLimiter, err := NewRouteConcurrencyLimiter(config.MaxConcurrentRequestsPerRoute)
+	if err != nil {
+		return nil, err
+	}
+
+	messageSigners, err := NewMessageSignersFromSeeds(config.MessageSignerSeeds, params)
+	if err != nil {
+		return nil, err
+	}
+
+	messagingDenylist, err := NewMessagingDenylist(config.MessagingDenylist, params)
+	if err != nil {
+		return nil, err
+	}
+
+	messageDecryptionKeys, err := NewMessageDecryptionKeys(config.EnableMessageDecryption,
+		config.MessageDecryptionSeeds, config.MessageDecryptionAllowedPublicKeys, params)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = ValidateJSONFieldNamingConvention(config.JSONFieldNamingConvention); err != nil {
+		return nil, err
+	}
+
+	if err = ValidateMaxRequestBodySizeBytes(config.MaxRequestBodySizeBytes); err != nil {
+		return nil, err
+	}
+
 	fes := &APIServer{
 		// TODO: It would be great if we could eliminate the dependency on
 		// the backendServer. Right now it's here because it was the easiest
@@ -562,6 +698,18 @@ func NewAPIServer(
 		// This helps prevents attacks that attempt to purchase $DESO at below market value.
 		LastTradePriceLookback:       uint64(time.Hour.Nanoseconds()),
 		AllCountryLevelSignUpBonuses: make(map[string]CountrySignUpBonusResponse),
+		clientEventRateLimiter:       NewKeyedRateLimiter(config.ClientEventsPerMinute, time.Minute),
+		databaseComparisonJobs:       NewDatabaseComparisonJobs(),
+		messagingStatsCache:          newMessagingThroughputStatsCache(MessagingThroughputStatsCacheTTL),
+		recentMessageIndexCache:      newRecentMessageIndexCache(RecentMessageIndexTTL),
+		stateSizeStatsCache:          newStateSizeStatsCache(StateSizeStatsCacheTTL),
+		utxoViewTimer:                newUtxoViewTimer(time.Duration(config.SlowUtxoViewThresholdMillis) * time.Millisecond),
+		ipRateLimiter:                ipRateLimiter,
+		routeConcurrencyLimiter:      routeConcurrencyLimiter,
+		messageSigners:               messageSigners,
+		messagingDenylist:            messagingDenylist,
+		messageDecryptionKeys:        messageDecryptionKeys,
+		startTime:                    time.Now(),
 		quit:                         make(chan struct{}),
 	}
 
@@ -590,6 +738,13 @@ func NewAPIServer(
 		fes.UpdateSupplyStats()
 	}
 
+	if fes.Config.RunDAOCoinOrderBookWarmer {
+		fes.DAOCoinOrderBookCache = NewDAOCoinOrderBookCache(fes.getDAOCoinOrderBookWarmInterval())
+		fes.DAOCoinSpreadHistory = NewDAOCoinSpreadHistory(
+			DAOCoinSpreadHistorySampleInterval, DAOCoinSpreadHistoryMaxSamplesPerPair)
+		fes.StartDAOCoinOrderBookWarmer()
+	}
+
 	fes.SetGlobalStateCache()
 	// Kick off Global State Monitoring to set up cache of Verified Username, Blacklist, and Graylist.
 	fes.StartGlobalStateMonitoring()
@@ -659,6 +814,14 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			PublicAccess,
 		},
 
+		{
+			"LogClientEvent",
+			[]string{"POST", "OPTIONS"},
+			RoutePathLogClientEvent,
+			fes.LogClientEvent,
+			PublicAccess,
+		},
+
 		// Routes for populating various UI elements.
 		{
 			"GetExchangeRate",
@@ -1058,6 +1221,13 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.CancelDAOCoinLimitOrder,
 			PublicAccess,
 		},
+		{
+			"BuildMatchingOrderForOrderID",
+			[]string{"POST", "OPTIONS"},
+			RoutePathBuildMatchingOrderForOrderID,
+			fes.BuildMatchingOrderForOrderID,
+			PublicAccess,
+		},
 		{
 			"AppendExtraData",
 			[]string{"POST", "OPTIONS"},
@@ -1086,6 +1256,20 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.GetTxnConstructionParams,
 			PublicAccess,
 		},
+		{
+			"GetFeeRateEstimates",
+			[]string{"GET"},
+			RoutePathGetFeeRateEstimates,
+			fes.GetFeeRateEstimates,
+			PublicAccess,
+		},
+		{
+			"GetTransactionStatus",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetTransactionStatus,
+			fes.GetTransactionStatus,
+			PublicAccess,
+		},
 		{
 			"GetCommittedTipBlockInfo",
 			[]string{"GET"},
@@ -1128,6 +1312,13 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.GetIngressCookie,
 			PublicAccess,
 		},
+		{
+			"GetNodeInfo",
+			[]string{"GET"},
+			RoutePathGetNodeInfo,
+			fes.GetNodeInfo,
+			PublicAccess,
+		},
 		{
 			"UpdateUserGlobalMetadata",
 			[]string{"POST", "OPTIONS"},
@@ -1289,6 +1480,125 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.GetTransactorDAOCoinLimitOrders,
 			PublicAccess,
 		},
+		{
+			"GetDAOCoinLimitOrderLadder",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDaoCoinLimitOrderLadder,
+			fes.GetDAOCoinLimitOrderLadder,
+			PublicAccess,
+		},
+		{
+			"GetTransactorOpenOrderCount",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetTransactorOpenOrderCount,
+			fes.GetTransactorOpenOrderCount,
+			PublicAccess,
+		},
+		{
+			"GetUserCoinInvolvement",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetUserCoinInvolvement,
+			fes.GetUserCoinInvolvement,
+			PublicAccess,
+		},
+		{
+			"GetAvailableBalanceForOrder",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetAvailableBalanceForOrder,
+			fes.GetAvailableBalanceForOrder,
+			PublicAccess,
+		},
+		{
+			"GetBestAffordableOrder",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetBestAffordableOrder,
+			fes.GetBestAffordableOrder,
+			PublicAccess,
+		},
+		{
+			"GetTransactorCrossingOrders",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetTransactorCrossingOrders,
+			fes.GetTransactorCrossingOrders,
+			PublicAccess,
+		},
+		{
+			"ValidateDAOCoinLimitOrdersBatch",
+			[]string{"POST", "OPTIONS"},
+			RoutePathValidateDAOCoinLimitOrdersBatch,
+			fes.ValidateDAOCoinLimitOrdersBatch,
+			PublicAccess,
+		},
+		{
+			"GetOrderBookPlacement",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetOrderBookPlacement,
+			fes.GetOrderBookPlacement,
+			PublicAccess,
+		},
+		{
+			"GetDAOCoinSpreadHistory",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDAOCoinSpreadHistory,
+			fes.GetDAOCoinSpreadHistory,
+			PublicAccess,
+		},
+		{
+			"GetTransactorFillHistory",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetTransactorFillHistory,
+			fes.GetTransactorFillHistory,
+			PublicAccess,
+		},
+		{
+			"GetDESOCostToBuyDAOCoin",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDESOCostToBuyDAOCoin,
+			fes.GetDESOCostToBuyDAOCoin,
+			PublicAccess,
+		},
+		{
+			"GetDAOCoinPriceImpact",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDAOCoinPriceImpact,
+			fes.GetDAOCoinPriceImpact,
+			PublicAccess,
+		},
+		{
+			"GetDAOCoinRoundTripBreakEven",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDAOCoinRoundTripBreakEven,
+			fes.GetDAOCoinRoundTripBreakEven,
+			PublicAccess,
+		},
+		{
+			"GetDAOCoinLiquidityWithinBand",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDAOCoinLiquidityWithinBand,
+			fes.GetDAOCoinLiquidityWithinBand,
+			PublicAccess,
+		},
+		{
+			"GetDAOCoinOrderBookImbalance",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDAOCoinOrderBookImbalance,
+			fes.GetDAOCoinOrderBookImbalance,
+			PublicAccess,
+		},
+		{
+			"GetDAOCoinCrossRate",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDAOCoinCrossRate,
+			fes.GetDAOCoinCrossRate,
+			PublicAccess,
+		},
+		{
+			"GetDAOCoin24hStats",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDAOCoin24hStats,
+			fes.GetDAOCoin24hStats,
+			PublicAccess,
+		},
 		{
 			"UpdateDaoCoinMarketFees",
 			[]string{"POST", "OPTIONS"},
@@ -1704,6 +2014,27 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.AdminGetMempoolStats,
 			AdminAccess,
 		},
+		{
+			"GetMessagingThroughputStats",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetMessagingThroughputStats,
+			fes.GetMessagingThroughputStats,
+			AdminAccess,
+		},
+		{
+			"GetPublicKeyMessageRate",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetPublicKeyMessageRate,
+			fes.GetPublicKeyMessageRate,
+			AdminAccess,
+		},
+		{
+			"GetStateSizeStats",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetStateSizeStats,
+			fes.GetStateSizeStats,
+			AdminAccess,
+		},
 		{
 			"AdminUpdateViewNumber",
 			[]string{"POST", "OPTIONS"},
@@ -1711,6 +2042,34 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.AdminUpdateViewNumber,
 			SuperAdminAccess,
 		},
+		{
+			"FlushViewCaches",
+			[]string{"POST", "OPTIONS"},
+			RoutePathFlushViewCaches,
+			fes.FlushViewCaches,
+			AdminAccess,
+		},
+		{
+			"StartDatabaseComparison",
+			[]string{"POST", "OPTIONS"},
+			RoutePathStartDatabaseComparison,
+			fes.StartDatabaseComparison,
+			SuperAdminAccess,
+		},
+		{
+			"GetDatabaseComparisonStatus",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDatabaseComparisonStatus,
+			fes.GetDatabaseComparisonStatus,
+			SuperAdminAccess,
+		},
+		{
+			"GetDatabaseComparisonResult",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDatabaseComparisonResult,
+			fes.GetDatabaseComparisonResult,
+			SuperAdminAccess,
+		},
 		{
 			"AdminGetGlobalParams",
 			[]string{"POST", "OPTIONS"},
@@ -1859,6 +2218,13 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.SetBuyDeSoFeeBasisPoints,
 			SuperAdminAccess,
 		},
+		{
+			"GetExchangeRateParameterHistory",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetExchangeRateParameterHistory,
+			fes.GetExchangeRateParameterHistory,
+			AdminAccess,
+		},
 		{
 			"AdminResetJumioForPublicKey",
 			[]string{"POST", "OPTIONS"},
@@ -2370,6 +2736,27 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.GetBulkAccessGroupEntries,
 			PublicAccess,
 		},
+		{
+			"IsAccessGroupKeyNameAvailable",
+			[]string{"POST", "OPTIONS"},
+			RoutePathIsAccessGroupKeyNameAvailable,
+			fes.IsAccessGroupKeyNameAvailable,
+			PublicAccess,
+		},
+		{
+			"SearchAccessGroupsByKeyNamePrefix",
+			[]string{"POST", "OPTIONS"},
+			RoutePathSearchAccessGroupsByKeyNamePrefix,
+			fes.SearchAccessGroupsByKeyNamePrefix,
+			PublicAccess,
+		},
+		{
+			"GetGroupKeyMaterialForMember",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetGroupKeyMaterialForMember,
+			fes.GetGroupKeyMaterialForMember,
+			PublicAccess,
+		},
 		// access group message APIs.
 		{
 			"SendDmMessage",
@@ -2378,6 +2765,13 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.SendDmMessage,
 			PublicAccess,
 		},
+		{
+			"SendDmMessageBatch",
+			[]string{"POST", "OPTIONS"},
+			RoutePathSendDmMessageBatch,
+			fes.SendDmMessageBatch,
+			PublicAccess,
+		},
 		{
 			"UpdateDmMessage",
 			[]string{"POST", "OPTIONS"},
@@ -2427,6 +2821,90 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 			fes.GetPaginatedMessagesForGroupChatThread,
 			PublicAccess,
 		},
+		{
+			"GetNewMessagesForThread",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetNewMessagesForThread,
+			fes.GetNewMessagesForThread,
+			PublicAccess,
+		},
+		{
+			"GetMessageTimestampsForThread",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetMessageTimestampsForThread,
+			fes.GetMessageTimestampsForThread,
+			PublicAccess,
+		},
+		{
+			"GetMostRecentMessageTimestamp",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetMostRecentMessageTimestamp,
+			fes.GetMostRecentMessageTimestamp,
+			PublicAccess,
+		},
+		{
+			"GetThreadLastActivity",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetThreadLastActivity,
+			fes.GetThreadLastActivity,
+			PublicAccess,
+		},
+		{
+			"GetUnreadMessageCount",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetUnreadMessageCount,
+			fes.GetUnreadMessageCount,
+			PublicAccess,
+		},
+		{
+			"GetThreadMessageStatusCounts",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetThreadMessageStatusCounts,
+			fes.GetThreadMessageStatusCounts,
+			PublicAccess,
+		},
+		{
+			"GetMessagesAcrossAllThreads",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetMessagesAcrossAllThreads,
+			fes.GetMessagesAcrossAllThreads,
+			PublicAccess,
+		},
+		{
+			"GetThreadParticipantKeys",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetThreadParticipantKeys,
+			fes.GetThreadParticipantKeys,
+			PublicAccess,
+		},
+		{
+			"GetMessagesByReferences",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetMessagesByReferences,
+			fes.GetMessagesByReferences,
+			PublicAccess,
+		},
+		{
+			"GetDmContacts",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDmContacts,
+			fes.GetDmContacts,
+			PublicAccess,
+		},
+		{
+			"GetRecentMessageSenders",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetRecentMessageSenders,
+			fes.GetRecentMessageSenders,
+			PublicAccess,
+		},
+		{
+			"GetDenylistStatus",
+			[]string{"POST", "OPTIONS"},
+			RoutePathGetDenylistStatus,
+			fes.GetDenylistStatus,
+			PublicAccess,
+		},
 		{
 			"GetAllUserMessageThreads",
 			[]string{"POST", "OPTIONS"},
@@ -2468,9 +2946,16 @@ func (fes *APIServer) NewRouter() *muxtrace.Router {
 		handler = CheckPrecedingTransactions(handler, fes.Config.MaxOptionalPrecedingTransactions)
 		if route.AccessLevel != PublicAccess {
 			handler = fes.CheckAdminPublicKey(handler, route.AccessLevel)
+			handler = CheckAdminSecret(handler, fes.Config.AdminSecret)
 		}
+		// The admin checks read the body, so an oversized one has to be rejected before they run.
+		handler = CheckContentLength(handler, fes.maxRequestBodySizeBytesForRoute(route.Pattern))
+		handler = ConvertJSONFieldNames(handler, fes.Config.JSONFieldNamingConvention)
 		handler = Logger(handler, route.Name)
+		handler = LimitConcurrency(handler, fes.routeConcurrencyLimiter, route.Name)
+		handler = RateLimitByIP(handler, fes.ipRateLimiter)
 		handler = AddHeaders(handler, fes.Config.AccessControlAllowOrigins)
+		handler = AddCorrelationID(handler, fes.Config.CorrelationIDHeader)
 
 		router.
 			Methods(route.Method...).
@@ -2536,6 +3021,54 @@ func CheckPrecedingTransactions(inner http.Handler, precedingTransactionsLimit i
 	})
 }
 
+// multipartRequestBodyOverheadBytes is the extra room given to endpoints that accept file uploads, so that a file
+// right at the size limit isn't rejected because of the multipart boundaries and form fields around it.
+const multipartRequestBodyOverheadBytes = 1 << 20 // 1M
+
+// multipartRoutes are the routes whose request bodies are multipart forms carrying a file.
+var multipartRoutes = map[string]interface{}{
+	RoutePathUploadImage:            nil,
+	RoutePathAdminUploadReferralCSV: nil,
+}
+
+// ValidateMaxRequestBodySizeBytes returns an error if the configured request body limit is above
+// MaxRequestBodySizeBytes. Handlers read at most MaxRequestBodySizeBytes of a body, so a larger body that got past
+// CheckContentLength would be cut off mid-decode.
+func ValidateMaxRequestBodySizeBytes(maxRequestBodySizeBytes uint64) error {
+	if maxRequestBodySizeBytes > MaxRequestBodySizeBytes {
+		return fmt.Errorf("ValidateMaxRequestBodySizeBytes: Max request body size of %d bytes can't be above %d "+
+			"bytes", maxRequestBodySizeBytes, uint64(MaxRequestBodySizeBytes))
+	}
+	return nil
+}
+
+// maxRequestBodySizeBytesForRoute returns the largest Content-Length we accept for requests to the route.
+func (fes *APIServer) maxRequestBodySizeBytesForRoute(routePattern string) int64 {
+	maxRequestBodySizeBytes := int64(MaxRequestBodySizeBytes)
+	if fes.Config.MaxRequestBodySizeBytes > 0 {
+		maxRequestBodySizeBytes = int64(fes.Config.MaxRequestBodySizeBytes)
+	}
+	if _, isMultipartRoute := multipartRoutes[routePattern]; isMultipartRoute {
+		maxRequestBodySizeBytes += multipartRequestBodyOverheadBytes
+	}
+	return maxRequestBodySizeBytes
+}
+
+// CheckContentLength is middleware that rejects requests whose Content-Length header exceeds maxBodySizeBytes with a
+// 413, before any of the body is read. Without this check, an oversized body is cut off mid-decode by the handler's
+// LimitReader, which surfaces as a confusing JSON error. Requests without a Content-Length, such as chunked requests,
+// are passed through and still bounded by the handler's LimitReader.
+func CheckContentLength(inner http.Handler, maxBodySizeBytes int64) http.Handler {
+	return http.HandlerFunc(func(ww http.ResponseWriter, rr *http.Request) {
+		if maxBodySizeBytes > 0 && rr.ContentLength > maxBodySizeBytes {
+			_AddPayloadTooLargeError(ww, fmt.Sprintf("CheckContentLength: Request body of %d bytes exceeds the "+
+				"maximum of %d bytes", rr.ContentLength, maxBodySizeBytes))
+			return
+		}
+		inner.ServeHTTP(ww, rr)
+	})
+}
+
 // Logger ...
 func Logger(inner http.Handler, name string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -2544,11 +3077,12 @@ func Logger(inner http.Handler, name string) http.Handler {
 		inner.ServeHTTP(w, r)
 
 		glog.V(2).Infof(
-			"%s\t%s\t%s\t%s",
+			"%s\t%s\t%s\t%s\t%s",
 			r.Method,
 			r.RequestURI,
 			name,
 			time.Since(start),
+			CorrelationIDFromContext(r.Context()),
 		)
 	})
 }
@@ -2567,6 +3101,7 @@ var publicRoutes = map[string]interface{}{
 	RoutePathGetUserMetadata:                nil,
 	RoutePathSubmitTransaction:              nil,
 	RoutePathGetTxn:                         nil,
+	RoutePathGetTransactionStatus:           nil,
 	RoutePathUpdateProfile:                  nil,
 }
 
@@ -2639,7 +3174,8 @@ func AddHeaders(inner http.Handler, allowedOrigins []string) http.Handler {
 
 			if r.RequestURI != RoutePathUploadVideo {
 				w.Header().Set("Access-Control-Allow-Origin", actualOrigin)
-				w.Header().Set("Access-Control-Allow-Headers", "Origin, X-Requested-With, Content-Type, Accept")
+				w.Header().Set("Access-Control-Allow-Headers",
+					"Origin, X-Requested-With, Content-Type, Accept, "+AdminSecretHeader)
 			} else {
 				w.Header().Set("Access-Control-Allow-Origin", "*")
 				w.Header().Set("Access-Control-Allow-Headers", "*")
@@ -2670,6 +3206,31 @@ func AddHeaders(inner http.Handler, allowedOrigins []string) http.Handler {
 	})
 }
 
+// AdminSecretHeader carries the shared secret required by admin routes when the node sets --admin-secret.
+const AdminSecretHeader = "X-Admin-Secret"
+
+// constantTimeCompare compares admin secrets. It's a variable so tests can check that it's used.
+var constantTimeCompare = subtle.ConstantTimeCompare
+
+// CheckAdminSecret rejects requests whose AdminSecretHeader doesn't match adminSecret with a 401. It's a second factor
+// on top of CheckAdminPublicKey rather than a replacement, and does nothing if adminSecret is empty.
+func CheckAdminSecret(inner http.Handler, adminSecret string) http.Handler {
+	return http.HandlerFunc(func(ww http.ResponseWriter, req *http.Request) {
+		if adminSecret == "" {
+			inner.ServeHTTP(ww, req)
+			return
+		}
+		// Hash both sides so that the comparison doesn't leak the secret's length either.
+		providedSecretHash := sha256.Sum256([]byte(req.Header.Get(AdminSecretHeader)))
+		adminSecretHash := sha256.Sum256([]byte(adminSecret))
+		if constantTimeCompare(providedSecretHash[:], adminSecretHash[:]) != 1 {
+			_AddUnauthorizedError(ww, fmt.Sprintf("CheckAdminSecret: Missing or invalid %v header", AdminSecretHeader))
+			return
+		}
+		inner.ServeHTTP(ww, req)
+	})
+}
+
 type AdminRequest struct {
 	JWT            string
 	AdminPublicKey string
@@ -2782,7 +3343,7 @@ func (fes *APIServer) ValidateJWT(publicKey string, jwtToken string) (bool, erro
 				return nil, errors.Wrapf(err, "Problem parsing derived public key bytes")
 			}
 			// Validate the derived public key.
-			utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+			utxoView, err := fes.getAugmentedUniversalView("ValidateJWT")
 			if err != nil {
 				return nil, errors.Wrapf(err, "Problem getting utxoView")
 			}
@@ -3015,7 +3576,7 @@ func GetIPsForURL(url string) ([]string, error) {
 
 // GetUntrackedValidatorUrls returns the URLs of the top 200 validators that aren't already being tracked.
 func (fes *APIServer) GetUntrackedValidatorUrls(trackedDomains map[string]bool) ([]string, map[string]bool, error) {
-	utxoView, error := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, error := fes.getAugmentedUniversalView("GetUntrackedValidatorUrls")
 	if error != nil {
 		return nil, nil, errors.Wrapf(error, "GetAllValidatorUrls: Error getting utxoView")
 	}
@@ -3209,7 +3770,7 @@ func (fes *APIServer) getBalanceForSeed(seedPhrase string) (uint64, error) {
 }
 
 func (fes *APIServer) getBalanceForPubKey(pubKey []byte) (uint64, error) {
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("getBalanceForPubKey")
 	if err != nil {
 		return 0, fmt.Errorf("getBalanceForPubKey: Error getting UtxoView: %v", err)
 	}
@@ -3245,7 +3806,7 @@ func (fes *APIServer) SetGlobalStateCache() {
 		fes.backendServer.DbMutex.Lock()
 		defer fes.backendServer.DbMutex.Unlock()
 	}
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("SetGlobalStateCache")
 	if err != nil {
 		glog.Errorf("SetGlobalStateCache: problem with GetAugmentedUniversalView: %v", err)
 		return
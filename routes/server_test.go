@@ -0,0 +1,119 @@
+package routes
+
+import (
+	"crypto/subtle"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/deso-protocol/backend/config"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckContentLength(t *testing.T) {
+	var bodyRead string
+	handler := CheckContentLength(http.HandlerFunc(func(ww http.ResponseWriter, rr *http.Request) {
+		body, err := io.ReadAll(io.LimitReader(rr.Body, 10))
+		require.NoError(t, err)
+		bodyRead = string(body)
+	}), 10)
+
+	// A body within the limit is passed through.
+	request := httptest.NewRequest("POST", RoutePathGetDaoCoinLimitOrders, strings.NewReader(`{"a":1}`))
+	response := httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Equal(t, `{"a":1}`, bodyRead)
+
+	// A declared length over the limit is rejected before the body is read.
+	bodyRead = ""
+	request = httptest.NewRequest("POST", RoutePathGetDaoCoinLimitOrders, strings.NewReader(`{"a":"too long"}`))
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	require.Equal(t, http.StatusRequestEntityTooLarge, response.Code)
+	require.Contains(t, response.Body.String(), "exceeds the maximum of 10 bytes")
+	require.Empty(t, bodyRead)
+
+	// A chunked request without a Content-Length falls back to the handler's LimitReader.
+	request = httptest.NewRequest("POST", RoutePathGetDaoCoinLimitOrders, io.NopCloser(strings.NewReader(`{"a":"too long"}`)))
+	request.ContentLength = -1
+	request.TransferEncoding = []string{"chunked"}
+	response = httptest.NewRecorder()
+	handler.ServeHTTP(response, request)
+	require.Equal(t, http.StatusOK, response.Code)
+	require.Equal(t, `{"a":"too `, bodyRead)
+}
+
+func TestCheckContentLengthOnAdminRoutes(t *testing.T) {
+	apiServer := newTestApiServer(t)
+
+	// The oversized body must be rejected before the admin checks read it.
+	request := httptest.NewRequest("POST", RoutePathAdminGetGlobalParams, strings.NewReader(`{}`))
+	request.ContentLength = MaxRequestBodySizeBytes + 1
+	response := httptest.NewRecorder()
+	apiServer.router.ServeHTTP(response, request)
+	require.Equal(t, http.StatusRequestEntityTooLarge, response.Code, response.Body.String())
+	require.Contains(t, response.Body.String(), "CheckContentLength")
+}
+
+func TestMaxRequestBodySizeBytesForRoute(t *testing.T) {
+	apiServer := &APIServer{Config: &config.Config{}}
+	require.Equal(t, int64(MaxRequestBodySizeBytes), apiServer.maxRequestBodySizeBytesForRoute(RoutePathGetDaoCoinLimitOrders))
+	require.Equal(t, int64(MaxRequestBodySizeBytes+multipartRequestBodyOverheadBytes),
+		apiServer.maxRequestBodySizeBytesForRoute(RoutePathUploadImage))
+
+	apiServer.Config.MaxRequestBodySizeBytes = 1000
+	require.Equal(t, int64(1000), apiServer.maxRequestBodySizeBytesForRoute(RoutePathGetDaoCoinLimitOrders))
+
+	// The limit can be lowered, but not raised above what handlers read.
+	require.NoError(t, ValidateMaxRequestBodySizeBytes(0))
+	require.NoError(t, ValidateMaxRequestBodySizeBytes(MaxRequestBodySizeBytes))
+	require.Error(t, ValidateMaxRequestBodySizeBytes(MaxRequestBodySizeBytes+1))
+}
+
+func TestCheckAdminSecret(t *testing.T) {
+	// The secret must be compared in constant time.
+	require.Equal(t, reflect.ValueOf(subtle.ConstantTimeCompare).Pointer(), reflect.ValueOf(constantTimeCompare).Pointer())
+	numComparisons := 0
+	constantTimeCompare = func(x, y []byte) int {
+		numComparisons++
+		return subtle.ConstantTimeCompare(x, y)
+	}
+	defer func() { constantTimeCompare = subtle.ConstantTimeCompare }()
+
+	innerCalled := false
+	inner := http.HandlerFunc(func(ww http.ResponseWriter, req *http.Request) {
+		innerCalled = true
+	})
+	serve := func(adminSecret string, headerValue *string) int {
+		innerCalled = false
+		request := httptest.NewRequest("POST", RoutePathAdminGetMempoolStats, strings.NewReader("{}"))
+		if headerValue != nil {
+			request.Header.Set(AdminSecretHeader, *headerValue)
+		}
+		response := httptest.NewRecorder()
+		CheckAdminSecret(inner, adminSecret).ServeHTTP(response, request)
+		return response.Code
+	}
+	correctSecret := "correct horse battery staple"
+	wrongSecret := "correct horse battery stapl"
+
+	// Without a configured secret, the check is disabled.
+	require.Equal(t, http.StatusOK, serve("", nil))
+	require.True(t, innerCalled)
+	require.Equal(t, 0, numComparisons)
+
+	require.Equal(t, http.StatusOK, serve(correctSecret, &correctSecret))
+	require.True(t, innerCalled)
+
+	require.Equal(t, http.StatusUnauthorized, serve(correctSecret, &wrongSecret))
+	require.False(t, innerCalled)
+
+	require.Equal(t, http.StatusUnauthorized, serve(correctSecret, nil))
+	require.False(t, innerCalled)
+
+	require.Equal(t, 3, numComparisons)
+}
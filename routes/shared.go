@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/deso-protocol/uint256"
+	"io"
 	"net/http"
+	"reflect"
 	"time"
 
 	"github.com/deso-protocol/core/lib"
@@ -19,16 +21,116 @@ func _AddBadRequestError(ww http.ResponseWriter, errorString string) {
 	_AddHttpError(ww, errorString, http.StatusBadRequest)
 }
 
+func _AddUnauthorizedError(ww http.ResponseWriter, errorString string) {
+	_AddHttpError(ww, errorString, http.StatusUnauthorized)
+}
+
 func _AddNotFoundError(ww http.ResponseWriter, errorString string) {
 	_AddHttpError(ww, errorString, http.StatusNotFound)
 }
 
+func _AddTooManyRequestsError(ww http.ResponseWriter, errorString string) {
+	_AddHttpError(ww, errorString, http.StatusTooManyRequests)
+}
+
+func _AddPayloadTooLargeError(ww http.ResponseWriter, errorString string) {
+	_AddHttpError(ww, errorString, http.StatusRequestEntityTooLarge)
+}
+
+func _AddServiceUnavailableError(ww http.ResponseWriter, errorString string) {
+	_AddHttpError(ww, errorString, http.StatusServiceUnavailable)
+}
+
 func _AddInternalServerError(ww http.ResponseWriter, errorString string) {
 	_AddHttpError(ww, errorString, http.StatusInternalServerError)
 }
 
-func _AddHttpError(ww http.ResponseWriter, errorString string, statusCode int) {
+// utxoViewError marks a failure to build a UtxoView. Helpers that hand their errors back to a handler return it so the
+// handler can tell a server-side failure apart from a problem with the request.
+type utxoViewError struct {
+	err error
+}
+
+func (e *utxoViewError) Error() string {
+	return e.err.Error()
+}
+
+func (e *utxoViewError) Unwrap() error {
+	return e.err
+}
+
+// _AddHandlerError reports an error returned by a handler helper. Failures to build a UtxoView are never the client's
+// fault, so they're returned as internal server errors; everything else is a bad request.
+func _AddHandlerError(ww http.ResponseWriter, errorString string, err error) {
+	var viewErr *utxoViewError
+	if errors.As(err, &viewErr) {
+		_AddInternalServerError(ww, errorString)
+		return
+	}
+	_AddBadRequestError(ww, errorString)
+}
+
+// describeDecodeError turns an error from decoding a JSON request body into a message that tells the client whether
+// the body wasn't valid JSON or a field had the wrong type. It returns nil if err is nil.
+func describeDecodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var syntaxError *json.SyntaxError
+	var unmarshalTypeError *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return fmt.Errorf("request body is empty")
+	case errors.Is(err, io.ErrUnexpectedEOF):
+		return fmt.Errorf("malformed JSON: unexpected end of request body")
+	case errors.As(err, &syntaxError):
+		return fmt.Errorf("malformed JSON at offset %d: %v", syntaxError.Offset, syntaxError)
+	case errors.As(err, &unmarshalTypeError):
+		expectedType := jsonTypeNameForGoType(unmarshalTypeError.Type)
+		if unmarshalTypeError.Field == "" {
+			return fmt.Errorf("request body expected %v, got %v", expectedType, unmarshalTypeError.Value)
+		}
+		return fmt.Errorf("field %v expected %v, got %v", unmarshalTypeError.Field, expectedType, unmarshalTypeError.Value)
+	}
+	return err
+}
+
+// jsonTypeNameForGoType returns the name of the JSON type that decodes into goType.
+func jsonTypeNameForGoType(goType reflect.Type) string {
+	if goType == nil {
+		return "unknown"
+	}
+	switch goType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return "number"
+	case reflect.String:
+		return "string"
+	case reflect.Bool:
+		return "boolean"
+	case reflect.Slice, reflect.Array:
+		return "array"
+	case reflect.Map, reflect.Struct:
+		return "object"
+	case reflect.Ptr:
+		return jsonTypeNameForGoType(goType.Elem())
+	}
+	return goType.String()
+}
+
+// logHttpError logs the errors returned to clients. It's a variable so tests can check what's logged.
+var logHttpError = func(errorString string) {
 	glog.Error(errorString)
+}
+
+func _AddHttpError(ww http.ResponseWriter, errorString string, statusCode int) {
+	if id := correlationIDFromResponseWriter(ww); id != "" {
+		logHttpError(fmt.Sprintf("[%v] %v", id, errorString))
+	} else {
+		logHttpError(errorString)
+	}
 	ww.WriteHeader(statusCode)
 	json.NewEncoder(ww).Encode(struct {
 		Error string `json:"error"`
@@ -396,7 +498,7 @@ func (fes *APIServer) SendSeedDeSo(recipientPkBytes []byte, amountNanos uint64,
 
 		// Add inputs to the transaction and do signing, validation, and broadcast
 		// depending on what the user requested.
-		utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		utxoView, err := fes.getAugmentedUniversalView("SendSeedDeSo")
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,141 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDescribeDecodeError(t *testing.T) {
+	type testRequest struct {
+		MaxMessagesToFetch   int
+		PublicKeyBase58Check string
+	}
+	decode := func(body string) error {
+		requestData := testRequest{}
+		decoder := json.NewDecoder(strings.NewReader(body))
+		return describeDecodeError(decoder.Decode(&requestData))
+	}
+
+	// A valid body decodes without error.
+	require.NoError(t, decode(`{"MaxMessagesToFetch": 10, "PublicKeyBase58Check": "abc"}`))
+
+	// Malformed JSON is reported as such.
+	err := decode(`{"MaxMessagesToFetch": 10,}`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "malformed JSON at offset")
+
+	err = decode(`{"MaxMessagesToFetch": 10`)
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "malformed JSON")
+
+	// A type mismatch names the field along with the expected and actual types.
+	err = decode(`{"MaxMessagesToFetch": "ten"}`)
+	require.Error(t, err)
+	require.Equal(t, "field MaxMessagesToFetch expected number, got string", err.Error())
+
+	err = decode(`{"PublicKeyBase58Check": 5}`)
+	require.Error(t, err)
+	require.Equal(t, "field PublicKeyBase58Check expected string, got number", err.Error())
+
+	err = decode(`[]`)
+	require.Error(t, err)
+	require.Equal(t, "request body expected object, got array", err.Error())
+
+	err = decode(``)
+	require.Error(t, err)
+	require.Equal(t, "request body is empty", err.Error())
+}
+
+func TestDecodeErrorInHandlerResponse(t *testing.T) {
+	apiServer := &APIServer{databaseComparisonJobs: NewDatabaseComparisonJobs()}
+
+	request, err := http.NewRequest("POST", RoutePathGetDatabaseComparisonStatus,
+		bytes.NewBufferString(`{"JobID": 5}`))
+	require.NoError(t, err)
+	response := httptest.NewRecorder()
+	apiServer.GetDatabaseComparisonStatus(response, request)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+	require.Contains(t, response.Body.String(), "field JobID expected string, got number")
+}
+
+func TestAddHandlerError(t *testing.T) {
+	response := httptest.NewRecorder()
+	_AddHandlerError(response, "wrapped view failure",
+		errors.Wrapf(&utxoViewError{err: errors.New("boom")}, "Problem generating utxo view: "))
+	require.Equal(t, http.StatusInternalServerError, response.Code)
+
+	response = httptest.NewRecorder()
+	_AddHandlerError(response, "bad input", errors.New("Problem decoding public key"))
+	require.Equal(t, http.StatusBadRequest, response.Code)
+}
+
+func TestUtxoViewFailureReturnsInternalServerError(t *testing.T) {
+	// Without a backend server there's no mempool, so every attempt to build the augmented view fails.
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams}
+
+	testCases := []struct {
+		name    string
+		handler http.HandlerFunc
+		body    interface{}
+	}{
+		{
+			name:    "GetPaginatedMessagesForDmThread",
+			handler: apiServer.GetPaginatedMessagesForDmThread,
+			body: GetPaginatedMessagesForDmThreadRequest{
+				UserGroupOwnerPublicKeyBase58Check:  senderPkString,
+				PartyGroupOwnerPublicKeyBase58Check: recipientPkString,
+			},
+		},
+		{
+			name:    "GetAllUserMessageThreads",
+			handler: apiServer.GetAllUserMessageThreads,
+			body:    GetUserMessageThreadsRequest{UserPublicKeyBase58Check: senderPkString},
+		},
+		{
+			name:    "GetAllUserAccessGroups",
+			handler: apiServer.GetAllUserAccessGroups,
+			body:    GetAccessGroupsRequest{PublicKeyBase58Check: senderPkString},
+		},
+		{
+			name:    "GetBulkMessagingPublicKeys",
+			handler: apiServer.GetBulkMessagingPublicKeys,
+			body:    GetBulkMessagingPublicKeysRequest{},
+		},
+		{
+			name:    "GetAppState",
+			handler: apiServer.GetAppState,
+			body:    GetAppStateRequest{},
+		},
+		{
+			name:    "GetExchangeRate",
+			handler: apiServer.GetExchangeRate,
+			body:    struct{}{},
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			bodyBytes, err := json.Marshal(testCase.body)
+			require.NoError(t, err)
+			request, err := http.NewRequest("POST", "/", bytes.NewBuffer(bodyBytes))
+			require.NoError(t, err)
+			response := httptest.NewRecorder()
+			testCase.handler(response, request)
+			require.Equal(t, http.StatusInternalServerError, response.Code, response.Body.String())
+		})
+	}
+
+	// A problem with the request is still reported as a bad request.
+	request, err := http.NewRequest("POST", "/", bytes.NewBufferString(`{"UserPublicKeyBase58Check": "not-a-key"}`))
+	require.NoError(t, err)
+	response := httptest.NewRecorder()
+	apiServer.GetAllUserMessageThreads(response, request)
+	require.Equal(t, http.StatusBadRequest, response.Code)
+}
@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/pkg/errors"
 )
 
 type GetSnapshotEpochMetadataResponse struct {
@@ -52,3 +55,70 @@ func (fes *APIServer) GetStateChecksum(ww http.ResponseWriter, req *http.Request
 		return
 	}
 }
+
+// SnapshotInfo describes the hypersync snapshot stored in a data dir.
+type SnapshotInfo struct {
+	// The block height of the most recent snapshot, and of the first snapshot the node took. Snapshots are taken
+	// every SnapshotEpochPeriod blocks in between.
+	SnapshotBlockHeight      uint64
+	FirstSnapshotBlockHeight uint64
+	SnapshotEpochPeriod      uint64
+
+	CurrentEpochChecksumHex  string
+	CurrentEpochBlockHashHex string
+	// The checksum of the current state, which can be ahead of the most recent snapshot.
+	StateChecksumHex string
+}
+
+// NewSnapshotInfo builds a SnapshotInfo from a snapshot's epoch metadata and the bytes of its state checksum.
+func NewSnapshotInfo(
+	metadata *lib.SnapshotEpochMetadata,
+	snapshotEpochPeriod uint64,
+	stateChecksumBytes []byte,
+) *SnapshotInfo {
+	info := &SnapshotInfo{
+		SnapshotBlockHeight:      metadata.SnapshotBlockHeight,
+		FirstSnapshotBlockHeight: metadata.FirstSnapshotBlockHeight,
+		SnapshotEpochPeriod:      snapshotEpochPeriod,
+		CurrentEpochChecksumHex:  hex.EncodeToString(metadata.CurrentEpochChecksumBytes),
+		StateChecksumHex:         hex.EncodeToString(stateChecksumBytes),
+	}
+	// The block hash is only set once the node has taken a snapshot.
+	if metadata.CurrentEpochBlockHash != nil {
+		info.CurrentEpochBlockHashHex = hex.EncodeToString(metadata.CurrentEpochBlockHash.ToBytes())
+	}
+	return info
+}
+
+// GetSnapshotInfoForDataDir opens the db in dataDir and reads its snapshot metadata. The node that owns dataDir must
+// not be running.
+func GetSnapshotInfoForDataDir(dataDir string, snapshotEpochPeriod uint64, params *lib.DeSoParams) (*SnapshotInfo, error) {
+	db, err := OpenBadgerDataDir(dataDir)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetSnapshotInfoForDataDir: Problem opening %v", dataDir)
+	}
+	defer db.Close()
+
+	snap, err, _, _ := lib.NewSnapshot(
+		db,
+		snapshotEpochPeriod,
+		false,
+		false,
+		params,
+		false,
+		lib.HypersyncDefaultMaxQueueSize,
+		nil)
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetSnapshotInfoForDataDir: Problem reading snapshot")
+	}
+	defer snap.Stop()
+	if snap.CurrentEpochSnapshotMetadata == nil {
+		return nil, fmt.Errorf("GetSnapshotInfoForDataDir: %v has no snapshot metadata", dataDir)
+	}
+
+	checksumBytes, err := snap.Checksum.ToBytes()
+	if err != nil {
+		return nil, errors.Wrapf(err, "GetSnapshotInfoForDataDir: Problem encoding checksum to bytes")
+	}
+	return NewSnapshotInfo(snap.CurrentEpochSnapshotMetadata, snapshotEpochPeriod, checksumBytes), nil
+}
@@ -0,0 +1,42 @@
+package routes
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSnapshotInfo(t *testing.T) {
+	blockHash := &lib.BlockHash{0x01, 0x02}
+	info := NewSnapshotInfo(&lib.SnapshotEpochMetadata{
+		SnapshotBlockHeight:       3000,
+		FirstSnapshotBlockHeight:  1000,
+		CurrentEpochChecksumBytes: []byte{0xab, 0xcd},
+		CurrentEpochBlockHash:     blockHash,
+	}, 1000, []byte{0xef})
+	require.Equal(t, &SnapshotInfo{
+		SnapshotBlockHeight:      3000,
+		FirstSnapshotBlockHeight: 1000,
+		SnapshotEpochPeriod:      1000,
+		CurrentEpochChecksumHex:  "abcd",
+		CurrentEpochBlockHashHex: hex.EncodeToString(blockHash.ToBytes()),
+		StateChecksumHex:         "ef",
+	}, info)
+
+	// Metadata from a node that hasn't taken a snapshot yet has no block hash.
+	info = NewSnapshotInfo(&lib.SnapshotEpochMetadata{}, 1000, nil)
+	require.Empty(t, info.CurrentEpochBlockHashHex)
+}
+
+func TestGetSnapshotInfoForDataDir(t *testing.T) {
+	// A fresh data dir reports an empty snapshot.
+	info, err := GetSnapshotInfoForDataDir(t.TempDir(), 1000, &lib.DeSoTestnetParams)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), info.SnapshotBlockHeight)
+	require.Equal(t, uint64(0), info.FirstSnapshotBlockHeight)
+	require.Equal(t, uint64(1000), info.SnapshotEpochPeriod)
+	require.Empty(t, info.CurrentEpochBlockHashHex)
+	require.NotEmpty(t, info.StateChecksumHex)
+}
@@ -121,7 +121,7 @@ func (fes *APIServer) CreateStakeTxn(ww http.ResponseWriter, req *http.Request)
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := StakeRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateStakeTxn: Problem parsing request body: %v", err))
 		return
 	}
@@ -246,7 +246,7 @@ func (fes *APIServer) CreateUnstakeTxn(ww http.ResponseWriter, req *http.Request
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UnstakeRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateUnstakeTxn: Problem parsing request body: %v", err))
 		return
 	}
@@ -366,7 +366,7 @@ func (fes *APIServer) CreateUnlockStakeTxn(ww http.ResponseWriter, req *http.Req
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UnlockStakeRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateUnlockStakeTxn: Problem parsing request body: %v", err))
 		return
 	}
@@ -489,7 +489,7 @@ func (fes *APIServer) GetStakeForValidatorAndStaker(ww http.ResponseWriter, req
 	}
 
 	// Create UTXO View
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetStakeForValidatorAndStaker")
 	if err != nil {
 		_AddInternalServerError(ww, fmt.Sprintf("GetStakeForValidatorAndStaker: Problem fetching utxoView: %v", err))
 		return
@@ -538,7 +538,7 @@ func (fes *APIServer) GetStakesForValidator(ww http.ResponseWriter, req *http.Re
 	}
 
 	// Create UTXO View
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetStakesForValidator")
 	if err != nil {
 		_AddInternalServerError(ww, fmt.Sprintf("GetStakesForValidator: Problem fetching utxoView: %v", err))
 		return
@@ -591,7 +591,7 @@ func (fes *APIServer) GetLockedStakesForValidatorAndStaker(ww http.ResponseWrite
 	}
 
 	// Create UTXO View
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetLockedStakesForValidatorAndStaker")
 	if err != nil {
 		_AddInternalServerError(ww, fmt.Sprintf(
 			"GetLockedStakesForValidatorAndStaker: Problem fetching utxoView: %v", err))
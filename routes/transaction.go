@@ -11,6 +11,7 @@ import (
 	"math/big"
 	"net/http"
 	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -49,7 +50,7 @@ type GetTxnResponse struct {
 func (fes *APIServer) GetTxn(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetTxnRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetTxn: Problem parsing request body: %v", err))
 		return
 	}
@@ -85,16 +86,9 @@ func (fes *APIServer) GetTxn(ww http.ResponseWriter, req *http.Request) {
 		txnStatus = TxnStatusInMempool
 	}
 	txnInMempool := fes.backendServer.GetMempool().IsTransactionInPool(txnHash)
-	startTime := time.Now()
-	// We have to wait until txindex has reached the uncommitted tip height, not the
-	// committed tip height. Otherwise we'll be missing ~2 blocks in limbo.
-	coreChainTipHeight := fes.TXIndex.CoreChain.BlockTip().Height
-	for fes.TXIndex.TXIndexChain.BlockTip().Height < coreChainTipHeight {
-		if time.Since(startTime) > 30*time.Second {
-			_AddBadRequestError(ww, fmt.Sprintf("GetTxn: Timed out waiting for txindex to sync."))
-			return
-		}
-		time.Sleep(10 * time.Millisecond)
+	if err := fes.waitForTxindexToSync(); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTxn: %v", err))
+		return
 	}
 	txnInTxindex := lib.DbCheckTxnExistence(fes.TXIndex.TXIndexChain.DB(), nil, txnHash)
 	txnFound := false
@@ -122,6 +116,145 @@ func (fes *APIServer) GetTxn(ww http.ResponseWriter, req *http.Request) {
 	}
 }
 
+// waitForTxindexToSync blocks until txindex has caught up with the core chain, for up to 30 seconds.
+func (fes *APIServer) waitForTxindexToSync() error {
+	startTime := time.Now()
+	// We have to wait until txindex has reached the uncommitted tip height, not the
+	// committed tip height. Otherwise we'll be missing ~2 blocks in limbo.
+	coreChainTipHeight := fes.TXIndex.CoreChain.BlockTip().Height
+	for fes.TXIndex.TXIndexChain.BlockTip().Height < coreChainTipHeight {
+		if time.Since(startTime) > 30*time.Second {
+			return errors.New("Timed out waiting for txindex to sync.")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	return nil
+}
+
+type TransactionStatus string
+
+const (
+	TransactionStatusInMempool TransactionStatus = "InMempool"
+	TransactionStatusConfirmed TransactionStatus = "Confirmed"
+	TransactionStatusUnknown   TransactionStatus = "Unknown"
+)
+
+type GetTransactionStatusRequest struct {
+	// The hash of the transaction, either hex-encoded or base58check-encoded as in TransactionIDBase58Check.
+	TxnHash string `safeForLogging:"true"`
+}
+
+type GetTransactionStatusResponse struct {
+	// Unknown means the node has seen the txn neither in its mempool nor in a block. A txn that was just submitted
+	// to another node may not have propagated yet, and one that was rejected never will.
+	Status TransactionStatus
+
+	// Only set when Status is Confirmed.
+	BlockHashHex string `json:",omitempty"`
+	BlockHeight  uint64 `json:",omitempty"`
+}
+
+// GetTransactionStatus reports whether the node has a txn in its mempool or in a block. Clients that submit txns
+// to another node can poll it to find out when this node has seen them.
+func (fes *APIServer) GetTransactionStatus(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := GetTransactionStatusRequest{}
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactionStatus: Problem parsing request body: %v", err))
+		return
+	}
+
+	if fes.TXIndex == nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactionStatus: Cannot be called when TXIndexChain "+
+			"is nil. This error occurs when --txindex was not passed to the program on startup"))
+		return
+	}
+
+	txnHash, err := parseTransactionHash(requestData.TxnHash)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("GetTransactionStatus: Invalid TxnHash: %v", err))
+		return
+	}
+
+	res, err := getTransactionStatus(
+		txnHash,
+		fes.backendServer.GetMempool().IsTransactionInPool,
+		func(txnHash *lib.BlockHash) (*lib.BlockHash, uint64, error) {
+			if err := fes.waitForTxindexToSync(); err != nil {
+				return nil, 0, err
+			}
+			txnMeta := lib.DbGetTxindexTransactionRefByTxID(fes.TXIndex.TXIndexChain.DB(), nil, txnHash)
+			if txnMeta == nil {
+				return nil, 0, nil
+			}
+			blockHashBytes, err := hex.DecodeString(txnMeta.BlockHashHex)
+			if err != nil || len(blockHashBytes) != lib.HashSizeBytes {
+				return nil, 0, errors.Errorf("Problem parsing block hash %v: %v", txnMeta.BlockHashHex, err)
+			}
+			blockHash := lib.NewBlockHash(blockHashBytes)
+			blockNode := fes.blockchain.GetBlockNodeWithHash(blockHash)
+			if blockNode == nil {
+				return nil, 0, errors.Errorf("Block %v containing the txn not found", blockHash)
+			}
+			return blockHash, uint64(blockNode.Height), nil
+		},
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactionStatus: %v", err))
+		return
+	}
+
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetTransactionStatus: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// parseTransactionHash decodes a txn hash given either as a base58check string or as hex.
+func parseTransactionHash(txnHashString string) (*lib.BlockHash, error) {
+	if txnHashString == "" {
+		return nil, errors.New("Must provide a txn hash")
+	}
+	txnHashBytes, _, err := lib.Base58CheckDecode(txnHashString)
+	if err != nil {
+		// If not base58 try hex decode
+		txnHashBytes, err = hex.DecodeString(txnHashString)
+		if err != nil {
+			return nil, errors.Errorf("Must be a base58check or hex string: %v", err)
+		}
+	}
+	if len(txnHashBytes) != lib.HashSizeBytes {
+		return nil, errors.Errorf("Txn hash byte length is %d but should be %d", len(txnHashBytes), lib.HashSizeBytes)
+	}
+	return lib.NewBlockHash(txnHashBytes), nil
+}
+
+// getTransactionStatus looks a txn up in the mempool and then in the blocks txindex has processed. getConfirmedBlock
+// returns a nil hash if the txn isn't in a block. As in GetTxn, the mempool has to be checked first, otherwise a txn
+// that a new block removes from the mempool before txindex processes the block would be reported as unknown.
+func getTransactionStatus(
+	txnHash *lib.BlockHash,
+	isInMempool func(txnHash *lib.BlockHash) bool,
+	getConfirmedBlock func(txnHash *lib.BlockHash) (_blockHash *lib.BlockHash, _blockHeight uint64, _err error),
+) (*GetTransactionStatusResponse, error) {
+	txnInMempool := isInMempool(txnHash)
+	blockHash, blockHeight, err := getConfirmedBlock(txnHash)
+	if err != nil {
+		return nil, err
+	}
+	if blockHash != nil {
+		return &GetTransactionStatusResponse{
+			Status:       TransactionStatusConfirmed,
+			BlockHashHex: hex.EncodeToString(blockHash[:]),
+			BlockHeight:  blockHeight,
+		}, nil
+	}
+	if txnInMempool {
+		return &GetTransactionStatusResponse{Status: TransactionStatusInMempool}, nil
+	}
+	return &GetTransactionStatusResponse{Status: TransactionStatusUnknown}, nil
+}
+
 // SubmitAtomicTransactionRequest is meant to aid in the submission of atomic transactions
 // with identity service signed transactions. Specifically, it takes an incomplete atomic transaction
 // and "completes" the transaction by adding in identity service signed transactions.
@@ -152,7 +285,7 @@ type SubmitAtomicTransactionResponse struct {
 func (fes *APIServer) SubmitAtomicTransaction(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SubmitAtomicTransactionRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SubmitAtomicTransaction: Problem parsing request body: %v", err))
 		return
 	}
@@ -388,7 +521,7 @@ func TransactionFeeRateTooHigh(txn *lib.MsgDeSoTxn, txnLen uint64) bool {
 func (fes *APIServer) SubmitTransaction(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SubmitTransactionRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SubmitTransactionRequest: Problem parsing request body: %v", err))
 		return
 	}
@@ -497,7 +630,7 @@ func (fes *APIServer) SubmitTransaction(ww http.ResponseWriter, req *http.Reques
 // 2. Attempt to auto-whitelist the post for the global feed
 func (fes *APIServer) _afterProcessSubmitPostTransaction(txn *lib.MsgDeSoTxn, response *SubmitTransactionResponse) error {
 	fes.backendServer.GetMempool().BlockUntilReadOnlyViewRegenerated()
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("_afterProcessSubmitPostTransaction")
 	if err != nil {
 		return errors.Errorf("Problem with GetAugmentedUniversalView: %v", err)
 	}
@@ -621,7 +754,7 @@ type UpdateProfileResponse struct {
 func (fes *APIServer) UpdateProfile(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UpdateProfileRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("UpdateProfile: Problem parsing request body: %v", err))
 		return
 	}
@@ -1042,7 +1175,7 @@ func (fes *APIServer) ExchangeBitcoinStateless(ww http.ResponseWriter, req *http
 	}
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := ExchangeBitcoinRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("ExchangeBitcoinStateless: Problem parsing request body: %v", err))
 		return
 	}
@@ -1124,7 +1257,7 @@ func (fes *APIServer) ExchangeBitcoinStateless(ww http.ResponseWriter, req *http
 			return
 		}
 		// Verify that the derived key has been authorized by the provided owner public key.
-		utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		utxoView, err := fes.getAugmentedUniversalView("ExchangeBitcoinStateless")
 		if err != nil {
 			_AddBadRequestError(ww, errors.Wrapf(err, "ExchangeBitcoinStateless: Problem getting universal view from mempool").Error())
 			return
@@ -1340,7 +1473,7 @@ func (fes *APIServer) GetNanosFromSats(satoshis uint64, feeBasisPoints uint64) u
 	usdCentsPerBitcoin := fes.UsdCentsPerBitCoinExchangeRate
 	// If we don't have a valid value from monitoring at this time, use the price from the protocol
 	if usdCentsPerBitcoin == 0 {
-		readUtxoView, _ := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		readUtxoView, _ := fes.getAugmentedUniversalView("GetNanosFromSats")
 		usdCentsPerBitcoin = float64(readUtxoView.GetCurrentUSDCentsPerBitcoin())
 	}
 	usdCents := (float64(satoshis) * usdCentsPerBitcoin) / lib.SatoshisPerBitcoin
@@ -1486,7 +1619,7 @@ func (fes *APIServer) CreateSendDesoTxn(
 func (fes *APIServer) SendDeSo(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SendDeSoRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SendDeSo: Problem parsing request body: %v", err))
 		return
 	}
@@ -1625,7 +1758,7 @@ func (fes *APIServer) CreateLikeStateless(ww http.ResponseWriter, req *http.Requ
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CreateLikeStatelessRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateLikeStateless: Problem parsing request body: %v", err))
 		return
 	}
@@ -1742,7 +1875,7 @@ type SubmitPostResponse struct {
 func (fes *APIServer) SubmitPost(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SubmitPostRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SubmitPost: Problem parsing request body: %v", err))
 		return
 	}
@@ -2037,7 +2170,7 @@ func (fes *APIServer) CreateFollowTxnStateless(ww http.ResponseWriter, req *http
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CreateFollowTxnStatelessRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateFollowTxnStateless: Problem parsing request body: %v", err))
 		return
 	}
@@ -2165,7 +2298,7 @@ type BuyOrSellCreatorCoinResponse struct {
 func (fes *APIServer) BuyOrSellCreatorCoin(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := BuyOrSellCreatorCoinRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("BuyOrSellCreatorCoin: Problem parsing request body: %v", err))
 		return
 	}
@@ -2431,7 +2564,7 @@ type TransferCreatorCoinResponse struct {
 func (fes *APIServer) TransferCreatorCoin(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := TransferCreatorCoinRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("TransferCreatorCoin: Problem parsing request body: %v", err))
 		return
 	}
@@ -2586,7 +2719,7 @@ type SendDiamondsResponse struct {
 func (fes *APIServer) SendDiamonds(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SendDiamondsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SendDiamonds: Problem parsing request body: %v", err))
 		return
 	}
@@ -2795,7 +2928,7 @@ type DAOCoinResponse struct {
 func (fes *APIServer) DAOCoin(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := DAOCoinRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("DAOCoin: Problem parsing request body: %v", err))
 		return
 	}
@@ -2976,7 +3109,7 @@ type TransferDAOCoinResponse struct {
 func (fes *APIServer) TransferDAOCoin(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := TransferDAOCoinRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("TransferDAOCoin: Problem parsing request body: %v", err))
 		return
 	}
@@ -3096,6 +3229,11 @@ type DAOCoinLimitOrderResponse struct {
 	TxnHashHex        string
 
 	SimulatedExecutionResult *DAOCoinLimitOrderSimulatedExecutionResult
+
+	// Base-10 strings. The scaled exchange rate and base-unit quantity CreateDAOCoinLimitOrder built the order with, so
+	// clients can check the node interpreted Price and Quantity the way they intended.
+	ScaledExchangeRateCoinsToSellPerCoinToBuy string `json:",omitempty"`
+	QuantityToFillInBaseUnits                 string `json:",omitempty"`
 }
 
 // DAOCoinLimitOrderWithExchangeRateAndQuantityRequest alias type for backwards compatibility
@@ -3135,21 +3273,20 @@ type DAOCoinLimitOrderCreationRequest struct {
 	OptionalPrecedingTransactions []*lib.MsgDeSoTxn `safeForLogging:"true"`
 }
 
-func (fes *APIServer) createDaoCoinLimitOrderHelper(
-	requestData *DAOCoinLimitOrderCreationRequest,
-) (
-	_res *DAOCoinLimitOrderResponse,
+// parseDAOCoinLimitOrderCreationRequest validates the side, fill type, price, and quantity of a limit order request and
+// converts them to the values the transaction is built from. Price and Quantity are converted exactly from their
+// decimal strings, and the deprecated float fields are only read when the corresponding string is empty.
+func parseDAOCoinLimitOrderCreationRequest(requestData *DAOCoinLimitOrderCreationRequest) (
+	_operationType lib.DAOCoinLimitOrderOperationType,
+	_fillType lib.DAOCoinLimitOrderFillType,
+	_scaledExchangeRateCoinsToSellPerCoinToBuy *uint256.Int,
+	_quantityToFillInBaseUnits *uint256.Int,
 	_err error,
 ) {
-	// Basic validation that we have a transactor
-	if requestData.TransactorPublicKeyBase58Check == "" {
-		return nil, errors.New("CreateDAOCoinLimitOrder: must provide a TransactorPublicKeyBase58Check")
-	}
-
 	// Validate operation type
 	operationType, err := orderOperationTypeToUint64(requestData.OperationType)
 	if err != nil {
-		return nil, errors.Errorf("CreateDAOCoinLimitOrder: %v", err)
+		return 0, 0, nil, nil, err
 	}
 
 	// Parse and validate fill type; for backwards compatibility, default the empty string to GoodTillCancelled
@@ -3157,7 +3294,7 @@ func (fes *APIServer) createDaoCoinLimitOrderHelper(
 	if requestData.FillType != "" {
 		fillType, err = orderFillTypeToUint64(requestData.FillType)
 		if err != nil {
-			return nil, errors.Errorf("CreateDAOCoinLimitOrder: %v", err)
+			return 0, 0, nil, nil, err
 		}
 	}
 
@@ -3173,7 +3310,7 @@ func (fes *APIServer) createDaoCoinLimitOrderHelper(
 			operationType,
 		)
 	} else if requestData.ExchangeRateCoinsToSellPerCoinToBuy <= 0 {
-		err = errors.Errorf("CreateDAOCoinLimitOrder: ExchangeRateCoinsToSellPerCoinToBuy must be greater than 0")
+		err = errors.Errorf("ExchangeRateCoinsToSellPerCoinToBuy must be greater than 0")
 	} else {
 		// ExchangeRateCoinsToSellPerCoinToBuy > 0
 		scaledExchangeRateCoinsToSellPerCoinToBuy, err = CalculateScaledExchangeRateFromFloat(
@@ -3183,7 +3320,7 @@ func (fes *APIServer) createDaoCoinLimitOrderHelper(
 		)
 	}
 	if err != nil {
-		return nil, errors.Errorf("CreateDAOCoinLimitOrder: %v", err)
+		return 0, 0, nil, nil, err
 	}
 
 	// Parse and validated quantity
@@ -3198,7 +3335,7 @@ func (fes *APIServer) createDaoCoinLimitOrderHelper(
 			requestData.Quantity,
 		)
 	} else if requestData.QuantityToFill <= 0 {
-		err = errors.Errorf("CreateDAOCoinLimitOrder: Quantity must be greater than 0")
+		err = errors.Errorf("Quantity must be greater than 0")
 	} else {
 		quantityToFillInBaseUnits, err = CalculateQuantityToFillAsBaseUnits(
 			requestData.BuyingDAOCoinCreatorPublicKeyBase58Check,
@@ -3207,6 +3344,29 @@ func (fes *APIServer) createDaoCoinLimitOrderHelper(
 			formatFloatAsString(requestData.QuantityToFill),
 		)
 	}
+	if err != nil {
+		return 0, 0, nil, nil, err
+	}
+
+	return operationType, fillType, scaledExchangeRateCoinsToSellPerCoinToBuy, quantityToFillInBaseUnits, nil
+}
+
+func (fes *APIServer) createDaoCoinLimitOrderHelper(
+	requestData *DAOCoinLimitOrderCreationRequest,
+) (
+	_res *DAOCoinLimitOrderResponse,
+	_err error,
+) {
+	// Basic validation that we have a transactor
+	if requestData.TransactorPublicKeyBase58Check == "" {
+		return nil, errors.New("CreateDAOCoinLimitOrder: must provide a TransactorPublicKeyBase58Check")
+	}
+	if err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		return nil, errors.Errorf("CreateDAOCoinLimitOrder: %v", err)
+	}
+
+	operationType, fillType, scaledExchangeRateCoinsToSellPerCoinToBuy, quantityToFillInBaseUnits, err :=
+		parseDAOCoinLimitOrderCreationRequest(requestData)
 	if err != nil {
 		return nil, errors.Errorf("CreateDAOCoinLimitOrder: %v", err)
 	}
@@ -3278,6 +3438,8 @@ func (fes *APIServer) createDaoCoinLimitOrderHelper(
 		return nil, errors.Errorf("CreateDAOCoinLimitOrder: %v", err)
 	}
 
+	res.ScaledExchangeRateCoinsToSellPerCoinToBuy = scaledExchangeRateCoinsToSellPerCoinToBuy.ToBig().Text(10)
+	res.QuantityToFillInBaseUnits = quantityToFillInBaseUnits.ToBig().Text(10)
 	return res, nil
 }
 
@@ -3287,7 +3449,7 @@ func (fes *APIServer) CreateDAOCoinLimitOrder(ww http.ResponseWriter, req *http.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := DAOCoinLimitOrderCreationRequest{}
 
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateDAOCoinLimitOrder: Problem parsing request body: %v", err))
 		return
 	}
@@ -3344,6 +3506,9 @@ func (fes *APIServer) createDaoCoinMarketOrderHelper(
 	if requestData.TransactorPublicKeyBase58Check == "" {
 		return nil, errors.New("CreateDAOCoinMarketOrder: must provide a TransactorPublicKeyBase58Check")
 	}
+	if err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		return nil, errors.Errorf("CreateDAOCoinMarketOrder: %v", err)
+	}
 
 	// Validate operation type
 	operationType, err := orderOperationTypeToUint64(requestData.OperationType)
@@ -3450,7 +3615,7 @@ func (fes *APIServer) CreateDAOCoinMarketOrder(ww http.ResponseWriter, req *http
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := DAOCoinMarketOrderCreationRequest{}
 
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("CreateDAOCoinMarketOrder: Problem parsing request body: %v", err))
 		return
 	}
@@ -3522,7 +3687,7 @@ func (fes *APIServer) CancelDAOCoinLimitOrder(ww http.ResponseWriter, req *http.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := DAOCoinLimitOrderWithCancelOrderIDRequest{}
 
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(
 			ww,
 			fmt.Sprintf("CancelDAOCoinLimitOrder: Problem parsing request body: %v", err),
@@ -3537,6 +3702,10 @@ func (fes *APIServer) CancelDAOCoinLimitOrder(ww http.ResponseWriter, req *http.
 		)
 		return
 	}
+	if err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("CancelDAOCoinLimitOrder: %v", err))
+		return
+	}
 
 	utxoView, err := lib.GetAugmentedUniversalViewWithAdditionalTransactions(
 		fes.backendServer.GetMempool(),
@@ -3581,6 +3750,255 @@ func (fes *APIServer) CancelDAOCoinLimitOrder(ww http.ResponseWriter, req *http.
 	}
 }
 
+type BuildMatchingOrderForOrderIDRequest struct {
+	// The public key of the user who will place the matching order
+	TransactorPublicKeyBase58Check string `safeForLogging:"true"`
+
+	// The hex-encoded ID of the resting order to fill
+	OrderID string `safeForLogging:"true"`
+
+	// Defaults to FILL_OR_KILL so the matching order is never left resting on the book
+	FillType DAOCoinLimitOrderFillTypeString `safeForLogging:"true"`
+
+	MinFeeRateNanosPerKB uint64           `safeForLogging:"true"`
+	TransactionFees      []TransactionFee `safeForLogging:"true"`
+
+	OptionalPrecedingTransactions []*lib.MsgDeSoTxn `safeForLogging:"true"`
+}
+
+type BuildMatchingOrderForOrderIDResponse struct {
+	*DAOCoinLimitOrderResponse
+
+	// The matching order in the same terms as the order book endpoints
+	BuyingDAOCoinCreatorPublicKeyBase58Check  string
+	SellingDAOCoinCreatorPublicKeyBase58Check string
+	Price                                     string
+	Quantity                                  string
+	OperationType                             DAOCoinLimitOrderOperationTypeString
+}
+
+// BuildMatchingOrderForOrderID Constructs a transaction for the counter-order that exactly fills the resting order with
+// the specified order id. The counter-order is on the opposite side of the book, at the inverse of the resting order's
+// exchange rate, and for the resting order's remaining quantity.
+func (fes *APIServer) BuildMatchingOrderForOrderID(ww http.ResponseWriter, req *http.Request) {
+	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
+	requestData := BuildMatchingOrderForOrderIDRequest{}
+
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
+		_AddBadRequestError(
+			ww,
+			fmt.Sprintf("BuildMatchingOrderForOrderID: Problem parsing request body: %v", err),
+		)
+		return
+	}
+
+	if requestData.TransactorPublicKeyBase58Check == "" {
+		_AddBadRequestError(ww, "BuildMatchingOrderForOrderID: must provide a TransactorPublicKeyBase58Check")
+		return
+	}
+	if err := ValidateNotDESOIdentifier("TransactorPublicKeyBase58Check", requestData.TransactorPublicKeyBase58Check); err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+
+	fillType := lib.DAOCoinLimitOrderFillTypeFillOrKill
+	if requestData.FillType != "" {
+		var err error
+		fillType, err = orderFillTypeToUint64(requestData.FillType)
+		if err != nil {
+			_AddBadRequestError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+			return
+		}
+	}
+
+	orderID, err := decodeBlockHashFromHex(requestData.OrderID)
+	if err != nil {
+		_AddBadRequestError(
+			ww,
+			fmt.Sprintf("BuildMatchingOrderForOrderID: OrderID param is not a valid order id: %v", err),
+		)
+		return
+	}
+
+	utxoView, err := lib.GetAugmentedUniversalViewWithAdditionalTransactions(
+		fes.backendServer.GetMempool(),
+		requestData.OptionalPrecedingTransactions,
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: problem fetching utxoView: %v", err))
+		return
+	}
+
+	restingOrder, err := utxoView.GetDAOCoinLimitOrderEntry(orderID)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: Error fetching order: %v", err))
+		return
+	}
+	if restingOrder == nil || restingOrder.IsDeleted() {
+		_AddNotFoundError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: No open order with OrderID %v", orderID))
+		return
+	}
+
+	// The matching order buys the coin the resting order sells, and sells the coin the resting order buys.
+	buyingCoinPublicKeyBase58Check := fes.getPublicKeyBase58CheckOrCoinIdentifierForPKID(
+		utxoView, restingOrder.SellingDAOCoinCreatorPKID)
+	sellingCoinPublicKeyBase58Check := fes.getPublicKeyBase58CheckOrCoinIdentifierForPKID(
+		utxoView, restingOrder.BuyingDAOCoinCreatorPKID)
+
+	scaledExchangeRateCoinsToSellPerCoinToBuy, quantityToFillInBaseUnits, operationType, err :=
+		BuildMatchingDAOCoinLimitOrder(restingOrder)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+	operationTypeString, err := orderOperationTypeToString(operationType)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+
+	// Validate transactor has sufficient selling coins.
+	err = fes.validateTransactorSellingCoinBalance(
+		requestData.TransactorPublicKeyBase58Check,
+		buyingCoinPublicKeyBase58Check,
+		sellingCoinPublicKeyBase58Check,
+		operationTypeString,
+		scaledExchangeRateCoinsToSellPerCoinToBuy,
+		quantityToFillInBaseUnits,
+	)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+
+	// Validate any transfer restrictions on buying the DAO coin.
+	err = fes.validateDAOCoinOrderTransferRestriction(
+		requestData.TransactorPublicKeyBase58Check,
+		buyingCoinPublicKeyBase58Check)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+
+	buyingCoinPublicKey, sellingCoinPublicKey, err := fes.getBuyingAndSellingDAOCoinPublicKeys(
+		buyingCoinPublicKeyBase58Check,
+		sellingCoinPublicKeyBase58Check,
+	)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+
+	limitOrderRes, err := fes.createDAOCoinLimitOrderResponse(
+		utxoView,
+		requestData.TransactorPublicKeyBase58Check,
+		buyingCoinPublicKey,
+		sellingCoinPublicKey,
+		scaledExchangeRateCoinsToSellPerCoinToBuy,
+		quantityToFillInBaseUnits,
+		operationType,
+		fillType,
+		nil,
+		requestData.MinFeeRateNanosPerKB,
+		requestData.TransactionFees,
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+
+	limitOrderRes.SimulatedExecutionResult, err = fes.getDAOCoinLimitOrderSimulatedExecutionResult(
+		utxoView,
+		requestData.TransactorPublicKeyBase58Check,
+		buyingCoinPublicKeyBase58Check,
+		sellingCoinPublicKeyBase58Check,
+		limitOrderRes.Transaction,
+	)
+	if err != nil {
+		_AddBadRequestError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+
+	price, err := CalculatePriceStringFromScaledExchangeRate(
+		buyingCoinPublicKeyBase58Check,
+		sellingCoinPublicKeyBase58Check,
+		scaledExchangeRateCoinsToSellPerCoinToBuy,
+		operationTypeString,
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+	quantity, err := CalculateStringQuantityFromBaseUnits(
+		buyingCoinPublicKeyBase58Check,
+		sellingCoinPublicKeyBase58Check,
+		operationTypeString,
+		quantityToFillInBaseUnits,
+	)
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: %v", err))
+		return
+	}
+
+	res := BuildMatchingOrderForOrderIDResponse{
+		DAOCoinLimitOrderResponse:                 limitOrderRes,
+		BuyingDAOCoinCreatorPublicKeyBase58Check:  buyingCoinPublicKeyBase58Check,
+		SellingDAOCoinCreatorPublicKeyBase58Check: sellingCoinPublicKeyBase58Check,
+		Price:         price,
+		Quantity:      quantity,
+		OperationType: operationTypeString,
+	}
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("BuildMatchingOrderForOrderID: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+// BuildMatchingDAOCoinLimitOrder returns the exchange rate, quantity, and operation type of the counter-order that
+// exactly fills restingOrder. The counter-order swaps the buying and selling coins, so:
+//   - A BID for Q of coin B becomes an ASK selling Q of coin B, and an ASK selling Q of coin S becomes a BID for Q of
+//     coin S. Either way the quantity refers to the same coin, so it carries over unchanged.
+//   - The exchange rate is the multiplicative inverse of the resting order's, rounded up so that the two rates always
+//     cross. Trades execute at the resting order's rate, so rounding up doesn't change the amounts exchanged.
+func BuildMatchingDAOCoinLimitOrder(
+	restingOrder *lib.DAOCoinLimitOrderEntry,
+) (
+	_scaledExchangeRateCoinsToSellPerCoinToBuy *uint256.Int,
+	_quantityToFillInBaseUnits *uint256.Int,
+	_operationType lib.DAOCoinLimitOrderOperationType,
+	_err error,
+) {
+	var operationType lib.DAOCoinLimitOrderOperationType
+	switch restingOrder.OperationType {
+	case lib.DAOCoinLimitOrderOperationTypeBID:
+		operationType = lib.DAOCoinLimitOrderOperationTypeASK
+	case lib.DAOCoinLimitOrderOperationTypeASK:
+		operationType = lib.DAOCoinLimitOrderOperationTypeBID
+	default:
+		return nil, nil, 0, errors.Errorf("Unknown DAOCoinLimitOrderOperationType %v", restingOrder.OperationType)
+	}
+
+	if restingOrder.ScaledExchangeRateCoinsToSellPerCoinToBuy == nil ||
+		restingOrder.ScaledExchangeRateCoinsToSellPerCoinToBuy.IsZero() {
+		return nil, nil, 0, errors.Errorf("Order %v has a zero exchange rate", restingOrder.OrderID)
+	}
+	if restingOrder.QuantityToFillInBaseUnits == nil || restingOrder.QuantityToFillInBaseUnits.IsZero() {
+		return nil, nil, 0, errors.Errorf("Order %v has nothing left to fill", restingOrder.OrderID)
+	}
+
+	// ceil(1e38 * 1e38 / rate) = (1e38 * 1e38 + rate - 1) / rate
+	restingRate := restingOrder.ScaledExchangeRateCoinsToSellPerCoinToBuy.ToBig()
+	oneE76 := big.NewInt(0).Mul(lib.OneE38.ToBig(), lib.OneE38.ToBig())
+	numerator := big.NewInt(0).Add(oneE76, restingRate)
+	numerator.Sub(numerator, big.NewInt(1))
+	invertedRate, overflows := uint256.FromBig(big.NewInt(0).Div(numerator, restingRate))
+	if overflows {
+		return nil, nil, 0, errors.Errorf("Overflow when inverting the exchange rate of order %v", restingOrder.OrderID)
+	}
+
+	return invertedRate, restingOrder.QuantityToFillInBaseUnits.Clone(), operationType, nil
+}
+
 func (fes *APIServer) createDAOCoinLimitOrderResponse(
 	utxoView *lib.UtxoView,
 	transactorPublicKeyBase58Check string,
@@ -3822,7 +4240,7 @@ type AuthorizeDerivedKeyResponse struct {
 func (fes *APIServer) AuthorizeDerivedKey(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AuthorizeDerivedKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AuthorizeDerivedKey: Problem parsing request body: %v", err))
 		return
 	}
@@ -4189,7 +4607,7 @@ func TransactionSpendingLimitToResponse(
 
 func (fes *APIServer) TransactionSpendingLimitFromResponse(
 	transactionSpendingLimitResponse TransactionSpendingLimitResponse) (*lib.TransactionSpendingLimit, error) {
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("TransactionSpendingLimitFromResponse")
 	if err != nil {
 		return nil, fmt.Errorf("TransactionSpendingLimitFromResponse: error getting utxoview: %v", err)
 	}
@@ -4432,7 +4850,7 @@ type AppendExtraDataResponse struct {
 func (fes *APIServer) AppendExtraData(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AppendExtraDataRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AppendExtraData: Problem parsing request body: %v", err))
 		return
 	}
@@ -4502,7 +4920,7 @@ type GetTransactionSpendingResponse struct {
 func (fes *APIServer) GetTransactionSpending(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetTransactionSpendingRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetTransactionSpending: Problem parsing request body: %v", err))
 		return
 	}
@@ -4523,7 +4941,7 @@ func (fes *APIServer) GetTransactionSpending(ww http.ResponseWriter, req *http.R
 	}
 
 	// Get augmented universal view from mempool.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetTransactionSpending")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetTransactionSpending: Problem getting AugmentedUniversalView: %v", err))
 		return
@@ -4637,7 +5055,7 @@ type GetSignatureIndexResponse struct {
 func (fes *APIServer) GetSignatureIndex(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetSignatureIndexRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetSignatureIndex: Problem parsing request body: %v", err))
 		return
 	}
@@ -4693,6 +5111,80 @@ func (fes *APIServer) GetTxnConstructionParams(ww http.ResponseWriter, req *http
 	}
 }
 
+// The percentiles of mempool fee rates recommended as low, medium, and high fee rates.
+const (
+	LowFeeRatePercentile    = 25
+	MediumFeeRatePercentile = 50
+	HighFeeRatePercentile   = 90
+)
+
+type GetFeeRateEstimatesResponse struct {
+	// The lowest fee rate the network accepts: the MinimumNetworkFeeNanosPerKB global param if it's set, and this
+	// node's --min-fee-rate-nanos-per-kb otherwise.
+	MinimumFeeRateNanosPerKB uint64
+
+	// Fee rates paid by the transactions currently in the mempool, at LowFeeRatePercentile, MediumFeeRatePercentile,
+	// and HighFeeRatePercentile. None is ever below MinimumFeeRateNanosPerKB, so they're all equal to it when the
+	// mempool is empty.
+	LowFeeRateNanosPerKB    uint64
+	MediumFeeRateNanosPerKB uint64
+	HighFeeRateNanosPerKB   uint64
+
+	// The number of mempool transactions the recommended fee rates were computed from.
+	NumMempoolTxns int
+}
+
+// GetFeeRateEstimates returns the minimum fee rate along with low, medium, and high fee rates based on what the
+// transactions currently in the mempool pay.
+func (fes *APIServer) GetFeeRateEstimates(ww http.ResponseWriter, req *http.Request) {
+	utxoView, err := fes.getAugmentedUniversalView("GetFeeRateEstimates")
+	if err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetFeeRateEstimates: Error getting augmented universal view: %v", err))
+		return
+	}
+
+	res := fes.getFeeRateEstimates(
+		utxoView.GetCurrentGlobalParamsEntry(), fes.backendServer.GetMempool().GetOrderedTransactions())
+	if err = json.NewEncoder(ww).Encode(res); err != nil {
+		_AddInternalServerError(ww, fmt.Sprintf("GetFeeRateEstimates: Problem encoding response as JSON: %v", err))
+		return
+	}
+}
+
+func (fes *APIServer) getFeeRateEstimates(
+	globalParams *lib.GlobalParamsEntry,
+	mempoolTxns []*lib.MempoolTx,
+) *GetFeeRateEstimatesResponse {
+	minFeeRateNanosPerKB := fes.MinFeeRateNanosPerKB
+	if globalParams != nil && globalParams.MinimumNetworkFeeNanosPerKB > 0 {
+		minFeeRateNanosPerKB = globalParams.MinimumNetworkFeeNanosPerKB
+	}
+
+	feeRates := make([]uint64, 0, len(mempoolTxns))
+	for _, mempoolTxn := range mempoolTxns {
+		feeRates = append(feeRates, mempoolTxn.FeePerKB)
+	}
+	sort.Slice(feeRates, func(ii, jj int) bool { return feeRates[ii] < feeRates[jj] })
+
+	feeRateAtPercentile := func(percentile int) uint64 {
+		if len(feeRates) == 0 {
+			return minFeeRateNanosPerKB
+		}
+		feeRate := feeRates[(len(feeRates)-1)*percentile/100]
+		if feeRate < minFeeRateNanosPerKB {
+			return minFeeRateNanosPerKB
+		}
+		return feeRate
+	}
+	return &GetFeeRateEstimatesResponse{
+		MinimumFeeRateNanosPerKB: minFeeRateNanosPerKB,
+		LowFeeRateNanosPerKB:     feeRateAtPercentile(LowFeeRatePercentile),
+		MediumFeeRateNanosPerKB:  feeRateAtPercentile(MediumFeeRatePercentile),
+		HighFeeRateNanosPerKB:    feeRateAtPercentile(HighFeeRatePercentile),
+		NumMempoolTxns:           len(mempoolTxns),
+	}
+}
+
 func (fes *APIServer) GetCommittedTipBlockInfo(ww http.ResponseWriter, req *http.Request) {
 	// Get the block tip from the blockchain.
 	fes.backendServer.GetBlockchain().ChainLock.RLock()
@@ -0,0 +1,92 @@
+package routes
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/deso-protocol/core/lib"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetFeeRateEstimates(t *testing.T) {
+	apiServer := &APIServer{Params: &lib.DeSoTestnetParams, MinFeeRateNanosPerKB: 1000}
+
+	// With an empty mempool, every estimate is the configured minimum.
+	res := apiServer.getFeeRateEstimates(nil, nil)
+	require.Equal(t, uint64(1000), res.MinimumFeeRateNanosPerKB)
+	require.Equal(t, uint64(1000), res.LowFeeRateNanosPerKB)
+	require.Equal(t, uint64(1000), res.MediumFeeRateNanosPerKB)
+	require.Equal(t, uint64(1000), res.HighFeeRateNanosPerKB)
+	require.Zero(t, res.NumMempoolTxns)
+
+	var mempoolTxns []*lib.MempoolTx
+	for _, feeRate := range []uint64{5000, 500, 2000, 10000, 3000, 1500, 4000, 8000, 2500, 6000, 7000} {
+		mempoolTxns = append(mempoolTxns, &lib.MempoolTx{FeePerKB: feeRate})
+	}
+	res = apiServer.getFeeRateEstimates(nil, mempoolTxns)
+	require.Equal(t, uint64(1000), res.MinimumFeeRateNanosPerKB)
+	require.Equal(t, uint64(2000), res.LowFeeRateNanosPerKB)
+	require.Equal(t, uint64(4000), res.MediumFeeRateNanosPerKB)
+	require.Equal(t, uint64(8000), res.HighFeeRateNanosPerKB)
+	require.Equal(t, len(mempoolTxns), res.NumMempoolTxns)
+
+	// The global param takes precedence over the config, and no estimate falls below it.
+	res = apiServer.getFeeRateEstimates(&lib.GlobalParamsEntry{MinimumNetworkFeeNanosPerKB: 3000}, mempoolTxns)
+	require.Equal(t, uint64(3000), res.MinimumFeeRateNanosPerKB)
+	require.Equal(t, uint64(3000), res.LowFeeRateNanosPerKB)
+	require.Equal(t, uint64(4000), res.MediumFeeRateNanosPerKB)
+	require.Equal(t, uint64(8000), res.HighFeeRateNanosPerKB)
+	require.LessOrEqual(t, res.LowFeeRateNanosPerKB, res.MediumFeeRateNanosPerKB)
+	require.LessOrEqual(t, res.MediumFeeRateNanosPerKB, res.HighFeeRateNanosPerKB)
+}
+
+func TestGetTransactionStatus(t *testing.T) {
+	mempoolTxnHash := lib.NewBlockHash(bytes.Repeat([]byte{1}, lib.HashSizeBytes))
+	confirmedTxnHash := lib.NewBlockHash(bytes.Repeat([]byte{2}, lib.HashSizeBytes))
+	unknownTxnHash := lib.NewBlockHash(bytes.Repeat([]byte{3}, lib.HashSizeBytes))
+	blockHash := lib.NewBlockHash(bytes.Repeat([]byte{4}, lib.HashSizeBytes))
+
+	isInMempool := func(txnHash *lib.BlockHash) bool {
+		return txnHash.IsEqual(mempoolTxnHash)
+	}
+	getConfirmedBlock := func(txnHash *lib.BlockHash) (*lib.BlockHash, uint64, error) {
+		if txnHash.IsEqual(confirmedTxnHash) {
+			return blockHash, 42, nil
+		}
+		return nil, 0, nil
+	}
+
+	res, err := getTransactionStatus(mempoolTxnHash, isInMempool, getConfirmedBlock)
+	require.NoError(t, err)
+	require.Equal(t, &GetTransactionStatusResponse{Status: TransactionStatusInMempool}, res)
+
+	res, err = getTransactionStatus(confirmedTxnHash, isInMempool, getConfirmedBlock)
+	require.NoError(t, err)
+	require.Equal(t, &GetTransactionStatusResponse{
+		Status:       TransactionStatusConfirmed,
+		BlockHashHex: hex.EncodeToString(blockHash[:]),
+		BlockHeight:  42,
+	}, res)
+
+	res, err = getTransactionStatus(unknownTxnHash, isInMempool, getConfirmedBlock)
+	require.NoError(t, err)
+	require.Equal(t, &GetTransactionStatusResponse{Status: TransactionStatusUnknown}, res)
+}
+
+func TestParseTransactionHash(t *testing.T) {
+	txnHash := lib.NewBlockHash(bytes.Repeat([]byte{1}, lib.HashSizeBytes))
+
+	parsedTxnHash, err := parseTransactionHash(hex.EncodeToString(txnHash[:]))
+	require.NoError(t, err)
+	require.Equal(t, txnHash, parsedTxnHash)
+
+	parsedTxnHash, err = parseTransactionHash(lib.PkToString(txnHash[:], &lib.DeSoTestnetParams))
+	require.NoError(t, err)
+	require.Equal(t, txnHash, parsedTxnHash)
+
+	_, err = parseTransactionHash("")
+	require.Error(t, err)
+	_, err = parseTransactionHash("abcd")
+	require.Error(t, err)
+}
@@ -37,7 +37,7 @@ func (fes *APIServer) UpdateTutorialStatus(ww http.ResponseWriter, req *http.Req
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UpdateTutorialStatusRequest{}
 
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("AdminResetTutorialStatus: Problem parsing request body: %v", err))
 		return
 	}
@@ -53,7 +53,7 @@ func (fes *APIServer) UpdateTutorialStatus(ww http.ResponseWriter, req *http.Req
 	}
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("UpdateTutorialStatus")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("UpdateTutorialStatus: Error getting utxoView: %v", err))
 		return
@@ -98,7 +98,7 @@ func (fes *APIServer) UpdateTutorialStatus(ww http.ResponseWriter, req *http.Req
 func (fes *APIServer) GetTutorialCreatorsByFR(ww http.ResponseWriter, req *http.Request, disregardFR bool) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetTutorialCreatorsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetTutorialCreators: Problem parsing request body: %v", err))
 		return
 	}
@@ -106,7 +106,7 @@ func (fes *APIServer) GetTutorialCreatorsByFR(ww http.ResponseWriter, req *http.
 	upAndComingSeekKey := _GlobalStateKeyUpAndComingTutorialCreators
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetTutorialCreatorsByFR")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetTutorialCreators: Error getting utxoView: %v", err))
 		return
@@ -191,7 +191,7 @@ type StartOrSkipTutorialRequest struct {
 func (fes *APIServer) StartOrSkipTutorial(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := StartOrSkipTutorialRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"StartOrSkipTutorial: Problem parsing request body: %v", err))
 		return
@@ -43,7 +43,7 @@ type GetUsersResponse struct {
 func (fes *APIServer) GetUsersStateless(ww http.ResponseWriter, rr *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	getUsersRequest := GetUsersStatelessRequest{}
-	if err := decoder.Decode(&getUsersRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&getUsersRequest)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetUsersStateless: Error parsing request body: %v", err))
 		return
 	}
@@ -93,7 +93,7 @@ func (fes *APIServer) GetUsersStateless(ww http.ResponseWriter, rr *http.Request
 func (fes *APIServer) updateUsersStateless(userList []*User, skipForLeaderboard bool, getUnminedBalance bool,
 	includeBalance bool) (
 	*lib.GlobalParamsEntry, error) {
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("updateUsersStateless")
 	if err != nil {
 		return nil, fmt.Errorf("updateUserFields: Error calling GetAugmentedUtxoViewForPublicKey: %v", err)
 	}
@@ -383,7 +383,7 @@ func (fes *APIServer) GetHodlingsForPublicKey(
 		utxoView = referenceUtxoView
 	} else {
 		var err error
-		utxoView, err = fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		utxoView, err = fes.getAugmentedUniversalView("GetHodlingsForPublicKey")
 		if err != nil {
 			return nil, nil, fmt.Errorf(
 				"GetHodlingsForPublicKey: Error calling GetAugmentedUtxoViewForPublicKey: %v", err)
@@ -497,7 +497,7 @@ func (fes *APIServer) GetUserMetadata(ww http.ResponseWriter, req *http.Request)
 	res.JumioReturned = userMetadata.JumioReturned
 	res.JumioFinishedTime = userMetadata.JumioFinishedTime
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetUserMetadata")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetUserMetadata: error getting utxoview: %v", err))
 		return
@@ -528,7 +528,7 @@ func (fes *APIServer) DeleteIdentities(ww http.ResponseWriter, req *http.Request
 	// Decode the request data.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := DeleteIdentityRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("DeleteIdentities: Problem parsing request body: %v", err))
 		return
 	}
@@ -653,7 +653,7 @@ func (fes *APIServer) GetProfiles(ww http.ResponseWriter, req *http.Request) {
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetProfilesRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetProfiles: Problem parsing request body: %v", err))
 		return
 	}
@@ -683,7 +683,7 @@ func (fes *APIServer) GetProfiles(ww http.ResponseWriter, req *http.Request) {
 	}
 
 	// Get a utxo view for lookups.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetProfiles")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetProfiles: Error fetching profiles from mempool: %v", err))
@@ -1130,7 +1130,7 @@ func (fes *APIServer) augmentProfileEntry(
 }
 
 func (fes *APIServer) _getProfilePictureForPublicKey(publicKey []byte) ([]byte, string, error) {
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("_getProfilePictureForPublicKey")
 	if err != nil {
 		return []byte{}, "", fmt.Errorf("_getProfilePictureforPublicKey: Error getting utxoView: %v", err)
 	}
@@ -1209,12 +1209,12 @@ type GetSingleProfileResponse struct {
 func (fes *APIServer) GetSingleProfile(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetSingleProfileRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetSingleProfile: Error parsing request body: %v", err))
 		return
 	}
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetSingleProfile")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetSingleProfile: Error getting utxoView: %v", err))
 		return
@@ -1342,14 +1342,14 @@ func (fes *APIServer) ComputeWealth(
 func (fes *APIServer) GetHodlersForPublicKey(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetHodlersForPublicKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetHodlersForPublicKey: Problem parsing request body: %v", err))
 		return
 	}
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetHodlersForPublicKey")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetHodlersForPublicKey: Error getting utxoView: %v", err))
 		return
@@ -1512,7 +1512,7 @@ type GetTokenBalancesForPublicKeyResponse struct {
 func (fes *APIServer) GetTokenBalancesForPublicKey(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetTokenBalancesForPublicKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetTokenBalancesForPublicKey: Problem parsing request body: %v", err))
 		return
@@ -1523,7 +1523,7 @@ func (fes *APIServer) GetTokenBalancesForPublicKey(ww http.ResponseWriter, req *
 		txnStatus = TxnStatusInMempool
 	}
 	// Get a view based on the txnStatus
-	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus)
+	utxoView, err := fes.GetUtxoViewGivenTxnStatus(txnStatus, "GetTokenBalancesForPublicKey")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetTokenBalancesForPublicKey: Error getting utxoView: %v", err))
 		return
@@ -1577,14 +1577,14 @@ type GetHolderCountForPublicKeysRequest struct {
 func (fes *APIServer) GetHodlersCountForPublicKeys(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetHolderCountForPublicKeysRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetHolderCountForPublicKeys: Problem parsing request body: %v", err))
 		return
 	}
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetHodlersCountForPublicKeys")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetHodlersForPublicKey: Error getting utxoView: %v", err))
 		return
@@ -1648,14 +1648,14 @@ type GetDiamondsForPublicKeyResponse struct {
 func (fes *APIServer) GetDiamondsForPublicKey(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetDiamondsForPublicKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetHodlersForPublicKey: Problem parsing request body: %v", err))
 		return
 	}
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetDiamondsForPublicKey")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetDiamondsForPublicKey: Error getting utxoView: %v", err))
 		return
@@ -1907,13 +1907,13 @@ func (fes *APIServer) getPublicKeyToProfileEntryMapForFollows(publicKeyBytes []b
 func (fes *APIServer) GetFollowsStateless(ww http.ResponseWriter, rr *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	getFollowsRequest := GetFollowsStatelessRequest{}
-	if err := decoder.Decode(&getFollowsRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&getFollowsRequest)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetFollowsStateless: Error parsing request body: %v", err))
 		return
 	}
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetFollowsStateless")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetFollowsStateless Error getting view: %v", err))
 		return
@@ -1990,7 +1990,7 @@ type GetUserGlobalMetadataResponse struct {
 func (fes *APIServer) GetUserGlobalMetadata(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetUserGlobalMetadataRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetUserGlobalMetadata: Problem parsing request body: %v", err))
 		return
 	}
@@ -2055,7 +2055,7 @@ type UpdateUserGlobalMetadataResponse struct{}
 func (fes *APIServer) UpdateUserGlobalMetadata(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UpdateUserGlobalMetadataRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("UpdateUserGlobalMetadata: Problem parsing request body: %v", err))
 		return
 	}
@@ -2139,7 +2139,7 @@ type GetNotificationsCountResponse struct {
 func (fes *APIServer) GetNotificationsCount(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNotificationsCountRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetNotificationsCount: Problem parsing request body: %v", err))
 		return
@@ -2222,7 +2222,7 @@ type GetNotificationsResponse struct {
 func (fes *APIServer) GetNotifications(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetNotificationsRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetNotifications: Problem parsing request body: %v", err))
 		return
@@ -2426,7 +2426,7 @@ type SetNotificationMetadataRequest struct {
 func (fes *APIServer) SetNotificationMetadata(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SetNotificationMetadataRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"SetNotificationMetadata: Problem parsing request body: %v", err))
 		return
@@ -2723,7 +2723,7 @@ func (fes *APIServer) _getNotificationsCount(request *GetNotificationsRequest) (
 
 	// A valid mempool object is used to compute the TransactionMetadata for the mempool
 	// and to allow for things like: filtering notifications for a hidden post.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("_getNotificationsCount")
 	if err != nil {
 		return 0, 0, errors.Errorf("GetNotifications: Problem getting view: %v", err)
 	}
@@ -2777,7 +2777,7 @@ func (fes *APIServer) _getNotifications(request *GetNotificationsRequest) ([]*Tr
 
 	// A valid mempool object is used to compute the TransactionMetadata for the mempool
 	// and to allow for things like: filtering notifications for a hidden post.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("_getNotifications")
 	if err != nil {
 		return nil, nil, errors.Errorf("GetNotifications: Problem getting view: %v", err)
 	}
@@ -3000,7 +3000,7 @@ type BlockPublicKeyResponse struct {
 func (fes *APIServer) BlockPublicKey(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := BlockPublicKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"BlockPublicKey: Problem parsing request body: %v", err))
 		return
@@ -3085,7 +3085,7 @@ func (fes *APIServer) IsFollowingPublicKey(ww http.ResponseWriter, req *http.Req
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := IsFollowingPublicKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"IsFollowingPublicKey: Problem parsing request body: %v", err))
 		return
@@ -3108,7 +3108,7 @@ func (fes *APIServer) IsFollowingPublicKey(ww http.ResponseWriter, req *http.Req
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("IsFollowingPublicKey")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("IsFollowingPublicKey Error getting view: %v", err))
 		return
@@ -3142,7 +3142,7 @@ func (fes *APIServer) IsHodlingPublicKey(ww http.ResponseWriter, req *http.Reque
 
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := IsHodlingPublicKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"IsHodlingPublicKey: Problem parsing request body: %v", err))
 		return
@@ -3169,7 +3169,7 @@ func (fes *APIServer) IsHodlingPublicKey(ww http.ResponseWriter, req *http.Reque
 	}
 
 	var utxoView *lib.UtxoView
-	utxoView, err = fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err = fes.getAugmentedUniversalView("IsHodlingPublicKey")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("IsHodlingPublicKey: Error getting utxoView: %v", err))
 		return
@@ -3214,7 +3214,7 @@ func (fes *APIServer) GetUsernameForPublicKey(ww http.ResponseWriter, req *http.
 	}
 
 	var utxoView *lib.UtxoView
-	utxoView, err = fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err = fes.getAugmentedUniversalView("GetUsernameForPublicKey")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetUsernameForPublicKey: Error getting utxoView: %v", err))
 		return
@@ -3242,7 +3242,7 @@ func (fes *APIServer) GetPublicKeyForUsername(ww http.ResponseWriter, req *http.
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetPublicKeyForUsername")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetPublicKeyForUsername: Error getting utxoView: %v", err))
 		return
@@ -3300,7 +3300,7 @@ type GetUserDerivedKeysResponse struct {
 func (fes *APIServer) GetUserDerivedKeys(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetUserDerivedKeysRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetUserDerivedKeys: Problem parsing request body: %v", err))
 		return
@@ -3390,7 +3390,7 @@ func (fes *APIServer) GetSingleDerivedKey(ww http.ResponseWriter, req *http.Requ
 	}
 
 	// Get augmented utxoView.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetSingleDerivedKey")
 	if err != nil {
 		_AddInternalServerError(ww, fmt.Sprintf("GetSingleDerivedKey: Problem getting augmented utxoView: %v", err))
 		return
@@ -3440,7 +3440,7 @@ type GetTransactionSpendingLimitHexStringResponse struct {
 func (fes *APIServer) GetTransactionSpendingLimitHexString(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetTransactionSpendingLimitHexStringRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetTransactionSpendingLimitHexString: Error parsing request body: %v", err))
 		return
 	}
@@ -3480,7 +3480,7 @@ type GetAccessBytesResponse struct {
 func (fes *APIServer) GetAccessBytes(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetAccessBytesRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetAccessBytes: Error parsing request body: %v", err))
 		return
 	}
@@ -3557,7 +3557,7 @@ func (fes *APIServer) GetTransactionSpendingLimitResponseFromHex(ww http.Respons
 	}
 
 	// Get augmented utxoView.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetTransactionSpendingLimitResponseFromHex")
 	if err != nil {
 		_AddInternalServerError(ww, fmt.Sprintf("GetTransactionSpendingLimitResponseFromHex: Problem getting augmented utxoView: %v", err))
 		return
@@ -3579,7 +3579,7 @@ type DeletePIIRequest struct {
 func (fes *APIServer) DeletePII(ww http.ResponseWriter, rr *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	requestData := DeletePIIRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("DeletePII: Error parsing request body: %v", err))
 		return
 	}
@@ -3792,14 +3792,14 @@ type GetHoldersForPublicKeyWithLockedBalancesResponse struct {
 func (fes *APIServer) GetHoldersForPublicKeyWithLockedBalances(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetHoldersForPublicKeyWithLockedBalancesRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf(
 			"GetHoldersForPublicKeyWithLockedBalances: Problem parsing request body: %v", err))
 		return
 	}
 
 	// Get a view
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetHoldersForPublicKeyWithLockedBalances")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetHoldersForPublicKeyWithLockedBalances: Error getting utxoView: %v", err))
 		return
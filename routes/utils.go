@@ -33,7 +33,7 @@ func parseRequestBodyParams[TRequestParams any](request *http.Request) (*TReques
 	var requestParams TRequestParams
 
 	decoder := json.NewDecoder(io.LimitReader(request.Body, MaxRequestBodySizeBytes))
-	if err := decoder.Decode(&requestParams); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestParams)); err != nil {
 		return nil, errors.Errorf("Error parsing request body: %v", err)
 	}
 
@@ -70,7 +70,7 @@ func (fes *APIServer) RegisterAsValidator(ww http.ResponseWriter, req *http.Requ
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := RegisterAsValidatorRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, "RegisterAsValidator: problem parsing request body")
 		return
 	}
@@ -170,7 +170,7 @@ func (fes *APIServer) UnregisterAsValidator(ww http.ResponseWriter, req *http.Re
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UnregisterAsValidatorRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, "UnregisterAsValidator: problem parsing request body")
 		return
 	}
@@ -244,7 +244,7 @@ func (fes *APIServer) UnjailValidator(ww http.ResponseWriter, req *http.Request)
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := UnjailValidatorRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, "UnjailValidator: problem parsing request body")
 		return
 	}
@@ -324,7 +324,7 @@ func (fes *APIServer) GetValidatorByPublicKeyBase58Check(ww http.ResponseWriter,
 	}
 
 	// Create UTXO view.
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("GetValidatorByPublicKeyBase58Check")
 	if err != nil {
 		_AddInternalServerError(ww, "GetValidatorByPublicKeyBase58Check: problem getting UTXO view")
 		return
@@ -507,7 +507,7 @@ func (fes *APIServer) CheckNodeStatus(ww http.ResponseWriter, req *http.Request)
 	// Decode request body.
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := CheckNodeStatusRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, "UnjailValidator: problem parsing request body")
 		return
 	}
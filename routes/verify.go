@@ -55,7 +55,7 @@ How verification works:
 func (fes *APIServer) SendPhoneNumberVerificationText(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SendPhoneNumberVerificationTextRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SendPhoneNumberVerificationText: Problem parsing request body: %v", err))
 		return
 	}
@@ -268,7 +268,7 @@ type SubmitCaptchaVerificationResponse struct {
 func (fes *APIServer) HandleCaptchaVerificationRequest(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SubmitCaptchaVerificationRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("HandleCaptchaVerificationRequest: Problem parsing request body: %v", err))
 		return
 	}
@@ -314,7 +314,7 @@ type AdminUpdateCaptchaRewardResponse struct {
 func (fes *APIServer) AdminSetCaptchaRewardNanos(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := AdminUpdateCaptchaRewardRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("HandleAdminUpdateCaptchaRewardRequest: Problem parsing request body: %v", err))
 		return
 	}
@@ -475,7 +475,7 @@ type SubmitPhoneNumberVerificationCodeResponse struct {
 func (fes *APIServer) SubmitPhoneNumberVerificationCode(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := SubmitPhoneNumberVerificationCodeRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("SubmitPhoneNumberVerificationCode: Problem parsing request body: %v", err))
 		return
 	}
@@ -635,7 +635,7 @@ type ResendVerifyEmailRequest struct {
 func (fes *APIServer) ResendVerifyEmail(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := ResendVerifyEmailRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("ResendVerifyEmail: Problem parsing request body: %v", err))
 		return
 	}
@@ -688,7 +688,7 @@ type SGRequestBody struct {
 func (fes *APIServer) VerifyEmail(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := VerifyEmailRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("VerifyEmail: Problem parsing request body: %v", err))
 		return
 	}
@@ -733,7 +733,7 @@ func (fes *APIServer) VerifyEmail(ww http.ResponseWriter, req *http.Request) {
 	}
 
 	var utxoView *lib.UtxoView
-	utxoView, err = fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err = fes.getAugmentedUniversalView("VerifyEmail")
 
 	// If the utxoview errors, just create the contact as is.
 	if err != nil {
@@ -874,7 +874,7 @@ type JumioBeginResponse struct {
 func (fes *APIServer) JumioBegin(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := JumioBeginRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("JumioBegin: Problem parsing request body: %v", err))
 		return
 	}
@@ -954,11 +954,13 @@ func (fes *APIServer) JumioBegin(ww http.ResponseWriter, req *http.Request) {
 	}
 
 	// Create the request
+	inboundCtx := req.Context()
 	req, err = http.NewRequest("POST", "https://netverify.com/api/v4/initiate", bytes.NewBuffer(jsonData))
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("JumioBegin: Request creation failed: %v", err))
 		return
 	}
+	forwardCorrelationID(inboundCtx, req)
 
 	// Set content-type and basic authentication (token, secret)
 	req.Header.Set("Content-Type", "application/json")
@@ -1009,7 +1011,7 @@ type JumioFlowFinishedRequest struct {
 func (fes *APIServer) JumioFlowFinished(ww http.ResponseWriter, req *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := JumioFlowFinishedRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("JumioFlowFinished: Problem parsing request body: %v", err))
 		return
 	}
@@ -1102,7 +1104,7 @@ func (fes *APIServer) JumioCallback(ww http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	utxoView, err := fes.backendServer.GetMempool().GetAugmentedUniversalView()
+	utxoView, err := fes.getAugmentedUniversalView("JumioCallback")
 	if err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("JumioCallback: error getting utxoview: %v", err))
 		return
@@ -1519,7 +1521,7 @@ type GetJumioStatusForPublicKeyResponse struct {
 func (fes *APIServer) GetJumioStatusForPublicKey(ww http.ResponseWriter, rr *http.Request) {
 	decoder := json.NewDecoder(io.LimitReader(rr.Body, MaxRequestBodySizeBytes))
 	requestData := GetJumioStatusForPublicKeyRequest{}
-	if err := decoder.Decode(&requestData); err != nil {
+	if err := describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetJumioStatusForPublicKey: Error parsing request body: %v", err))
 		return
 	}
@@ -1544,7 +1546,7 @@ func (fes *APIServer) GetJumioStatusForPublicKey(ww http.ResponseWriter, rr *htt
 
 	if userMetadata.JumioVerified {
 		var utxoView *lib.UtxoView
-		utxoView, err = fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		utxoView, err = fes.getAugmentedUniversalView("GetJumioStatusForPublicKey")
 		if err != nil {
 			_AddBadRequestError(ww, fmt.Sprintf("GetJumioStatusForPublicKey: error getting utxoview: %v", err))
 			return
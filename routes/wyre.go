@@ -121,7 +121,7 @@ func (fes *APIServer) WyreWalletOrderSubscription(ww http.ResponseWriter, req *h
 	// Decode the request body
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	wyreWalletOrderWebhookRequest := WyreWalletOrderWebhookPayload{}
-	if err := decoder.Decode(&wyreWalletOrderWebhookRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&wyreWalletOrderWebhookRequest)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("WyreWalletOrderSubscription: Error parsing request body: %v", err))
 		return
 	}
@@ -363,7 +363,7 @@ func (fes *APIServer) GetWyreWalletOrderQuotation(ww http.ResponseWriter, req *h
 	// Decode the request body
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	wyreWalletOrderQuotationRequest := WalletOrderQuotationRequest{}
-	if err := decoder.Decode(&wyreWalletOrderQuotationRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&wyreWalletOrderQuotationRequest)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetWyreWalletOrderQuotation: Error parsing request body: %v", err))
 		return
 	}
@@ -421,7 +421,7 @@ func (fes *APIServer) GetWyreWalletOrderReservation(ww http.ResponseWriter, req
 	// Decode the request body
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	wyreWalletOrderReservationRequest := WalletOrderReservationRequest{}
-	if err := decoder.Decode(&wyreWalletOrderReservationRequest); err != nil {
+	if err := describeDecodeError(decoder.Decode(&wyreWalletOrderReservationRequest)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetWyreWalletOrderReservation: Error parsing request body: %v", err))
 		return
 	}
@@ -593,7 +593,7 @@ func (fes *APIServer) GetWyreWalletOrdersForPublicKey(ww http.ResponseWriter, re
 	decoder := json.NewDecoder(io.LimitReader(req.Body, MaxRequestBodySizeBytes))
 	requestData := GetWyreWalletOrderForPublicKeyRequest{}
 	var err error
-	if err = decoder.Decode(&requestData); err != nil {
+	if err = describeDecodeError(decoder.Decode(&requestData)); err != nil {
 		_AddBadRequestError(ww, fmt.Sprintf("GetWyreWalletOrdersForPublicKey: Error parsing request body: %v", err))
 		return
 	}
@@ -607,7 +607,7 @@ func (fes *APIServer) GetWyreWalletOrdersForPublicKey(ww http.ResponseWriter, re
 		}
 	} else if requestData.Username != "" {
 		var utxoView *lib.UtxoView
-		utxoView, err = fes.backendServer.GetMempool().GetAugmentedUniversalView()
+		utxoView, err = fes.getAugmentedUniversalView("GetWyreWalletOrdersForPublicKey")
 		if err != nil {
 			_AddBadRequestError(ww, fmt.Sprintf("GetWyreWalletOrdersForPublicKey: error getting utxoview: %v", err))
 			return
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/deso-protocol/backend/routes"
+	"github.com/deso-protocol/core/lib"
+)
+
+func main() {
+	dataDir := flag.String("data-dir", "$HOME/data_dirs/hypersync/runner", "The data dir of a stopped node")
+	testnet := flag.Bool("testnet", false, "Whether the data dir belongs to a testnet node")
+	flag.Parse()
+
+	params := &lib.DeSoMainnetParams
+	if *testnet {
+		params = &lib.DeSoTestnetParams
+	}
+	info, err := routes.GetSnapshotInfoForDataDir(*dataDir, uint64(lib.DefaultSnapshotEpochPeriodPoS), params)
+	if err != nil {
+		fmt.Printf("Error reading snapshot info err: %v", err)
+		return
+	}
+
+	fmt.Printf("Most recent snapshot height: %v\n", info.SnapshotBlockHeight)
+	fmt.Printf("First snapshot height: %v\n", info.FirstSnapshotBlockHeight)
+	fmt.Printf("Snapshot epoch period: %v\n", info.SnapshotEpochPeriod)
+	fmt.Printf("Current epoch checksum: %v\n", info.CurrentEpochChecksumHex)
+	fmt.Printf("Current epoch block hash: %v\n", info.CurrentEpochBlockHashHex)
+	fmt.Printf("State checksum: %v\n", info.StateChecksumHex)
+}
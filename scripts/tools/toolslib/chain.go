@@ -1,6 +1,7 @@
 package toolslib
 
 import (
+	"github.com/deso-protocol/backend/routes"
 	"github.com/deso-protocol/core/lib"
 	"github.com/dgraph-io/badger/v3"
 	"github.com/pkg/errors"
@@ -8,14 +9,7 @@ import (
 
 // Returns the badgerDB handler associated with a dataDir path.
 func OpenDataDir(dataDir string) (*badger.DB, error) {
-	dir := lib.GetBadgerDbPath(dataDir)
-	opts := lib.PerformanceBadgerOptions(dir)
-	opts.ValueDir = lib.GetBadgerDbPath(dataDir)
-	db, err := badger.Open(opts)
-	if err != nil {
-		return nil, errors.Wrap(err, "OpenBadgerDB() failed to open badger")
-	}
-	return db, nil
+	return routes.OpenBadgerDataDir(dataDir)
 }
 
 // Returns the best chain associated with a badgerDB handle.